@@ -0,0 +1,69 @@
+// Command backfill fills in columns added to existing tables for the rows
+// written before they existed. Run it once after pushing a schema that adds
+// such a column; it's safe to run again.
+package main
+
+import (
+	"context"
+	"flag"
+	"slices"
+	"strings"
+
+	_ "github.com/joho/godotenv/autoload"
+	database "github.com/vaidik-bajpai/medibridge/internal/prisma"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+type task struct {
+	name string
+	run  func(context.Context) (int, error)
+}
+
+func main() {
+	var only string
+	flag.StringVar(&only, "only", "", "comma-separated tasks to run (defaults to all): mrn, phonetic, alerts, reactions")
+	flag.Parse()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	prismaClient, err := database.NewPrismaClient()
+	if err != nil {
+		logger.Fatal("connecting to the database failed.", zap.Error(err))
+	}
+	defer prismaClient.Disconnect()
+
+	backfill := store.NewBackfill(prismaClient)
+	tasks := []task{
+		{name: "mrn", run: backfill.MRNs},
+		{name: "phonetic", run: backfill.PhoneticKeys},
+		{name: "alerts", run: backfill.AlertKeys},
+		{name: "reactions", run: backfill.AllergyReactions},
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selected[name] = true
+		}
+	}
+
+	for name := range selected {
+		if !slices.ContainsFunc(tasks, func(t task) bool { return t.name == name }) {
+			logger.Fatal("unknown backfill task.", zap.String("task", name))
+		}
+	}
+
+	ctx := context.Background()
+	for _, t := range tasks {
+		if len(selected) > 0 && !selected[t.name] {
+			continue
+		}
+		n, err := t.run(ctx)
+		if err != nil {
+			logger.Fatal("backfill failed.", zap.String("task", t.name), zap.Int("rows", n), zap.Error(err))
+		}
+		logger.Info("backfill done.", zap.String("task", t.name), zap.Int("rows", n))
+	}
+}
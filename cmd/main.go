@@ -7,14 +7,34 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/vaidik-bajpai/medibridge/internal/alerts"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/growth"
 	"github.com/vaidik-bajpai/medibridge/internal/handlers"
+	"github.com/vaidik-bajpai/medibridge/internal/immunization"
+	"github.com/vaidik-bajpai/medibridge/internal/interactions"
+	"github.com/vaidik-bajpai/medibridge/internal/labs"
+	"github.com/vaidik-bajpai/medibridge/internal/notes"
 	database "github.com/vaidik-bajpai/medibridge/internal/prisma"
+	"github.com/vaidik-bajpai/medibridge/internal/scheduling"
 	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"github.com/vaidik-bajpai/medibridge/internal/terminology"
 	"go.uber.org/zap"
 )
 
 type Config struct {
-	serverPort string
+	serverPort      string
+	referenceRanges string
+	alertRules      string
+	icd10           string
+	substances      string
+	interactions    string
+	timeZone        string
+	noteTemplates   string
+	labCatalogue    string
+
+	immunizationSchedule string
+	growthStandards      string
 }
 
 // @title           MediBridge API
@@ -31,6 +51,16 @@ type Config struct {
 func main() {
 	var config Config
 	flag.StringVar(&config.serverPort, "sAddr", "8080", "http server address")
+	flag.StringVar(&config.referenceRanges, "ranges", "", "vitals reference ranges file (defaults to the built-in table)")
+	flag.StringVar(&config.alertRules, "alertRules", "", "clinical alert rules file (defaults to the built-in rules)")
+	flag.StringVar(&config.icd10, "icd10", "", "ICD-10 code set file (defaults to the bundled common codes)")
+	flag.StringVar(&config.substances, "substances", "", "allergy substance list file (defaults to the bundled common substances)")
+	flag.StringVar(&config.interactions, "interactions", "", "drug interaction dataset file (defaults to the bundled dataset)")
+	flag.StringVar(&config.noteTemplates, "noteTemplates", "", "clinical note templates file (defaults to the bundled templates)")
+	flag.StringVar(&config.labCatalogue, "labCatalogue", "", "lab test catalogue file (defaults to the bundled catalogue)")
+	flag.StringVar(&config.immunizationSchedule, "immunizationSchedule", "", "national immunization schedule file (defaults to the bundled India UIP schedule)")
+	flag.StringVar(&config.growthStandards, "growthStandards", "", "growth standards LMS tables file (defaults to the bundled abridged WHO tables)")
+	flag.StringVar(&config.timeZone, "tz", "UTC", "clinic time zone working hours are kept in, e.g. Asia/Kolkata")
 	flag.Parse()
 
 	validate := validator.New()
@@ -38,6 +68,56 @@ func main() {
 	logger, _ := zap.NewProduction()
 	defer logger.Sync()
 
+	if config.referenceRanges != "" {
+		if err := clinical.LoadRangeTableFile(config.referenceRanges); err != nil {
+			logger.Fatal("loading the reference ranges failed.", zap.Error(err))
+		}
+	}
+	if config.alertRules != "" {
+		if err := alerts.LoadRulesFile(config.alertRules); err != nil {
+			logger.Fatal("loading the alert rules failed.", zap.Error(err))
+		}
+	}
+	if config.icd10 != "" {
+		if err := terminology.LoadICD10File(config.icd10); err != nil {
+			logger.Fatal("loading the ICD-10 code set failed.", zap.Error(err))
+		}
+	}
+	if config.substances != "" {
+		if err := terminology.LoadSubstancesFile(config.substances); err != nil {
+			logger.Fatal("loading the allergy substances failed.", zap.Error(err))
+		}
+	}
+	if config.interactions != "" {
+		if err := interactions.LoadDatasetFile(config.interactions); err != nil {
+			logger.Fatal("loading the drug interaction dataset failed.", zap.Error(err))
+		}
+	}
+	if config.noteTemplates != "" {
+		if err := notes.LoadTemplatesFile(config.noteTemplates); err != nil {
+			logger.Fatal("loading the note templates failed.", zap.Error(err))
+		}
+	}
+	if config.labCatalogue != "" {
+		if err := labs.LoadCatalogueFile(config.labCatalogue); err != nil {
+			logger.Fatal("loading the lab test catalogue failed.", zap.Error(err))
+		}
+	}
+	if config.immunizationSchedule != "" {
+		if err := immunization.LoadScheduleFile(config.immunizationSchedule); err != nil {
+			logger.Fatal("loading the immunization schedule failed.", zap.Error(err))
+		}
+	}
+	if config.growthStandards != "" {
+		if err := growth.LoadStandardsFile(config.growthStandards); err != nil {
+			logger.Fatal("loading the growth standards failed.", zap.Error(err))
+		}
+	}
+
+	if err := scheduling.LoadLocation(config.timeZone); err != nil {
+		logger.Fatal("loading the clinic time zone failed.", zap.Error(err))
+	}
+
 	prismaClient, err := database.NewPrismaClient()
 	if err != nil {
 
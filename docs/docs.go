@@ -18,35 +18,60 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/allergy/{allergyID}": {
-            "put": {
-                "description": "Updates an existing allergy using its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/alerts": {
+            "get": {
+                "description": "Lists clinical alerts, critical and most recent first. By default only the alerts assigned\nto the current user are listed; pass assigned=all to list everyone's.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Allergy"
+                    "Alerts"
                 ],
-                "summary": "Update an allergy",
+                "summary": "List alerts",
                 "parameters": [
                     {
+                        "enum": [
+                            "me",
+                            "all"
+                        ],
                         "type": "string",
-                        "description": "Allergy ID (UUID)",
-                        "name": "allergyID",
-                        "in": "path",
-                        "required": true
+                        "description": "Whose alerts to list",
+                        "name": "assigned",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated allergy details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateAllergyReq"
-                        }
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "acknowledged",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "critical",
+                            "warning",
+                            "info"
+                        ],
+                        "type": "string",
+                        "description": "Severity",
+                        "name": "severity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of alerts (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -62,12 +87,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/models.FailureResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -75,24 +94,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an allergy from the patient’s record by its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/v1/alerts/{alertID}/acknowledge": {
+            "post": {
+                "description": "Marks an open alert as seen by the current user, who must be assigned to the alert or on\nthe patient's care team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Allergy"
+                    "Alerts"
                 ],
-                "summary": "Delete an allergy",
+                "summary": "Acknowledge an alert",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Allergy ID (UUID)",
-                        "name": "allergyID",
+                        "description": "Alert ID",
+                        "name": "alertID",
                         "in": "path",
                         "required": true
                     }
@@ -110,6 +128,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -119,9 +155,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/condition/{conditionID}": {
-            "delete": {
-                "description": "Marks an existing condition as inactive by its ID.",
+        "/v1/alerts/{alertID}/resolve": {
+            "post": {
+                "description": "Closes an alert, with an optional note on how it was handled. Resolving an open alert\nalso acknowledges it. Only the alert's assignees and the patient's care team can resolve it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -129,16 +165,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Conditions"
+                    "Alerts"
                 ],
-                "summary": "Inactivate a medical condition",
+                "summary": "Resolve an alert",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Condition ID (UUID)",
-                        "name": "conditionID",
+                        "description": "Alert ID",
+                        "name": "alertID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Resolution note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertActionReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -154,6 +198,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -163,9 +231,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/diagnoses/{diagnosesID}": {
+        "/v1/allergy/{allergyID}": {
             "put": {
-                "description": "Updates an existing diagnosis using the diagnosis ID.",
+                "description": "Updates an existing allergy using its ID. Reactions, when given, replace the allergy's\nreactions; a free-text reaction replaces them with a single reaction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -173,24 +241,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnoses"
+                    "Allergy"
                 ],
-                "summary": "Update an existing diagnosis",
+                "summary": "Update an allergy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Diagnosis ID (UUID)",
-                        "name": "diagnosesID",
+                        "description": "Allergy ID (UUID)",
+                        "name": "allergyID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated diagnosis details",
+                        "description": "Updated allergy details",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateDiagnosesReq"
+                            "$ref": "#/definitions/models.UpdateAllergyReq"
                         }
                     }
                 ],
@@ -203,6 +271,12 @@ const docTemplate = `{
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -222,7 +296,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a diagnosis using the diagnosis ID.",
+                "description": "Deletes an allergy from the patient’s record by its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -230,14 +304,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnoses"
+                    "Allergy"
                 ],
-                "summary": "Delete a diagnosis",
+                "summary": "Delete an allergy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Diagnosis ID (UUID)",
-                        "name": "diagnosesID",
+                        "description": "Allergy ID (UUID)",
+                        "name": "allergyID",
                         "in": "path",
                         "required": true
                     }
@@ -264,9 +338,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/patient": {
-            "get": {
-                "description": "Lists all registered patients with optional pagination and search.",
+        "/v1/appointment/{appointmentID}/cancel": {
+            "post": {
+                "description": "Cancels a booked appointment with a reason and frees its slot.",
                 "consumes": [
                     "application/json"
                 ],
@@ -274,82 +348,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Appointments"
                 ],
-                "summary": "List patients",
+                "summary": "Cancel an appointment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Appointment ID",
+                        "name": "appointmentID",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Search term (e.g., name or email)",
-                        "name": "searchTerm",
-                        "in": "query"
+                        "description": "Cancellation",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelAppointmentReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Appointment"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.FailureResponse"
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Registers a new patient with the provided details.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Patients"
-                ],
-                "summary": "Register a new patient",
-                "parameters": [
-                    {
-                        "description": "Patient registration data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RegPatientReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -369,9 +421,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/patient/{patientID}": {
-            "get": {
-                "description": "Retrieves a patient's details using their patient ID.",
+        "/v1/appointment/{appointmentID}/reschedule": {
+            "put": {
+                "description": "Moves a booked appointment to another free slot of the same doctor. The appointment is closed\nas rescheduled and the new one, linked to it by rescheduledFromId, is returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -379,29 +431,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Appointments"
                 ],
-                "summary": "Get patient details",
+                "summary": "Reschedule an appointment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID (UUID)",
-                        "name": "patientID",
+                        "description": "Appointment ID",
+                        "name": "appointmentID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New time",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RescheduleAppointmentReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Appointment"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.FailureResponse"
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
                         }
                     },
                     "404": {
@@ -410,6 +483,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -417,9 +502,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/condition/{conditionID}": {
             "put": {
-                "description": "Updates details of an existing patient by patient ID.",
+                "description": "Updates the clinical or verification status, dates or note of a condition. A change of\nstatus is recorded in the condition's history. A condition that comes back after it stopped\nbeing active is a recurrence; moving to a status that isn't allowed from the current one\nis a conflict.",
                 "consumes": [
                     "application/json"
                 ],
@@ -427,24 +514,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Conditions"
                 ],
-                "summary": "Update a patient",
+                "summary": "Update a medical condition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID (UUID)",
-                        "name": "patientID",
+                        "description": "Condition ID (UUID)",
+                        "name": "conditionID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated patient data",
+                        "description": "Updated condition details",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdatePatientReq"
+                            "$ref": "#/definitions/models.UpdateConditionReq"
                         }
                     }
                 ],
@@ -457,6 +544,18 @@ const docTemplate = `{
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -476,7 +575,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a patient by their patient ID.",
+                "description": "Marks an existing condition as inactive by its ID. The condition is kept, with the change\nrecorded in its history; a resolved condition can't be made inactive.",
                 "consumes": [
                     "application/json"
                 ],
@@ -484,14 +583,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Conditions"
                 ],
-                "summary": "Delete a patient",
+                "summary": "Inactivate a medical condition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID (UUID)",
-                        "name": "patientID",
+                        "description": "Condition ID (UUID)",
+                        "name": "conditionID",
                         "in": "path",
                         "required": true
                     }
@@ -515,6 +614,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -524,9 +629,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/patient/{patientID}/allergy": {
-            "post": {
-                "description": "Records a new allergy for the specified patient.",
+        "/v1/diagnoses/{diagnosesID}": {
+            "put": {
+                "description": "Updates the name, ICD-10 code, type or onset date of an existing diagnosis. A new code\nrenames the diagnosis after the code's display text unless a name is given; a code missing\nfrom the code set is marked codeUnverified and needs a name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -534,36 +639,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Allergy"
+                    "Diagnoses"
                 ],
-                "summary": "Record a new allergy",
+                "summary": "Update an existing diagnosis",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID (UUID)",
-                        "name": "patientID",
+                        "description": "Diagnosis ID (UUID)",
+                        "name": "diagnosesID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Allergy input",
+                        "description": "Updated diagnosis details",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RegAllergyReq"
+                            "$ref": "#/definitions/models.UpdateDiagnosesReq"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -581,11 +692,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/patient/{patientID}/condition": {
-            "post": {
-                "description": "Adds a new medical condition associated with a patient ID.",
+            },
+            "delete": {
+                "description": "Deletes a diagnosis using the diagnosis ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -593,30 +702,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Conditions"
+                    "Diagnoses"
                 ],
-                "summary": "Add a new medical condition",
+                "summary": "Delete a diagnosis",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID (UUID)",
-                        "name": "patientID",
+                        "description": "Diagnosis ID (UUID)",
+                        "name": "diagnosesID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Condition details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddConditionReq"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
@@ -627,12 +727,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/models.FailureResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -642,9 +736,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/patient/{patientID}/diagnoses": {
+        "/v1/doctor/{doctorID}/exceptions": {
             "post": {
-                "description": "Adds a new diagnosis for a patient using their patient ID.",
+                "description": "Records leave or other time the doctor isn't available; no slots are offered during it.\nAppointments already booked in it are kept and returned as conflictingAppointments so\nthey can be rescheduled. Doctors may only block out their own time.",
                 "consumes": [
                     "application/json"
                 ],
@@ -652,24 +746,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnoses"
+                    "Scheduling"
                 ],
-                "summary": "Add a new diagnosis",
+                "summary": "Block out a doctor's time",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID (UUID)",
-                        "name": "patientID",
+                        "description": "Doctor ID",
+                        "name": "doctorID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Diagnosis details",
+                        "description": "Exception",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.DiagnosesReq"
+                            "$ref": "#/definitions/models.AddScheduleExceptionReq"
                         }
                     }
                 ],
@@ -677,11 +771,29 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleException"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -707,45 +819,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/patient/{patientID}/vitals": {
-            "put": {
-                "description": "Updates the vitals information of a patient.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/doctor/{doctorID}/exceptions/{exceptionID}": {
+            "delete": {
+                "description": "Removes leave or other blocked out time, offering its slots again. Doctors may only remove\ntheir own exceptions.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vitals"
+                    "Scheduling"
                 ],
-                "summary": "Update patient's vitals",
+                "summary": "Remove a schedule exception",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID",
-                        "name": "patientID",
+                        "description": "Doctor ID",
+                        "name": "doctorID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Vital Information",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateVitalReq"
-                        }
+                        "type": "string",
+                        "description": "Exception ID",
+                        "name": "exceptionID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
                     "400": {
@@ -754,8 +858,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -767,45 +877,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Captures the vitals of a patient, including details like blood pressure, temperature, etc.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/v1/doctor/{doctorID}/schedule": {
+            "get": {
+                "description": "Returns the doctor's working hours, exceptions, booked appointments and free slots for each\nday of the view. A week starts on the Monday of the date. It defaults to today's day view.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vitals"
+                    "Scheduling"
                 ],
-                "summary": "Capture patient's vitals",
+                "summary": "Get a doctor's day or week",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID",
-                        "name": "patientID",
+                        "description": "Doctor ID",
+                        "name": "doctorID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Vital Information",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateVitalReq"
-                        }
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "description": "View",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A day of the view (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DoctorSchedule"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -814,14 +939,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/models.FailureResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -833,33 +952,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes the vitals of a patient.",
+            }
+        },
+        "/v1/doctor/{doctorID}/slots": {
+            "get": {
+                "description": "Lists the doctor's free slots that start from the first to the last day, in the clinic's\ntime zone. It defaults to the seven days from today; at most 31 days are listed at once.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vitals"
+                    "Scheduling"
                 ],
-                "summary": "Delete patient's vitals",
+                "summary": "List a doctor's free slots",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID",
-                        "name": "patientID",
+                        "description": "Doctor ID",
+                        "name": "doctorID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/scheduling.Slot"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -868,8 +1013,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -883,29 +1028,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/logout": {
-            "post": {
-                "description": "Clears the session cookie for the current user.",
+        "/v1/doctor/{doctorID}/working-hours": {
+            "get": {
+                "description": "Returns the doctor's weekly template of working hours, from Sunday.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Scheduling"
+                ],
+                "summary": "Get a doctor's working hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Doctor ID",
+                        "name": "doctorID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Log out a user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WorkingHours"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/user/signin": {
-            "post": {
-                "description": "Authenticates a user and sets a session cookie upon successful login.",
+            },
+            "put": {
+                "description": "Replaces the doctor's weekly template of working hours, as wall-clock times in the clinic's\ntime zone. Periods on the same weekday must not overlap. Appointments already booked are\nkept. Doctors may only set their own hours.",
                 "consumes": [
                     "application/json"
                 ],
@@ -913,17 +1092,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Scheduling"
                 ],
-                "summary": "Log in a user",
+                "summary": "Set a doctor's working hours",
                 "parameters": [
                     {
-                        "description": "Login request payload",
+                        "type": "string",
+                        "description": "Doctor ID",
+                        "name": "doctorID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Weekly template",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SigninReq"
+                            "$ref": "#/definitions/models.SetWorkingHoursReq"
                         }
                     }
                 ],
@@ -931,17 +1117,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WorkingHours"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -961,35 +1168,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/signup": {
-            "post": {
-                "description": "Registers a new user with fullname, email, password, and role.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/encounter/{encounterID}": {
+            "get": {
+                "description": "Returns an encounter with the diagnoses, conditions, allergies, medications and vitals\nrecorded in it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Encounters"
                 ],
-                "summary": "Register a new user",
+                "summary": "Get an encounter",
                 "parameters": [
                     {
-                        "description": "Signup request payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SignupReq"
-                        }
+                        "type": "string",
+                        "description": "Encounter ID",
+                        "name": "encounterID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.EncounterRecord"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -998,8 +1212,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FailureResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.FailureResponse"
                         }
@@ -1011,295 +1225,9096 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
+            },
+            "put": {
+                "description": "Changes an encounter or moves it on: a planned encounter starts or is cancelled, one in\nprogress finishes or is cancelled. Finishing it sets the end to now unless endedAt is given.\nA cancelled encounter can't be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "Update an encounter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Encounter ID",
+                        "name": "encounterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateEncounterReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Encounter"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an encounter opened by mistake. An encounter with clinical data recorded in it\ncan't be deleted; cancel it instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "Delete an encounter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Encounter ID",
+                        "name": "encounterID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/family-history/{familyHistoryID}": {
+            "put": {
+                "description": "Changes the fields given of a relative's condition. A new code replaces the display text of\nthe old one; marking the relative as not deceased clears their age at death.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Correct a relative's condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Family history ID",
+                        "name": "familyHistoryID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFamilyHistoryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyHistory"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a relative's condition recorded by mistake.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Delete a relative's condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Family history ID",
+                        "name": "familyHistoryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/immunization-schedule": {
+            "get": {
+                "description": "Returns the national immunization schedule the clinic follows, with the age each dose is due\nat and the age after which it can no longer be given.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Immunizations"
+                ],
+                "summary": "Get the immunization schedule",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/immunization.Schedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/immunization/{immunizationID}": {
+            "delete": {
+                "description": "Deletes a vaccine dose recorded by mistake.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Immunizations"
+                ],
+                "summary": "Delete an immunization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Immunization ID",
+                        "name": "immunizationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/immunizations/overdue": {
+            "get": {
+                "description": "Lists the patients overdue for a dose of the schedule, those overdue longest first, with their\ncontact number for recall.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Immunizations"
+                ],
+                "summary": "List patients with overdue immunizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report date (YYYY-MM-DD), defaults to today",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vaccine code",
+                        "name": "vaccine",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OverdueReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lab-order/{orderID}": {
+            "get": {
+                "description": "Returns a lab order with the results received so far.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "Get a lab order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lab order ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LabOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lab-order/{orderID}/cancel": {
+            "post": {
+                "description": "Cancels a lab order none of whose tests have a result yet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "Cancel a lab order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lab order ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LabOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lab-order/{orderID}/results": {
+            "post": {
+                "description": "Records results of the order's tests by hand. Values may be given in any unit the catalogue\nconverts from and are stored in the unit of the test. Each result is flagged against the\nrange given with it or the catalogue's range for the patient's sex and age. A test of the\norder can only have one result.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "Enter the results of a lab order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lab order ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Results",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EnterLabResultsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AddLabResultsRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lab-results": {
+            "post": {
+                "description": "Receives results from a lab system. Each result names its patient and may name the order\nit answers. Results with an externalId that was already received are skipped, so a batch\ncan be sent again. Values are converted and flagged as for results entered by hand. The\nbatch is stored as a whole or not at all.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "Ingest lab results",
+                "parameters": [
+                    {
+                        "description": "Results",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IngestLabResultsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AddLabResultsRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lab-tests": {
+            "get": {
+                "description": "Lists the tests that can be ordered with their units and reference ranges, and the panels\ngrouping them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "List the lab test catalogue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/labs.Catalogue"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/medication/{medicationID}": {
+            "put": {
+                "description": "Changes a medication's dose or schedule, or its status: put on hold, restart, complete or\nstop it. Stopping or completing it sets the stop date to today. Completed, stopped and\nentered-in-error medications can't be changed; prescribe a new one instead.\nRestarting an on-hold medication checks it against the patient's allergies and other current\nmedications again; severe warnings are returned with a 409 unless an override reason is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "Update a medication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medication ID",
+                        "name": "medicationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMedicationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Medication"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.InteractionWarningRes"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news2": {
+            "get": {
+                "description": "Lists the patients on a ward by the NEWS2 early warning score of their latest fully scored\nobservation, highest first, so that deteriorating patients are seen first. Patients without\na score are listed last. Only doctors can list a ward.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "NEWS2 scores of a ward",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ward",
+                        "name": "ward",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/note-templates": {
+            "get": {
+                "description": "Lists the templates a note can be started from, with the prompts they prefill each SOAP section with.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "List note templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/notes.Template"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/note/{noteID}": {
+            "get": {
+                "description": "Returns a note with its amendments. A draft is only returned to its author.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Get a clinical note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ClinicalNote"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Edits the title or sections of a draft note. Only its author can edit a draft; a signed\nnote is immutable and is amended instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Edit a draft note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateNoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ClinicalNote"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a draft note of the signed in doctor. Signed notes are part of the record and can't\nbe deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Discard a draft note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/note/{noteID}/amendments": {
+            "post": {
+                "description": "Appends an amendment with a reason to a signed note, authored by the signed in doctor. The\nsigned text is kept as it was; the amendment holds the sections it corrects or adds to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Amend a signed note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amendment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AmendNoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ClinicalNote"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/note/{noteID}/sign": {
+            "post": {
+                "description": "Signs a draft note of the signed in doctor. The note becomes visible to others and can no\nlonger be edited or deleted, only amended. A note without any text can't be signed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Sign a note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ClinicalNote"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient": {
+            "get": {
+                "description": "Lists registered patients with pagination, filters and sorting. The search term\nmatches the patient's name, MRN or phone number; the fuzzy and phonetic search modes match\nmisspelt or alike-sounding names only and rank the results by relevance. Sending the cursor parameter switches\nto keyset pagination ordered by registration time, with next/prev links in the Link header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "List patients",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term (name, MRN or phone)",
+                        "name": "searchTerm",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "contains",
+                            "fuzzy",
+                            "phonetic"
+                        ],
+                        "type": "string",
+                        "description": "Name matching mode",
+                        "name": "searchMode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor; send it empty for the first page of cursor mode",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include an estimated total in cursor mode",
+                        "name": "withCount",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MALE",
+                            "FEMALE",
+                            "OTHER"
+                        ],
+                        "type": "string",
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum age in years",
+                        "name": "minAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum age in years",
+                        "name": "maxAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest date of birth (YYYY-MM-DD)",
+                        "name": "dobFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest date of birth (YYYY-MM-DD)",
+                        "name": "dobTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the registering user",
+                        "name": "registeredBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or after (YYYY-MM-DD)",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or before (YYYY-MM-DD)",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only patients with (true) or without (false) allergies",
+                        "name": "hasAllergy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only patients with (true) or without (false) diagnoses",
+                        "name": "hasDiagnosis",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "age",
+                            "dob",
+                            "createdAt",
+                            "updatedAt"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction",
+                        "name": "sortDir",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "next and prev page URLs in cursor mode"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a new patient with the provided details. Probable duplicates of existing\npatients are rejected with a 409 listing the candidates unless \"force\" is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Register a new patient",
+                "parameters": [
+                    {
+                        "description": "Patient registration data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegPatientReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.DuplicatePatientRes"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/merge": {
+            "post": {
+                "description": "Moves diagnoses, conditions, allergies, medications, vitals, encounters, appointments, notes, lab results, immunizations, family history and referrals from the source patient to the target,\nmoves the source's social history when the target has none, copies the listed demographics from the source\nand keeps the source as a redirecting tombstone. Only doctors can merge patients.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Merge duplicate patients",
+                "parameters": [
+                    {
+                        "description": "Merge request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergePatientReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/merge/{mergeID}/unmerge": {
+            "post": {
+                "description": "Moves the records listed in the merge manifest back to the source patient,\nrestores the target's overwritten demographics and reactivates the source. Only doctors can unmerge patients.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Reverse a patient merge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Merge ID (UUID)",
+                        "name": "mergeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}": {
+            "get": {
+                "description": "Retrieves a patient's details using their patient ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Get patient details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID (UUID)",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "307": {
+                        "description": "Patient was merged; Location points to the surviving record",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates details of an existing patient by patient ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Update a patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID (UUID)",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated patient data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePatientReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a patient by their patient ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Delete a patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID (UUID)",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/allergy": {
+            "post": {
+                "description": "Records a new allergy for the specified patient. The substance is coded from the terminology\nsubstance list, and its name and category default to the substance's. A free-text reaction\nand severity are recorded as a single reaction. Recording an allergy that isn't refuted\nclears a \"no known allergies\" assertion.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Allergy"
+                ],
+                "summary": "Record a new allergy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID (UUID)",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Allergy input",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegAllergyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/appointments": {
+            "get": {
+                "description": "Lists the patient's appointments, including cancelled and rescheduled ones, the earliest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "List a patient's appointments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Appointment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Books the patient into a free slot of the doctor. startsAt must be the start of one of the\nslots listed by the doctor's slots endpoint; a slot that was booked in the meantime is a\nconflict.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Book an appointment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Appointment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BookAppointmentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Appointment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/care-team": {
+            "get": {
+                "description": "Lists the users looking after a patient. Alerts about the patient are assigned to them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "List a patient's care team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a user to a patient's care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Add a care team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Care team member",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddCareTeamMemberReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/care-team/{userID}": {
+            "delete": {
+                "description": "Removes a user from a patient's care team. Alerts already assigned to them are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Remove a care team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/condition": {
+            "post": {
+                "description": "Adds a new medical condition associated with a patient ID. A condition is active and\nconfirmed unless a status is given; a condition from the patient's history may be added\nas resolved, in remission or inactive with its abatement date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conditions"
+                ],
+                "summary": "Add a new medical condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID (UUID)",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Condition details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddConditionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/diagnoses": {
+            "post": {
+                "description": "Adds a new diagnosis for a patient using their patient ID. A diagnosis is coded with an\nICD-10 code from the terminology endpoint; its name defaults to the code's display text.\nA well formed code missing from the code set is kept but marked codeUnverified, and then\nthe name is required. The diagnosing clinician is the signed in user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Diagnoses"
+                ],
+                "summary": "Add a new diagnosis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID (UUID)",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Diagnosis details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DiagnosesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/encounters": {
+            "get": {
+                "description": "Lists the patient's encounters with the clinical data recorded in each, the most recently\nstarted first, as a timeline of the patient's visits.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "List a patient's encounters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "planned",
+                            "in-progress",
+                            "finished",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Encounter status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest start (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest start (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.EncounterRecord"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Opens a visit for the patient. It starts now and is in progress unless it is planned ahead.\nThe attending doctor defaults to the signed in user and must be a doctor. Diagnoses, conditions,\nallergies, medications and vitals may then be recorded in it by passing its ID as encounterId.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "Open an encounter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Encounter",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateEncounterReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Encounter"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/family-history": {
+            "get": {
+                "description": "Lists the conditions of the patient's relatives, oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "List a patient's family history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FamilyHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records a condition of a blood relative of the patient. The condition may be coded with an\nICD-10 code from the terminology endpoint; its name defaults to the code's display text.\nAn age at death is only recorded for a deceased relative.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Add to a patient's family history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relative's condition",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddFamilyHistoryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyHistory"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/growth": {
+            "get": {
+                "description": "Scores the child's weights, heights and BMIs from vitals against the WHO growth standards\nfor their sex and age, with a z-score and percentile for each measurement and the z-score\nlines to chart them on. Heights under 24 months are taken as lying length. Measurements\npast the ages the standards cover are left out. Patients whose gender is neither MALE nor\nFEMALE need the sex parameter.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "Growth charts of a child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "weight-for-age",
+                            "height-for-age",
+                            "bmi-for-age"
+                        ],
+                        "type": "string",
+                        "description": "Indicator",
+                        "name": "indicator",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MALE",
+                            "FEMALE"
+                        ],
+                        "type": "string",
+                        "description": "Sex of the chart",
+                        "name": "sex",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GrowthCharts"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/immunizations": {
+            "get": {
+                "description": "Lists the vaccine doses given to the patient, the most recent first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Immunizations"
+                ],
+                "summary": "List a patient's immunizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Immunization"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records a dose given to the patient, at the clinic or elsewhere. Vaccines of the schedule\ntake their name from it; other vaccines need a vaccineName. Unless administeredById or\nadministeredByName is given, the signed in user gave the dose. A dose of a vaccine can only\nbe recorded once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Immunizations"
+                ],
+                "summary": "Record a vaccine dose",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dose",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RecordImmunizationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Immunization"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/immunizations/status": {
+            "get": {
+                "description": "Works out every dose of the schedule for the patient from their date of birth and the doses\ngiven: given, upcoming, due, overdue once the grace period after the due date is over, or\nlapsed once the patient is too old for it. Doses after one given late are due later.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Immunizations"
+                ],
+                "summary": "Get a patient's immunization status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImmunizationStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/lab-orders": {
+            "get": {
+                "description": "Lists the patient's lab orders with their results, the most recent first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "List a patient's lab orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ordered",
+                            "partial",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LabOrder"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Orders tests of the catalogue for the patient, ordered by the signed in doctor. Panels are\nexpanded to their tests. The order may be placed in one of the patient's encounters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "Order lab tests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Order",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateLabOrderReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LabOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/labs": {
+            "get": {
+                "description": "Returns the patient's results as a table with a row per test, in catalogue order, and a\ncolumn per collection time, oldest first. Cells are null where a test wasn't done. The\ntests may be limited to test or panel codes, and the collection time bounded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labs"
+                ],
+                "summary": "Get a patient's cumulative lab results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "CBC,GLUF",
+                        "description": "Comma separated test or panel codes",
+                        "name": "tests",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collected at or after (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collected at or before (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LabTable"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/medications": {
+            "get": {
+                "description": "Lists the patient's medications, most recently started first. Pass status=current for the\nactive and on-hold medications. A course past its stop date is listed as completed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "List a patient's medications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "current",
+                            "active",
+                            "on-hold",
+                            "completed",
+                            "stopped",
+                            "entered-in-error"
+                        ],
+                        "type": "string",
+                        "description": "Medication status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Medication"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a prescription to the patient's medication list. The drug is coded from the terminology\nsubstance list and its name defaults to the drug's display text. Without a stop date a\ncourse with a duration stops after it. The prescriber is the signed in doctor.\nThe drug is checked against the patient's allergies and current medications. Severe warnings\nare returned with a 409 unless an override reason is given; all warnings are kept with the\nprescription.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "Prescribe a medication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Prescription",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PrescribeMedicationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Medication"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.InteractionWarningRes"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/no-known-allergies": {
+            "put": {
+                "description": "Records that the patient was asked and has no known allergies, so an empty allergy list\nisn't mistaken for one that was never taken. It conflicts with recorded allergies that\naren't refuted or entered in error, and is cleared when an allergy is recorded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Allergy"
+                ],
+                "summary": "Assert no known allergies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID (UUID)",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.NoKnownAllergies"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/notes": {
+            "get": {
+                "description": "Lists the patient's notes with their amendments, the most recent first. Drafts are only\nlisted for their author.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "List a patient's clinical notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "signed",
+                            "amended"
+                        ],
+                        "type": "string",
+                        "description": "Note status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ClinicalNote"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Starts a draft SOAP note about the patient, authored by the signed in doctor. A template\nprefills the sections left blank. The note may be written in one of the patient's\nencounters. Drafts are only visible to their author until they are signed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Start a clinical note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateNoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ClinicalNote"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/referrals": {
+            "get": {
+                "description": "Lists the referrals of the patient, newest first, without the record snapshots.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "List a patient's referrals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Referral"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Refers the patient to a doctor of the clinic or to an outside facility, with the reason and\nurgency. A snapshot of the patient's record is attached for the recipient. The referral\nstarts pending.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Refer a patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Referral",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateReferralReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Referral"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/social-history": {
+            "get": {
+                "description": "Returns the patient's smoking with pack-years, alcohol use and occupation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Get a patient's social history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SocialHistory"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Records the patient's smoking, alcohol use and occupation, replacing the social history\nrecorded before. Pack-years are worked out from the cigarettes a day and the years smoked,\nup to the quit age for a former smoker or the patient's age for a current one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Record a patient's social history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Social history",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetSocialHistoryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SocialHistory"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the patient's social history recorded by mistake.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Delete a patient's social history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/timeline": {
+            "get": {
+                "description": "Merges the patient's registration, encounters, vitals readings, diagnoses, conditions and their\nstatus changes, allergies, prescriptions, lab results, immunizations, notes and their amendments,\nreferrals, and the edits of the patient's details, diagnoses, allergies and vitals into one list\nof events, newest first, with the name of the user who caused each event where it's recorded.\nDraft notes are only shown to their author.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Timeline of a patient's record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated event types (registration, vitals, diagnosis, condition, condition-status, allergy, note, note-amendment, referral, edit, encounter, medication, lab-result, immunization)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest event time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest event time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Events per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Timeline"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/vitals": {
+            "get": {
+                "description": "Lists a patient's vitals observations, newest first, optionally within a time range.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "List patient's vitals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest measurement time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest measurement time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of observations",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial",
+                            "si"
+                        ],
+                        "type": "string",
+                        "description": "Units of the response values",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records a new vitals observation for a patient. Earlier observations are kept, so\nthe patient's vitals form a time series. Values may be sent in other units (lb, in, °F, kPa)\nby naming them in \"units\"; they are stored as cm, kg, °C and mmHg. The BMI is computed from\nheight and weight, and a supplied BMI that disagrees with them is rejected. Physiologically\nimpossible values are rejected with a message per field, and the response flags every value\nas low, normal, high or critical for the patient's age and sex. When respiratory rate, SpO2,\nsupplemental oxygen, systolic pressure, pulse, consciousness and temperature are all present the\nNEWS2 score is computed and stored with the observation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "Capture patient's vitals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vital Information",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateVitalReq"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial",
+                            "si"
+                        ],
+                        "type": "string",
+                        "description": "Units of the response values",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/vitals/latest": {
+            "get": {
+                "description": "Returns the most recent reading of every metric recorded for the patient.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "Latest value of each vital",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial",
+                            "si"
+                        ],
+                        "type": "string",
+                        "description": "Units of the response values",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/patient/{patientID}/vitals/series/{metric}": {
+            "get": {
+                "description": "Returns the readings of one metric over time, oldest first, for charting trends.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "Series of a single vital",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "heightCm",
+                            "weightKg",
+                            "bmi",
+                            "temperatureC",
+                            "pulse",
+                            "respiratoryRate",
+                            "bloodPressureSystolic",
+                            "bloodPressureDiastolic",
+                            "oxygenSaturation"
+                        ],
+                        "type": "string",
+                        "description": "Metric",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest measurement time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest measurement time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of readings",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial",
+                            "si"
+                        ],
+                        "type": "string",
+                        "description": "Units of the response values",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.VitalSeries"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/queue": {
+            "get": {
+                "description": "Returns today's queue: waiting patients in the order they'll be seen with their estimated\nwait, patients in consultation and patients done. Waits are estimated from the day's\naverage consultation length and the number of doctors seeing patients.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Get today's walk-in queue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueueBoard"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a registered patient to today's walk-in queue. The priority defaults to standard until\nthe patient is triaged. A patient can't check in again until their entry is done.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Check a patient in to the walk-in queue",
+                "parameters": [
+                    {
+                        "description": "Check-in",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckInReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueueEntry"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/queue/stream": {
+            "get": {
+                "description": "Server-Sent Events stream for reception screens. A \"board\" event carrying today's queue is\nsent on connecting and after every check-in, triage or status change; a comment is sent\nevery 15 seconds while the queue is idle.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Stream today's walk-in queue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueueBoard"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/queue/{entryID}/status": {
+            "put": {
+                "description": "Moves a patient from waiting to in-consultation and on to done. A patient in consultation may\nbe sent back to wait, and a waiting patient who left is marked done. Calling a patient in\nrecords the doctor, by default the signed in doctor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Move a patient along the queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue entry ID",
+                        "name": "entryID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.QueueStatusReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueueEntry"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/queue/{entryID}/triage": {
+            "put": {
+                "description": "Sets the priority of a patient waiting or in consultation. Waiting patients are seen by\npriority, then in the order they checked in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Triage a patient in the queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue entry ID",
+                        "name": "entryID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Priority",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TriageReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueueEntry"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/referral/{referralID}": {
+            "get": {
+                "description": "Returns a referral with the snapshot of the patient's record taken when it was made.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Get a referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "referralID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Referral"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/referral/{referralID}/accept": {
+            "post": {
+                "description": "Accepts a pending referral. The doctor referred to accepts it; for an outside facility the\nreferring doctor records that the facility accepted it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Accept a referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "referralID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReferralActionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Referral"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/referral/{referralID}/cancel": {
+            "post": {
+                "description": "Cancels a pending or accepted referral. Only the referring doctor can cancel it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Cancel a referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "referralID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for cancelling",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReferralActionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Referral"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/referral/{referralID}/complete": {
+            "post": {
+                "description": "Completes an accepted referral once the patient was seen, with the outcome in the note.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Complete a referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "referralID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReferralActionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Referral"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/referral/{referralID}/decline": {
+            "post": {
+                "description": "Declines a pending referral with the reason in the note. The doctor referred to declines it;\nfor an outside facility the referring doctor records that the facility declined it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Decline a referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "referralID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for declining",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReferralActionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Referral"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/referrals/inbox": {
+            "get": {
+                "description": "Lists the referrals made to the signed in doctor, or with box=sent the ones they made,\nnewest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Referral inbox",
+                "parameters": [
+                    {
+                        "enum": [
+                            "received",
+                            "sent"
+                        ],
+                        "type": "string",
+                        "default": "received",
+                        "description": "Received or sent referrals",
+                        "name": "box",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "declined",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "routine",
+                            "urgent",
+                            "emergency"
+                        ],
+                        "type": "string",
+                        "description": "Urgency",
+                        "name": "urgency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of referrals",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Referral"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/terminology/icd10": {
+            "get": {
+                "description": "Autocompletes ICD-10 codes for coding diagnoses. Codes starting with the query come first,\nthen codes whose display text has a word starting with it, then any other text match.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Diagnoses"
+                ],
+                "summary": "Search ICD-10 codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Code or text to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of codes (default 20, at most 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/terminology.Code"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/terminology/substances": {
+            "get": {
+                "description": "Autocompletes coded substances for recording allergies: RxNorm medication ingredients and\nSNOMED CT food, environmental and biologic substances. Substances with a word starting with\nthe query come first, then any other text match.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Allergy"
+                ],
+                "summary": "Search allergy substances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Code or text to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "food",
+                            "medication",
+                            "environment",
+                            "biologic"
+                        ],
+                        "type": "string",
+                        "description": "Substance category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of substances (default 20, at most 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/terminology.Substance"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/logout": {
+            "post": {
+                "description": "Clears the session cookie for the current user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Log out a user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/signin": {
+            "post": {
+                "description": "Authenticates a user and sets a session cookie upon successful login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Log in a user",
+                "parameters": [
+                    {
+                        "description": "Login request payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SigninReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/signup": {
+            "post": {
+                "description": "Registers a new user with fullname, email, password, and role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Signup request payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SignupReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/vitals/{vitalID}": {
+            "put": {
+                "description": "Corrects the values of a single vitals observation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "Correct a vitals observation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vital ID",
+                        "name": "vitalID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Vital Information",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateVitalReq"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial",
+                            "si"
+                        ],
+                        "type": "string",
+                        "description": "Units of the response values",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationFailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a single vitals observation, e.g. one recorded against the wrong patient.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vitals"
+                ],
+                "summary": "Delete a vitals observation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vital ID",
+                        "name": "vitalID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.FailureResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "clinical.NEWS2Risk": {
+            "type": "string",
+            "enum": [
+                "low",
+                "low-medium",
+                "medium",
+                "high"
+            ],
+            "x-enum-varnames": [
+                "NEWS2RiskLow",
+                "NEWS2RiskLowMedium",
+                "NEWS2RiskMedium",
+                "NEWS2RiskHigh"
+            ]
+        },
+        "clinical.NEWS2Score": {
+            "type": "object",
+            "properties": {
+                "risk": {
+                    "description": "Risk is low, low-medium, medium or high.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/clinical.NEWS2Risk"
+                        }
+                    ],
+                    "example": "low-medium"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "clinical.ReferenceRange": {
+            "type": "object",
+            "properties": {
+                "criticalHigh": {
+                    "type": "number"
+                },
+                "criticalLow": {
+                    "type": "number"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "maxAge": {
+                    "type": "number"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "minAge": {
+                    "description": "MinAge is inclusive and MaxAge exclusive, both in years. A zero\nMaxAge means no upper bound.",
+                    "type": "number"
+                },
+                "sex": {
+                    "description": "Sex is MALE, FEMALE or empty when the range applies to both.",
+                    "type": "string"
+                }
+            }
+        },
+        "growth.Curve": {
+            "type": "object",
+            "properties": {
+                "percentile": {
+                    "type": "number",
+                    "example": 2.3
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/growth.CurvePoint"
+                    }
+                },
+                "zScore": {
+                    "type": "number",
+                    "example": -2
+                }
+            }
+        },
+        "growth.CurvePoint": {
+            "type": "object",
+            "properties": {
+                "ageMonths": {
+                    "type": "number",
+                    "example": 12
+                },
+                "value": {
+                    "type": "number",
+                    "example": 7.7
+                }
+            }
+        },
+        "immunization.Dose": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "Age is when the dose is due and MaxAge when it can no longer be given.",
+                    "type": "string",
+                    "example": "6w"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Pentavalent 1"
+                },
+                "maxAge": {
+                    "type": "string",
+                    "example": "1y"
+                },
+                "minInterval": {
+                    "description": "MinInterval is the least time after the previous dose of the vaccine.",
+                    "type": "string",
+                    "example": "4w"
+                },
+                "number": {
+                    "description": "Number counts the doses of the vaccine from 1.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "immunization.DoseStatus": {
+            "type": "object",
+            "properties": {
+                "dose": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "dueDate": {
+                    "description": "DueDate is when the dose is due, later than the schedule's age when an\nearlier dose was given late. OverdueDate ends the grace period and\nLastDate is the last day it can be given.",
+                    "type": "string"
+                },
+                "givenOn": {
+                    "description": "GivenOn is set once the dose was given.",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Pentavalent 2"
+                },
+                "lastDate": {
+                    "type": "string"
+                },
+                "overdueDate": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is given, upcoming, due, overdue or lapsed.",
+                    "type": "string",
+                    "example": "overdue"
+                },
+                "vaccine": {
+                    "type": "string",
+                    "example": "PENTA"
+                },
+                "vaccineName": {
+                    "type": "string",
+                    "example": "Pentavalent (DPT, Hep B, Hib)"
+                }
+            }
+        },
+        "immunization.Schedule": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "India Universal Immunization Programme"
+                },
+                "overdueAfter": {
+                    "description": "OverdueAfter is the grace period after the due date of a dose.",
+                    "type": "string",
+                    "example": "4w"
+                },
+                "vaccines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/immunization.Vaccine"
+                    }
+                }
+            }
+        },
+        "immunization.Vaccine": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "PENTA"
+                },
+                "doses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/immunization.Dose"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pentavalent (DPT, Hep B, Hib)"
+                }
+            }
+        },
+        "labs.Catalogue": {
+            "type": "object",
+            "properties": {
+                "panels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/labs.Panel"
+                    }
+                },
+                "tests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/labs.Test"
+                    }
+                }
+            }
+        },
+        "labs.Panel": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "CBC"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Complete blood count"
+                },
+                "tests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "HGB",
+                        "WBC",
+                        "PLT"
+                    ]
+                }
+            }
+        },
+        "labs.Test": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "HGB"
+                },
+                "conversions": {
+                    "description": "Conversions maps another unit results may be reported in to the factor\nturning a value in it into Unit.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Haemoglobin"
+                },
+                "normalValues": {
+                    "description": "NormalValues are the expected results of a qualitative test.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "negative"
+                    ]
+                },
+                "ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clinical.ReferenceRange"
+                    }
+                },
+                "specimen": {
+                    "type": "string",
+                    "example": "Whole blood (EDTA)"
+                },
+                "unit": {
+                    "description": "Unit is the unit results are stored and reported in.",
+                    "type": "string",
+                    "example": "g/dL"
+                }
+            }
+        },
+        "models.AddCareTeamMemberReq": {
+            "description": "Request payload to add a user to a patient's care team.",
+            "type": "object",
+            "required": [
+                "role",
+                "userId"
+            ],
+            "properties": {
+                "role": {
+                    "description": "Role is the member's role in the team, such as attending or nurse.\nrequired: true\nmax length: 50",
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "attending"
+                },
+                "userId": {
+                    "description": "UserID is the user joining the care team.\nrequired: true",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
         "models.AddConditionReq": {
             "type": "object",
             "required": [
-                "condition"
+                "condition"
+            ],
+            "properties": {
+                "abatementDate": {
+                    "description": "AbatementDate is when the condition stopped being active. Only allowed\nwith a status other than active.\noptional: true\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "clinicalStatus": {
+                    "description": "ClinicalStatus defaults to active. A condition recorded from the\npatient's history may already be resolved or in remission.\noptional: true\nallowed values: active, remission, resolved, inactive",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "remission",
+                        "resolved",
+                        "inactive"
+                    ],
+                    "example": "active"
+                },
+                "condition": {
+                    "description": "The condition to be added.\nrequired: true\nmin length: 2\nmax length: 30",
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                },
+                "encounterId": {
+                    "description": "EncounterID is the encounter the entry is recorded in. It must be an\nencounter of the patient that wasn't cancelled.\noptional: true",
+                    "type": "string"
+                },
+                "note": {
+                    "description": "Note is free text about the condition.\noptional: true\nmax length: 1000",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "onsetDate": {
+                    "description": "OnsetDate is when the condition began.\noptional: true\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2024-03-01"
+                },
+                "verificationStatus": {
+                    "description": "VerificationStatus defaults to confirmed.\noptional: true\nallowed values: unconfirmed, provisional, differential, confirmed",
+                    "type": "string",
+                    "enum": [
+                        "unconfirmed",
+                        "provisional",
+                        "differential",
+                        "confirmed"
+                    ],
+                    "example": "confirmed"
+                }
+            }
+        },
+        "models.AddFamilyHistoryReq": {
+            "description": "Request payload to record a condition of a blood relative.",
+            "type": "object",
+            "required": [
+                "relationship"
+            ],
+            "properties": {
+                "ageAtDeath": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "ageAtOnset": {
+                    "description": "AgeAtOnset is the relative's age when the condition began.\noptional: true",
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0,
+                    "example": 45
+                },
+                "code": {
+                    "description": "Code is the ICD-10 code of the condition. It must be a known code.\noptional: true",
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 3,
+                    "example": "E11.9"
+                },
+                "condition": {
+                    "description": "Condition defaults to the display text of the code.\nrequired: without code",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2,
+                    "example": "Type 2 diabetes"
+                },
+                "deceased": {
+                    "description": "Deceased tells whether the relative has died; AgeAtDeath is only\nrecorded for a relative who has.\noptional: true",
+                    "type": "boolean",
+                    "example": false
+                },
+                "notes": {
+                    "description": "Notes about the relative or the condition.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "relationship": {
+                    "description": "Relationship of the relative to the patient.\nrequired: true",
+                    "type": "string",
+                    "enum": [
+                        "mother",
+                        "father",
+                        "sister",
+                        "brother",
+                        "daughter",
+                        "son",
+                        "maternal-grandmother",
+                        "maternal-grandfather",
+                        "paternal-grandmother",
+                        "paternal-grandfather",
+                        "aunt",
+                        "uncle",
+                        "cousin",
+                        "other"
+                    ],
+                    "example": "mother"
+                }
+            }
+        },
+        "models.AddLabResultsRes": {
+            "description": "Stored results and the external IDs of the results that were already received.",
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LabResult"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.AddScheduleExceptionReq": {
+            "description": "Request payload to block out a doctor's time.",
+            "type": "object",
+            "required": [
+                "endsAt",
+                "startsAt"
+            ],
+            "properties": {
+                "endsAt": {
+                    "type": "string",
+                    "example": "2026-10-23T00:00:00+05:30"
+                },
+                "reason": {
+                    "description": "Reason for the exception.\noptional: true\nmax length: 255",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Annual leave"
+                },
+                "startsAt": {
+                    "description": "StartsAt and EndsAt bound the exception. A whole day off runs from\nmidnight to midnight.\nrequired: true",
+                    "type": "string",
+                    "example": "2026-10-20T00:00:00+05:30"
+                }
+            }
+        },
+        "models.AlertActionReq": {
+            "description": "Request payload to acknowledge or resolve an alert.",
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "Note explains how the alert was handled. Only kept on resolve.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Repeat obs normal, reviewed by registrar"
+                }
+            }
+        },
+        "models.Allergy": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "medication"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "criticality": {
+                    "type": "string",
+                    "example": "high"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "reaction": {
+                    "type": "string"
+                },
+                "reactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AllergyReaction"
+                    }
+                },
+                "severity": {
+                    "description": "Severity and Reaction summarise Reactions: the worst severity and the\nmanifestations.",
+                    "type": "string"
+                },
+                "substance": {
+                    "$ref": "#/definitions/models.CodedSubstance"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verificationStatus": {
+                    "type": "string",
+                    "example": "confirmed"
+                }
+            }
+        },
+        "models.AllergyModel": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "criticality": {
+                    "type": "string"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "patientID": {
+                    "type": "string"
+                },
+                "reaction": {
+                    "type": "string"
+                },
+                "reactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AllergyReaction"
+                    }
+                },
+                "recordedAt": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "substance": {
+                    "$ref": "#/definitions/models.CodedSubstance"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verificationStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AllergyReaction": {
+            "type": "object",
+            "required": [
+                "manifestation"
+            ],
+            "properties": {
+                "manifestation": {
+                    "description": "Manifestation is what happened, e.g. urticaria or wheeze.\nrequired: true\nmin length: 2\nmax length: 255",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2,
+                    "example": "Urticaria"
+                },
+                "note": {
+                    "description": "Note is free text about the reaction.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "onsetDate": {
+                    "description": "OnsetDate is when the reaction happened.\noptional: true\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2021-06-14"
+                },
+                "severity": {
+                    "description": "Severity of the reaction.\noptional: true\nallowed values: mild, moderate, severe",
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe"
+                    ],
+                    "example": "moderate"
+                }
+            }
+        },
+        "models.AmendNoteReq": {
+            "description": "Request payload to append an amendment to a signed note.",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "assessment": {
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "Dengue fever without warning signs"
+                },
+                "objective": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "plan": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "reason": {
+                    "description": "Reason for the amendment.\nrequired: true\nmin length: 5\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 5,
+                    "example": "Dengue NS1 came back positive"
+                },
+                "subjective": {
+                    "description": "The sections the amendment adds to; at least one is required.\nmax length: 10000",
+                    "type": "string",
+                    "maxLength": 10000
+                }
+            }
+        },
+        "models.Appointment": {
+            "description": "Appointment of a patient with a doctor.",
+            "type": "object",
+            "properties": {
+                "bookedById": {
+                    "type": "string"
+                },
+                "cancellationReason": {
+                    "type": "string",
+                    "example": "Patient travelling"
+                },
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "cancelledById": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "doctorId": {
+                    "type": "string"
+                },
+                "endsAt": {
+                    "type": "string",
+                    "example": "2026-10-19T09:15:00+05:30"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Follow-up of blood pressure"
+                },
+                "rescheduledFromId": {
+                    "description": "RescheduledFromID is the appointment this one replaced.",
+                    "type": "string"
+                },
+                "startsAt": {
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00+05:30"
+                },
+                "status": {
+                    "description": "Status is booked, cancelled or rescheduled.",
+                    "type": "string",
+                    "example": "booked"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BookAppointmentReq": {
+            "description": "Request payload to book a patient into a doctor's slot.",
+            "type": "object",
+            "required": [
+                "doctorId",
+                "startsAt"
+            ],
+            "properties": {
+                "doctorId": {
+                    "description": "DoctorID is the doctor the patient is booked with.\nrequired: true",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason for the visit.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Follow-up of blood pressure"
+                },
+                "startsAt": {
+                    "description": "StartsAt is the start of a free slot of the doctor.\nrequired: true",
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00+05:30"
+                }
+            }
+        },
+        "models.CancelAppointmentReq": {
+            "description": "Request payload to cancel an appointment.",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Reason for the cancellation.\nrequired: true\nmin length: 3\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 3,
+                    "example": "Patient travelling"
+                }
+            }
+        },
+        "models.CheckInReq": {
+            "description": "Request payload to check a registered patient in to the walk-in queue.",
+            "type": "object",
+            "required": [
+                "patientId"
+            ],
+            "properties": {
+                "complaint": {
+                    "description": "Complaint is why the patient came.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Fever since yesterday"
+                },
+                "patientId": {
+                    "description": "PatientID is the registered patient checking in.\nrequired: true",
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "Priority defaults to standard until the patient is triaged.\noptional: true\nallowed values: immediate, urgent, standard, non-urgent",
+                    "type": "string",
+                    "enum": [
+                        "immediate",
+                        "urgent",
+                        "standard",
+                        "non-urgent"
+                    ],
+                    "example": "standard"
+                }
+            }
+        },
+        "models.ClinicalNote": {
+            "description": "Narrative note with subjective, objective, assessment and plan sections, and the amendments made after it was signed.",
+            "type": "object",
+            "properties": {
+                "amendments": {
+                    "description": "Amendments are oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NoteAmendment"
+                    }
+                },
+                "assessment": {
+                    "type": "string"
+                },
+                "authorId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "objective": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "plan": {
+                    "type": "string"
+                },
+                "signedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is draft, signed or amended.",
+                    "type": "string",
+                    "example": "signed"
+                },
+                "subjective": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "string",
+                    "example": "fever"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fever, day 3"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CodedSubstance": {
+            "type": "object",
+            "required": [
+                "code",
+                "system"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Code is the substance's code in System.",
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "723"
+                },
+                "display": {
+                    "description": "Display is filled in from the substance list.",
+                    "type": "string",
+                    "example": "Amoxicillin"
+                },
+                "system": {
+                    "description": "System is the code system of Code.\nallowed values: rxnorm, snomed",
+                    "type": "string",
+                    "enum": [
+                        "rxnorm",
+                        "snomed"
+                    ],
+                    "example": "rxnorm"
+                }
+            }
+        },
+        "models.Condition": {
+            "type": "object",
+            "properties": {
+                "abatementDate": {
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "clinicalStatus": {
+                    "description": "ClinicalStatus is active, recurrence, remission, resolved or inactive.",
+                    "type": "string",
+                    "example": "active"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "onsetDate": {
+                    "type": "string",
+                    "example": "2024-03-01"
+                },
+                "patientID": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verificationStatus": {
+                    "description": "VerificationStatus is unconfirmed, provisional, differential,\nconfirmed, refuted or entered-in-error.",
+                    "type": "string",
+                    "example": "confirmed"
+                }
+            }
+        },
+        "models.ConditionModel": {
+            "type": "object",
+            "properties": {
+                "abatementDate": {
+                    "type": "string"
+                },
+                "clinicalStatus": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "history": {
+                    "description": "History lists the condition's status changes, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConditionTransition"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "onsetDate": {
+                    "type": "string"
+                },
+                "patientID": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verificationStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ConditionTransition": {
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "type": "string"
+                },
+                "changedById": {
+                    "type": "string"
+                },
+                "conditionId": {
+                    "type": "string"
+                },
+                "fromClinicalStatus": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "fromVerificationStatus": {
+                    "type": "string",
+                    "example": "confirmed"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "toClinicalStatus": {
+                    "type": "string",
+                    "example": "resolved"
+                },
+                "toVerificationStatus": {
+                    "type": "string",
+                    "example": "confirmed"
+                }
+            }
+        },
+        "models.CreateEncounterReq": {
+            "description": "Request payload to open an encounter.",
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "attendingId": {
+                    "description": "AttendingID is the doctor responsible for the encounter. It defaults\nto the signed in user.\noptional: true",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is why the patient came.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Fever and cough for three days"
+                },
+                "startedAt": {
+                    "description": "StartedAt defaults to now.\noptional: true",
+                    "type": "string",
+                    "example": "2026-10-18T09:30:00Z"
+                },
+                "status": {
+                    "description": "Status defaults to in-progress; a visit booked ahead is planned.\noptional: true\nallowed values: planned, in-progress",
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "in-progress"
+                    ],
+                    "example": "in-progress"
+                },
+                "type": {
+                    "description": "Type is the kind of visit.\nrequired: true\nallowed values: outpatient, inpatient, emergency, teleconsultation, home-visit",
+                    "type": "string",
+                    "enum": [
+                        "outpatient",
+                        "inpatient",
+                        "emergency",
+                        "teleconsultation",
+                        "home-visit"
+                    ],
+                    "example": "outpatient"
+                }
+            }
+        },
+        "models.CreateLabOrderReq": {
+            "description": "Request payload to order tests or panels of the lab catalogue.",
+            "type": "object",
+            "required": [
+                "tests"
+            ],
+            "properties": {
+                "encounterId": {
+                    "description": "EncounterID is the encounter the tests are ordered in.\noptional: true",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "Notes for the lab.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Fever for three days"
+                },
+                "priority": {
+                    "description": "Priority defaults to routine.\noptional: true",
+                    "type": "string",
+                    "enum": [
+                        "routine",
+                        "urgent",
+                        "stat"
+                    ],
+                    "example": "urgent"
+                },
+                "tests": {
+                    "description": "Tests are codes of tests or panels of the catalogue.\nrequired: true",
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CBC",
+                        "NS1"
+                    ]
+                }
+            }
+        },
+        "models.CreateNoteReq": {
+            "description": "Request payload to start a draft note, optionally from a template.",
+            "type": "object",
+            "properties": {
+                "assessment": {
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "Acute febrile illness, likely viral."
+                },
+                "encounterId": {
+                    "description": "EncounterID is the encounter the note is written in.\noptional: true",
+                    "type": "string"
+                },
+                "objective": {
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "Temp 38.9 C, pulse 104, no rash."
+                },
+                "plan": {
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "CBC and dengue NS1. Paracetamol. Review in two days."
+                },
+                "subjective": {
+                    "description": "The SOAP sections.\noptional: true\nmax length: 10000",
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "Fever for three days with body ache."
+                },
+                "templateId": {
+                    "description": "TemplateID prefills the sections left blank.\noptional: true",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "fever"
+                },
+                "title": {
+                    "description": "Title of the note.\noptional: true\nmax length: 200",
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Fever, day 3"
+                }
+            }
+        },
+        "models.CreateReferralReq": {
+            "description": "Request payload to refer a patient to a doctor of the clinic or to an outside facility.",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "facility": {
+                    "description": "Facility is the outside facility the patient is referred to.\nrequired: without toDoctorId",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReferralFacility"
+                        }
+                    ]
+                },
+                "reason": {
+                    "description": "Reason for the referral.\nrequired: true",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 5,
+                    "example": "Exertional chest pain, TMT advised"
+                },
+                "specialty": {
+                    "description": "Specialty the patient is referred for.\noptional: true",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Cardiology"
+                },
+                "toDoctorId": {
+                    "description": "ToDoctorID is the doctor of the clinic the patient is referred to.\nrequired: without facility",
+                    "type": "string"
+                },
+                "urgency": {
+                    "description": "Urgency defaults to routine.\noptional: true",
+                    "type": "string",
+                    "enum": [
+                        "routine",
+                        "urgent",
+                        "emergency"
+                    ],
+                    "example": "urgent"
+                }
+            }
+        },
+        "models.CreateVitalReq": {
+            "description": "Request payload to capture new vital signs of a patient.",
+            "type": "object",
+            "properties": {
+                "bloodPressureDiastolic": {
+                    "type": "number",
+                    "example": 80
+                },
+                "bloodPressureSystolic": {
+                    "type": "number",
+                    "example": 120
+                },
+                "bmi": {
+                    "type": "number",
+                    "example": 22.5
+                },
+                "consciousness": {
+                    "description": "Consciousness is the level of consciousness on the ACVPU scale.\nallowed values: alert, confusion, voice, pain, unresponsive",
+                    "type": "string",
+                    "enum": [
+                        "alert",
+                        "confusion",
+                        "voice",
+                        "pain",
+                        "unresponsive"
+                    ],
+                    "example": "alert"
+                },
+                "deviceId": {
+                    "description": "DeviceID identifies the monitor that produced a device reading.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "ward3-monitor-07"
+                },
+                "encounterId": {
+                    "description": "EncounterID is the encounter the observation is taken in. It must be\nan encounter of the patient that wasn't cancelled.",
+                    "type": "string"
+                },
+                "heightCm": {
+                    "type": "number",
+                    "example": 170
+                },
+                "measuredAt": {
+                    "description": "MeasuredAt is when the observation was taken; it defaults to now and\ncannot be in the future.",
+                    "type": "string",
+                    "example": "2026-10-18T09:30:00Z"
+                },
+                "oxygenSaturation": {
+                    "type": "number",
+                    "maximum": 100,
+                    "example": 98
+                },
+                "pulse": {
+                    "type": "integer",
+                    "example": 72
+                },
+                "respiratoryRate": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "source": {
+                    "description": "Source tells where the observation came from.\nallowed values: manual, device, import",
+                    "type": "string",
+                    "enum": [
+                        "manual",
+                        "device",
+                        "import"
+                    ],
+                    "example": "manual"
+                },
+                "supplementalOxygen": {
+                    "description": "SupplementalOxygen tells whether the patient was on oxygen when the\nsaturation was measured.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "temperatureC": {
+                    "type": "number",
+                    "maximum": 45,
+                    "example": 36.5
+                },
+                "units": {
+                    "description": "Units gives the units of the values in this request when they are not\ncm, kg, °C and mmHg. Values are stored converted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.VitalUnits"
+                        }
+                    ]
+                },
+                "weightKg": {
+                    "type": "number",
+                    "example": 65
+                }
+            }
+        },
+        "models.Diagnoses": {
+            "type": "object",
+            "properties": {
+                "clinicianId": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "J45.9"
+                },
+                "codeUnverified": {
+                    "description": "CodeUnverified is set when the code isn't in the bundled code set and\nonly its format was checked.",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "display": {
+                    "type": "string",
+                    "example": "Asthma, unspecified"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "onsetDate": {
+                    "type": "string",
+                    "example": "2026-09-01"
+                },
+                "patientID": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "primary"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DiagnosesModel": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "display": {
+                    "type": "string"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "patientID": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DiagnosesReq": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the ICD-10 code of the diagnosis. A well formed code missing\nfrom the code set is kept as unverified.\noptional: true",
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 3,
+                    "example": "J45.9"
+                },
+                "encounterId": {
+                    "description": "EncounterID is the encounter the entry is recorded in. It must be an\nencounter of the patient that wasn't cancelled.\noptional: true",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name represents the name of the diagnosis. It defaults to the display\ntext of the code, and is required with an unverified code.\nrequired: without code\nmin length: 2\nmax length: 255",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2
+                },
+                "onsetDate": {
+                    "description": "OnsetDate is when the condition began.\noptional: true\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-09-01"
+                },
+                "type": {
+                    "description": "Type is the role of the diagnosis in the patient's care.\noptional: true\nallowed values: primary, secondary, provisional",
+                    "type": "string",
+                    "enum": [
+                        "primary",
+                        "secondary",
+                        "provisional"
+                    ],
+                    "example": "primary"
+                }
+            }
+        },
+        "models.DoctorSchedule": {
+            "description": "A doctor's schedule for a day or a week.",
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScheduleDay"
+                    }
+                },
+                "doctorId": {
+                    "type": "string"
+                },
+                "view": {
+                    "type": "string",
+                    "example": "week"
+                }
+            }
+        },
+        "models.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "contactNo": {
+                    "description": "ContactNumber is the existing patient's contact number.",
+                    "type": "string"
+                },
+                "matchedOn": {
+                    "description": "MatchedOn lists the fields that matched exactly or near exactly.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "patient": {
+                    "description": "Patient is the existing patient record.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ListPatientItem"
+                        }
+                    ]
+                },
+                "score": {
+                    "description": "Score is the match probability in the range [0, 1].",
+                    "type": "number",
+                    "example": 0.92
+                }
+            }
+        },
+        "models.DuplicatePatientRes": {
+            "description": "Conflict response listing probable duplicate patients.",
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateCandidate"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "probable duplicate patients found"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 409
+                }
+            }
+        },
+        "models.Encounter": {
+            "description": "Patient visit with its type, attending doctor, reason and status.",
+            "type": "object",
+            "properties": {
+                "attendingId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Fever and cough for three days"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is planned, in-progress, finished or cancelled.",
+                    "type": "string",
+                    "example": "in-progress"
+                },
+                "type": {
+                    "description": "Type is outpatient, inpatient, emergency, teleconsultation or\nhome-visit.",
+                    "type": "string",
+                    "example": "outpatient"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EncounterRecord": {
+            "description": "Encounter with its diagnoses, conditions, allergies, medications, vitals, signed notes, lab orders and immunizations.",
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergy"
+                    }
+                },
+                "attendingId": {
+                    "type": "string"
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Condition"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "diagnoses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Diagnoses"
+                    }
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "immunizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Immunization"
+                    }
+                },
+                "labOrders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LabOrder"
+                    }
+                },
+                "medications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Medication"
+                    }
+                },
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ClinicalNote"
+                    }
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Fever and cough for three days"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is planned, in-progress, finished or cancelled.",
+                    "type": "string",
+                    "example": "in-progress"
+                },
+                "type": {
+                    "description": "Type is outpatient, inpatient, emergency, teleconsultation or\nhome-visit.",
+                    "type": "string",
+                    "example": "outpatient"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "vitals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VitalModel"
+                    }
+                }
+            }
+        },
+        "models.EnterLabResultsReq": {
+            "description": "Request payload with results of the tests of an order.",
+            "type": "object",
+            "required": [
+                "results"
+            ],
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.LabResultInput"
+                    }
+                }
+            }
+        },
+        "models.FailureResponse": {
+            "description": "Standard error response format with status and error message.",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                }
+            }
+        },
+        "models.FamilyHistory": {
+            "description": "Condition of a relative with their age at onset and whether they died.",
+            "type": "object",
+            "properties": {
+                "ageAtDeath": {
+                    "type": "integer"
+                },
+                "ageAtOnset": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "code": {
+                    "type": "string",
+                    "example": "E11.9"
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "Type 2 diabetes"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deceased": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "display": {
+                    "type": "string",
+                    "example": "Type 2 diabetes mellitus without complications"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "recordedById": {
+                    "type": "string"
+                },
+                "relationship": {
+                    "type": "string",
+                    "example": "mother"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GrowthChart": {
+            "type": "object",
+            "properties": {
+                "curves": {
+                    "description": "Curves are the z-score lines from birth to the child's age.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/growth.Curve"
+                    }
+                },
+                "indicator": {
+                    "type": "string",
+                    "example": "weight-for-age"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weight-for-age"
+                },
+                "points": {
+                    "description": "Points are the child's measurements, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GrowthPoint"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "kg"
+                }
+            }
+        },
+        "models.GrowthCharts": {
+            "description": "Weight, height and BMI of a child scored against the WHO growth standards, with the z-score lines to draw them on.",
+            "type": "object",
+            "properties": {
+                "charts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GrowthChart"
+                    }
+                },
+                "dateOfBirth": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string",
+                    "example": "FEMALE"
+                },
+                "standards": {
+                    "type": "string",
+                    "example": "WHO Child Growth Standards (0-5 years) and WHO Growth Reference (5-19 years), abridged"
+                }
+            }
+        },
+        "models.GrowthPoint": {
+            "type": "object",
+            "properties": {
+                "ageMonths": {
+                    "type": "number",
+                    "example": 14.3
+                },
+                "measuredAt": {
+                    "type": "string"
+                },
+                "percentile": {
+                    "type": "number",
+                    "example": 13.1
+                },
+                "value": {
+                    "type": "number",
+                    "example": 9.2
+                },
+                "vitalId": {
+                    "type": "string"
+                },
+                "zScore": {
+                    "type": "number",
+                    "example": -1.12
+                }
+            }
+        },
+        "models.Immunization": {
+            "description": "Vaccine dose with its lot, site, date and who gave it.",
+            "type": "object",
+            "properties": {
+                "administeredAt": {
+                    "type": "string"
+                },
+                "administeredById": {
+                    "type": "string"
+                },
+                "administeredByName": {
+                    "type": "string",
+                    "example": "PHC Rampur"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "doseNumber": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lot": {
+                    "type": "string",
+                    "example": "PV24031"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "recordedById": {
+                    "type": "string"
+                },
+                "site": {
+                    "type": "string",
+                    "example": "left-thigh"
+                },
+                "vaccine": {
+                    "type": "string",
+                    "example": "PENTA"
+                },
+                "vaccineName": {
+                    "type": "string",
+                    "example": "Pentavalent (DPT, Hep B, Hib)"
+                }
+            }
+        },
+        "models.ImmunizationStatus": {
+            "description": "Every dose of the schedule for the patient with its due date and status.",
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string"
+                },
+                "doses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/immunization.DoseStatus"
+                    }
+                },
+                "due": {
+                    "description": "Due and Overdue count the doses in those statuses.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "overdue": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string",
+                    "example": "India Universal Immunization Programme"
+                }
+            }
+        },
+        "models.IngestLabResultsReq": {
+            "description": "Request payload with results from a lab system. Each names its patient, and optionally its order.",
+            "type": "object",
+            "required": [
+                "results"
+            ],
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.LabResultInput"
+                    }
+                }
+            }
+        },
+        "models.InteractionWarning": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Amoxicillin is a penicillin and the patient is allergic to Penicillin V"
+                },
+                "severity": {
+                    "description": "Severity is minor, moderate or severe.",
+                    "type": "string",
+                    "example": "severe"
+                },
+                "subjectId": {
+                    "description": "SubjectID is the allergy or medication the drug conflicts with.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is drug-allergy, drug-drug or duplicate-therapy.",
+                    "type": "string",
+                    "example": "drug-allergy"
+                }
+            }
+        },
+        "models.InteractionWarningRes": {
+            "description": "Conflict response listing the interaction warnings to override.",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "severe interaction warnings need an override reason"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InteractionWarning"
+                    }
+                }
+            }
+        },
+        "models.LabOrder": {
+            "description": "Lab tests ordered for a patient with the results received so far.",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Fever for three days"
+                },
+                "orderedById": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "Priority is routine, urgent or stat.",
+                    "type": "string",
+                    "example": "routine"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LabResult"
+                    }
+                },
+                "status": {
+                    "description": "Status is ordered, partial, completed or cancelled.",
+                    "type": "string",
+                    "example": "partial"
+                },
+                "tests": {
+                    "description": "Tests are the codes of the ordered tests, with panels expanded.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "HGB",
+                        "WBC",
+                        "PLT"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LabResult": {
+            "description": "Result of a lab test with its unit, the reference range it was flagged against and the flag.",
+            "type": "object",
+            "properties": {
+                "collectedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "enteredById": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "type": "string"
+                },
+                "flag": {
+                    "description": "Flag is low, normal, high, critical or abnormal, or empty when no\nreference range applies.",
+                    "type": "string",
+                    "example": "low"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "referenceHigh": {
+                    "type": "number",
+                    "example": 15.5
+                },
+                "referenceLow": {
+                    "type": "number",
+                    "example": 12
+                },
+                "referenceText": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is manual or ingested.",
+                    "type": "string",
+                    "example": "manual"
+                },
+                "testCode": {
+                    "type": "string",
+                    "example": "HGB"
+                },
+                "testName": {
+                    "type": "string",
+                    "example": "Haemoglobin"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g/dL"
+                },
+                "value": {
+                    "description": "Value is set for quantitative tests and ValueText for qualitative ones.",
+                    "type": "number",
+                    "example": 10.8
+                },
+                "valueText": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LabResultInput": {
+            "description": "Result of one test. The value may be given in any unit the catalogue converts from.",
+            "type": "object",
+            "required": [
+                "testCode"
+            ],
+            "properties": {
+                "collectedAt": {
+                    "description": "CollectedAt is when the specimen was collected. It defaults to now.\noptional: true",
+                    "type": "string"
+                },
+                "externalId": {
+                    "description": "ExternalID is the ID of the result in the lab system it comes from.\nResults already received with the ID are skipped.\noptional: true",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "description": "PatientID and OrderID are taken from the URL when results of an order\nare entered by hand.",
+                    "type": "string"
+                },
+                "referenceHigh": {
+                    "type": "number"
+                },
+                "referenceLow": {
+                    "description": "ReferenceLow and ReferenceHigh are the range the lab reported the\nresult with, in Unit. They replace the range of the catalogue.\noptional: true",
+                    "type": "number"
+                },
+                "testCode": {
+                    "description": "TestCode is the code of a test of the catalogue.\nrequired: true",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "HGB"
+                },
+                "unit": {
+                    "description": "Unit defaults to the unit of the test.\noptional: true",
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "g/L"
+                },
+                "value": {
+                    "description": "Value of a quantitative test, in Unit.",
+                    "type": "number",
+                    "example": 108
+                },
+                "valueText": {
+                    "description": "ValueText of a qualitative test.\nmax length: 200",
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "models.LabTable": {
+            "description": "Cumulative results with a row per test, in catalogue order, and a column per collection time, oldest first.",
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LabTableRow"
+                    }
+                }
+            }
+        },
+        "models.LabTableCell": {
+            "type": "object",
+            "properties": {
+                "flag": {
+                    "type": "string",
+                    "example": "low"
+                },
+                "resultId": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10.8
+                },
+                "valueText": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LabTableRow": {
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LabTableCell"
+                    }
+                },
+                "testCode": {
+                    "type": "string",
+                    "example": "HGB"
+                },
+                "testName": {
+                    "type": "string",
+                    "example": "Haemoglobin"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g/dL"
+                }
+            }
+        },
+        "models.ListPatientItem": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "Age is the patient's age.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the patient was registered.",
+                    "type": "string"
+                },
+                "dob": {
+                    "description": "DOB is the patient's date of birth.",
+                    "type": "string"
+                },
+                "fullName": {
+                    "description": "Username is the username of the patient.",
+                    "type": "string"
+                },
+                "gender": {
+                    "description": "Gender is the patient's gender.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the unique identifier of the patient.",
+                    "type": "string"
+                },
+                "mrn": {
+                    "description": "MRN is the patient's medical record number.",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Score is the search relevance in the range [0, 1], only set for fuzzy\nand phonetic searches.",
+                    "type": "number",
+                    "example": 0.82
+                }
+            }
+        },
+        "models.ListPatientMetadata": {
+            "type": "object",
+            "properties": {
+                "currentPage": {
+                    "description": "e.g., 2",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "e.g., 11",
+                    "type": "integer"
+                },
+                "hasNext": {
+                    "description": "true if next page exists",
+                    "type": "boolean"
+                },
+                "hasPrevious": {
+                    "description": "true if previous page exists",
+                    "type": "boolean"
+                },
+                "pageSize": {
+                    "description": "e.g., 10",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "e.g., 20",
+                    "type": "integer"
+                },
+                "totalItems": {
+                    "description": "e.g., 43",
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "description": "e.g., 5",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Medication": {
+            "description": "Prescribed medication with its dose, route, frequency and status.",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "doseUnit": {
+                    "type": "string",
+                    "example": "mg"
+                },
+                "doseValue": {
+                    "type": "number",
+                    "example": 500
+                },
+                "drug": {
+                    "$ref": "#/definitions/models.CodedSubstance"
+                },
+                "drugName": {
+                    "type": "string",
+                    "example": "Amoxicillin"
+                },
+                "durationDays": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "tds"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instructions": {
+                    "type": "string",
+                    "example": "After food"
+                },
+                "interactionWarnings": {
+                    "description": "InteractionWarnings are the warnings raised when the medication was\nprescribed or last restarted, and OverrideReason why a severe one was\noverridden.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InteractionWarning"
+                    }
+                },
+                "overrideReason": {
+                    "type": "string",
+                    "example": "Tolerated amoxicillin in 2024 without reaction"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "prescriberId": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string",
+                    "example": "oral"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "status": {
+                    "description": "Status is active, on-hold, completed, stopped or entered-in-error.",
+                    "type": "string",
+                    "example": "active"
+                },
+                "stopDate": {
+                    "type": "string",
+                    "example": "2026-10-25"
+                },
+                "stopReason": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MergePatientReq": {
+            "type": "object",
+            "required": [
+                "sourceId",
+                "targetId"
+            ],
+            "properties": {
+                "keepFromSource": {
+                    "description": "KeepFromSource lists the demographic fields whose source value replaces\nthe target value. All other fields keep the target value.\noptional: true\nallowed values: fullname, gender, dob, contactNo, address, emergencyName, emergencyRelation, emergencyPhone",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sourceId": {
+                    "description": "SourceID is the duplicate patient that becomes a tombstone.\nrequired: true",
+                    "type": "string"
+                },
+                "targetId": {
+                    "description": "TargetID is the surviving patient that receives the source's records.\nrequired: true",
+                    "type": "string"
+                }
+            }
+        },
+        "models.NoKnownAllergies": {
+            "type": "object",
+            "properties": {
+                "assertedAt": {
+                    "type": "string"
+                },
+                "assertedById": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NoteAmendment": {
+            "description": "Amendment appended to a signed note with the reason for it.",
+            "type": "object",
+            "properties": {
+                "assessment": {
+                    "type": "string",
+                    "example": "Dengue fever without warning signs"
+                },
+                "authorId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "noteId": {
+                    "type": "string"
+                },
+                "objective": {
+                    "type": "string"
+                },
+                "plan": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Dengue NS1 came back positive"
+                },
+                "subjective": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OverduePatient": {
+            "type": "object",
+            "properties": {
+                "contactNumber": {
+                    "type": "string"
+                },
+                "dateOfBirth": {
+                    "type": "string"
+                },
+                "doses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/immunization.DoseStatus"
+                    }
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "mrn": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OverdueReport": {
+            "description": "Patients with overdue doses, those overdue longest first.",
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string"
+                },
+                "patients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OverduePatient"
+                    }
+                }
+            }
+        },
+        "models.Patient": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "age": {
+                    "type": "integer"
+                },
+                "contactNo": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dob": {
+                    "type": "string"
+                },
+                "duplicateOverrideAt": {
+                    "type": "string"
+                },
+                "duplicateOverrideById": {
+                    "type": "string"
+                },
+                "duplicateOverrideReason": {
+                    "type": "string"
+                },
+                "emergencyName": {
+                    "type": "string"
+                },
+                "emergencyPhone": {
+                    "type": "string"
+                },
+                "emergencyRelation": {
+                    "type": "string"
+                },
+                "fullname": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mrn": {
+                    "type": "string"
+                },
+                "regById": {
+                    "type": "string"
+                },
+                "registrar": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "ward": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PrescribeMedicationReq": {
+            "description": "Request payload to prescribe a medication.",
+            "type": "object",
+            "required": [
+                "doseUnit",
+                "doseValue",
+                "frequency",
+                "route"
+            ],
+            "properties": {
+                "doseUnit": {
+                    "description": "DoseUnit is the unit of DoseValue.\nrequired: true\nallowed values: mg, g, mcg, ml, units, tablet, capsule, puff, drop, sachet, patch",
+                    "type": "string",
+                    "enum": [
+                        "mg",
+                        "g",
+                        "mcg",
+                        "ml",
+                        "units",
+                        "tablet",
+                        "capsule",
+                        "puff",
+                        "drop",
+                        "sachet",
+                        "patch"
+                    ],
+                    "example": "mg"
+                },
+                "doseValue": {
+                    "description": "DoseValue is the amount given each time, in DoseUnit.\nrequired: true",
+                    "type": "number",
+                    "maximum": 100000,
+                    "example": 500
+                },
+                "drug": {
+                    "description": "Drug is the coded drug from the terminology substance list.\noptional: true",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CodedSubstance"
+                        }
+                    ]
+                },
+                "drugName": {
+                    "description": "DrugName is the name of the drug. It defaults to the display text of\nDrug.\nrequired: without drug\nmin length: 2\nmax length: 100",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Amoxicillin"
+                },
+                "durationDays": {
+                    "description": "DurationDays is how long the course lasts. The stop date defaults to\nthe start date plus the duration.\noptional: true",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1,
+                    "example": 7
+                },
+                "encounterId": {
+                    "description": "EncounterID is the encounter the medication is prescribed in. It must\nbe an encounter of the patient that wasn't cancelled.\noptional: true",
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "Frequency is how often the drug is given.\nrequired: true\nallowed values: od, bd, tds, qid, qhs, q4h, q6h, q8h, q12h, weekly, prn, stat",
+                    "type": "string",
+                    "enum": [
+                        "od",
+                        "bd",
+                        "tds",
+                        "qid",
+                        "qhs",
+                        "q4h",
+                        "q6h",
+                        "q8h",
+                        "q12h",
+                        "weekly",
+                        "prn",
+                        "stat"
+                    ],
+                    "example": "tds"
+                },
+                "instructions": {
+                    "description": "Instructions for the patient.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "After food"
+                },
+                "overrideReason": {
+                    "description": "OverrideReason explains why the drug is prescribed despite a severe\ninteraction warning. It is required when there is one.\noptional: true\nmin length: 5\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 5,
+                    "example": "Tolerated amoxicillin in 2024 without reaction"
+                },
+                "route": {
+                    "description": "Route is how the drug is given.\nrequired: true\nallowed values: oral, sublingual, buccal, iv, im, sc, intradermal, topical, transdermal, inhaled, nasal, ophthalmic, otic, rectal, vaginal",
+                    "type": "string",
+                    "enum": [
+                        "oral",
+                        "sublingual",
+                        "buccal",
+                        "iv",
+                        "im",
+                        "sc",
+                        "intradermal",
+                        "topical",
+                        "transdermal",
+                        "inhaled",
+                        "nasal",
+                        "ophthalmic",
+                        "otic",
+                        "rectal",
+                        "vaginal"
+                    ],
+                    "example": "oral"
+                },
+                "startDate": {
+                    "description": "StartDate defaults to today.\noptional: true\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "stopDate": {
+                    "description": "StopDate is when the course ends.\noptional: true\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-10-25"
+                }
+            }
+        },
+        "models.QueueBoard": {
+            "description": "Walk-in queue of the day: who is waiting in the order they'll be seen, who is with a doctor and who is done.",
+            "type": "object",
+            "properties": {
+                "averageConsultationMinutes": {
+                    "description": "AverageConsultationMinutes is the mean length of the day's finished\nconsultations that wait estimates are based on.",
+                    "type": "integer",
+                    "example": 12
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "done": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QueueEntry"
+                    }
+                },
+                "inConsultation": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QueueEntry"
+                    }
+                },
+                "waiting": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QueueEntry"
+                    }
+                }
+            }
+        },
+        "models.QueueEntry": {
+            "description": "Patient checked in to the walk-in queue with their triage priority and status.",
+            "type": "object",
+            "properties": {
+                "calledAt": {
+                    "type": "string"
+                },
+                "checkedInAt": {
+                    "type": "string"
+                },
+                "checkedInById": {
+                    "type": "string"
+                },
+                "complaint": {
+                    "type": "string",
+                    "example": "Fever since yesterday"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "doctorId": {
+                    "type": "string"
+                },
+                "estimatedWaitMinutes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mrn": {
+                    "type": "string",
+                    "example": "MB-20261018-3FA2C1"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "patientName": {
+                    "type": "string",
+                    "example": "Asha Verma"
+                },
+                "position": {
+                    "description": "Position and EstimatedWaitMinutes are set for waiting patients on the\nboard; the first patient to be seen is at position 1.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "priority": {
+                    "description": "Priority is immediate, urgent, standard or non-urgent.",
+                    "type": "string",
+                    "example": "standard"
+                },
+                "status": {
+                    "description": "Status is waiting, in-consultation or done.",
+                    "type": "string",
+                    "example": "waiting"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QueueStatusReq": {
+            "description": "Request payload to change the status of a patient in the queue.",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "doctorId": {
+                    "description": "DoctorID is the doctor seeing the patient when the status is\nin-consultation. It defaults to the signed in doctor.\noptional: true",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the new status.\nrequired: true\nallowed values: waiting, in-consultation, done",
+                    "type": "string",
+                    "enum": [
+                        "waiting",
+                        "in-consultation",
+                        "done"
+                    ],
+                    "example": "in-consultation"
+                }
+            }
+        },
+        "models.Record": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AllergyModel"
+                    }
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConditionModel"
+                    }
+                },
+                "diagnoses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DiagnosesModel"
+                    }
+                },
+                "familyHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FamilyHistory"
+                    }
+                },
+                "medications": {
+                    "description": "Medications are the patient's current (active and on-hold) medications.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Medication"
+                    }
+                },
+                "noKnownAllergies": {
+                    "description": "NoKnownAllergies is set when the patient was asserted to have no\nknown allergies and none were recorded since.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NoKnownAllergies"
+                        }
+                    ]
+                },
+                "patient": {
+                    "$ref": "#/definitions/models.Patient"
+                },
+                "socialHistory": {
+                    "description": "SocialHistory is left out until one is recorded.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SocialHistory"
+                        }
+                    ]
+                },
+                "vitals": {
+                    "$ref": "#/definitions/models.VitalModel"
+                }
+            }
+        },
+        "models.RecordImmunizationReq": {
+            "description": "Request payload to record a vaccine dose given at the clinic or copied from a vaccination card.",
+            "type": "object",
+            "required": [
+                "doseNumber",
+                "vaccine"
+            ],
+            "properties": {
+                "administeredAt": {
+                    "description": "AdministeredAt defaults to now.\noptional: true",
+                    "type": "string"
+                },
+                "administeredById": {
+                    "description": "AdministeredByID is the user who gave the dose and AdministeredByName\nthe person or facility that gave a dose elsewhere. Without either the\nsigned in user gave it.\noptional: true",
+                    "type": "string"
+                },
+                "administeredByName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "PHC Rampur"
+                },
+                "doseNumber": {
+                    "description": "DoseNumber counts the doses of the vaccine from 1.\nrequired: true",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "encounterId": {
+                    "description": "EncounterID is the encounter the dose was given in.\noptional: true",
+                    "type": "string"
+                },
+                "lot": {
+                    "description": "Lot is the batch number on the vial.\noptional: true",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "PV24031"
+                },
+                "notes": {
+                    "description": "Notes such as a reaction after the dose.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "site": {
+                    "description": "Site the dose was given at.\noptional: true",
+                    "type": "string",
+                    "enum": [
+                        "left-arm",
+                        "right-arm",
+                        "left-thigh",
+                        "right-thigh",
+                        "oral",
+                        "nasal"
+                    ],
+                    "example": "left-thigh"
+                },
+                "vaccine": {
+                    "description": "Vaccine is the code of a vaccine of the schedule, or of another one.\nrequired: true",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "PENTA"
+                },
+                "vaccineName": {
+                    "description": "VaccineName is required for vaccines outside the schedule.\noptional: true",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Typhoid conjugate vaccine"
+                }
+            }
+        },
+        "models.Referral": {
+            "description": "Referral of a patient with its status and, when fetched on its own, a snapshot of the patient's record.",
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "facility": {
+                    "$ref": "#/definitions/models.ReferralFacility"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "patientName": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Exertional chest pain, TMT advised"
+                },
+                "referredById": {
+                    "type": "string"
+                },
+                "referredByName": {
+                    "type": "string"
+                },
+                "respondedAt": {
+                    "type": "string"
+                },
+                "responseNote": {
+                    "type": "string"
+                },
+                "specialty": {
+                    "type": "string",
+                    "example": "Cardiology"
+                },
+                "status": {
+                    "description": "Status is pending, accepted, declined, completed or cancelled.",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "summary": {
+                    "description": "Summary is the patient's record when the referral was made.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Record"
+                        }
+                    ]
+                },
+                "toDoctorId": {
+                    "description": "ToDoctorID is set for a referral within the clinic and Facility for\none to an outside facility.",
+                    "type": "string"
+                },
+                "toDoctorName": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "urgency": {
+                    "type": "string",
+                    "example": "urgent"
+                }
+            }
+        },
+        "models.ReferralActionReq": {
+            "description": "Request payload to answer or close a referral.",
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "Note to the other doctor, such as the reason for declining or the\noutcome. It's required to decline.\noptional: true\nmax length: 1000",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Seen, TMT negative, continue medical management"
+                }
+            }
+        },
+        "models.ReferralFacility": {
+            "type": "object",
+            "required": [
+                "name"
             ],
             "properties": {
-                "condition": {
-                    "description": "The condition to be added.\nrequired: true\nmin length: 2\nmax length: 30",
+                "contact": {
+                    "description": "Contact is a phone number or address of the facility.\noptional: true",
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "05862-242345"
+                },
+                "name": {
+                    "description": "Name of the facility.\nrequired: true",
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 2,
+                    "example": "District Hospital, Sitapur"
+                }
+            }
+        },
+        "models.RegAllergyReq": {
+            "description": "A request to register a new allergy for a patient",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "@Param category query string false \"Category, defaults to the substance's\" validate:\"omitempty,oneof=food medication environment biologic\"\n@example \"food\"",
+                    "type": "string",
+                    "enum": [
+                        "food",
+                        "medication",
+                        "environment",
+                        "biologic"
+                    ]
+                },
+                "criticality": {
+                    "description": "@Param criticality query string false \"Risk of a future life-threatening reaction\" validate:\"omitempty,oneof=low high unable-to-assess\"\n@example \"high\"",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "high",
+                        "unable-to-assess"
+                    ]
+                },
+                "encounterId": {
+                    "description": "EncounterID is the encounter the allergy is recorded in. It must be an\nencounter of the patient that wasn't cancelled.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "@Param name query string false \"Allergy Name, defaults to the substance's display text\" validate:\"required_without=Substance,omitempty,min=2,max=100\"\n@example \"Peanut\"",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "reaction": {
+                    "description": "@Param reaction query string false \"Reaction to the allergy, recorded as a single reaction when reactions is empty\" validate:\"omitempty,min=2,max=255\"\n@example \"Swelling\"",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2
+                },
+                "reactions": {
+                    "description": "Reactions the patient had to the substance, at most 10.",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/models.AllergyReaction"
+                    }
+                },
+                "severity": {
+                    "description": "@Param severity query string false \"Severity of the allergy, defaults to the worst reaction's\" validate:\"omitempty,oneof=mild moderate severe\"\n@example \"mild\"",
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe"
+                    ]
+                },
+                "substance": {
+                    "description": "Substance is the coded substance from the terminology substance list.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CodedSubstance"
+                        }
+                    ]
+                },
+                "verificationStatus": {
+                    "description": "@Param verificationStatus query string false \"Verification status, defaults to confirmed\" validate:\"omitempty,oneof=unconfirmed confirmed refuted entered-in-error\"\n@example \"confirmed\"",
+                    "type": "string",
+                    "enum": [
+                        "unconfirmed",
+                        "confirmed",
+                        "refuted",
+                        "entered-in-error"
+                    ]
+                }
+            }
+        },
+        "models.RegPatientReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "contactNo",
+                "dob",
+                "emergencyName",
+                "emergencyPhone",
+                "emergencyRelation",
+                "fullname",
+                "gender"
+            ],
+            "properties": {
+                "address": {
+                    "description": "Address is the patient's address.\nrequired: true\nmin length: 5\nmax length: 255",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5
+                },
+                "contactNo": {
+                    "description": "ContactNumber is the patient's contact number.\nrequired: true\nnumeric: true\nlength: 10 digits",
+                    "type": "string"
+                },
+                "dob": {
+                    "description": "DOB is the date of birth of the patient.\nrequired: true",
+                    "type": "string"
+                },
+                "emergencyName": {
+                    "description": "EmergencyName is the name of the emergency contact person.\nrequired: true",
+                    "type": "string"
+                },
+                "emergencyPhone": {
+                    "description": "EmergencyPhone is the phone number of the emergency contact person.\nrequired: true\nnumeric: true\nlength: 10 digits",
+                    "type": "string"
+                },
+                "emergencyRelation": {
+                    "description": "EmergencyRelation is the relationship to the emergency contact person.\nrequired: true",
+                    "type": "string"
+                },
+                "force": {
+                    "description": "Force registers the patient even when probable duplicates exist.\nThe user forcing the registration is recorded on the patient.\noptional: true",
+                    "type": "boolean"
+                },
+                "forceReason": {
+                    "description": "ForceReason explains why a probable duplicate was registered anyway.\noptional: true\nmax length: 255",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "fullname": {
+                    "description": "FullName is the full name of the patient.\nrequired: true\nmin length: 2\nmax length: 100",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "gender": {
+                    "description": "Gender represents the patient's gender.\nrequired: true\nallowed values: MALE, FEMALE, OTHER",
+                    "type": "string",
+                    "enum": [
+                        "MALE",
+                        "FEMALE",
+                        "OTHER"
+                    ]
+                },
+                "ward": {
+                    "description": "Ward is the ward the patient is admitted to.\noptional: true\nmax length: 50",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.RescheduleAppointmentReq": {
+            "description": "Request payload to move an appointment to another slot of the same doctor.",
+            "type": "object",
+            "required": [
+                "startsAt"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Reason for moving the appointment.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Doctor on leave"
+                },
+                "startsAt": {
+                    "description": "StartsAt is the start of a free slot of the doctor.\nrequired: true",
+                    "type": "string",
+                    "example": "2026-10-21T10:30:00+05:30"
+                }
+            }
+        },
+        "models.ScheduleDay": {
+            "description": "A day of a doctor's schedule.",
+            "type": "object",
+            "properties": {
+                "appointments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Appointment"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-19"
+                },
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScheduleException"
+                    }
+                },
+                "freeSlots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scheduling.Slot"
+                    }
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WorkingPeriod"
+                    }
+                }
+            }
+        },
+        "models.ScheduleException": {
+            "description": "Leave or other time a doctor isn't available for appointments.",
+            "type": "object",
+            "properties": {
+                "conflictingAppointments": {
+                    "description": "ConflictingAppointments are the booked appointments during the\nexception, returned when it is added so they can be rescheduled.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Appointment"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "doctorId": {
+                    "type": "string"
+                },
+                "endsAt": {
+                    "type": "string",
+                    "example": "2026-10-23T00:00:00+05:30"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Annual leave"
+                },
+                "startsAt": {
+                    "type": "string",
+                    "example": "2026-10-20T00:00:00+05:30"
+                }
+            }
+        },
+        "models.SetSocialHistoryReq": {
+            "description": "Request payload to record or replace the patient's social history.",
+            "type": "object",
+            "required": [
+                "smokingStatus"
+            ],
+            "properties": {
+                "alcoholUnitsPerWeek": {
+                    "description": "AlcoholUnitsPerWeek is not recorded when AlcoholUse is none.\noptional: true",
+                    "type": "number",
+                    "maximum": 500,
+                    "minimum": 0,
+                    "example": 4
+                },
+                "alcoholUse": {
+                    "description": "AlcoholUse of the patient.\noptional: true",
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "occasional",
+                        "moderate",
+                        "heavy",
+                        "former"
+                    ],
+                    "example": "occasional"
+                },
+                "cigarettesPerDay": {
+                    "description": "CigarettesPerDay and SmokingStartAge are recorded for current and\nformer smokers, SmokingQuitAge for former smokers only.\noptional: true",
+                    "type": "integer",
+                    "maximum": 200,
+                    "example": 10
+                },
+                "notes": {
+                    "description": "Notes such as occupational exposures.\noptional: true\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "occupation": {
+                    "description": "Occupation, current or the main one before retiring.\noptional: true",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Stone cutter"
+                },
+                "smokingQuitAge": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0,
+                    "example": 38
+                },
+                "smokingStartAge": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0,
+                    "example": 18
+                },
+                "smokingStatus": {
+                    "description": "SmokingStatus of the patient.\nrequired: true",
+                    "type": "string",
+                    "enum": [
+                        "never",
+                        "former",
+                        "current",
+                        "unknown"
+                    ],
+                    "example": "former"
+                }
+            }
+        },
+        "models.SetWorkingHoursReq": {
+            "description": "Request payload to replace a doctor's weekly working hours.",
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "description": "Hours are the working periods of the week. An empty list clears the\ntemplate.\nmax items: 50",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/models.WorkingHoursPeriod"
+                    }
+                }
+            }
+        },
+        "models.SigninReq": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email is the email address of the user.\nrequired: true\nformat: email\nexample: \"user@example.com\"",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password is the password chosen by the user.\nrequired: true\nmin length: 8\nmax length: 64",
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                }
+            }
+        },
+        "models.SignupReq": {
+            "type": "object",
+            "required": [
+                "email",
+                "fullname",
+                "password",
+                "role"
+            ],
+            "properties": {
+                "activated": {
+                    "description": "Activated is a flag that determines whether the user is active.\noptional: true",
+                    "type": "boolean"
+                },
+                "email": {
+                    "description": "Email is the email address of the user.\nrequired: true\nformat: email\nexample: \"user@example.com\"",
+                    "type": "string"
+                },
+                "fullname": {
+                    "description": "Fullname is the full name of the user.\nrequired: true\nmin length: 3\nmax length: 30",
                     "type": "string",
                     "maxLength": 30,
-                    "minLength": 2
+                    "minLength": 3
+                },
+                "password": {
+                    "description": "Password is the password chosen by the user.\nrequired: true\nmin length: 8\nmax length: 64",
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                },
+                "role": {
+                    "description": "Role defines the user's role in the system.\nrequired: true\nallowed values: doctor, receptionist",
+                    "type": "string",
+                    "enum": [
+                        "doctor",
+                        "receptionist"
+                    ]
                 }
             }
         },
-        "models.CreateVitalReq": {
-            "description": "Request payload to capture new vital signs of a patient.",
+        "models.SocialHistory": {
+            "description": "Smoking with pack-years, alcohol use and occupation of the patient.",
             "type": "object",
             "properties": {
-                "bloodPressureDiastolic": {
-                    "type": "integer",
-                    "example": 80
+                "alcoholUnitsPerWeek": {
+                    "type": "number",
+                    "example": 4
                 },
-                "bloodPressureSystolic": {
+                "alcoholUse": {
+                    "type": "string",
+                    "example": "occasional"
+                },
+                "cigarettesPerDay": {
                     "type": "integer",
-                    "example": 120
+                    "example": 10
                 },
-                "bmi": {
-                    "type": "number",
-                    "example": 22.5
+                "createdAt": {
+                    "type": "string"
                 },
-                "heightCm": {
-                    "type": "number",
-                    "example": 170
+                "id": {
+                    "type": "string"
                 },
-                "oxygenSaturation": {
+                "notes": {
+                    "type": "string"
+                },
+                "occupation": {
+                    "type": "string",
+                    "example": "Stone cutter"
+                },
+                "packYears": {
+                    "description": "PackYears is the packs of 20 smoked a day times the years smoked, up\nto the quit age or today. It is left out without the daily amount and\nthe start age.",
                     "type": "number",
-                    "maximum": 100,
-                    "example": 98
+                    "example": 10
                 },
-                "pulse": {
+                "patientId": {
+                    "type": "string"
+                },
+                "recordedById": {
+                    "type": "string"
+                },
+                "smokingQuitAge": {
                     "type": "integer",
-                    "example": 72
+                    "example": 38
                 },
-                "respiratoryRate": {
+                "smokingStartAge": {
                     "type": "integer",
                     "example": 18
                 },
-                "temperatureC": {
-                    "type": "number",
-                    "maximum": 45,
-                    "example": 36.5
+                "smokingStatus": {
+                    "type": "string",
+                    "example": "former"
                 },
-                "weightKg": {
-                    "type": "number",
-                    "example": 65
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "models.DiagnosesReq": {
+        "models.SuccessResponse": {
+            "description": "Standard success response format with an optional data field.",
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "description": "Name represents the name of the diagnosis.\nrequired: true\nmin length: 2\nmax length: 30",
+                "data": {},
+                "message": {
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
+                    "example": "Operation successful"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
                 }
             }
         },
-        "models.FailureResponse": {
-            "description": "Standard error response format with status and error message.",
+        "models.Timeline": {
             "type": "object",
             "properties": {
-                "error": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimelineEvent"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ListPatientMetadata"
+                },
+                "patientId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TimelineEvent": {
+            "description": "Event of the patient's record with who caused it.",
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "description": "ActorID and ActorName are the user who caused the event. They are\nleft out when it isn't recorded.",
+                    "type": "string"
+                },
+                "actorName": {
                     "type": "string",
-                    "example": "Bad Request"
+                    "example": "Dr. Asha Rao"
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 400
+                "entity": {
+                    "description": "Entity and EntityID name the entry the event is about.",
+                    "type": "string",
+                    "example": "diagnosis"
+                },
+                "entityId": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "Fields are the fields changed by an edit.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Diagnosed with Type 2 diabetes mellitus without complications (E11.9)"
+                },
+                "type": {
+                    "description": "Type of the event: registration, vitals, diagnosis, condition,\ncondition-status, allergy, note, note-amendment, referral, edit,\nencounter, medication, lab-result or immunization.",
+                    "type": "string",
+                    "example": "diagnosis"
                 }
             }
         },
-        "models.RegAllergyReq": {
-            "description": "A request to register a new allergy for a patient",
+        "models.TriageReq": {
+            "description": "Request payload to triage a patient in the queue.",
             "type": "object",
             "required": [
-                "name",
-                "reaction",
-                "severity"
+                "priority"
             ],
             "properties": {
+                "priority": {
+                    "description": "Priority assigned at triage.\nrequired: true\nallowed values: immediate, urgent, standard, non-urgent",
+                    "type": "string",
+                    "enum": [
+                        "immediate",
+                        "urgent",
+                        "standard",
+                        "non-urgent"
+                    ],
+                    "example": "urgent"
+                }
+            }
+        },
+        "models.UpdateAllergyReq": {
+            "description": "A request to update an existing allergy record",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "@example \"food\"\n@Param category query string false \"Updated category\" validate:\"omitempty,oneof=food medication environment biologic\"",
+                    "type": "string",
+                    "enum": [
+                        "food",
+                        "medication",
+                        "environment",
+                        "biologic"
+                    ]
+                },
+                "criticality": {
+                    "description": "@example \"high\"\n@Param criticality query string false \"Updated criticality\" validate:\"omitempty,oneof=low high unable-to-assess\"",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "high",
+                        "unable-to-assess"
+                    ]
+                },
                 "name": {
-                    "description": "@Param name query string true \"Allergy Name\" validate:\"required,min=2,max=100\"\n@example \"Peanut\"",
+                    "description": "@example \"Peanut\"\n@Param name query string false \"Updated name of the allergy\" validate:\"omitempty,min=2,max=100\"",
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2
                 },
                 "reaction": {
-                    "description": "@Param reaction query string true \"Reaction to the allergy\" validate:\"required,min=2,max=255\"\n@example \"Swelling\"",
+                    "description": "@example \"swelling\"\n@Param reaction query string false \"Updated reaction to the allergy, replaces the reactions when reactions isn't given\" validate:\"omitempty,min=2,max=255\"",
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 2
                 },
+                "reactions": {
+                    "description": "Reactions replaces the allergy's reactions when given.",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/models.AllergyReaction"
+                    }
+                },
                 "severity": {
-                    "description": "@Param severity query string true \"Severity of the allergy\" validate:\"required,oneof=mild moderate severe\"\n@example \"mild\"",
+                    "description": "@example \"moderate\"\n@Param severity query string false \"Updated severity of the allergy\" validate:\"omitempty,oneof=mild moderate severe\"",
                     "type": "string",
                     "enum": [
                         "mild",
                         "moderate",
                         "severe"
                     ]
+                },
+                "substance": {
+                    "description": "Substance is the corrected coded substance.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CodedSubstance"
+                        }
+                    ]
+                },
+                "verificationStatus": {
+                    "description": "@example \"refuted\"\n@Param verificationStatus query string false \"Updated verification status\" validate:\"omitempty,oneof=unconfirmed confirmed refuted entered-in-error\"",
+                    "type": "string",
+                    "enum": [
+                        "unconfirmed",
+                        "confirmed",
+                        "refuted",
+                        "entered-in-error"
+                    ]
                 }
             }
         },
-        "models.RegPatientReq": {
+        "models.UpdateConditionReq": {
             "type": "object",
-            "required": [
-                "address",
-                "contactNo",
-                "dob",
-                "emergencyName",
-                "emergencyPhone",
-                "emergencyRelation",
-                "fullname",
-                "gender"
-            ],
             "properties": {
-                "address": {
-                    "description": "Address is the patient's address.\nrequired: true\nmin length: 5\nmax length: 255",
+                "abatementDate": {
+                    "description": "AbatementDate is when the condition stopped being active. It defaults\nto today when the condition stops being active.\noptional: true\nformat: YYYY-MM-DD",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5
-                },
-                "contactNo": {
-                    "description": "ContactNumber is the patient's contact number.\nrequired: true\nnumeric: true\nlength: 10 digits",
-                    "type": "string"
-                },
-                "dob": {
-                    "description": "DOB is the date of birth of the patient.\nrequired: true",
-                    "type": "string"
-                },
-                "emergencyName": {
-                    "description": "EmergencyName is the name of the emergency contact person.\nrequired: true",
-                    "type": "string"
+                    "example": "2026-10-01"
                 },
-                "emergencyPhone": {
-                    "description": "EmergencyPhone is the phone number of the emergency contact person.\nrequired: true\nnumeric: true\nlength: 10 digits",
-                    "type": "string"
+                "clinicalStatus": {
+                    "description": "ClinicalStatus is the new clinical status. A condition that comes back\nafter it stopped being active is a recurrence.\noptional: true\nallowed values: active, recurrence, remission, resolved, inactive",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "recurrence",
+                        "remission",
+                        "resolved",
+                        "inactive"
+                    ],
+                    "example": "resolved"
                 },
-                "emergencyRelation": {
-                    "description": "EmergencyRelation is the relationship to the emergency contact person.\nrequired: true",
-                    "type": "string"
+                "note": {
+                    "description": "Note replaces the condition's note and is kept on the transition.\noptional: true\nmax length: 1000",
+                    "type": "string",
+                    "maxLength": 1000
                 },
-                "fullname": {
-                    "description": "FullName is the full name of the patient.\nrequired: true\nmin length: 2\nmax length: 100",
+                "onsetDate": {
+                    "description": "OnsetDate is the corrected onset date.\noptional: true\nformat: YYYY-MM-DD",
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "example": "2024-03-01"
                 },
-                "gender": {
-                    "description": "Gender represents the patient's gender.\nrequired: true\nallowed values: MALE, FEMALE, OTHER",
+                "verificationStatus": {
+                    "description": "VerificationStatus is the new verification status.\noptional: true\nallowed values: unconfirmed, provisional, differential, confirmed, refuted, entered-in-error",
                     "type": "string",
                     "enum": [
-                        "MALE",
-                        "FEMALE",
-                        "OTHER"
-                    ]
+                        "unconfirmed",
+                        "provisional",
+                        "differential",
+                        "confirmed",
+                        "refuted",
+                        "entered-in-error"
+                    ],
+                    "example": "confirmed"
                 }
             }
         },
-        "models.SigninReq": {
+        "models.UpdateDiagnosesReq": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "description": "Email is the email address of the user.\nrequired: true\nformat: email\nexample: \"user@example.com\"",
-                    "type": "string"
+                "code": {
+                    "description": "Code is the corrected ICD-10 code. A well formed code missing from\nthe code set is kept as unverified.\noptional: true",
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 3,
+                    "example": "J45.0"
                 },
-                "password": {
-                    "description": "Password is the password chosen by the user.\nrequired: true\nmin length: 8\nmax length: 64",
+                "name": {
+                    "description": "Name represents the updated name of the diagnosis. It defaults to the\ndisplay text of an updated code, and is required with an unverified\ncode.\noptional: true\nmin length: 2\nmax length: 255",
                     "type": "string",
-                    "maxLength": 64,
-                    "minLength": 8
+                    "maxLength": 255,
+                    "minLength": 2
+                },
+                "onsetDate": {
+                    "description": "OnsetDate is the corrected onset date.\noptional: true\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-09-01"
+                },
+                "type": {
+                    "description": "Type is the updated role of the diagnosis, e.g. to confirm a\nprovisional diagnosis.\noptional: true\nallowed values: primary, secondary, provisional",
+                    "type": "string",
+                    "enum": [
+                        "primary",
+                        "secondary",
+                        "provisional"
+                    ],
+                    "example": "primary"
                 }
             }
         },
-        "models.SignupReq": {
+        "models.UpdateEncounterReq": {
+            "description": "Request payload to change an encounter or move it to another status.",
             "type": "object",
-            "required": [
-                "email",
-                "fullname",
-                "password",
-                "role"
-            ],
             "properties": {
-                "activated": {
-                    "description": "Activated is a flag that determines whether the user is active.\noptional: true",
-                    "type": "boolean"
+                "attendingId": {
+                    "type": "string"
                 },
-                "email": {
-                    "description": "Email is the email address of the user.\nrequired: true\nformat: email\nexample: \"user@example.com\"",
+                "endedAt": {
+                    "description": "EndedAt is when the encounter ended. It defaults to now when the\nencounter is finished.",
                     "type": "string"
                 },
-                "fullname": {
-                    "description": "Fullname is the full name of the user.\nrequired: true\nmin length: 3\nmax length: 30",
+                "reason": {
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 3
+                    "maxLength": 500
                 },
-                "password": {
-                    "description": "Password is the password chosen by the user.\nrequired: true\nmin length: 8\nmax length: 64",
+                "startedAt": {
+                    "description": "StartedAt corrects when the encounter started.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the new status. Finished and cancelled are final.\nallowed values: planned, in-progress, finished, cancelled",
                     "type": "string",
-                    "maxLength": 64,
-                    "minLength": 8
+                    "enum": [
+                        "planned",
+                        "in-progress",
+                        "finished",
+                        "cancelled"
+                    ],
+                    "example": "finished"
                 },
-                "role": {
-                    "description": "Role defines the user's role in the system.\nrequired: true\nallowed values: doctor, receptionist",
+                "type": {
                     "type": "string",
                     "enum": [
-                        "doctor",
-                        "receptionist"
-                    ]
+                        "outpatient",
+                        "inpatient",
+                        "emergency",
+                        "teleconsultation",
+                        "home-visit"
+                    ],
+                    "example": "inpatient"
                 }
             }
         },
-        "models.SuccessResponse": {
-            "description": "Standard success response format with an optional data field.",
+        "models.UpdateFamilyHistoryReq": {
+            "description": "Request payload to correct a condition of a relative.",
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
-                    "type": "string",
-                    "example": "Operation successful"
+                "ageAtDeath": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
                 },
-                "status": {
+                "ageAtOnset": {
                     "type": "integer",
-                    "example": 200
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "code": {
+                    "description": "Code is the corrected ICD-10 code. It must be a known code.",
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 3,
+                    "example": "E11.9"
+                },
+                "condition": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2
+                },
+                "deceased": {
+                    "description": "Deceased set to false clears AgeAtDeath.",
+                    "type": "boolean"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "relationship": {
+                    "type": "string",
+                    "enum": [
+                        "mother",
+                        "father",
+                        "sister",
+                        "brother",
+                        "daughter",
+                        "son",
+                        "maternal-grandmother",
+                        "maternal-grandfather",
+                        "paternal-grandmother",
+                        "paternal-grandfather",
+                        "aunt",
+                        "uncle",
+                        "cousin",
+                        "other"
+                    ],
+                    "example": "mother"
                 }
             }
         },
-        "models.UpdateAllergyReq": {
-            "description": "A request to update an existing allergy record",
+        "models.UpdateMedicationReq": {
+            "description": "Request payload to change a medication's dose, schedule or status.",
             "type": "object",
             "properties": {
-                "name": {
-                    "description": "@example \"Peanut\"\n@Param name query string false \"Updated name of the allergy\" validate:\"omitempty,min=2,max=100\"",
+                "doseUnit": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "enum": [
+                        "mg",
+                        "g",
+                        "mcg",
+                        "ml",
+                        "units",
+                        "tablet",
+                        "capsule",
+                        "puff",
+                        "drop",
+                        "sachet",
+                        "patch"
+                    ],
+                    "example": "mg"
                 },
-                "reaction": {
-                    "description": "@example \"swelling\"\n@Param reaction query string false \"Updated reaction to the allergy\" validate:\"omitempty,min=2,max=255\"",
+                "doseValue": {
+                    "type": "number",
+                    "maximum": 100000,
+                    "example": 250
+                },
+                "durationDays": {
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1,
+                    "example": 5
+                },
+                "frequency": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 2
+                    "enum": [
+                        "od",
+                        "bd",
+                        "tds",
+                        "qid",
+                        "qhs",
+                        "q4h",
+                        "q6h",
+                        "q8h",
+                        "q12h",
+                        "weekly",
+                        "prn",
+                        "stat"
+                    ],
+                    "example": "bd"
                 },
-                "severity": {
-                    "description": "@example \"moderate\"\n@Param severity query string false \"Updated severity of the allergy\" validate:\"omitempty,oneof=mild moderate severe\"",
+                "instructions": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "overrideReason": {
+                    "description": "OverrideReason explains why an on-hold medication is restarted\ndespite a severe interaction warning. It is required when there is\none.\nmin length: 5\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 5,
+                    "example": "Warfarin dose reduced, INR monitored"
+                },
+                "route": {
                     "type": "string",
                     "enum": [
-                        "mild",
-                        "moderate",
-                        "severe"
-                    ]
+                        "oral",
+                        "sublingual",
+                        "buccal",
+                        "iv",
+                        "im",
+                        "sc",
+                        "intradermal",
+                        "topical",
+                        "transdermal",
+                        "inhaled",
+                        "nasal",
+                        "ophthalmic",
+                        "otic",
+                        "rectal",
+                        "vaginal"
+                    ],
+                    "example": "oral"
+                },
+                "status": {
+                    "description": "Status is the new status. Completed, stopped and entered-in-error are\nfinal.\nallowed values: active, on-hold, completed, stopped, entered-in-error",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "on-hold",
+                        "completed",
+                        "stopped",
+                        "entered-in-error"
+                    ],
+                    "example": "stopped"
+                },
+                "stopDate": {
+                    "description": "StopDate is when the course ends. It defaults to today when the\nmedication is stopped or completed.\nformat: YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-10-20"
+                },
+                "stopReason": {
+                    "description": "StopReason explains why the medication was stopped or put on hold.\nmax length: 500",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Rash after second dose"
                 }
             }
         },
-        "models.UpdateDiagnosesReq": {
+        "models.UpdateNoteReq": {
+            "description": "Request payload to edit a draft note.",
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "description": "Name represents the updated name of the diagnosis.\nrequired: true\nmin length: 2\nmax length: 30",
+                "assessment": {
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
+                    "maxLength": 10000
+                },
+                "objective": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "plan": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "subjective": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200
                 }
             }
         },
@@ -1351,20 +10366,25 @@ const docTemplate = `{
                         "FEMALE",
                         "OTHER"
                     ]
+                },
+                "ward": {
+                    "description": "Ward is the ward the patient is moved to; an empty ward discharges\nthe patient from their ward.\noptional: true\nmax length: 50",
+                    "type": "string",
+                    "maxLength": 50
                 }
             }
         },
         "models.UpdateVitalReq": {
-            "description": "Request payload to update existing vital signs of a patient. All fields are optional.",
+            "description": "Request payload to correct an existing vitals observation. All fields are optional.",
             "type": "object",
             "properties": {
                 "bloodPressureDiastolic": {
-                    "type": "integer",
+                    "type": "number",
                     "minimum": 0,
                     "example": 82
                 },
                 "bloodPressureSystolic": {
-                    "type": "integer",
+                    "type": "number",
                     "minimum": 0,
                     "example": 122
                 },
@@ -1373,11 +10393,26 @@ const docTemplate = `{
                     "minimum": 0,
                     "example": 23
                 },
+                "consciousness": {
+                    "type": "string",
+                    "enum": [
+                        "alert",
+                        "confusion",
+                        "voice",
+                        "pain",
+                        "unresponsive"
+                    ],
+                    "example": "alert"
+                },
                 "heightCm": {
                     "type": "number",
                     "minimum": 0,
                     "example": 172
                 },
+                "measuredAt": {
+                    "type": "string",
+                    "example": "2026-10-18T09:30:00Z"
+                },
                 "oxygenSaturation": {
                     "type": "number",
                     "maximum": 100,
@@ -1394,18 +10429,379 @@ const docTemplate = `{
                     "minimum": 0,
                     "example": 20
                 },
+                "supplementalOxygen": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "temperatureC": {
                     "type": "number",
                     "maximum": 45,
                     "minimum": 30,
                     "example": 37
                 },
+                "units": {
+                    "$ref": "#/definitions/models.VitalUnits"
+                },
                 "weightKg": {
                     "type": "number",
                     "minimum": 0,
                     "example": 68
                 }
             }
+        },
+        "models.ValidationFailureResponse": {
+            "description": "Validation error response with a message for every invalid field.",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                }
+            }
+        },
+        "models.VitalModel": {
+            "type": "object",
+            "properties": {
+                "blood_pressure_diastolic": {
+                    "type": "number"
+                },
+                "blood_pressure_systolic": {
+                    "type": "number"
+                },
+                "bmi": {
+                    "type": "number"
+                },
+                "consciousness": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deviceId": {
+                    "type": "string"
+                },
+                "encounterId": {
+                    "type": "string"
+                },
+                "flags": {
+                    "description": "Flags classifies each measurement as low, normal, high or critical\nfor the patient's age and sex.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "height_cm": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "measuredAt": {
+                    "type": "string"
+                },
+                "news2": {
+                    "description": "NEWS2 is only set when every parameter of the score was observed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/clinical.NEWS2Score"
+                        }
+                    ]
+                },
+                "oxygen_saturation": {
+                    "type": "number"
+                },
+                "patientID": {
+                    "type": "string"
+                },
+                "pulse": {
+                    "type": "integer"
+                },
+                "recordedById": {
+                    "type": "string"
+                },
+                "respiratory_rate": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "supplementalOxygen": {
+                    "type": "boolean"
+                },
+                "temperature_c": {
+                    "type": "number"
+                },
+                "units": {
+                    "description": "Units are the units of the measurement values above.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.VitalUnits"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "weight_kg": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.VitalReading": {
+            "type": "object",
+            "properties": {
+                "flag": {
+                    "description": "Flag is low, normal, high or critical against the reference range.",
+                    "type": "string",
+                    "example": "normal"
+                },
+                "measuredAt": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "bpm"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 72
+                },
+                "vitalId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.VitalSeries": {
+            "type": "object",
+            "properties": {
+                "metric": {
+                    "type": "string",
+                    "example": "pulse"
+                },
+                "readings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VitalReading"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "bpm"
+                }
+            }
+        },
+        "models.VitalUnits": {
+            "type": "object",
+            "properties": {
+                "bloodPressure": {
+                    "type": "string",
+                    "enum": [
+                        "mmHg",
+                        "kPa"
+                    ],
+                    "example": "mmHg"
+                },
+                "height": {
+                    "type": "string",
+                    "enum": [
+                        "cm",
+                        "m",
+                        "in"
+                    ],
+                    "example": "cm"
+                },
+                "temperature": {
+                    "type": "string",
+                    "enum": [
+                        "C",
+                        "F"
+                    ],
+                    "example": "C"
+                },
+                "weight": {
+                    "type": "string",
+                    "enum": [
+                        "kg",
+                        "lb"
+                    ],
+                    "example": "kg"
+                }
+            }
+        },
+        "models.WorkingHours": {
+            "description": "Weekly working period of a doctor, in the clinic's time zone.",
+            "type": "object",
+            "properties": {
+                "doctorId": {
+                    "type": "string"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "13:00"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "slotMinutes": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "start": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "description": "Weekday is 0 for Sunday to 6 for Saturday.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.WorkingHoursPeriod": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "13:00"
+                },
+                "slotMinutes": {
+                    "description": "SlotMinutes is the length of an appointment. It defaults to 15.\noptional: true\nallowed values: 5, 10, 15, 20, 30, 45, 60",
+                    "type": "integer",
+                    "enum": [
+                        5,
+                        10,
+                        15,
+                        20,
+                        30,
+                        45,
+                        60
+                    ],
+                    "example": 15
+                },
+                "start": {
+                    "description": "Start and End are wall-clock times in the clinic's time zone.\nrequired: true\nformat: HH:MM",
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "description": "Weekday is 0 for Sunday to 6 for Saturday.\nrequired: true",
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "models.WorkingPeriod": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "2026-10-19T13:00:00+05:30"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00+05:30"
+                }
+            }
+        },
+        "notes.Sections": {
+            "type": "object",
+            "properties": {
+                "assessment": {
+                    "type": "string"
+                },
+                "objective": {
+                    "type": "string"
+                },
+                "plan": {
+                    "type": "string"
+                },
+                "subjective": {
+                    "type": "string"
+                }
+            }
+        },
+        "notes.Template": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Fever of less than two weeks"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "fever"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acute fever"
+                },
+                "sections": {
+                    "$ref": "#/definitions/notes.Sections"
+                }
+            }
+        },
+        "scheduling.Slot": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "2026-10-19T09:15:00+05:30"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00+05:30"
+                }
+            }
+        },
+        "terminology.Code": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "J45.9"
+                },
+                "display": {
+                    "type": "string",
+                    "example": "Asthma, unspecified"
+                }
+            }
+        },
+        "terminology.Substance": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "medication"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "723"
+                },
+                "display": {
+                    "type": "string",
+                    "example": "Amoxicillin"
+                },
+                "system": {
+                    "type": "string",
+                    "example": "rxnorm"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,219 @@
+// Package alerts evaluates clinical events against declarative rules and
+// decides which alerts to raise.
+package alerts
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Alert severities, from the most to the least urgent.
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// Alert lifecycle states.
+const (
+	StatusOpen         = "open"
+	StatusAcknowledged = "acknowledged"
+	StatusResolved     = "resolved"
+)
+
+// Event sources.
+const (
+	SourceVital     = "vital"
+	SourceNEWS2     = "news2"
+	SourceAllergy   = "allergy"
+	SourceDiagnosis = "diagnosis"
+)
+
+// Priority orders severities so that critical alerts sort first.
+func Priority(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 3
+	case SeverityWarning:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Rule raises an alert for events of Source whose facts match. Every fact
+// in When must equal one of the listed values and every fact in Contains
+// must contain one of the listed substrings, ignoring case.
+type Rule struct {
+	ID       string              `json:"id"`
+	Source   string              `json:"source"`
+	Severity string              `json:"severity"`
+	When     map[string][]string `json:"when,omitempty"`
+	Contains map[string][]string `json:"contains,omitempty"`
+
+	// Message may refer to facts as {{name}}.
+	Message string `json:"message"`
+}
+
+// Event is something that happened to a patient's record, such as a vital
+// being flagged or an allergy being recorded.
+type Event struct {
+	Source    string
+	PatientID string
+
+	// SubjectID is the record the event is about.
+	SubjectID string
+
+	// Key identifies what the event is about across records, e.g. the
+	// metric of a vital, so that repeated events do not raise duplicate
+	// alerts. It defaults to SubjectID.
+	Key string
+
+	Facts map[string]string
+}
+
+// Triggered is an alert raised by a rule.
+type Triggered struct {
+	RuleID    string
+	Severity  string
+	Source    string
+	SubjectID string
+	Message   string
+
+	// DedupKey is shared by alerts about the same thing; only one of them
+	// is open at a time.
+	DedupKey string
+}
+
+//go:embed rules.json
+var defaultRules []byte
+
+var (
+	mu    sync.RWMutex
+	rules []Rule
+)
+
+func init() {
+	r, err := ParseRules(bytes.NewReader(defaultRules))
+	if err != nil {
+		panic(fmt.Sprintf("alerts: invalid embedded rules: %v", err))
+	}
+	rules = r
+}
+
+// ParseRules reads rules in the format of rules.json.
+func ParseRules(r io.Reader) ([]Rule, error) {
+	var rs []Rule
+	if err := json.NewDecoder(r).Decode(&rs); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(rs))
+	for _, rule := range rs {
+		if rule.ID == "" || rule.Source == "" || rule.Message == "" {
+			return nil, fmt.Errorf("rule %q needs an id, a source and a message", rule.ID)
+		}
+		if seen[rule.ID] {
+			return nil, fmt.Errorf("duplicate rule %q", rule.ID)
+		}
+		seen[rule.ID] = true
+
+		switch rule.Severity {
+		case SeverityCritical, SeverityWarning, SeverityInfo:
+		default:
+			return nil, fmt.Errorf("rule %q has unknown severity %q", rule.ID, rule.Severity)
+		}
+		if len(rule.When) == 0 && len(rule.Contains) == 0 {
+			return nil, fmt.Errorf("rule %q has no conditions", rule.ID)
+		}
+	}
+	return rs, nil
+}
+
+// LoadRulesFile replaces the built-in rules with those in the file at path.
+func LoadRulesFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rs, err := ParseRules(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	rules = rs
+	mu.Unlock()
+	return nil
+}
+
+// Evaluate returns the alerts raised by events.
+func Evaluate(events ...Event) []Triggered {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var triggered []Triggered
+	for _, e := range events {
+		for _, rule := range rules {
+			if rule.Source != e.Source || !rule.matches(e.Facts) {
+				continue
+			}
+
+			key := e.Key
+			if key == "" {
+				key = e.SubjectID
+			}
+			triggered = append(triggered, Triggered{
+				RuleID:    rule.ID,
+				Severity:  rule.Severity,
+				Source:    e.Source,
+				SubjectID: e.SubjectID,
+				Message:   render(rule.Message, e.Facts),
+				DedupKey:  strings.Join([]string{e.PatientID, rule.ID, key}, ":"),
+			})
+		}
+	}
+	return triggered
+}
+
+func (r *Rule) matches(facts map[string]string) bool {
+	for fact, values := range r.When {
+		v, ok := facts[fact]
+		if !ok || !anyOf(values, func(want string) bool { return strings.EqualFold(v, want) }) {
+			return false
+		}
+	}
+	for fact, values := range r.Contains {
+		v, ok := facts[fact]
+		v = strings.ToLower(v)
+		if !ok || !anyOf(values, func(want string) bool { return strings.Contains(v, strings.ToLower(want)) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyOf(values []string, match func(string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func render(message string, facts map[string]string) string {
+	pairs := make([]string, 0, 2*len(facts))
+	for k, v := range facts {
+		pairs = append(pairs, "{{"+k+"}}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
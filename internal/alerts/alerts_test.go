@@ -0,0 +1,192 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  []Triggered
+	}{
+		{
+			name: "Critical vital",
+			event: Event{
+				Source:    SourceVital,
+				PatientID: "p1",
+				SubjectID: "v1",
+				Key:       "pulse",
+				Facts:     map[string]string{"flag": "critical", "metric": "pulse", "value": "150"},
+			},
+			want: []Triggered{{
+				RuleID:    "vital-critical",
+				Severity:  SeverityCritical,
+				Source:    SourceVital,
+				SubjectID: "v1",
+				Message:   "Critical pulse of 150",
+				DedupKey:  "p1:vital-critical:pulse",
+			}},
+		},
+		{
+			name: "Abnormal vital",
+			event: Event{
+				Source:    SourceVital,
+				PatientID: "p1",
+				SubjectID: "v1",
+				Facts:     map[string]string{"flag": "abnormal", "metric": "pulse", "value": "105"},
+			},
+		},
+		{
+			name: "Facts match ignoring case",
+			event: Event{
+				Source:    SourceNEWS2,
+				PatientID: "p1",
+				SubjectID: "v2",
+				Facts:     map[string]string{"risk": "HIGH", "score": "8"},
+			},
+			want: []Triggered{{
+				RuleID:    "news2-high",
+				Severity:  SeverityCritical,
+				Source:    SourceNEWS2,
+				SubjectID: "v2",
+				Message:   "NEWS2 score 8: emergency assessment required",
+				// the key defaults to the subject
+				DedupKey: "p1:news2-high:v2",
+			}},
+		},
+		{
+			name: "Rule of another source",
+			event: Event{
+				Source:    SourceAllergy,
+				PatientID: "p1",
+				SubjectID: "a1",
+				Facts:     map[string]string{"risk": "high"},
+			},
+		},
+		{
+			name: "Every condition must match",
+			event: Event{
+				Source:    SourceAllergy,
+				PatientID: "p1",
+				SubjectID: "a1",
+				Facts:     map[string]string{"severity": "mild", "reaction": "rash", "name": "Peanut"},
+			},
+		},
+		{
+			name: "Contained substring",
+			event: Event{
+				Source:    SourceAllergy,
+				PatientID: "p1",
+				SubjectID: "a1",
+				Facts:     map[string]string{"severity": "moderate", "reaction": "Anaphylactic shock", "name": "Peanut"},
+			},
+			want: []Triggered{{
+				RuleID:    "allergy-anaphylaxis",
+				Severity:  SeverityCritical,
+				Source:    SourceAllergy,
+				SubjectID: "a1",
+				Message:   "Anaphylaxis recorded for Peanut allergy",
+				DedupKey:  "p1:allergy-anaphylaxis:a1",
+			}},
+		},
+		{
+			name: "Several rules match",
+			event: Event{
+				Source:    SourceAllergy,
+				PatientID: "p1",
+				SubjectID: "a1",
+				Facts:     map[string]string{"severity": "moderate", "criticality": "high", "reaction": "anaphylaxis", "name": "Penicillin"},
+			},
+			want: []Triggered{
+				{
+					RuleID:    "allergy-anaphylaxis",
+					Severity:  SeverityCritical,
+					Source:    SourceAllergy,
+					SubjectID: "a1",
+					Message:   "Anaphylaxis recorded for Penicillin allergy",
+					DedupKey:  "p1:allergy-anaphylaxis:a1",
+				},
+				{
+					RuleID:    "allergy-high-criticality",
+					Severity:  SeverityWarning,
+					Source:    SourceAllergy,
+					SubjectID: "a1",
+					Message:   "High criticality allergy to Penicillin",
+					DedupKey:  "p1:allergy-high-criticality:a1",
+				},
+			},
+		},
+		{
+			name: "Missing fact",
+			event: Event{
+				Source:    SourceDiagnosis,
+				PatientID: "p1",
+				SubjectID: "d1",
+				Facts:     map[string]string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Evaluate(tt.event))
+		})
+	}
+}
+
+func TestEvaluateSeveralEvents(t *testing.T) {
+	triggered := Evaluate(
+		Event{Source: SourceDiagnosis, PatientID: "p1", SubjectID: "d1", Facts: map[string]string{"name": "Septic shock"}},
+		Event{Source: SourceDiagnosis, PatientID: "p1", SubjectID: "d2", Facts: map[string]string{"name": "Common cold"}},
+		Event{Source: SourceDiagnosis, PatientID: "p1", SubjectID: "d3", Facts: map[string]string{"name": "Acute myocardial infarction"}},
+	)
+
+	require.Len(t, triggered, 2)
+	require.Equal(t, "d1", triggered[0].SubjectID)
+	require.Equal(t, "Critical diagnosis recorded: Septic shock", triggered[0].Message)
+	require.Equal(t, "d3", triggered[1].SubjectID)
+}
+
+func TestParseRules(t *testing.T) {
+	rs, err := ParseRules(strings.NewReader(`[
+		{"id": "r1", "source": "vital", "severity": "warning", "when": {"flag": ["abnormal"]}, "message": "m"},
+		{"id": "r2", "source": "diagnosis", "severity": "info", "contains": {"name": ["flu"]}, "message": "m"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, rs, 2)
+	require.Equal(t, map[string][]string{"flag": {"abnormal"}}, rs[0].When)
+	require.Equal(t, map[string][]string{"name": {"flu"}}, rs[1].Contains)
+
+	_, err = ParseRules(strings.NewReader(string(defaultRules)))
+	require.NoError(t, err)
+}
+
+func TestParseRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{"Malformed JSON", `[{"id": }]`},
+		{"Not a list", `{"id": "r1"}`},
+		{"Missing ID", `[{"source": "vital", "severity": "info", "when": {"flag": ["x"]}, "message": "m"}]`},
+		{"Missing source", `[{"id": "r1", "severity": "info", "when": {"flag": ["x"]}, "message": "m"}]`},
+		{"Missing message", `[{"id": "r1", "source": "vital", "severity": "info", "when": {"flag": ["x"]}}]`},
+		{"Unknown severity", `[{"id": "r1", "source": "vital", "severity": "urgent", "when": {"flag": ["x"]}, "message": "m"}]`},
+		{"No conditions", `[{"id": "r1", "source": "vital", "severity": "info", "message": "m"}]`},
+		{"Duplicate ID", `[
+			{"id": "r1", "source": "vital", "severity": "info", "when": {"flag": ["x"]}, "message": "m"},
+			{"id": "r1", "source": "vital", "severity": "info", "when": {"flag": ["y"]}, "message": "m"}
+		]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules(strings.NewReader(tt.rules))
+			require.Error(t, err)
+		})
+	}
+}
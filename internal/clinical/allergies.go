@@ -0,0 +1,23 @@
+package clinical
+
+// Criticality of an allergy: the risk of a future life-threatening reaction.
+const (
+	CriticalityLow            = "low"
+	CriticalityHigh           = "high"
+	CriticalityUnableToAssess = "unable-to-assess"
+)
+
+// Verification statuses of an allergy.
+const (
+	AllergyUnconfirmed    = "unconfirmed"
+	AllergyConfirmed      = "confirmed"
+	AllergyRefuted        = "refuted"
+	AllergyEnteredInError = "entered-in-error"
+)
+
+// IsAllergyRuledOut reports whether an allergy with the verification status
+// is known not to exist, so it neither raises alerts nor contradicts a
+// "no known allergies" assertion.
+func IsAllergyRuledOut(verificationStatus string) bool {
+	return verificationStatus == AllergyRefuted || verificationStatus == AllergyEnteredInError
+}
@@ -0,0 +1,18 @@
+package clinical
+
+// BMITolerance is how far a client-supplied BMI may differ from the one
+// computed from height and weight before it is rejected.
+const BMITolerance = 0.5
+
+// BMI returns the body mass index for a height in centimetres and a weight in
+// kilograms, rounded to one decimal place.
+func BMI(heightCm, weightKg float64) float64 {
+	m := heightCm / 100
+	return Round(weightKg/(m*m), 1)
+}
+
+// BMIConsistent reports whether a supplied BMI agrees with the computed one.
+func BMIConsistent(supplied, computed float64) bool {
+	d := supplied - computed
+	return d >= -BMITolerance && d <= BMITolerance
+}
@@ -0,0 +1,43 @@
+package clinical
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBMI(t *testing.T) {
+	tests := []struct {
+		heightCm float64
+		weightKg float64
+		want     float64
+	}{
+		{170, 65, 22.5},
+		{180, 80, 24.7},
+		{160, 90, 35.2},
+		{150, 40, 17.8},
+		{100, 16, 16},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, BMI(tt.heightCm, tt.weightKg), "%v cm, %v kg", tt.heightCm, tt.weightKg)
+	}
+}
+
+func TestBMIConsistent(t *testing.T) {
+	tests := []struct {
+		supplied float64
+		computed float64
+		want     bool
+	}{
+		{22.5, 22.5, true},
+		{23.0, 22.5, true},
+		{22.0, 22.5, true},
+		{23.1, 22.5, false},
+		{21.9, 22.5, false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, BMIConsistent(tt.supplied, tt.computed), "%v vs %v", tt.supplied, tt.computed)
+	}
+}
@@ -0,0 +1,48 @@
+package clinical
+
+// Clinical statuses of a condition. Active and recurrence are the active
+// statuses; the others mean the condition is no longer active.
+const (
+	ConditionActive     = "active"
+	ConditionRecurrence = "recurrence"
+	ConditionRemission  = "remission"
+	ConditionResolved   = "resolved"
+	ConditionInactive   = "inactive"
+)
+
+// Verification statuses of a condition.
+const (
+	VerificationUnconfirmed    = "unconfirmed"
+	VerificationProvisional    = "provisional"
+	VerificationDifferential   = "differential"
+	VerificationConfirmed      = "confirmed"
+	VerificationRefuted        = "refuted"
+	VerificationEnteredInError = "entered-in-error"
+)
+
+// conditionTransitions lists the clinical statuses a condition may move to
+// from each status. A condition that comes back after it stopped being
+// active is a recurrence, never active again.
+var conditionTransitions = map[string][]string{
+	ConditionActive:     {ConditionRemission, ConditionResolved, ConditionInactive},
+	ConditionRecurrence: {ConditionRemission, ConditionResolved, ConditionInactive},
+	ConditionRemission:  {ConditionRecurrence, ConditionResolved, ConditionInactive},
+	ConditionResolved:   {ConditionRecurrence},
+	ConditionInactive:   {ConditionRecurrence, ConditionResolved},
+}
+
+// CanTransitionCondition reports whether a condition may move from one
+// clinical status to another.
+func CanTransitionCondition(from, to string) bool {
+	for _, s := range conditionTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConditionActive reports whether the clinical status is an active one.
+func IsConditionActive(status string) bool {
+	return status == ConditionActive || status == ConditionRecurrence
+}
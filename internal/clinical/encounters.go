@@ -0,0 +1,25 @@
+package clinical
+
+// Statuses of an encounter. Finished and cancelled are final.
+const (
+	EncounterPlanned    = "planned"
+	EncounterInProgress = "in-progress"
+	EncounterFinished   = "finished"
+	EncounterCancelled  = "cancelled"
+)
+
+var encounterTransitions = map[string][]string{
+	EncounterPlanned:    {EncounterInProgress, EncounterCancelled},
+	EncounterInProgress: {EncounterFinished, EncounterCancelled},
+}
+
+// CanTransitionEncounter reports whether an encounter may move from one
+// status to another.
+func CanTransitionEncounter(from, to string) bool {
+	for _, s := range encounterTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package clinical
+
+// Smoking statuses of a patient's social history.
+const (
+	SmokingNever   = "never"
+	SmokingFormer  = "former"
+	SmokingCurrent = "current"
+	SmokingUnknown = "unknown"
+)
+
+// Alcohol use of a patient's social history.
+const (
+	AlcoholNone       = "none"
+	AlcoholOccasional = "occasional"
+	AlcoholModerate   = "moderate"
+	AlcoholHeavy      = "heavy"
+	AlcoholFormer     = "former"
+)
+
+// CigarettesPerPack is the number of cigarettes counted as a pack in
+// pack-years.
+const CigarettesPerPack = 20
+
+// PackYears returns the packs smoked a day times the years smoked, rounded
+// to one decimal place.
+func PackYears(cigarettesPerDay int, years float64) float64 {
+	if years < 0 {
+		years = 0
+	}
+	return Round(float64(cigarettesPerDay)/CigarettesPerPack*years, 1)
+}
+
+// SmokingYears returns the years a smoker with the status has smoked, from
+// the age they started to the age they quit or, while they still smoke, to
+// their age now. It reports false when the years can't be told, e.g. for a
+// former smoker without a quit age.
+func SmokingYears(status string, startAge int, quitAge *int, age float64) (float64, bool) {
+	var years float64
+	switch {
+	case status == SmokingFormer && quitAge != nil:
+		years = float64(*quitAge - startAge)
+	case status == SmokingCurrent:
+		years = age - float64(startAge)
+	}
+	return years, years > 0
+}
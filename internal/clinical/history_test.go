@@ -0,0 +1,70 @@
+package clinical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackYears(t *testing.T) {
+	tests := []struct {
+		cigarettes int
+		years      float64
+		want       float64
+	}{
+		{20, 10, 10},
+		{10, 15, 7.5},
+		{7, 3.2, 1.1},
+		{40, 0.5, 1},
+		{20, -2, 0},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, PackYears(tt.cigarettes, tt.years), "%d a day for %v years", tt.cigarettes, tt.years)
+	}
+}
+
+func TestSmokingYears(t *testing.T) {
+	quitAt := func(age int) *int {
+		return &age
+	}
+	// 36 years old, born on 1 January 1990
+	age := AgeAt(time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, 36.0, age)
+
+	tests := []struct {
+		name     string
+		status   string
+		startAge int
+		quitAge  *int
+		want     float64
+		ok       bool
+	}{
+		{"Former smoker", SmokingFormer, 18, quitAt(38), 20, true},
+		// the quit age is all that tells how long a former smoker smoked
+		{"Former smoker without a quit age", SmokingFormer, 18, nil, 0, false},
+		{"Quit the year they started", SmokingFormer, 18, quitAt(18), 0, false},
+		{"Current smoker", SmokingCurrent, 16, nil, 20, true},
+		// a quit age recorded earlier doesn't stop the count for a smoker
+		// who started again
+		{"Current smoker with a quit age", SmokingCurrent, 16, quitAt(25), 20, true},
+		{"Started after their age", SmokingCurrent, 40, nil, 0, false},
+		{"Never smoked", SmokingNever, 16, nil, 0, false},
+		{"Unknown", SmokingUnknown, 16, quitAt(25), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			years, ok := SmokingYears(tt.status, tt.startAge, tt.quitAge, age)
+			require.Equal(t, tt.ok, ok)
+			if ok {
+				require.Equal(t, tt.want, years)
+			}
+		})
+	}
+
+	// 20 a day for the 20 years a current smoker has smoked
+	years, _ := SmokingYears(SmokingCurrent, 16, nil, age)
+	require.Equal(t, 20.0, PackYears(20, years))
+}
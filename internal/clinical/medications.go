@@ -0,0 +1,45 @@
+package clinical
+
+import "time"
+
+// Statuses of a medication. Completed, stopped and entered-in-error are
+// final.
+const (
+	MedicationActive         = "active"
+	MedicationOnHold         = "on-hold"
+	MedicationCompleted      = "completed"
+	MedicationStopped        = "stopped"
+	MedicationEnteredInError = "entered-in-error"
+)
+
+var medicationTransitions = map[string][]string{
+	MedicationActive: {MedicationOnHold, MedicationCompleted, MedicationStopped, MedicationEnteredInError},
+	MedicationOnHold: {MedicationActive, MedicationCompleted, MedicationStopped, MedicationEnteredInError},
+}
+
+// CanTransitionMedication reports whether a medication may move from one
+// status to another.
+func CanTransitionMedication(from, to string) bool {
+	for _, s := range medicationTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMedicationCurrent reports whether a medication with the status is on
+// the patient's current medication list.
+func IsMedicationCurrent(status string) bool {
+	return status == MedicationActive || status == MedicationOnHold
+}
+
+// MedicationStatusOn returns the status of a medication on the day today.
+// A current medication whose stop date has passed, such as a finished
+// course, is completed even though it wasn't marked so.
+func MedicationStatusOn(status string, stop *time.Time, today time.Time) string {
+	if IsMedicationCurrent(status) && stop != nil && stop.Before(today) {
+		return MedicationCompleted
+	}
+	return status
+}
@@ -0,0 +1,40 @@
+package clinical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMedicationStatusOn(t *testing.T) {
+	today := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		d := today.AddDate(0, 0, offset)
+		return &d
+	}
+
+	tests := []struct {
+		name   string
+		status string
+		stop   *time.Time
+		want   string
+	}{
+		{"Without a stop date", MedicationActive, nil, MedicationActive},
+		{"Course stopping later", MedicationActive, day(3), MedicationActive},
+		{"Course stopping today", MedicationActive, day(0), MedicationActive},
+		// a 7 day course started 10 days ago
+		{"Course already ended", MedicationActive, day(-3), MedicationCompleted},
+		{"On hold past its stop date", MedicationOnHold, day(-1), MedicationCompleted},
+		{"Stopped early", MedicationStopped, day(-3), MedicationStopped},
+		{"Entered in error", MedicationEnteredInError, day(-3), MedicationEnteredInError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MedicationStatusOn(tt.status, tt.stop, today)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.want == MedicationActive || tt.want == MedicationOnHold, IsMedicationCurrent(got))
+		})
+	}
+}
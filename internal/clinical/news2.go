@@ -0,0 +1,97 @@
+package clinical
+
+// Levels of consciousness on the ACVPU scale.
+const (
+	ConsciousnessAlert        = "alert"
+	ConsciousnessConfusion    = "confusion"
+	ConsciousnessVoice        = "voice"
+	ConsciousnessPain         = "pain"
+	ConsciousnessUnresponsive = "unresponsive"
+)
+
+// NEWS2Risk is the clinical risk band of a NEWS2 aggregate score.
+type NEWS2Risk string
+
+const (
+	NEWS2RiskLow       NEWS2Risk = "low"
+	NEWS2RiskLowMedium NEWS2Risk = "low-medium"
+	NEWS2RiskMedium    NEWS2Risk = "medium"
+	NEWS2RiskHigh      NEWS2Risk = "high"
+)
+
+// NEWS2Input holds the physiological parameters scored by NEWS2. A nil
+// value or an empty consciousness level means the parameter was not
+// measured.
+type NEWS2Input struct {
+	RespiratoryRate    *float64
+	OxygenSaturation   *float64
+	SupplementalOxygen *bool
+	SystolicBP         *float64
+	Pulse              *float64
+	Consciousness      string
+	TemperatureC       *float64
+}
+
+// NEWS2Score is the National Early Warning Score 2 of an observation.
+type NEWS2Score struct {
+	Total int `json:"total" example:"3"`
+
+	// Risk is low, low-medium, medium or high.
+	Risk NEWS2Risk `json:"risk" example:"low-medium"`
+}
+
+// NEWS2 scores an observation using the Royal College of Physicians NEWS2
+// chart with SpO2 scale 1. It returns false unless all seven parameters
+// are present, as a partial score would understate the risk.
+func NEWS2(in NEWS2Input) (*NEWS2Score, bool) {
+	if in.RespiratoryRate == nil || in.OxygenSaturation == nil || in.SupplementalOxygen == nil ||
+		in.SystolicBP == nil || in.Pulse == nil || in.Consciousness == "" || in.TemperatureC == nil {
+		return nil, false
+	}
+
+	parts := []int{
+		band(*in.RespiratoryRate, []float64{8, 11, 20, 24}, []int{3, 1, 0, 2, 3}),
+		band(*in.OxygenSaturation, []float64{91, 93, 95}, []int{3, 2, 1, 0}),
+		band(*in.SystolicBP, []float64{90, 100, 110, 219}, []int{3, 2, 1, 0, 3}),
+		band(*in.Pulse, []float64{40, 50, 90, 110, 130}, []int{3, 1, 0, 1, 2, 3}),
+		band(*in.TemperatureC, []float64{35.0, 36.0, 38.0, 39.0}, []int{3, 1, 0, 1, 2}),
+	}
+	if *in.SupplementalOxygen {
+		parts = append(parts, 2)
+	}
+	if in.Consciousness != ConsciousnessAlert {
+		parts = append(parts, 3)
+	}
+
+	score := &NEWS2Score{}
+	red := false
+	for _, p := range parts {
+		score.Total += p
+		if p == 3 {
+			red = true
+		}
+	}
+
+	switch {
+	case score.Total >= 7:
+		score.Risk = NEWS2RiskHigh
+	case score.Total >= 5:
+		score.Risk = NEWS2RiskMedium
+	case red:
+		score.Risk = NEWS2RiskLowMedium
+	default:
+		score.Risk = NEWS2RiskLow
+	}
+	return score, true
+}
+
+// band returns the points of the first band whose inclusive upper bound is
+// not below v, or the last points when v is above every bound.
+func band(v float64, upper []float64, points []int) int {
+	for i, u := range upper {
+		if v <= u {
+			return points[i]
+		}
+	}
+	return points[len(points)-1]
+}
@@ -0,0 +1,189 @@
+package clinical
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// normalNEWS2 is an observation that scores 0 on every parameter.
+func normalNEWS2() NEWS2Input {
+	return NEWS2Input{
+		RespiratoryRate:    ptr(16.0),
+		OxygenSaturation:   ptr(98.0),
+		SupplementalOxygen: ptr(false),
+		SystolicBP:         ptr(120.0),
+		Pulse:              ptr(70.0),
+		Consciousness:      ConsciousnessAlert,
+		TemperatureC:       ptr(37.0),
+	}
+}
+
+// The bands follow the Royal College of Physicians NEWS2 chart; every band
+// is checked at both of its bounds.
+func TestNEWS2ParameterBands(t *testing.T) {
+	tests := []struct {
+		name   string
+		set    func(*NEWS2Input, float64)
+		values []float64
+		points []int
+	}{
+		{
+			name:   "Respiration rate",
+			set:    func(in *NEWS2Input, v float64) { in.RespiratoryRate = &v },
+			values: []float64{8, 9, 11, 12, 20, 21, 24, 25},
+			points: []int{3, 1, 1, 0, 0, 2, 2, 3},
+		},
+		{
+			name:   "SpO2 scale 1",
+			set:    func(in *NEWS2Input, v float64) { in.OxygenSaturation = &v },
+			values: []float64{91, 92, 93, 94, 95, 96},
+			points: []int{3, 2, 2, 1, 1, 0},
+		},
+		{
+			name:   "Systolic blood pressure",
+			set:    func(in *NEWS2Input, v float64) { in.SystolicBP = &v },
+			values: []float64{90, 91, 100, 101, 110, 111, 219, 220},
+			points: []int{3, 2, 2, 1, 1, 0, 0, 3},
+		},
+		{
+			name:   "Pulse",
+			set:    func(in *NEWS2Input, v float64) { in.Pulse = &v },
+			values: []float64{40, 41, 50, 51, 90, 91, 110, 111, 130, 131},
+			points: []int{3, 1, 1, 0, 0, 1, 1, 2, 2, 3},
+		},
+		{
+			name:   "Temperature",
+			set:    func(in *NEWS2Input, v float64) { in.TemperatureC = &v },
+			values: []float64{35.0, 35.1, 36.0, 36.1, 38.0, 38.1, 39.0, 39.1},
+			points: []int{3, 1, 1, 0, 0, 1, 1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, v := range tt.values {
+				in := normalNEWS2()
+				tt.set(&in, v)
+
+				score, ok := NEWS2(in)
+				require.True(t, ok)
+				require.Equal(t, tt.points[i], score.Total, "value %v", v)
+			}
+		})
+	}
+}
+
+func TestNEWS2OxygenAndConsciousness(t *testing.T) {
+	in := normalNEWS2()
+	in.SupplementalOxygen = ptr(true)
+	score, ok := NEWS2(in)
+	require.True(t, ok)
+	require.Equal(t, 2, score.Total)
+
+	for _, level := range []string{ConsciousnessConfusion, ConsciousnessVoice, ConsciousnessPain, ConsciousnessUnresponsive} {
+		in := normalNEWS2()
+		in.Consciousness = level
+		score, ok := NEWS2(in)
+		require.True(t, ok)
+		require.Equal(t, 3, score.Total, level)
+	}
+}
+
+func TestNEWS2Risk(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(*NEWS2Input)
+		total int
+		risk  NEWS2Risk
+	}{
+		{
+			name:  "Normal",
+			edit:  func(in *NEWS2Input) {},
+			total: 0,
+			risk:  NEWS2RiskLow,
+		},
+		{
+			name: "Aggregate 4",
+			edit: func(in *NEWS2Input) {
+				in.RespiratoryRate = ptr(22.0)
+				in.OxygenSaturation = ptr(93.0)
+			},
+			total: 4,
+			risk:  NEWS2RiskLow,
+		},
+		{
+			name:  "Single red parameter",
+			edit:  func(in *NEWS2Input) { in.Consciousness = ConsciousnessVoice },
+			total: 3,
+			risk:  NEWS2RiskLowMedium,
+		},
+		{
+			name: "Aggregate 5",
+			edit: func(in *NEWS2Input) {
+				in.RespiratoryRate = ptr(22.0)
+				in.SupplementalOxygen = ptr(true)
+				in.Pulse = ptr(95.0)
+			},
+			total: 5,
+			risk:  NEWS2RiskMedium,
+		},
+		{
+			name: "Aggregate 6 with a red parameter",
+			edit: func(in *NEWS2Input) {
+				in.SystolicBP = ptr(85.0)
+				in.OxygenSaturation = ptr(90.0)
+			},
+			total: 6,
+			risk:  NEWS2RiskMedium,
+		},
+		{
+			name: "Aggregate 7",
+			edit: func(in *NEWS2Input) {
+				in.RespiratoryRate = ptr(26.0)
+				in.SupplementalOxygen = ptr(true)
+				in.Pulse = ptr(115.0)
+			},
+			total: 7,
+			risk:  NEWS2RiskHigh,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := normalNEWS2()
+			tt.edit(&in)
+
+			score, ok := NEWS2(in)
+			require.True(t, ok)
+			require.Equal(t, tt.total, score.Total)
+			require.Equal(t, tt.risk, score.Risk)
+		})
+	}
+}
+
+func TestNEWS2NeedsEveryParameter(t *testing.T) {
+	clears := map[string]func(*NEWS2Input){
+		"respiration rate":    func(in *NEWS2Input) { in.RespiratoryRate = nil },
+		"SpO2":                func(in *NEWS2Input) { in.OxygenSaturation = nil },
+		"supplemental oxygen": func(in *NEWS2Input) { in.SupplementalOxygen = nil },
+		"systolic pressure":   func(in *NEWS2Input) { in.SystolicBP = nil },
+		"pulse":               func(in *NEWS2Input) { in.Pulse = nil },
+		"consciousness":       func(in *NEWS2Input) { in.Consciousness = "" },
+		"temperature":         func(in *NEWS2Input) { in.TemperatureC = nil },
+	}
+
+	for name, clear := range clears {
+		t.Run(name, func(t *testing.T) {
+			in := normalNEWS2()
+			clear(&in)
+
+			_, ok := NEWS2(in)
+			require.False(t, ok)
+		})
+	}
+}
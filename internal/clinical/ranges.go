@@ -0,0 +1,186 @@
+package clinical
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Flag classifies a value against its reference range.
+type Flag string
+
+const (
+	FlagLow      Flag = "low"
+	FlagNormal   Flag = "normal"
+	FlagHigh     Flag = "high"
+	FlagCritical Flag = "critical"
+)
+
+// ReferenceRange is the expected range of a metric for an age band and,
+// optionally, a sex. Any bound may be left out.
+type ReferenceRange struct {
+	Metric string `json:"metric"`
+
+	// Sex is MALE, FEMALE or empty when the range applies to both.
+	Sex string `json:"sex,omitempty"`
+
+	// MinAge is inclusive and MaxAge exclusive, both in years. A zero
+	// MaxAge means no upper bound.
+	MinAge float64 `json:"minAge"`
+	MaxAge float64 `json:"maxAge,omitempty"`
+
+	CriticalLow  *float64 `json:"criticalLow,omitempty"`
+	Low          *float64 `json:"low,omitempty"`
+	High         *float64 `json:"high,omitempty"`
+	CriticalHigh *float64 `json:"criticalHigh,omitempty"`
+}
+
+// RangeTable holds the reference ranges and the bounds outside of which a
+// value is physiologically impossible and rejected.
+type RangeTable struct {
+	Plausible map[string][2]float64 `json:"plausible"`
+	Ranges    []ReferenceRange      `json:"ranges"`
+}
+
+//go:embed reference_ranges.json
+var defaultRanges []byte
+
+var (
+	mu    sync.RWMutex
+	table *RangeTable
+)
+
+func init() {
+	t, err := ParseRangeTable(bytes.NewReader(defaultRanges))
+	if err != nil {
+		panic(fmt.Sprintf("clinical: invalid embedded reference ranges: %v", err))
+	}
+	table = t
+}
+
+// ParseRangeTable reads a range table in the format of reference_ranges.json.
+func ParseRangeTable(r io.Reader) (*RangeTable, error) {
+	var t RangeTable
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, err
+	}
+	for _, rr := range t.Ranges {
+		if rr.Metric == "" {
+			return nil, fmt.Errorf("reference range without a metric")
+		}
+		if rr.MaxAge != 0 && rr.MaxAge <= rr.MinAge {
+			return nil, fmt.Errorf("reference range for %s has maxAge %g <= minAge %g", rr.Metric, rr.MaxAge, rr.MinAge)
+		}
+	}
+	return &t, nil
+}
+
+// LoadRangeTableFile replaces the built-in reference ranges with those in
+// the file at path.
+func LoadRangeTableFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	t, err := ParseRangeTable(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	table = t
+	mu.Unlock()
+	return nil
+}
+
+// Lookup returns the reference range of metric for a patient, preferring a
+// sex-specific range over one that applies to both sexes.
+func Lookup(metric, sex string, age float64) *ReferenceRange {
+	mu.RLock()
+	defer mu.RUnlock()
+	return SelectRange(table.Ranges, metric, sex, age)
+}
+
+// SelectRange returns the range of metric in ranges for a patient of the
+// given sex and age in years, preferring a sex-specific range over one that
+// applies to both sexes.
+func SelectRange(ranges []ReferenceRange, metric, sex string, age float64) *ReferenceRange {
+	var match *ReferenceRange
+	for i := range ranges {
+		rr := &ranges[i]
+		if rr.Metric != metric || age < rr.MinAge || (rr.MaxAge != 0 && age >= rr.MaxAge) {
+			continue
+		}
+		if rr.Sex != "" && rr.Sex != sex {
+			continue
+		}
+		if match == nil || (match.Sex == "" && rr.Sex != "") {
+			match = rr
+		}
+	}
+	return match
+}
+
+// Classify flags a value of metric for a patient of the given sex and age
+// in years. It returns "" when no reference range applies.
+func Classify(metric string, value float64, sex string, age float64) Flag {
+	rr := Lookup(metric, sex, age)
+	if rr == nil {
+		return ""
+	}
+	return rr.Flag(value)
+}
+
+// Flag classifies value against the range.
+func (rr *ReferenceRange) Flag(value float64) Flag {
+	switch {
+	case rr.CriticalLow != nil && value <= *rr.CriticalLow:
+		return FlagCritical
+	case rr.CriticalHigh != nil && value >= *rr.CriticalHigh:
+		return FlagCritical
+	case rr.Low != nil && value < *rr.Low:
+		return FlagLow
+	case rr.High != nil && value > *rr.High:
+		return FlagHigh
+	}
+	return FlagNormal
+}
+
+// AgeAt returns the age in years, with a fraction, of someone born on dob at
+// time t.
+func AgeAt(dob, t time.Time) float64 {
+	return t.Sub(dob).Hours() / 24 / 365.25
+}
+
+// CheckPlausible returns a message for every value that cannot be a real
+// measurement, keyed by metric.
+func CheckPlausible(values map[string]float64) map[string]string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	problems := make(map[string]string)
+	for m, v := range values {
+		bounds, ok := table.Plausible[m]
+		if !ok {
+			continue
+		}
+		if v < bounds[0] || v > bounds[1] {
+			problems[m] = fmt.Sprintf("%s must be between %g and %g", m, bounds[0], bounds[1])
+		}
+	}
+
+	sys, hasSys := values["bloodPressureSystolic"]
+	dia, hasDia := values["bloodPressureDiastolic"]
+	if hasSys && hasDia && dia >= sys {
+		problems["bloodPressureDiastolic"] = "bloodPressureDiastolic must be lower than bloodPressureSystolic"
+	}
+
+	return problems
+}
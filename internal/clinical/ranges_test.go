@@ -0,0 +1,190 @@
+package clinical
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func bound(v float64) *float64 {
+	return &v
+}
+
+func TestSelectRange(t *testing.T) {
+	child := ReferenceRange{Metric: "haemoglobin", MinAge: 0, MaxAge: 12, Low: bound(11)}
+	adult := ReferenceRange{Metric: "haemoglobin", MinAge: 12, Low: bound(12)}
+	male := ReferenceRange{Metric: "haemoglobin", Sex: "MALE", MinAge: 12, Low: bound(13)}
+
+	tests := []struct {
+		name   string
+		ranges []ReferenceRange
+		metric string
+		sex    string
+		age    float64
+		want   *ReferenceRange
+	}{
+		{"Sex-specific range", []ReferenceRange{child, adult, male}, "haemoglobin", "MALE", 30, &male},
+		// the sex-specific range wins wherever it is listed
+		{"Sex-specific range listed first", []ReferenceRange{male, adult, child}, "haemoglobin", "MALE", 30, &male},
+		{"Other sex", []ReferenceRange{child, adult, male}, "haemoglobin", "FEMALE", 30, &adult},
+		{"Sex not recorded", []ReferenceRange{child, adult, male}, "haemoglobin", "", 30, &adult},
+		{"Within a band", []ReferenceRange{child, adult, male}, "haemoglobin", "MALE", 11.99, &child},
+		// minAge is inclusive and maxAge exclusive
+		{"At a band edge", []ReferenceRange{child, adult}, "haemoglobin", "FEMALE", 12, &adult},
+		{"Only a range of the other sex", []ReferenceRange{male}, "haemoglobin", "FEMALE", 30, nil},
+		{"Unknown metric", []ReferenceRange{child, adult, male}, "ferritin", "MALE", 30, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SelectRange(tt.ranges, tt.metric, tt.sex, tt.age)
+			if tt.want == nil {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			require.Equal(t, *tt.want, *got)
+		})
+	}
+}
+
+func TestFlag(t *testing.T) {
+	// the adult pulse range: critical at 40 and 130, normal from 60 to 100
+	pulse := Lookup("pulse", "MALE", 30)
+	require.NotNil(t, pulse)
+
+	tests := []struct {
+		value float64
+		want  Flag
+	}{
+		{39, FlagCritical},
+		{40, FlagCritical},
+		{41, FlagLow},
+		{59, FlagLow},
+		{60, FlagNormal},
+		{100, FlagNormal},
+		{101, FlagHigh},
+		{129, FlagHigh},
+		{130, FlagCritical},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, pulse.Flag(tt.value), "pulse %v", tt.value)
+	}
+
+	// a range without an upper bound is never high
+	spo2 := Lookup("oxygenSaturation", "FEMALE", 30)
+	require.NotNil(t, spo2)
+	require.Equal(t, FlagNormal, spo2.Flag(100))
+	require.Equal(t, FlagLow, spo2.Flag(93))
+	require.Equal(t, FlagCritical, spo2.Flag(90))
+}
+
+func TestClassifyAtBandEdges(t *testing.T) {
+	tests := []struct {
+		name string
+		age  float64
+		want Flag
+	}{
+		// a pulse of 95 is low for an infant, whose band ends at 1 year
+		{"Infant", 0.99, FlagLow},
+		{"First birthday", 1, FlagNormal},
+		{"Adult", 18, FlagNormal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Classify("pulse", 95, "FEMALE", tt.age))
+		})
+	}
+
+	require.Equal(t, Flag(""), Classify("ferritin", 95, "FEMALE", 30))
+}
+
+func TestCheckPlausible(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]float64
+		want   map[string]string
+	}{
+		{
+			name:   "Real measurements",
+			values: map[string]float64{"pulse": 72, "heightCm": 170, "bloodPressureSystolic": 120, "bloodPressureDiastolic": 80},
+			want:   map[string]string{},
+		},
+		{
+			name:   "At the bounds",
+			values: map[string]float64{"temperatureC": 25, "oxygenSaturation": 100},
+			want:   map[string]string{},
+		},
+		{
+			name:   "Out of bounds",
+			values: map[string]float64{"heightCm": 300, "oxygenSaturation": 101},
+			want: map[string]string{
+				"heightCm":         "heightCm must be between 20 and 272",
+				"oxygenSaturation": "oxygenSaturation must be between 40 and 100",
+			},
+		},
+		{
+			name:   "Diastolic not below systolic",
+			values: map[string]float64{"bloodPressureSystolic": 90, "bloodPressureDiastolic": 90},
+			want:   map[string]string{"bloodPressureDiastolic": "bloodPressureDiastolic must be lower than bloodPressureSystolic"},
+		},
+		{
+			name:   "Metric without bounds",
+			values: map[string]float64{"news2Score": 25},
+			want:   map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, CheckPlausible(tt.values))
+		})
+	}
+}
+
+func TestLoadRangeTableFile(t *testing.T) {
+	mu.RLock()
+	previous := table
+	mu.RUnlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		table = previous
+		mu.Unlock()
+	})
+
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "ranges.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	invalid := []struct {
+		name    string
+		content string
+	}{
+		{"Malformed JSON", `{"ranges": [`},
+		{"Range without a metric", `{"ranges": [{"minAge": 0, "low": 1}]}`},
+		{"maxAge not above minAge", `{"ranges": [{"metric": "pulse", "minAge": 12, "maxAge": 12}]}`},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, LoadRangeTableFile(write(t, tt.content)))
+			// the ranges in use are kept
+			require.Equal(t, previous, table)
+		})
+	}
+
+	require.Error(t, LoadRangeTableFile(filepath.Join(t.TempDir(), "missing.json")))
+
+	path := write(t, `{
+		"plausible": {"pulse": [30, 250]},
+		"ranges": [{"metric": "pulse", "sex": "FEMALE", "minAge": 18, "low": 50, "high": 90}]
+	}`)
+	require.NoError(t, LoadRangeTableFile(path))
+	require.Equal(t, FlagHigh, Classify("pulse", 95, "FEMALE", 30))
+	require.Equal(t, Flag(""), Classify("pulse", 95, "MALE", 30))
+	require.Equal(t, map[string]string{"pulse": "pulse must be between 30 and 250"}, CheckPlausible(map[string]float64{"pulse": 20}))
+}
@@ -0,0 +1,45 @@
+package clinical
+
+// Statuses of a referral. Declined, completed and cancelled are final.
+const (
+	ReferralPending   = "pending"
+	ReferralAccepted  = "accepted"
+	ReferralDeclined  = "declined"
+	ReferralCompleted = "completed"
+	ReferralCancelled = "cancelled"
+)
+
+// Urgencies of a referral.
+const (
+	UrgencyRoutine   = "routine"
+	UrgencyUrgent    = "urgent"
+	UrgencyEmergency = "emergency"
+)
+
+var referralTransitions = map[string][]string{
+	ReferralPending:  {ReferralAccepted, ReferralDeclined, ReferralCancelled},
+	ReferralAccepted: {ReferralCompleted, ReferralCancelled},
+}
+
+// CanTransitionReferral reports whether a referral may move from one status
+// to another.
+func CanTransitionReferral(from, to string) bool {
+	for _, s := range referralTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// UrgencyRank orders urgencies, the most urgent first.
+func UrgencyRank(urgency string) int {
+	switch urgency {
+	case UrgencyEmergency:
+		return 0
+	case UrgencyUrgent:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,149 @@
+package clinical
+
+import (
+	"fmt"
+	"math"
+)
+
+// Unit systems accepted by the units query parameter.
+const (
+	UnitSystemMetric   = "metric"
+	UnitSystemImperial = "imperial"
+	UnitSystemSI       = "si"
+)
+
+// Units names the unit of each kind of measurement.
+type Units struct {
+	Height        string
+	Weight        string
+	Temperature   string
+	BloodPressure string
+}
+
+// Canonical is the unit set vitals are stored in.
+var Canonical = Units{Height: "cm", Weight: "kg", Temperature: "C", BloodPressure: "mmHg"}
+
+// UnitSystems maps a unit system to its units.
+var UnitSystems = map[string]Units{
+	UnitSystemMetric:   Canonical,
+	UnitSystemImperial: {Height: "in", Weight: "lb", Temperature: "F", BloodPressure: "mmHg"},
+	UnitSystemSI:       {Height: "m", Weight: "kg", Temperature: "C", BloodPressure: "kPa"},
+}
+
+const (
+	cmPerInch  = 2.54
+	kgPerPound = 0.45359237
+	mmHgPerKPa = 7.50061683
+)
+
+// ToCanonical converts a value of the given kind ("height", "weight",
+// "temperature" or "bloodPressure") from unit to the stored unit.
+func ToCanonical(kind string, value float64, unit string) (float64, error) {
+	switch kind {
+	case "height":
+		switch unit {
+		case "", "cm":
+			return value, nil
+		case "m":
+			return value * 100, nil
+		case "in":
+			return value * cmPerInch, nil
+		}
+	case "weight":
+		switch unit {
+		case "", "kg":
+			return value, nil
+		case "lb":
+			return value * kgPerPound, nil
+		}
+	case "temperature":
+		switch unit {
+		case "", "C":
+			return value, nil
+		case "F":
+			return (value - 32) * 5 / 9, nil
+		}
+	case "bloodPressure":
+		switch unit {
+		case "", "mmHg":
+			return value, nil
+		case "kPa":
+			return value * mmHgPerKPa, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported %s unit %q", kind, unit)
+}
+
+// FromCanonical converts a stored value of the given kind to unit.
+func FromCanonical(kind string, value float64, unit string) (float64, error) {
+	switch kind {
+	case "height":
+		switch unit {
+		case "", "cm":
+			return value, nil
+		case "m":
+			return value / 100, nil
+		case "in":
+			return value / cmPerInch, nil
+		}
+	case "weight":
+		switch unit {
+		case "", "kg":
+			return value, nil
+		case "lb":
+			return value / kgPerPound, nil
+		}
+	case "temperature":
+		switch unit {
+		case "", "C":
+			return value, nil
+		case "F":
+			return value*9/5 + 32, nil
+		}
+	case "bloodPressure":
+		switch unit {
+		case "", "mmHg":
+			return value, nil
+		case "kPa":
+			return value / mmHgPerKPa, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported %s unit %q", kind, unit)
+}
+
+// MetricKind returns the kind of measurement a vitals metric is, or "" for
+// metrics that have a single unit such as pulse or SpO2.
+func MetricKind(metric string) string {
+	switch metric {
+	case "heightCm":
+		return "height"
+	case "weightKg":
+		return "weight"
+	case "temperatureC":
+		return "temperature"
+	case "bloodPressureSystolic", "bloodPressureDiastolic":
+		return "bloodPressure"
+	}
+	return ""
+}
+
+// Unit returns the unit of kind in the unit set.
+func (u Units) Unit(kind string) string {
+	switch kind {
+	case "height":
+		return u.Height
+	case "weight":
+		return u.Weight
+	case "temperature":
+		return u.Temperature
+	case "bloodPressure":
+		return u.BloodPressure
+	}
+	return ""
+}
+
+// Round rounds v to the given number of decimal places.
+func Round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,93 @@
+package clinical
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToCanonical(t *testing.T) {
+	tests := []struct {
+		kind  string
+		value float64
+		unit  string
+		want  float64
+	}{
+		{"height", 170, "", 170},
+		{"height", 170, "cm", 170},
+		{"height", 1.7, "m", 170},
+		{"height", 70, "in", 177.8},
+		{"weight", 65, "kg", 65},
+		{"weight", 150, "lb", 68.0388555},
+		{"temperature", 37, "C", 37},
+		{"temperature", 98.6, "F", 37},
+		{"temperature", 32, "F", 0},
+		{"bloodPressure", 120, "mmHg", 120},
+		{"bloodPressure", 16, "kPa", 120.0098693},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind+" "+tt.unit, func(t *testing.T) {
+			got, err := ToCanonical(tt.kind, tt.value, tt.unit)
+			require.NoError(t, err)
+			require.InDelta(t, tt.want, got, 1e-6)
+
+			back, err := FromCanonical(tt.kind, got, tt.unit)
+			require.NoError(t, err)
+			require.InDelta(t, tt.value, back, 1e-9)
+		})
+	}
+}
+
+func TestUnsupportedUnits(t *testing.T) {
+	tests := []struct {
+		kind string
+		unit string
+	}{
+		{"height", "ft"},
+		{"weight", "st"},
+		{"temperature", "K"},
+		{"bloodPressure", "cmH2O"},
+		{"glucose", "mg/dL"},
+		// units of one kind aren't accepted for another
+		{"weight", "cm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind+" "+tt.unit, func(t *testing.T) {
+			_, err := ToCanonical(tt.kind, 1, tt.unit)
+			require.Error(t, err)
+			_, err = FromCanonical(tt.kind, 1, tt.unit)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestUnitSystems(t *testing.T) {
+	for name, units := range UnitSystems {
+		t.Run(name, func(t *testing.T) {
+			for _, kind := range []string{"height", "weight", "temperature", "bloodPressure"} {
+				_, err := FromCanonical(kind, 1, units.Unit(kind))
+				require.NoError(t, err, kind)
+			}
+		})
+	}
+	require.Equal(t, Canonical, UnitSystems[UnitSystemMetric])
+}
+
+func TestMetricKind(t *testing.T) {
+	require.Equal(t, "height", MetricKind("heightCm"))
+	require.Equal(t, "weight", MetricKind("weightKg"))
+	require.Equal(t, "temperature", MetricKind("temperatureC"))
+	require.Equal(t, "bloodPressure", MetricKind("bloodPressureSystolic"))
+	require.Equal(t, "bloodPressure", MetricKind("bloodPressureDiastolic"))
+	require.Equal(t, "", MetricKind("pulse"))
+	require.Equal(t, "", MetricKind("oxygenSaturation"))
+}
+
+func TestRound(t *testing.T) {
+	require.Equal(t, 22.5, Round(22.49, 1))
+	require.Equal(t, 22.0, Round(22.04, 1))
+	require.Equal(t, 177.8, Round(177.80000001, 2))
+	require.Equal(t, 37.0, Round(36.99999, 0))
+}
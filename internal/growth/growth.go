@@ -0,0 +1,299 @@
+// Package growth holds the WHO growth standards as LMS tables and scores a
+// child's weight, height and BMI against them.
+//
+// The tables come from the WHO Child Growth Standards for 0 to 5 years,
+// https://www.who.int/tools/child-growth-standards/standards, and the WHO
+// Growth Reference 2007 for 5 to 19 years,
+// https://www.who.int/tools/growth-reference-data-for-5to19-years. The
+// embedded who.json is abridged; the complete tables can be loaded with
+// LoadStandardsFile.
+package growth
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// Indicators of the growth standards.
+const (
+	IndicatorWeight = "weight-for-age"
+	IndicatorHeight = "height-for-age"
+	IndicatorBMI    = "bmi-for-age"
+)
+
+// DaysPerMonth is the average length of a month the WHO uses to turn an age
+// in days into months.
+const DaysPerMonth = 30.4375
+
+// CurveZScores are the z-score lines drawn on a growth chart.
+var CurveZScores = []float64{-3, -2, 0, 2, 3}
+
+// LMS holds the Box-Cox power L, median M and coefficient of variation S of
+// a measurement at an age in months.
+type LMS struct {
+	Age, L, M, S float64
+}
+
+// UnmarshalJSON reads a row of a table, written [age, L, M, S].
+func (p *LMS) UnmarshalJSON(b []byte) error {
+	var row []float64
+	if err := json.Unmarshal(b, &row); err != nil {
+		return err
+	}
+	if len(row) != 4 {
+		return fmt.Errorf("LMS row %v must hold the age, L, M and S", row)
+	}
+	*p = LMS{Age: row[0], L: row[1], M: row[2], S: row[3]}
+	return nil
+}
+
+func (p LMS) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{p.Age, p.L, p.M, p.S})
+}
+
+// Value returns the measurement at z-score z.
+func (p LMS) Value(z float64) float64 {
+	if p.L == 0 {
+		return p.M * math.Exp(p.S*z)
+	}
+	return p.M * math.Pow(1+p.L*p.S*z, 1/p.L)
+}
+
+// Z returns the z-score of the measurement x.
+func (p LMS) Z(x float64) float64 {
+	if p.L == 0 {
+		return math.Log(x/p.M) / p.S
+	}
+	return (math.Pow(x/p.M, p.L) - 1) / (p.L * p.S)
+}
+
+// Indicator is a growth standard for a measurement by age.
+type Indicator struct {
+	Code string `json:"code" example:"weight-for-age"`
+	Name string `json:"name" example:"Weight-for-age"`
+
+	// Metric is the vitals metric the indicator scores.
+	Metric string `json:"metric" example:"weightKg"`
+	Unit   string `json:"unit" example:"kg"`
+
+	// Restricted scores beyond ±3 SD on the distance between the 2 and 3 SD
+	// lines, as the WHO does for weight and BMI.
+	Restricted bool `json:"restricted"`
+
+	// Tables holds the LMS rows by sex, MALE or FEMALE, by increasing age.
+	Tables map[string][]LMS `json:"tables" swaggertype:"object"`
+}
+
+// MaxAge returns the oldest age in months the indicator covers for sex.
+func (ind Indicator) MaxAge(sex string) float64 {
+	table := ind.Tables[sex]
+	if len(table) == 0 {
+		return 0
+	}
+	return table[len(table)-1].Age
+}
+
+// At returns the LMS values for sex at an age in months, interpolated
+// linearly between the rows of the table.
+func (ind Indicator) At(sex string, age float64) (LMS, bool) {
+	table := ind.Tables[sex]
+	if len(table) == 0 || age < table[0].Age || age > table[len(table)-1].Age {
+		return LMS{}, false
+	}
+
+	for i := 1; i < len(table); i++ {
+		lo, hi := table[i-1], table[i]
+		if age > hi.Age {
+			continue
+		}
+		f := (age - lo.Age) / (hi.Age - lo.Age)
+		return LMS{
+			Age: age,
+			L:   lo.L + f*(hi.L-lo.L),
+			M:   lo.M + f*(hi.M-lo.M),
+			S:   lo.S + f*(hi.S-lo.S),
+		}, true
+	}
+	return table[0], true
+}
+
+// ZScore returns the z-score of a measurement of a child of sex at an age
+// in months.
+func (ind Indicator) ZScore(sex string, age, x float64) (float64, bool) {
+	p, ok := ind.At(sex, age)
+	if !ok || x <= 0 {
+		return 0, false
+	}
+
+	z := p.Z(x)
+	if ind.Restricted {
+		switch {
+		case z > 3:
+			sd3, sd2 := p.Value(3), p.Value(2)
+			z = 3 + (x-sd3)/(sd3-sd2)
+		case z < -3:
+			sd3, sd2 := p.Value(-3), p.Value(-2)
+			z = -3 + (x-sd3)/(sd2-sd3)
+		}
+	}
+	return round(z, 2), true
+}
+
+// Curve is a z-score line of a growth chart.
+type Curve struct {
+	ZScore     float64      `json:"zScore" example:"-2"`
+	Percentile float64      `json:"percentile" example:"2.3"`
+	Points     []CurvePoint `json:"points"`
+}
+
+// CurvePoint is a point of a curve.
+type CurvePoint struct {
+	AgeMonths float64 `json:"ageMonths" example:"12"`
+	Value     float64 `json:"value" example:"7.7"`
+}
+
+// Curves returns the CurveZScores lines for sex between two ages in months,
+// with a point at each row of the table.
+func (ind Indicator) Curves(sex string, from, to float64) []Curve {
+	var ages []float64
+	for _, p := range ind.Tables[sex] {
+		if p.Age > from && p.Age < to {
+			ages = append(ages, p.Age)
+		}
+	}
+	ages = append(append([]float64{from}, ages...), to)
+
+	curves := make([]Curve, 0, len(CurveZScores))
+	for _, z := range CurveZScores {
+		curve := Curve{ZScore: z, Percentile: Percentile(z), Points: []CurvePoint{}}
+		for _, age := range ages {
+			p, ok := ind.At(sex, age)
+			if !ok {
+				continue
+			}
+			curve.Points = append(curve.Points, CurvePoint{AgeMonths: round(age, 2), Value: round(p.Value(z), 2)})
+		}
+		curves = append(curves, curve)
+	}
+	return curves
+}
+
+// Percentile returns the percentile of a z-score.
+func Percentile(z float64) float64 {
+	return round(50*(1+math.Erf(z/math.Sqrt2)), 1)
+}
+
+// AgeMonths returns the age in months of a child born on dob at t, counting
+// whole days.
+func AgeMonths(dob, t time.Time) float64 {
+	dob = time.Date(dob.Year(), dob.Month(), dob.Day(), 0, 0, 0, 0, time.UTC)
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return t.Sub(dob).Hours() / 24 / DaysPerMonth
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	r := math.Round(v*p) / p
+	if r == 0 {
+		// no negative zero
+		return 0
+	}
+	return r
+}
+
+// Standards are the growth standards in use.
+type Standards struct {
+	Name       string      `json:"name"`
+	Indicators []Indicator `json:"indicators"`
+}
+
+//go:embed who.json
+var defaultStandards []byte
+
+var (
+	mu        sync.RWMutex
+	standards *Standards
+)
+
+func init() {
+	s, err := ParseStandards(bytes.NewReader(defaultStandards))
+	if err != nil {
+		panic(fmt.Sprintf("growth: invalid embedded standards: %v", err))
+	}
+	standards = s
+}
+
+// ParseStandards reads standards in the format of who.json. Each table must
+// be sorted by age with a positive M and S.
+func ParseStandards(r io.Reader) (*Standards, error) {
+	var s Standards
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+
+	for _, ind := range s.Indicators {
+		if ind.Code == "" || ind.Metric == "" {
+			return nil, fmt.Errorf("indicator without a code or a metric")
+		}
+		for sex, table := range ind.Tables {
+			if sex != "MALE" && sex != "FEMALE" {
+				return nil, fmt.Errorf("%s has a table for unknown sex %q", ind.Code, sex)
+			}
+			for i, p := range table {
+				if p.M <= 0 || p.S <= 0 {
+					return nil, fmt.Errorf("%s %s at %g months has a non-positive M or S", ind.Code, sex, p.Age)
+				}
+				if i > 0 && p.Age <= table[i-1].Age {
+					return nil, fmt.Errorf("%s %s is not sorted by age at %g months", ind.Code, sex, p.Age)
+				}
+			}
+		}
+	}
+	return &s, nil
+}
+
+// LoadStandardsFile replaces the built-in standards with the ones in the
+// file at path, such as the complete WHO tables.
+func LoadStandardsFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s, err := ParseStandards(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	standards = s
+	mu.Unlock()
+	return nil
+}
+
+// Current returns the standards.
+func Current() *Standards {
+	mu.RLock()
+	defer mu.RUnlock()
+	return standards
+}
+
+// Lookup returns the indicator with the code.
+func Lookup(code string) (Indicator, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, ind := range standards.Indicators {
+		if ind.Code == code {
+			return ind, true
+		}
+	}
+	return Indicator{}, false
+}
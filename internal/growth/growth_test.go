@@ -0,0 +1,104 @@
+package growth
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func lookup(t *testing.T, code string) Indicator {
+	t.Helper()
+	ind, ok := Lookup(code)
+	require.True(t, ok, code)
+	return ind
+}
+
+// The -3, -2, 0, +2 and +3 SD lines at birth, as printed to one decimal in
+// the WHO Child Growth Standards z-score tables.
+func TestLMSValueMatchesPublishedLines(t *testing.T) {
+	tests := []struct {
+		code  string
+		sex   string
+		lines []float64
+	}{
+		{IndicatorWeight, "MALE", []float64{2.1, 2.5, 3.3, 4.4, 5.0}},
+		{IndicatorWeight, "FEMALE", []float64{2.0, 2.4, 3.2, 4.2, 4.8}},
+		{IndicatorHeight, "MALE", []float64{44.2, 46.1, 49.9, 53.7, 55.6}},
+		{IndicatorHeight, "FEMALE", []float64{43.6, 45.4, 49.1, 52.9, 54.7}},
+		{IndicatorBMI, "MALE", []float64{10.2, 11.1, 13.4, 16.3, 18.1}},
+		{IndicatorBMI, "FEMALE", []float64{10.1, 11.1, 13.3, 16.1, 17.7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code+" "+tt.sex, func(t *testing.T) {
+			p, ok := lookup(t, tt.code).At(tt.sex, 0)
+			require.True(t, ok)
+			for i, z := range CurveZScores {
+				require.Equal(t, tt.lines[i], round(p.Value(z), 1), "z %v", z)
+			}
+		})
+	}
+}
+
+func TestLMSZ(t *testing.T) {
+	// weight-for-age of boys at birth
+	boys := LMS{L: 0.3487, M: 3.3464, S: 0.14602}
+	require.Equal(t, 0.0, boys.Z(boys.M))
+	require.InDelta(t, 1.2606, boys.Z(4.0), 0.0001)
+	require.InDelta(t, -1.8988, boys.Z(2.5), 0.0001)
+
+	// a table with L = 0 is log-normal
+	logNormal := LMS{L: 0, M: 10, S: 0.1}
+	require.InDelta(t, math.Log(1.2)/0.1, logNormal.Z(12), 1e-9)
+
+	for _, p := range []LMS{boys, logNormal, {L: 1, M: 49.8842, S: 0.03795}, {L: -0.3053, M: 13.4069, S: 0.0956}} {
+		for _, z := range []float64{-3.5, -2, -0.5, 0, 1, 2.75} {
+			require.InDelta(t, z, p.Z(p.Value(z)), 1e-9, "%+v at z %v", p, z)
+		}
+	}
+}
+
+func TestZScore(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		sex  string
+		x    float64
+		want float64
+	}{
+		{"Median", IndicatorWeight, "MALE", 3.3464, 0},
+		{"Within 3 SD", IndicatorWeight, "MALE", 4.0, 1.26},
+		// restricted: 3 + (5.6 - SD3) / (SD3 - SD2), where the LMS z-score
+		// would be 3.86
+		{"Weight above +3 SD", IndicatorWeight, "MALE", 5.6, 3.93},
+		// the LMS z-score would be -4.70
+		{"Weight below -3 SD", IndicatorWeight, "FEMALE", 1.5, -4.47},
+		{"BMI above +3 SD", IndicatorBMI, "MALE", 19.5, 3.79},
+		{"BMI below -3 SD", IndicatorBMI, "FEMALE", 9.0, -4.16},
+		// height is normally distributed and isn't restricted
+		{"Height above +3 SD", IndicatorHeight, "MALE", 60, 5.34},
+		{"Height below -3 SD", IndicatorHeight, "MALE", 40, -5.22},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z, ok := lookup(t, tt.code).ZScore(tt.sex, 0, tt.x)
+			require.True(t, ok)
+			require.Equal(t, tt.want, z)
+		})
+	}
+}
+
+func TestZScoreOutOfRange(t *testing.T) {
+	weight := lookup(t, IndicatorWeight)
+
+	_, ok := weight.ZScore("MALE", -1, 3.3)
+	require.False(t, ok)
+	_, ok = weight.ZScore("MALE", weight.MaxAge("MALE")+1, 30)
+	require.False(t, ok)
+	_, ok = weight.ZScore("MALE", 0, 0)
+	require.False(t, ok)
+	_, ok = weight.ZScore("OTHER", 0, 3.3)
+	require.False(t, ok)
+}
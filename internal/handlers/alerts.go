@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/alerts"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// raiseAlerts evaluates events against the alert rules and stores the
+// alerts they trigger. A failure is logged rather than failing the write
+// that caused the events.
+func (h *handler) raiseAlerts(patientID, raisedByID string, events ...alerts.Event) {
+	triggered := alerts.Evaluate(events...)
+	if len(triggered) == 0 {
+		return
+	}
+
+	req := &models.RaiseAlertsReq{
+		PatientID:  patientID,
+		RaisedByID: raisedByID,
+	}
+	for _, t := range triggered {
+		req.Alerts = append(req.Alerts, models.NewAlert{
+			RuleID:    t.RuleID,
+			Severity:  t.Severity,
+			Source:    t.Source,
+			SubjectID: t.SubjectID,
+			Message:   t.Message,
+			DedupKey:  t.DedupKey,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	raised, err := h.store.Alerts.Raise(ctx, req)
+	if err != nil {
+		h.logger.Error("raising alerts failed", zap.String("patient", patientID), zap.Error(err))
+		return
+	}
+	for _, a := range raised {
+		h.logger.Info("alert raised",
+			zap.String("alert", a.ID),
+			zap.String("rule", a.RuleID),
+			zap.String("severity", a.Severity))
+	}
+}
+
+// vitalEvents returns an event for every flagged measurement of an
+// observation and one for its NEWS2 score. The values must still be in
+// the stored units.
+func vitalEvents(v *models.VitalModel) []alerts.Event {
+	values := map[string]*float64{
+		"heightCm":               v.HeightCm,
+		"weightKg":               v.WeightKg,
+		"bmi":                    v.BMI,
+		"temperatureC":           v.TemperatureC,
+		"bloodPressureSystolic":  v.BloodPressureSystolic,
+		"bloodPressureDiastolic": v.BloodPressureDiastolic,
+		"oxygenSaturation":       v.OxygenSaturation,
+	}
+	if v.Pulse != nil {
+		pulse := float64(*v.Pulse)
+		values["pulse"] = &pulse
+	}
+	if v.RespiratoryRate != nil {
+		rr := float64(*v.RespiratoryRate)
+		values["respiratoryRate"] = &rr
+	}
+
+	metrics := make([]string, 0, len(v.Flags))
+	for metric := range v.Flags {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	var events []alerts.Event
+	for _, metric := range metrics {
+		value, ok := values[metric]
+		if !ok || value == nil {
+			continue
+		}
+		events = append(events, alerts.Event{
+			Source:    alerts.SourceVital,
+			PatientID: v.PatientID,
+			SubjectID: v.ID,
+			Key:       metric,
+			Facts: map[string]string{
+				"metric": metric,
+				"flag":   v.Flags[metric],
+				"value":  strconv.FormatFloat(*value, 'f', -1, 64),
+			},
+		})
+	}
+
+	if v.NEWS2 != nil {
+		events = append(events, alerts.Event{
+			Source:    alerts.SourceNEWS2,
+			PatientID: v.PatientID,
+			SubjectID: v.ID,
+			Key:       "news2",
+			Facts: map[string]string{
+				"score": strconv.Itoa(v.NEWS2.Total),
+				"risk":  string(v.NEWS2.Risk),
+			},
+		})
+	}
+	return events
+}
+
+// allergyEvents returns the event of an allergy, or none when the allergy
+// is ruled out.
+func allergyEvents(a *models.Allergy) []alerts.Event {
+	if clinical.IsAllergyRuledOut(a.VerificationStatus) {
+		return nil
+	}
+	return []alerts.Event{{
+		Source:    alerts.SourceAllergy,
+		PatientID: a.PatientID,
+		SubjectID: a.ID,
+		Facts: map[string]string{
+			"name":        a.Name,
+			"severity":    a.Severity,
+			"reaction":    a.Reaction,
+			"category":    a.Category,
+			"criticality": a.Criticality,
+		},
+	}}
+}
+
+func diagnosisEvent(d *models.Diagnoses) alerts.Event {
+	return alerts.Event{
+		Source:    alerts.SourceDiagnosis,
+		PatientID: d.PatientID,
+		SubjectID: d.ID,
+		Facts: map[string]string{
+			"name": d.Name,
+		},
+	}
+}
+
+// HandleListAlerts godoc
+// @Summary List alerts
+// @Description Lists clinical alerts, critical and most recent first. By default only the alerts assigned
+// @Description to the current user are listed; pass assigned=all to list everyone's.
+// @Tags Alerts
+// @Produce json
+// @Param assigned query string false "Whose alerts to list" Enums(me, all)
+// @Param patientId query string false "Patient ID"
+// @Param status query string false "Status" Enums(open, acknowledged, resolved)
+// @Param severity query string false "Severity" Enums(critical, warning, info)
+// @Param limit query int false "Maximum number of alerts (default 50, at most 200)"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/alerts [get]
+func (h *handler) HandleListAlerts(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromCtx(r)
+	query := r.URL.Query()
+
+	req := models.AlertQuery{
+		UserID:    user.ID,
+		PatientID: query.Get("patientId"),
+		Status:    query.Get("status"),
+		Severity:  query.Get("severity"),
+		Limit:     50,
+	}
+
+	switch query.Get("assigned") {
+	case "", "me":
+		req.Mine = true
+	case "all":
+	default:
+		badRequestResponse(w, r)
+		return
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		req.Limit = n
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.store.Alerts.List(ctx, &req)
+	if err != nil {
+		h.logger.Error("listing alerts failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "alerts fetched successfully",
+		Data:    list,
+	})
+}
+
+// HandleAcknowledgeAlert godoc
+// @Summary Acknowledge an alert
+// @Description Marks an open alert as seen by the current user, who must be assigned to the alert or on
+// @Description the patient's care team.
+// @Tags Alerts
+// @Produce json
+// @Param alertID path string true "Alert ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/alerts/{alertID}/acknowledge [post]
+func (h *handler) HandleAcknowledgeAlert(w http.ResponseWriter, r *http.Request) {
+	h.handleAlertAction(w, r, "acknowledged", h.store.Alerts.Acknowledge)
+}
+
+// HandleResolveAlert godoc
+// @Summary Resolve an alert
+// @Description Closes an alert, with an optional note on how it was handled. Resolving an open alert
+// @Description also acknowledges it. Only the alert's assignees and the patient's care team can resolve it.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Param alertID path string true "Alert ID"
+// @Param body body models.AlertActionReq false "Resolution note"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/alerts/{alertID}/resolve [post]
+func (h *handler) HandleResolveAlert(w http.ResponseWriter, r *http.Request) {
+	h.handleAlertAction(w, r, "resolved", h.store.Alerts.Resolve)
+}
+
+func (h *handler) handleAlertAction(w http.ResponseWriter, r *http.Request, done string,
+	action func(context.Context, *models.AlertActionReq) (*models.Alert, error)) {
+	user := getUserFromCtx(r)
+
+	var req models.AlertActionReq
+	if r.ContentLength > 0 {
+		if err := helpers.DecodeJSON(r, &req); err != nil {
+			h.logger.Info("unprocessable entity", zap.Error(err))
+			unprocessableEntityResponse(w, r)
+			return
+		}
+	}
+
+	req.AlertID = chi.URLParam(r, "alertID")
+	req.UserID = user.ID
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	alert, err := action(ctx, &req)
+	if err != nil {
+		h.logger.Info("alert "+done+" failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrAlertNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrNotAlertAssignee):
+			forbiddenErrorResponse(w, r)
+		case errors.Is(err, store.ErrAlertResolved):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "alert " + done + " successfully",
+		Data:    alert,
+	})
+}
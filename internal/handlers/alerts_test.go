@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleListAlerts(t *testing.T) {
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.AlertStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Unknown status",
+			query:              "status=closed",
+			mockSetup:          func(as *mocks.AlertStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Limit too large",
+			query:              "limit=1000",
+			mockSetup:          func(as *mocks.AlertStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown assignment",
+			query:              "assigned=team",
+			mockSetup:          func(as *mocks.AlertStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Own alerts by default",
+			query: "status=open",
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("List", mock.Anything, mock.MatchedBy(func(q *models.AlertQuery) bool {
+					return q.Mine && q.UserID == userID && q.Status == "open" && q.Limit == 50
+				})).Return([]*models.Alert{{ID: "alert-id"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Everyone's alerts",
+			query: "assigned=all&severity=critical",
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("List", mock.Anything, mock.MatchedBy(func(q *models.AlertQuery) bool {
+					return !q.Mine && q.Severity == "critical"
+				})).Return([]*models.Alert{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "DB error",
+			query: "",
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := mocks.NewAlertStorer(t)
+			tt.mockSetup(as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Alerts: as},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/alerts?"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleListAlerts(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleResolveAlert(t *testing.T) {
+	alertID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		alertID            string
+		body               []byte
+		mockSetup          func(*mocks.AlertStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid alert ID",
+			alertID:            "not-a-uuid",
+			mockSetup:          func(as *mocks.AlertStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed JSON",
+			alertID:            alertID,
+			body:               []byte(`{"note":}`),
+			mockSetup:          func(as *mocks.AlertStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "Alert not found",
+			alertID: alertID,
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("Resolve", mock.Anything, mock.Anything).Return(nil, store.ErrAlertNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "Not assigned to the alert",
+			alertID: alertID,
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("Resolve", mock.Anything, mock.Anything).Return(nil, store.ErrNotAlertAssignee).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:    "Already resolved",
+			alertID: alertID,
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("Resolve", mock.Anything, mock.Anything).Return(nil, store.ErrAlertResolved).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:    "Resolved with a note",
+			alertID: alertID,
+			body:    []byte(`{"note":"repeat obs normal"}`),
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("Resolve", mock.Anything, mock.MatchedBy(func(r *models.AlertActionReq) bool {
+					return r.AlertID == alertID && r.UserID == userID && r.Note == "repeat obs normal"
+				})).Return(&models.Alert{ID: alertID, Status: "resolved"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := mocks.NewAlertStorer(t)
+			tt.mockSetup(as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Alerts: as},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/alerts/"+tt.alertID+"/resolve", bytes.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("alertID", tt.alertID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleResolveAlert(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleAcknowledgeAlert(t *testing.T) {
+	alertID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		mockSetup          func(*mocks.AlertStorer)
+		expectedStatusCode int
+	}{
+		{
+			name: "Acknowledged",
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("Acknowledge", mock.Anything, mock.MatchedBy(func(r *models.AlertActionReq) bool {
+					return r.AlertID == alertID && r.UserID == userID
+				})).Return(&models.Alert{ID: alertID, Status: "acknowledged"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Already resolved",
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("Acknowledge", mock.Anything, mock.Anything).Return(nil, store.ErrAlertResolved).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Not assigned to the alert",
+			mockSetup: func(as *mocks.AlertStorer) {
+				as.On("Acknowledge", mock.Anything, mock.Anything).Return(nil, store.ErrNotAlertAssignee).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := mocks.NewAlertStorer(t)
+			tt.mockSetup(as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Alerts: as},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/alerts/"+alertID+"/acknowledge", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("alertID", alertID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleAcknowledgeAlert(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
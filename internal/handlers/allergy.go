@@ -2,25 +2,60 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/vaidik-bajpai/medibridge/internal/helpers"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"github.com/vaidik-bajpai/medibridge/internal/terminology"
 )
 
+// codeSubstance fills in the display text of a coded substance from the
+// substance list and checks it against the category. It returns the field
+// errors and the substance's category.
+func codeSubstance(s *models.CodedSubstance, category string) (map[string]string, string) {
+	substance, ok := terminology.LookupSubstance(s.System, s.Code)
+	if !ok {
+		return map[string]string{
+			"substance": fmt.Sprintf("%s is not a known %s substance code", s.Code, s.System),
+		}, ""
+	}
+	if category != "" && category != substance.Category {
+		return map[string]string{
+			"category": fmt.Sprintf("%s is a %s substance", substance.Display, substance.Category),
+		}, ""
+	}
+	s.System, s.Code, s.Display = substance.System, substance.Code, substance.Display
+	return nil, substance.Category
+}
+
+func trimReactions(reactions []models.AllergyReaction) {
+	for i := range reactions {
+		reactions[i].Manifestation = strings.TrimSpace(reactions[i].Manifestation)
+		reactions[i].Note = strings.TrimSpace(reactions[i].Note)
+	}
+}
+
 // HandleRecordAllergy godoc
 // @Summary      Record a new allergy
-// @Description  Records a new allergy for the specified patient.
+// @Description  Records a new allergy for the specified patient. The substance is coded from the terminology
+// @Description  substance list, and its name and category default to the substance's. A free-text reaction
+// @Description  and severity are recorded as a single reaction. Recording an allergy that isn't refuted
+// @Description  clears a "no known allergies" assertion.
 // @Tags         Allergy
 // @Accept       json
 // @Produce      json
 // @Param        patientID  path      string                 true  "Patient ID (UUID)"
 // @Param        body       body      models.RegAllergyReq  true  "Allergy input"
 // @Success      201        {object}  models.SuccessResponse
-// @Failure      400        {object}  models.FailureResponse
+// @Failure      400        {object}  models.ValidationFailureResponse
+// @Failure      404        {object}  models.FailureResponse
 // @Failure      422        {object}  models.FailureResponse
 // @Failure      500        {object}  models.FailureResponse
 // @Router       /v1/patient/{patientID}/allergy [post]
@@ -42,23 +77,49 @@ func (h *handler) HandleRecordAllergy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req.PatientID = pID
+	req.Name = strings.TrimSpace(req.Name)
+	req.Reaction = strings.TrimSpace(req.Reaction)
+	trimReactions(req.Reactions)
 
 	if err := h.validate.Struct(req); err != nil {
 		log.Println(err)
-		badRequestResponse(w, r)
+		validationErrorResponse(w, r, fieldErrors(req, err))
 		return
 	}
 
+	if req.Substance != nil {
+		errs, category := codeSubstance(req.Substance, req.Category)
+		if errs != nil {
+			validationErrorResponse(w, r, errs)
+			return
+		}
+		req.Category = category
+		if req.Name == "" {
+			req.Name = req.Substance.Display
+		}
+	}
+	req.Normalise()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	allergy, err := h.store.Allergy.Record(ctx, &req)
 	if err != nil {
 		log.Println(err)
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
 		serverErrorResponse(w, r)
 		return
 	}
 
+	h.raiseAlerts(allergy.PatientID, userID(r), allergyEvents(allergy)...)
+
 	h.logger.Info("allergy recorded successfully")
 
 	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
@@ -70,14 +131,16 @@ func (h *handler) HandleRecordAllergy(w http.ResponseWriter, r *http.Request) {
 
 // HandleUpdateAllergy godoc
 // @Summary      Update an allergy
-// @Description  Updates an existing allergy using its ID.
+// @Description  Updates an existing allergy using its ID. Reactions, when given, replace the allergy's
+// @Description  reactions; a free-text reaction replaces them with a single reaction.
 // @Tags         Allergy
 // @Accept       json
 // @Produce      json
 // @Param        allergyID  path      string                    true  "Allergy ID (UUID)"
 // @Param        body       body      models.UpdateAllergyReq  true  "Updated allergy details"
 // @Success      200        {object}  models.SuccessResponse
-// @Failure      400        {object}  models.FailureResponse
+// @Failure      400        {object}  models.ValidationFailureResponse
+// @Failure      404        {object}  models.FailureResponse
 // @Failure      422        {object}  models.FailureResponse
 // @Failure      500        {object}  models.FailureResponse
 // @Router       /v1/allergy/{allergyID} [put]
@@ -98,6 +161,8 @@ func (h *handler) HandleUpdateAllergy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req.AllergyID = aID
+	req.EditedByID = userID(r)
+	trimReactions(req.Reactions)
 
 	if err := h.validate.Struct(req); err != nil {
 		log.Println(err)
@@ -105,16 +170,36 @@ func (h *handler) HandleUpdateAllergy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Substance != nil {
+		category := ""
+		if req.Category != nil {
+			category = *req.Category
+		}
+		errs, substanceCategory := codeSubstance(req.Substance, category)
+		if errs != nil {
+			validationErrorResponse(w, r, errs)
+			return
+		}
+		req.Category = &substanceCategory
+	}
+	req.Normalise()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	allergy, err := h.store.Allergy.Update(ctx, &req)
 	if err != nil {
 		log.Println(err)
+		if errors.Is(err, store.ErrAllergyNotFound) {
+			notFoundError(w, r)
+			return
+		}
 		serverErrorResponse(w, r)
 		return
 	}
 
+	h.raiseAlerts(allergy.PatientID, userID(r), allergyEvents(allergy)...)
+
 	h.logger.Info("allergy updated successfully")
 
 	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
@@ -160,3 +245,54 @@ func (h *handler) HandleDeleteAllergy(w http.ResponseWriter, r *http.Request) {
 		Message: "allergy deleted successfully",
 	})
 }
+
+// HandleAssertNoKnownAllergies godoc
+// @Summary      Assert no known allergies
+// @Description  Records that the patient was asked and has no known allergies, so an empty allergy list
+// @Description  isn't mistaken for one that was never taken. It conflicts with recorded allergies that
+// @Description  aren't refuted or entered in error, and is cleared when an allergy is recorded.
+// @Tags         Allergy
+// @Produce      json
+// @Param        patientID  path      string  true  "Patient ID (UUID)"
+// @Success      200        {object}  models.SuccessResponse{data=models.NoKnownAllergies}
+// @Failure      400        {object}  models.FailureResponse
+// @Failure      404        {object}  models.FailureResponse
+// @Failure      409        {object}  models.FailureResponse
+// @Failure      500        {object}  models.FailureResponse
+// @Router       /v1/patient/{patientID}/no-known-allergies [put]
+func (h *handler) HandleAssertNoKnownAllergies(w http.ResponseWriter, r *http.Request) {
+	req := models.AssertNKAReq{
+		PatientID: chi.URLParam(r, "patientID"),
+		UserID:    userID(r),
+	}
+	if err := h.validate.Struct(req); err != nil {
+		log.Println(err)
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	nka, err := h.store.Allergy.AssertNoKnownAllergies(ctx, &req)
+	if err != nil {
+		log.Println(err)
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrPatientHasAllergies):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	h.logger.Info("no known allergies asserted successfully")
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "no known allergies asserted successfully",
+		Data:    nka,
+	})
+}
@@ -71,6 +71,65 @@ func TestHandleRecordAllergy(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:  "Free-text reaction is recorded as a reaction",
+			urlID: validUUID,
+			body:  body,
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("Record", mock.Anything, mock.MatchedBy(func(r *models.RegAllergyReq) bool {
+					return len(r.Reactions) == 1 && r.Reactions[0].Manifestation == "Coughing" &&
+						r.Reactions[0].Severity == "moderate" && r.Reaction == "Coughing"
+				})).Return(&models.Allergy{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Coded substance",
+			urlID: validUUID,
+			body: []byte(`{"substance":{"system":"rxnorm","code":"723"},"criticality":"high","reactions":[` +
+				`{"manifestation":"Urticaria","severity":"moderate"},{"manifestation":" Wheeze ","severity":"severe"}]}`),
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("Record", mock.Anything, mock.MatchedBy(func(r *models.RegAllergyReq) bool {
+					return r.Name == "Amoxicillin" && r.Category == "medication" &&
+						r.Substance.Display == "Amoxicillin" && r.Severity == "severe" &&
+						r.Reaction == "Urticaria; Wheeze"
+				})).Return(&models.Allergy{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Unknown substance",
+			urlID: validUUID,
+			body:  []byte(`{"substance":{"system":"rxnorm","code":"0"}}`),
+			mockSetup: func(as *mocks.AllergyStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Category doesn't match the substance",
+			urlID: validUUID,
+			body:  []byte(`{"substance":{"system":"snomed","code":"256349002"},"category":"medication"}`),
+			mockSetup: func(as *mocks.AllergyStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Reaction without a manifestation",
+			urlID: validUUID,
+			body:  []byte(`{"name":"Peanut","reactions":[{"severity":"mild"}]}`),
+			mockSetup: func(as *mocks.AllergyStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: validUUID,
+			body:  body,
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("Record", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 		{
 			name:  "DB Error",
 			urlID: validUUID,
@@ -156,6 +215,34 @@ func TestHandleUpdateAllergy(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:  "Reactions replace the free-text reaction",
+			urlID: validUUID,
+			body:  []byte(`{"reactions":[{"manifestation":"Angioedema","severity":"severe"}]}`),
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateAllergyReq) bool {
+					return *r.Reaction == "Angioedema" && *r.Severity == "severe"
+				})).Return(&models.Allergy{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Unknown substance",
+			urlID: validUUID,
+			body:  []byte(`{"substance":{"system":"snomed","code":"723"}}`),
+			mockSetup: func(as *mocks.AllergyStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Allergy not found",
+			urlID: validUUID,
+			body:  body,
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrAllergyNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 		{
 			name:  "DB Error",
 			urlID: validUUID,
@@ -252,3 +339,69 @@ func TestHandleDeleteAllergy(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleAssertNoKnownAllergies(t *testing.T) {
+	validUUID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		mockSetup          func(*mocks.AllergyStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Success",
+			urlID: validUUID,
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("AssertNoKnownAllergies", mock.Anything, mock.MatchedBy(func(r *models.AssertNKAReq) bool {
+					return r.PatientID == validUUID && r.UserID == userID
+				})).Return(&models.NoKnownAllergies{AssertedByID: userID}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Patient has allergies",
+			urlID: validUUID,
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("AssertNoKnownAllergies", mock.Anything, mock.Anything).Return(nil, store.ErrPatientHasAllergies)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Patient not found",
+			urlID: validUUID,
+			mockSetup: func(as *mocks.AllergyStorer) {
+				as.On("AssertNoKnownAllergies", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := mocks.NewAllergyStorer(t)
+			tt.mockSetup(as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Allergy: as},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, nil, "/v1/patient/"+tt.urlID+"/no-known-allergies", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleAssertNoKnownAllergies(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,663 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+	"github.com/vaidik-bajpai/medibridge/internal/scheduling"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// canManageSchedule reports whether the signed in user may change the
+// doctor's working hours and exceptions: receptionists may change anyone's,
+// doctors only their own.
+func canManageSchedule(r *http.Request, doctorID string) bool {
+	user := getUserFromCtx(r)
+	if user == nil {
+		return false
+	}
+	return user.Role == string(db.RoleReceptionist) || user.ID == doctorID
+}
+
+// slotFieldErrors returns the field errors for a booking whose doctor or
+// time was rejected by the store, or nil for any other error.
+func slotFieldErrors(err error) map[string]string {
+	switch {
+	case errors.Is(err, store.ErrDoctorNotFound):
+		return map[string]string{"doctorId": "doctorId is not a doctor"}
+	case errors.Is(err, store.ErrSlotUnavailable):
+		return map[string]string{"startsAt": "startsAt is not a free slot of the doctor"}
+	}
+	return nil
+}
+
+// parseClinicDate parses a date as midnight in the clinic's time zone.
+func parseClinicDate(s string) (time.Time, error) {
+	return time.ParseInLocation(time.DateOnly, s, scheduling.Location())
+}
+
+// clinicToday returns midnight of today in the clinic's time zone.
+func clinicToday() time.Time {
+	now := time.Now().In(scheduling.Location())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// HandleSetWorkingHours godoc
+// @Summary Set a doctor's working hours
+// @Description Replaces the doctor's weekly template of working hours, as wall-clock times in the clinic's
+// @Description time zone. Periods on the same weekday must not overlap. Appointments already booked are
+// @Description kept. Doctors may only set their own hours.
+// @Tags Scheduling
+// @Accept json
+// @Produce json
+// @Param doctorID path string true "Doctor ID"
+// @Param body body models.SetWorkingHoursReq true "Weekly template"
+// @Success 200 {object} models.SuccessResponse{data=[]models.WorkingHours}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/doctor/{doctorID}/working-hours [put]
+func (h *handler) HandleSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	doctorID := chi.URLParam(r, "doctorID")
+	if err := h.validate.Var(doctorID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+	if !canManageSchedule(r, doctorID) {
+		forbiddenErrorResponse(w, r)
+		return
+	}
+
+	var req models.SetWorkingHoursReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.DoctorID = doctorID
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	req.Template = make([]scheduling.WorkingHours, 0, len(req.Hours))
+	for i, period := range req.Hours {
+		start, err := scheduling.ParseClock(period.Start)
+		if err != nil {
+			validationErrorResponse(w, r, map[string]string{fmt.Sprintf("hours[%d].start", i): err.Error()})
+			return
+		}
+		end, err := scheduling.ParseClock(period.End)
+		if err != nil {
+			validationErrorResponse(w, r, map[string]string{fmt.Sprintf("hours[%d].end", i): err.Error()})
+			return
+		}
+		if period.SlotMinutes == 0 {
+			period.SlotMinutes = 15
+		}
+		req.Template = append(req.Template, scheduling.WorkingHours{
+			Weekday:     time.Weekday(period.Weekday),
+			Start:       start,
+			End:         end,
+			SlotMinutes: period.SlotMinutes,
+		})
+	}
+	if err := scheduling.ValidateHours(req.Template); err != nil {
+		validationErrorResponse(w, r, map[string]string{"hours": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hours, err := h.store.Schedules.SetWorkingHours(ctx, &req)
+	if err != nil {
+		h.logger.Info("setting working hours failed", zap.Error(err))
+		if errors.Is(err, store.ErrDoctorNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "working hours set successfully",
+		Data:    hours,
+	})
+}
+
+// HandleGetWorkingHours godoc
+// @Summary Get a doctor's working hours
+// @Description Returns the doctor's weekly template of working hours, from Sunday.
+// @Tags Scheduling
+// @Produce json
+// @Param doctorID path string true "Doctor ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.WorkingHours}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/doctor/{doctorID}/working-hours [get]
+func (h *handler) HandleGetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	doctorID := chi.URLParam(r, "doctorID")
+	if err := h.validate.Var(doctorID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hours, err := h.store.Schedules.WorkingHours(ctx, doctorID)
+	if err != nil {
+		h.logger.Error("fetching working hours failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "working hours fetched successfully",
+		Data:    hours,
+	})
+}
+
+// HandleAddScheduleException godoc
+// @Summary Block out a doctor's time
+// @Description Records leave or other time the doctor isn't available; no slots are offered during it.
+// @Description Appointments already booked in it are kept and returned as conflictingAppointments so
+// @Description they can be rescheduled. Doctors may only block out their own time.
+// @Tags Scheduling
+// @Accept json
+// @Produce json
+// @Param doctorID path string true "Doctor ID"
+// @Param body body models.AddScheduleExceptionReq true "Exception"
+// @Success 201 {object} models.SuccessResponse{data=models.ScheduleException}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/doctor/{doctorID}/exceptions [post]
+func (h *handler) HandleAddScheduleException(w http.ResponseWriter, r *http.Request) {
+	doctorID := chi.URLParam(r, "doctorID")
+	if err := h.validate.Var(doctorID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+	if !canManageSchedule(r, doctorID) {
+		forbiddenErrorResponse(w, r)
+		return
+	}
+
+	var req models.AddScheduleExceptionReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.DoctorID = doctorID
+	req.CreatedByID = userID(r)
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exception, err := h.store.Schedules.AddException(ctx, &req)
+	if err != nil {
+		h.logger.Info("adding schedule exception failed", zap.Error(err))
+		if errors.Is(err, store.ErrDoctorNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "schedule exception added successfully",
+		Data:    exception,
+	})
+}
+
+// HandleDeleteScheduleException godoc
+// @Summary Remove a schedule exception
+// @Description Removes leave or other blocked out time, offering its slots again. Doctors may only remove
+// @Description their own exceptions.
+// @Tags Scheduling
+// @Produce json
+// @Param doctorID path string true "Doctor ID"
+// @Param exceptionID path string true "Exception ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/doctor/{doctorID}/exceptions/{exceptionID} [delete]
+func (h *handler) HandleDeleteScheduleException(w http.ResponseWriter, r *http.Request) {
+	doctorID := chi.URLParam(r, "doctorID")
+	exceptionID := chi.URLParam(r, "exceptionID")
+	if err := h.validate.Var(doctorID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+	if err := h.validate.Var(exceptionID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+	if !canManageSchedule(r, doctorID) {
+		forbiddenErrorResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.Schedules.DeleteException(ctx, doctorID, exceptionID); err != nil {
+		h.logger.Info("removing schedule exception failed", zap.Error(err))
+		if errors.Is(err, store.ErrExceptionNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "schedule exception removed successfully",
+	})
+}
+
+// HandleListSlots godoc
+// @Summary List a doctor's free slots
+// @Description Lists the doctor's free slots that start from the first to the last day, in the clinic's
+// @Description time zone. It defaults to the seven days from today; at most 31 days are listed at once.
+// @Tags Scheduling
+// @Produce json
+// @Param doctorID path string true "Doctor ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} models.SuccessResponse{data=[]scheduling.Slot}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/doctor/{doctorID}/slots [get]
+func (h *handler) HandleListSlots(w http.ResponseWriter, r *http.Request) {
+	query, err := h.parseSlotQuery(r)
+	if err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	slots, err := h.store.Schedules.Slots(ctx, query)
+	if err != nil {
+		if errors.Is(err, store.ErrDoctorNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("listing slots failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "slots fetched successfully",
+		Data:    slots,
+	})
+}
+
+func (h *handler) parseSlotQuery(r *http.Request) (*models.SlotQuery, error) {
+	query := &models.SlotQuery{
+		DoctorID: chi.URLParam(r, "doctorID"),
+		From:     clinicToday(),
+	}
+
+	params := r.URL.Query()
+	if from := params.Get("from"); from != "" {
+		t, err := parseClinicDate(from)
+		if err != nil {
+			return nil, err
+		}
+		query.From = t
+	}
+	query.To = query.From.AddDate(0, 0, 7)
+	if to := params.Get("to"); to != "" {
+		t, err := parseClinicDate(to)
+		if err != nil {
+			return nil, err
+		}
+		// the last day is included
+		query.To = t.AddDate(0, 0, 1)
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		return nil, err
+	}
+	if !query.From.Before(query.To) {
+		return nil, errors.New("from is after to")
+	}
+	if query.To.Sub(query.From) > scheduling.MaxRange {
+		return nil, errors.New("slots are listed for at most 31 days")
+	}
+
+	return query, nil
+}
+
+// HandleDoctorSchedule godoc
+// @Summary Get a doctor's day or week
+// @Description Returns the doctor's working hours, exceptions, booked appointments and free slots for each
+// @Description day of the view. A week starts on the Monday of the date. It defaults to today's day view.
+// @Tags Scheduling
+// @Produce json
+// @Param doctorID path string true "Doctor ID"
+// @Param view query string false "View" Enums(day, week)
+// @Param date query string false "A day of the view (YYYY-MM-DD)"
+// @Success 200 {object} models.SuccessResponse{data=models.DoctorSchedule}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/doctor/{doctorID}/schedule [get]
+func (h *handler) HandleDoctorSchedule(w http.ResponseWriter, r *http.Request) {
+	query := &models.ScheduleQuery{
+		DoctorID: chi.URLParam(r, "doctorID"),
+		View:     r.URL.Query().Get("view"),
+		Date:     clinicToday(),
+	}
+	if query.View == "" {
+		query.View = "day"
+	}
+	if date := r.URL.Query().Get("date"); date != "" {
+		t, err := parseClinicDate(date)
+		if err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		query.Date = t
+	}
+	if err := h.validate.Struct(query); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schedule, err := h.store.Schedules.Schedule(ctx, query)
+	if err != nil {
+		if errors.Is(err, store.ErrDoctorNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching schedule failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "schedule fetched successfully",
+		Data:    schedule,
+	})
+}
+
+// HandleBookAppointment godoc
+// @Summary Book an appointment
+// @Description Books the patient into a free slot of the doctor. startsAt must be the start of one of the
+// @Description slots listed by the doctor's slots endpoint; a slot that was booked in the meantime is a
+// @Description conflict.
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.BookAppointmentReq true "Appointment"
+// @Success 201 {object} models.SuccessResponse{data=models.Appointment}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/appointments [post]
+func (h *handler) HandleBookAppointment(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.BookAppointmentReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.BookedByID = userID(r)
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	appointment, err := h.store.Appointments.Book(ctx, &req)
+	if err != nil {
+		h.logger.Info("booking appointment failed", zap.Error(err))
+		if fields := slotFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		switch {
+		case errors.Is(err, store.ErrSlotTaken):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "appointment booked successfully",
+		Data:    appointment,
+	})
+}
+
+// HandleListAppointments godoc
+// @Summary List a patient's appointments
+// @Description Lists the patient's appointments, including cancelled and rescheduled ones, the earliest first.
+// @Tags Appointments
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Appointment}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/appointments [get]
+func (h *handler) HandleListAppointments(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	appointments, err := h.store.Appointments.List(ctx, pID)
+	if err != nil {
+		h.logger.Error("listing appointments failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "appointments fetched successfully",
+		Data:    appointments,
+	})
+}
+
+// HandleRescheduleAppointment godoc
+// @Summary Reschedule an appointment
+// @Description Moves a booked appointment to another free slot of the same doctor. The appointment is closed
+// @Description as rescheduled and the new one, linked to it by rescheduledFromId, is returned.
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param appointmentID path string true "Appointment ID"
+// @Param body body models.RescheduleAppointmentReq true "New time"
+// @Success 200 {object} models.SuccessResponse{data=models.Appointment}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/appointment/{appointmentID}/reschedule [put]
+func (h *handler) HandleRescheduleAppointment(w http.ResponseWriter, r *http.Request) {
+	aID := chi.URLParam(r, "appointmentID")
+	if err := h.validate.Var(aID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.RescheduleAppointmentReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.AppointmentID = aID
+	req.RescheduledByID = userID(r)
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	appointment, err := h.store.Appointments.Reschedule(ctx, &req)
+	if err != nil {
+		h.logger.Info("rescheduling appointment failed", zap.Error(err))
+		if fields := slotFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		switch {
+		case errors.Is(err, store.ErrSlotTaken), errors.Is(err, store.ErrAppointmentTransition):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrAppointmentNotFound):
+			notFoundError(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "appointment rescheduled successfully",
+		Data:    appointment,
+	})
+}
+
+// HandleCancelAppointment godoc
+// @Summary Cancel an appointment
+// @Description Cancels a booked appointment with a reason and frees its slot.
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param appointmentID path string true "Appointment ID"
+// @Param body body models.CancelAppointmentReq true "Cancellation"
+// @Success 200 {object} models.SuccessResponse{data=models.Appointment}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/appointment/{appointmentID}/cancel [post]
+func (h *handler) HandleCancelAppointment(w http.ResponseWriter, r *http.Request) {
+	aID := chi.URLParam(r, "appointmentID")
+	if err := h.validate.Var(aID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.CancelAppointmentReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.AppointmentID = aID
+	req.CancelledByID = userID(r)
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	appointment, err := h.store.Appointments.Cancel(ctx, &req)
+	if err != nil {
+		h.logger.Info("cancelling appointment failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrAppointmentTransition):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrAppointmentNotFound):
+			notFoundError(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "appointment cancelled successfully",
+		Data:    appointment,
+	})
+}
@@ -0,0 +1,510 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleSetWorkingHours(t *testing.T) {
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+	otherDoctorID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		user               *models.UserModel
+		body               []byte
+		mockSetup          func(*mocks.ScheduleStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Another doctor's hours",
+			user:               &models.UserModel{ID: otherDoctorID, Role: "doctor"},
+			body:               []byte(`{"hours":[]}`),
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Malformed JSON",
+			user:               &models.UserModel{ID: doctorID, Role: "doctor"},
+			body:               []byte(`{"hours":}`),
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Invalid weekday",
+			user:               &models.UserModel{ID: doctorID, Role: "doctor"},
+			body:               []byte(`{"hours":[{"weekday":7,"start":"09:00","end":"13:00"}]}`),
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid time",
+			user:               &models.UserModel{ID: doctorID, Role: "doctor"},
+			body:               []byte(`{"hours":[{"weekday":1,"start":"9h:00","end":"13:00"}]}`),
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Overlapping periods",
+			user: &models.UserModel{ID: doctorID, Role: "doctor"},
+			body: []byte(`{"hours":[{"weekday":1,"start":"09:00","end":"13:00"},
+				{"weekday":1,"start":"12:00","end":"16:00"}]}`),
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Own hours with default slots",
+			user: &models.UserModel{ID: doctorID, Role: "doctor"},
+			body: []byte(`{"hours":[{"weekday":1,"start":"09:00","end":"13:00"},
+				{"weekday":1,"start":"14:00","end":"17:00","slotMinutes":30}]}`),
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("SetWorkingHours", mock.Anything, mock.MatchedBy(func(r *models.SetWorkingHoursReq) bool {
+					return r.DoctorID == doctorID && len(r.Template) == 2 &&
+						r.Template[0].Start == 9*60 && r.Template[0].SlotMinutes == 15 &&
+						r.Template[1].SlotMinutes == 30
+				})).Return([]*models.WorkingHours{{ID: "hours-id"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Set by a receptionist",
+			user: &models.UserModel{ID: otherDoctorID, Role: "receptionist"},
+			body: []byte(`{"hours":[]}`),
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("SetWorkingHours", mock.Anything, mock.Anything).Return([]*models.WorkingHours{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Not a doctor",
+			user: &models.UserModel{ID: otherDoctorID, Role: "receptionist"},
+			body: []byte(`{"hours":[]}`),
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("SetWorkingHours", mock.Anything, mock.Anything).Return(nil, store.ErrDoctorNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "DB error",
+			user: &models.UserModel{ID: doctorID, Role: "doctor"},
+			body: []byte(`{"hours":[]}`),
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("SetWorkingHours", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := mocks.NewScheduleStorer(t)
+			tt.mockSetup(ss)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Schedules: ss},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/doctor/"+doctorID+"/working-hours", "doctorID", doctorID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, tt.user))
+
+			rr := httptest.NewRecorder()
+			h.HandleSetWorkingHours(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleAddScheduleException(t *testing.T) {
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.ScheduleStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Ends before it starts",
+			body:               []byte(`{"startsAt":"2026-10-23T00:00:00Z","endsAt":"2026-10-20T00:00:00Z"}`),
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Leave with a booked appointment",
+			body: []byte(`{"startsAt":"2026-10-20T00:00:00Z","endsAt":"2026-10-23T00:00:00Z","reason":" Annual leave "}`),
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("AddException", mock.Anything, mock.MatchedBy(func(r *models.AddScheduleExceptionReq) bool {
+					return r.DoctorID == doctorID && r.CreatedByID == doctorID && r.Reason == "Annual leave"
+				})).Return(&models.ScheduleException{
+					ID:                      "exception-id",
+					ConflictingAppointments: []models.Appointment{{ID: "appointment-id"}},
+				}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "DB error",
+			body: []byte(`{"startsAt":"2026-10-20T00:00:00Z","endsAt":"2026-10-23T00:00:00Z"}`),
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("AddException", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := mocks.NewScheduleStorer(t)
+			tt.mockSetup(ss)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Schedules: ss},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/doctor/"+doctorID+"/exceptions", "doctorID", doctorID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID, Role: "doctor"}))
+
+			rr := httptest.NewRecorder()
+			h.HandleAddScheduleException(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleListSlots(t *testing.T) {
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.ScheduleStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid date",
+			query:              "?from=19-10-2026",
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "More than 31 days",
+			query:              "?from=2026-10-01&to=2026-12-01",
+			mockSetup:          func(ss *mocks.ScheduleStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Last day included",
+			query: "?from=2026-10-19&to=2026-10-19",
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("Slots", mock.Anything, mock.MatchedBy(func(q *models.SlotQuery) bool {
+					return q.DoctorID == doctorID && q.To.Sub(q.From).Hours() == 24
+				})).Return(nil, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Not a doctor",
+			mockSetup: func(ss *mocks.ScheduleStorer) {
+				ss.On("Slots", mock.Anything, mock.Anything).Return(nil, store.ErrDoctorNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := mocks.NewScheduleStorer(t)
+			tt.mockSetup(ss)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Schedules: ss},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/doctor/"+doctorID+"/slots"+tt.query, "doctorID", doctorID)
+
+			rr := httptest.NewRecorder()
+			h.HandleListSlots(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleBookAppointment(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+	receptionistID := "3f1c2b7a-8d4e-4b6f-9a0c-1e2d3f4a5b6c"
+	body := []byte(`{"doctorId":"` + doctorID + `","startsAt":"2999-10-19T09:00:00Z"}`)
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.AppointmentStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               body,
+			mockSetup:          func(as *mocks.AppointmentStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed JSON",
+			urlID:              patientID,
+			body:               []byte(`{"doctorId":}`),
+			mockSetup:          func(as *mocks.AppointmentStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Missing doctor",
+			urlID:              patientID,
+			body:               []byte(`{"startsAt":"2999-10-19T09:00:00Z"}`),
+			mockSetup:          func(as *mocks.AppointmentStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Booked",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Book", mock.Anything, mock.MatchedBy(func(r *models.BookAppointmentReq) bool {
+					return r.PatientID == patientID && r.DoctorID == doctorID && r.BookedByID == receptionistID
+				})).Return(&models.Appointment{ID: "appointment-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Not a slot of the doctor",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Book", mock.Anything, mock.Anything).Return(nil, store.ErrSlotUnavailable).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Not a doctor",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Book", mock.Anything, mock.Anything).Return(nil, store.ErrDoctorNotFound).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Slot already booked",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Book", mock.Anything, mock.Anything).Return(nil, store.ErrSlotTaken).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Book", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "DB error",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Book", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := mocks.NewAppointmentStorer(t)
+			tt.mockSetup(as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Appointments: as},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/appointments", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: receptionistID, Role: "receptionist"}))
+
+			rr := httptest.NewRecorder()
+			h.HandleBookAppointment(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleRescheduleAppointment(t *testing.T) {
+	appointmentID := "550e8400-e29b-41d4-a716-446655440000"
+	body := []byte(`{"startsAt":"2999-10-21T10:30:00Z","reason":"Doctor on leave"}`)
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.AppointmentStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Missing time",
+			body:               []byte(`{"reason":"Doctor on leave"}`),
+			mockSetup:          func(as *mocks.AppointmentStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Rescheduled",
+			body: body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Reschedule", mock.Anything, mock.MatchedBy(func(r *models.RescheduleAppointmentReq) bool {
+					return r.AppointmentID == appointmentID
+				})).Return(&models.Appointment{ID: "new-id", RescheduledFromID: appointmentID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Already cancelled",
+			body: body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Reschedule", mock.Anything, mock.Anything).Return(nil, store.ErrAppointmentTransition).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Slot already booked",
+			body: body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Reschedule", mock.Anything, mock.Anything).Return(nil, store.ErrSlotTaken).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Doctor on leave",
+			body: body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Reschedule", mock.Anything, mock.Anything).Return(nil, store.ErrSlotUnavailable).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Appointment not found",
+			body: body,
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Reschedule", mock.Anything, mock.Anything).Return(nil, store.ErrAppointmentNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := mocks.NewAppointmentStorer(t)
+			tt.mockSetup(as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Appointments: as},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/appointment/"+appointmentID+"/reschedule", "appointmentID", appointmentID)
+
+			rr := httptest.NewRecorder()
+			h.HandleRescheduleAppointment(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleCancelAppointment(t *testing.T) {
+	appointmentID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.AppointmentStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Missing reason",
+			body:               []byte(`{"reason":"  "}`),
+			mockSetup:          func(as *mocks.AppointmentStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Cancelled",
+			body: []byte(`{"reason":"Patient travelling"}`),
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Cancel", mock.Anything, mock.MatchedBy(func(r *models.CancelAppointmentReq) bool {
+					return r.AppointmentID == appointmentID && r.Reason == "Patient travelling"
+				})).Return(&models.Appointment{ID: appointmentID, Status: "cancelled"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Already cancelled",
+			body: []byte(`{"reason":"Patient travelling"}`),
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Cancel", mock.Anything, mock.Anything).Return(nil, store.ErrAppointmentTransition).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Appointment not found",
+			body: []byte(`{"reason":"Patient travelling"}`),
+			mockSetup: func(as *mocks.AppointmentStorer) {
+				as.On("Cancel", mock.Anything, mock.Anything).Return(nil, store.ErrAppointmentNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := mocks.NewAppointmentStorer(t)
+			tt.mockSetup(as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Appointments: as},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/appointment/"+appointmentID+"/cancel", "appointmentID", appointmentID)
+
+			rr := httptest.NewRecorder()
+			h.HandleCancelAppointment(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// HandleListCareTeam godoc
+// @Summary List a patient's care team
+// @Description Lists the users looking after a patient. Alerts about the patient are assigned to them.
+// @Tags Alerts
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/care-team [get]
+func (h *handler) HandleListCareTeam(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	members, err := h.store.CareTeam.List(ctx, pID)
+	if err != nil {
+		h.logger.Error("listing care team failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "care team fetched successfully",
+		Data:    members,
+	})
+}
+
+// HandleAddCareTeamMember godoc
+// @Summary Add a care team member
+// @Description Adds a user to a patient's care team.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.AddCareTeamMemberReq true "Care team member"
+// @Success 201 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/care-team [post]
+func (h *handler) HandleAddCareTeamMember(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.AddCareTeamMemberReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	member, err := h.store.CareTeam.Add(ctx, &req)
+	if err != nil {
+		h.logger.Info("adding care team member failed", zap.Error(err))
+		if errors.Is(err, store.ErrCareTeamMemberExists) {
+			conflictErrorResponse(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "care team member added successfully",
+		Data:    member,
+	})
+}
+
+// HandleRemoveCareTeamMember godoc
+// @Summary Remove a care team member
+// @Description Removes a user from a patient's care team. Alerts already assigned to them are kept.
+// @Tags Alerts
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param userID path string true "User ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/care-team/{userID} [delete]
+func (h *handler) HandleRemoveCareTeamMember(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	userID := chi.URLParam(r, "userID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+	if err := h.validate.Var(userID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.CareTeam.Remove(ctx, pID, userID); err != nil {
+		h.logger.Info("removing care team member failed", zap.Error(err))
+		if errors.Is(err, store.ErrCareTeamMemberNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "care team member removed successfully",
+	})
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleAddCareTeamMember(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	validBody := []byte(`{"userId":"` + userID + `","role":"attending"}`)
+
+	tests := []struct {
+		name               string
+		patientID          string
+		body               []byte
+		mockSetup          func(*mocks.CareTeamStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid patient ID",
+			patientID:          "not-a-uuid",
+			body:               validBody,
+			mockSetup:          func(cs *mocks.CareTeamStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed JSON",
+			patientID:          patientID,
+			body:               []byte(`{"userId":}`),
+			mockSetup:          func(cs *mocks.CareTeamStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Missing role",
+			patientID:          patientID,
+			body:               []byte(`{"userId":"` + userID + `"}`),
+			mockSetup:          func(cs *mocks.CareTeamStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Already a member",
+			patientID: patientID,
+			body:      validBody,
+			mockSetup: func(cs *mocks.CareTeamStorer) {
+				cs.On("Add", mock.Anything, mock.Anything).Return(nil, store.ErrCareTeamMemberExists).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:      "DB error",
+			patientID: patientID,
+			body:      validBody,
+			mockSetup: func(cs *mocks.CareTeamStorer) {
+				cs.On("Add", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "Member added",
+			patientID: patientID,
+			body:      validBody,
+			mockSetup: func(cs *mocks.CareTeamStorer) {
+				cs.On("Add", mock.Anything, mock.MatchedBy(func(r *models.AddCareTeamMemberReq) bool {
+					return r.PatientID == patientID && r.UserID == userID && r.Role == "attending"
+				})).Return(&models.CareTeamMember{ID: "member-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := mocks.NewCareTeamStorer(t)
+			tt.mockSetup(cs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{CareTeam: cs},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/patient/"+tt.patientID+"/care-team", bytes.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("patientID", tt.patientID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			h.HandleAddCareTeamMember(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleRemoveCareTeamMember(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		userID             string
+		mockSetup          func(*mocks.CareTeamStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid user ID",
+			userID:             "not-a-uuid",
+			mockSetup:          func(cs *mocks.CareTeamStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Not a member",
+			userID: userID,
+			mockSetup: func(cs *mocks.CareTeamStorer) {
+				cs.On("Remove", mock.Anything, patientID, userID).Return(store.ErrCareTeamMemberNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "Member removed",
+			userID: userID,
+			mockSetup: func(cs *mocks.CareTeamStorer) {
+				cs.On("Remove", mock.Anything, patientID, userID).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := mocks.NewCareTeamStorer(t)
+			tt.mockSetup(cs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{CareTeam: cs},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/v1/patient/"+patientID+"/care-team/"+tt.userID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("patientID", patientID)
+			rctx.URLParams.Add("userID", tt.userID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			h.HandleRemoveCareTeamMember(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
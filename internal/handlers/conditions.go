@@ -2,26 +2,49 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
 	"github.com/vaidik-bajpai/medibridge/internal/helpers"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
 )
 
+// checkConditionDates returns the field errors of a condition's onset and
+// abatement dates.
+func checkConditionDates(onset, abatement *models.DateOnly) map[string]string {
+	errs := make(map[string]string)
+	today := time.Now()
+	if onset != nil && time.Time(*onset).After(today) {
+		errs["onsetDate"] = "onsetDate can't be in the future"
+	}
+	if abatement != nil && time.Time(*abatement).After(today) {
+		errs["abatementDate"] = "abatementDate can't be in the future"
+	}
+	if onset != nil && abatement != nil && time.Time(*abatement).Before(time.Time(*onset)) {
+		errs["abatementDate"] = "abatementDate can't be before onsetDate"
+	}
+	return errs
+}
+
 // HandleAddCondition godoc
 // @Summary      Add a new medical condition
-// @Description  Adds a new medical condition associated with a patient ID.
+// @Description  Adds a new medical condition associated with a patient ID. A condition is active and
+// @Description  confirmed unless a status is given; a condition from the patient's history may be added
+// @Description  as resolved, in remission or inactive with its abatement date.
 // @Tags         Conditions
 // @Accept       json
 // @Produce      json
 // @Param        patientID  path      string                  true  "Patient ID (UUID)"
 // @Param        body       body      models.AddConditionReq  true  "Condition details"
 // @Success      201        {object}  models.SuccessResponse
-// @Failure      400        {object}  models.FailureResponse
+// @Failure      400        {object}  models.ValidationFailureResponse
+// @Failure      404        {object}  models.FailureResponse
 // @Failure      422        {object}  models.FailureResponse
 // @Failure      500        {object}  models.FailureResponse
 // @Router       /v1/patient/{patientID}/condition [post]
@@ -41,11 +64,21 @@ func (h *handler) HandleAddCondition(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req.Condition = strings.TrimSpace(req.Condition)
+	req.Note = strings.TrimSpace(req.Note)
 	req.PatientID = pID
 
 	if err := h.validate.Struct(req); err != nil {
 		log.Println(err)
-		badRequestResponse(w, r)
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	errs := checkConditionDates(req.OnsetDate, req.AbatementDate)
+	if req.AbatementDate != nil && (req.ClinicalStatus == "" || clinical.IsConditionActive(req.ClinicalStatus)) {
+		errs["abatementDate"] = "abatementDate is only allowed for a condition that is no longer active"
+	}
+	if len(errs) > 0 {
+		validationErrorResponse(w, r, errs)
 		return
 	}
 
@@ -55,6 +88,14 @@ func (h *handler) HandleAddCondition(w http.ResponseWriter, r *http.Request) {
 	condition, err := h.store.Conditions.Add(ctx, &req)
 	if err != nil {
 		log.Println(err)
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
 		serverErrorResponse(w, r)
 		return
 	}
@@ -67,15 +108,74 @@ func (h *handler) HandleAddCondition(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleUpdateCondition godoc
+// @Summary      Update a medical condition
+// @Description  Updates the clinical or verification status, dates or note of a condition. A change of
+// @Description  status is recorded in the condition's history. A condition that comes back after it stopped
+// @Description  being active is a recurrence; moving to a status that isn't allowed from the current one
+// @Description  is a conflict.
+// @Tags         Conditions
+// @Accept       json
+// @Produce      json
+// @Param        conditionID  path      string                     true  "Condition ID (UUID)"
+// @Param        body         body      models.UpdateConditionReq  true  "Updated condition details"
+// @Success      200          {object}  models.SuccessResponse
+// @Failure      400          {object}  models.ValidationFailureResponse
+// @Failure      404          {object}  models.FailureResponse
+// @Failure      409          {object}  models.FailureResponse
+// @Failure      422          {object}  models.FailureResponse
+// @Failure      500          {object}  models.FailureResponse
+// @Router       /v1/condition/{conditionID} [put]
+func (h *handler) HandleUpdateCondition(w http.ResponseWriter, r *http.Request) {
+	cID := chi.URLParam(r, "conditionID")
+	if err := h.validate.Var(cID, "required,uuid"); err != nil {
+		log.Println(err)
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.UpdateConditionReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		log.Println(err)
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.Note = strings.TrimSpace(req.Note)
+	req.CID = cID
+	req.ChangedByID = userID(r)
+
+	if req.Empty() {
+		badRequestResponse(w, r)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Println(err)
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	if errs := checkConditionDates(req.OnsetDate, req.AbatementDate); len(errs) > 0 {
+		validationErrorResponse(w, r, errs)
+		return
+	}
+
+	h.updateCondition(w, r, &req, "condition updated successfully")
+}
+
 // HandleInactiveCondition godoc
 // @Summary      Inactivate a medical condition
-// @Description  Marks an existing condition as inactive by its ID.
+// @Description  Marks an existing condition as inactive by its ID. The condition is kept, with the change
+// @Description  recorded in its history; a resolved condition can't be made inactive.
 // @Tags         Conditions
 // @Accept       json
 // @Produce      json
 // @Param        conditionID  path      string  true  "Condition ID (UUID)"
 // @Success      200          {object}  models.SuccessResponse
 // @Failure      400          {object}  models.FailureResponse
+// @Failure      404          {object}  models.FailureResponse
+// @Failure      409          {object}  models.FailureResponse
 // @Failure      500          {object}  models.FailureResponse
 // @Router       /v1/condition/{conditionID} [delete]
 func (h *handler) HandleInactiveCondition(w http.ResponseWriter, r *http.Request) {
@@ -86,17 +186,39 @@ func (h *handler) HandleInactiveCondition(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	h.updateCondition(w, r, &models.UpdateConditionReq{
+		CID:            cID,
+		ChangedByID:    userID(r),
+		ClinicalStatus: clinical.ConditionInactive,
+	}, "condition made inactive successfully")
+}
+
+func (h *handler) updateCondition(w http.ResponseWriter, r *http.Request, req *models.UpdateConditionReq, message string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := h.store.Conditions.Delete(ctx, cID); err != nil {
+	condition, err := h.store.Conditions.Update(ctx, req)
+	if err != nil {
 		log.Println(err)
-		serverErrorResponse(w, r)
+		switch {
+		case errors.Is(err, store.ErrConditionNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrConditionTransition):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrAbatementWhileActive):
+			validationErrorResponse(w, r, map[string]string{
+				"abatementDate": "abatementDate is only allowed for a condition that is no longer active",
+			})
+		default:
+			serverErrorResponse(w, r)
+		}
 		return
 	}
 
-	h.logger.Info("condition made inactive successfully")
-	helpers.WriteJSONResponse(w, r, http.StatusOK, map[string]string{
-		"message": "condition made inactive successfully",
+	h.logger.Info(message)
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: message,
+		Data:    condition,
 	})
 }
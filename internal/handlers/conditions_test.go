@@ -55,6 +55,42 @@ func TestHandleAddCondition(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			name:  "Abatement date on an active condition",
+			urlID: validUUID,
+			body:  []byte(`{"condition":"Asthma","abatementDate":"2025-01-01"}`),
+			mockSetup: func(cs *mocks.ConditionStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Abatement before onset",
+			urlID: validUUID,
+			body:  []byte(`{"condition":"Asthma","clinicalStatus":"resolved","onsetDate":"2025-01-01","abatementDate":"2024-01-01"}`),
+			mockSetup: func(cs *mocks.ConditionStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Resolved condition from history",
+			urlID: validUUID,
+			body:  []byte(`{"condition":"Tuberculosis","clinicalStatus":"resolved","onsetDate":"2019-02-01","abatementDate":"2019-09-01"}`),
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Add", mock.Anything, mock.MatchedBy(func(r *models.AddConditionReq) bool {
+					return r.ClinicalStatus == "resolved" && r.AbatementDate != nil
+				})).Return(&models.Condition{ClinicalStatus: "resolved"}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Patient not found",
+			urlID: validUUID,
+			body:  body,
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Add", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 		{
 			name:  "Success",
 			urlID: validUUID,
@@ -119,15 +155,33 @@ func TestHandleInactiveCondition(t *testing.T) {
 			name:  "Success",
 			urlID: validUUID,
 			mockSetup: func(cs *mocks.ConditionStorer) {
-				cs.On("Delete", mock.Anything, validUUID).Return(nil)
+				cs.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateConditionReq) bool {
+					return r.CID == validUUID && r.ClinicalStatus == "inactive"
+				})).Return(&models.Condition{ID: validUUID, ClinicalStatus: "inactive"}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:  "Condition not found",
+			urlID: validUUID,
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrConditionNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "Resolved condition",
+			urlID: validUUID,
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrConditionTransition)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
 		{
 			name:  "DB Error",
 			urlID: validUUID,
 			mockSetup: func(cs *mocks.ConditionStorer) {
-				cs.On("Delete", mock.Anything, validUUID).Return(errors.New("db error"))
+				cs.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -154,3 +208,102 @@ func TestHandleInactiveCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleUpdateCondition(t *testing.T) {
+	validUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.ConditionStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Condition UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"clinicalStatus":"resolved"}`),
+			mockSetup:          func(cs *mocks.ConditionStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Empty update",
+			urlID:              validUUID,
+			body:               []byte(`{}`),
+			mockSetup:          func(cs *mocks.ConditionStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown clinical status",
+			urlID:              validUUID,
+			body:               []byte(`{"clinicalStatus":"cured"}`),
+			mockSetup:          func(cs *mocks.ConditionStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Abatement date in the future",
+			urlID:              validUUID,
+			body:               []byte(`{"clinicalStatus":"resolved","abatementDate":"2999-01-01"}`),
+			mockSetup:          func(cs *mocks.ConditionStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Resolved with a note",
+			urlID: validUUID,
+			body:  []byte(`{"clinicalStatus":"resolved","note":"  symptom free for 6 months "}`),
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateConditionReq) bool {
+					return r.CID == validUUID && r.ClinicalStatus == "resolved" && r.Note == "symptom free for 6 months"
+				})).Return(&models.Condition{ID: validUUID, ClinicalStatus: "resolved"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Transition not allowed",
+			urlID: validUUID,
+			body:  []byte(`{"clinicalStatus":"active"}`),
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrConditionTransition)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Abatement date on an active condition",
+			urlID: validUUID,
+			body:  []byte(`{"abatementDate":"2025-01-01"}`),
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrAbatementWhileActive)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Condition not found",
+			urlID: validUUID,
+			body:  []byte(`{"verificationStatus":"refuted"}`),
+			mockSetup: func(cs *mocks.ConditionStorer) {
+				cs.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrConditionNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := mocks.NewConditionStorer(t)
+			tt.mockSetup(cs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Conditions: cs},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/condition/"+tt.urlID, "conditionID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleUpdateCondition(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
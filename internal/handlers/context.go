@@ -15,6 +15,14 @@ func getUserFromCtx(r *http.Request) *models.UserModel {
 	return user
 }
 
+// userID returns the ID of the signed in user, or "" without one.
+func userID(r *http.Request) string {
+	if user := getUserFromCtx(r); user != nil {
+		return user.ID
+	}
+	return ""
+}
+
 type paginateKey string
 
 const paginateCtx paginateKey = "paginate"
@@ -23,3 +31,12 @@ func getPaginateFromContext(r *http.Request) *models.Paginate {
 	paginate, _ := r.Context().Value(paginateCtx).(*models.Paginate)
 	return paginate
 }
+
+type patientFilterKey string
+
+const patientFilterCtx patientFilterKey = "patientFilter"
+
+func getPatientFilterFromContext(r *http.Request) *models.PatientFilter {
+	filter, _ := r.Context().Value(patientFilterCtx).(*models.PatientFilter)
+	return filter
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -11,18 +12,45 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/vaidik-bajpai/medibridge/internal/helpers"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"github.com/vaidik-bajpai/medibridge/internal/terminology"
 )
 
+func unknownICD10(code string) string {
+	return fmt.Sprintf("%s is not a known ICD-10 code", terminology.NormaliseICD10(code))
+}
+
+// unverifiedNameRequired is the error on the name of a diagnosis coded
+// with a code the code set hasn't got, which has no display text to name
+// it after.
+const unverifiedNameRequired = "is required with a code outside the ICD-10 code set"
+
+// lookupDiagnosisCode returns the ICD-10 code with its display text. A well
+// formed code the bundled code set hasn't got is returned alone and
+// reported unverified; ok is false when code isn't an ICD-10 code at all.
+func lookupDiagnosisCode(code string) (c terminology.Code, unverified, ok bool) {
+	if c, ok := terminology.LookupICD10(code); ok {
+		return c, false, true
+	}
+	if !terminology.WellFormedICD10(code) {
+		return terminology.Code{}, false, false
+	}
+	return terminology.Code{Code: terminology.NormaliseICD10(code)}, true, true
+}
+
 // HandleAddDiagnoses godoc
 // @Summary      Add a new diagnosis
-// @Description  Adds a new diagnosis for a patient using their patient ID.
+// @Description  Adds a new diagnosis for a patient using their patient ID. A diagnosis is coded with an
+// @Description  ICD-10 code from the terminology endpoint; its name defaults to the code's display text.
+// @Description  A well formed code missing from the code set is kept but marked codeUnverified, and then
+// @Description  the name is required. The diagnosing clinician is the signed in user.
 // @Tags         Diagnoses
 // @Accept       json
 // @Produce      json
 // @Param        patientID  path      string                true  "Patient ID (UUID)"
 // @Param        body       body      models.DiagnosesReq  true  "Diagnosis details"
 // @Success      201        {object}  models.SuccessResponse
-// @Failure      400        {object}  models.FailureResponse
+// @Failure      400        {object}  models.ValidationFailureResponse
 // @Failure      422        {object}  models.FailureResponse
 // @Failure      404        {object}  models.FailureResponse
 // @Failure      500        {object}  models.FailureResponse
@@ -44,23 +72,46 @@ func (h *handler) HandleAddDiagnoses(w http.ResponseWriter, r *http.Request) {
 
 	req.Name = strings.TrimSpace(req.Name)
 	req.PID = pID
+	req.ClinicianID = userID(r)
 
 	if err := h.validate.Struct(req); err != nil {
 		log.Println(err)
-		badRequestResponse(w, r)
+		validationErrorResponse(w, r, fieldErrors(req, err))
 		return
 	}
 
+	if req.Code != "" {
+		code, unverified, ok := lookupDiagnosisCode(req.Code)
+		if !ok {
+			validationErrorResponse(w, r, map[string]string{"code": unknownICD10(req.Code)})
+			return
+		}
+		if unverified && req.Name == "" {
+			validationErrorResponse(w, r, map[string]string{"name": unverifiedNameRequired})
+			return
+		}
+		req.Code, req.Display, req.CodeUnverified = code.Code, code.Display, unverified
+		if req.Name == "" {
+			req.Name = code.Display
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	diag, err := h.store.Diagnoses.Add(ctx, &req)
 	if err != nil {
 		log.Println(err)
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
 		serverErrorResponse(w, r)
 		return
 	}
 
+	h.raiseAlerts(diag.PatientID, userID(r), diagnosisEvent(diag))
+
 	h.logger.Info("diagnoses added successfully")
 
 	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
@@ -72,14 +123,17 @@ func (h *handler) HandleAddDiagnoses(w http.ResponseWriter, r *http.Request) {
 
 // HandleUpdateDiagnoses godoc
 // @Summary      Update an existing diagnosis
-// @Description  Updates an existing diagnosis using the diagnosis ID.
+// @Description  Updates the name, ICD-10 code, type or onset date of an existing diagnosis. A new code
+// @Description  renames the diagnosis after the code's display text unless a name is given; a code missing
+// @Description  from the code set is marked codeUnverified and needs a name.
 // @Tags         Diagnoses
 // @Accept       json
 // @Produce      json
 // @Param        diagnosesID  path      string                     true  "Diagnosis ID (UUID)"
 // @Param        body         body      models.UpdateDiagnosesReq true  "Updated diagnosis details"
 // @Success      200          {object}  models.SuccessResponse
-// @Failure      400          {object}  models.FailureResponse
+// @Failure      400          {object}  models.ValidationFailureResponse
+// @Failure      404          {object}  models.FailureResponse
 // @Failure      422          {object}  models.FailureResponse
 // @Failure      500          {object}  models.FailureResponse
 // @Router       /v1/diagnoses/{diagnosesID} [put]
@@ -100,25 +154,51 @@ func (h *handler) HandleUpdateDiagnoses(w http.ResponseWriter, r *http.Request)
 
 	req.Name = strings.TrimSpace(req.Name)
 	req.DID = dID
+	req.EditedByID = userID(r)
 
-	fmt.Println(req.Name)
+	if req.Empty() {
+		badRequestResponse(w, r)
+		return
+	}
 
 	if err := h.validate.Struct(req); err != nil {
 		log.Println(err)
-		badRequestResponse(w, r)
+		validationErrorResponse(w, r, fieldErrors(req, err))
 		return
 	}
 
+	if req.Code != "" {
+		code, unverified, ok := lookupDiagnosisCode(req.Code)
+		if !ok {
+			validationErrorResponse(w, r, map[string]string{"code": unknownICD10(req.Code)})
+			return
+		}
+		if unverified && req.Name == "" {
+			validationErrorResponse(w, r, map[string]string{"name": unverifiedNameRequired})
+			return
+		}
+		req.Code, req.Display, req.CodeUnverified = code.Code, code.Display, unverified
+		if req.Name == "" {
+			req.Name = code.Display
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	diag, err := h.store.Diagnoses.Update(ctx, &req)
 	if err != nil {
 		log.Println(err)
+		if errors.Is(err, store.ErrDiagnosisNotFound) {
+			notFoundError(w, r)
+			return
+		}
 		serverErrorResponse(w, r)
 		return
 	}
 
+	h.raiseAlerts(diag.PatientID, userID(r), diagnosisEvent(diag))
+
 	h.logger.Info("diagnoses updated successfully")
 
 	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
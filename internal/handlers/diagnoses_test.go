@@ -55,6 +55,65 @@ func TestHandleAddDiagnoses(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			name:  "Malformed ICD-10 code",
+			urlID: validUUID,
+			body:  []byte(`{"name":"Asthma","code":"45.9"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Code outside the code set without a name",
+			urlID: validUUID,
+			body:  []byte(`{"code":"X99.9"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Code outside the code set is unverified",
+			urlID: validUUID,
+			body:  []byte(`{"name":"Exposure to smoke","code":"x00.0"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+				ds.On("Add", mock.Anything, mock.MatchedBy(func(r *models.DiagnosesReq) bool {
+					return r.Code == "X00.0" && r.Display == "" && r.CodeUnverified &&
+						r.Name == "Exposure to smoke"
+				})).Return(&models.Diagnoses{}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Clinician is the signed in user",
+			urlID: validUUID,
+			body:  []byte(`{"name":"Asthma","clinicianId":"7c9e6679-7425-40de-944b-e07fc1f90ae7"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+				ds.On("Add", mock.Anything, mock.MatchedBy(func(r *models.DiagnosesReq) bool {
+					return r.ClinicianID == ""
+				})).Return(&models.Diagnoses{}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Unknown diagnosis type",
+			urlID: validUUID,
+			body:  []byte(`{"code":"J45.9","type":"final"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Coded diagnosis is named after the code",
+			urlID: validUUID,
+			body:  []byte(`{"code":"j459","type":"provisional","onsetDate":"2026-09-01"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+				ds.On("Add", mock.Anything, mock.MatchedBy(func(r *models.DiagnosesReq) bool {
+					return r.Code == "J45.9" && r.Display == "Asthma, unspecified" &&
+						r.Name == "Asthma, unspecified" && r.Type == "provisional" && r.OnsetDate != nil
+				})).Return(&models.Diagnoses{}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
 		{
 			name:  "Success",
 			urlID: validUUID,
@@ -66,6 +125,17 @@ func TestHandleAddDiagnoses(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusCreated,
 		},
+		{
+			name:  "Cancelled encounter",
+			urlID: validUUID,
+			body:  []byte(`{"name":"Asthma","encounterId":"7c9e6679-7425-40de-944b-e07fc1f90ae7"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+				ds.On("Add", mock.Anything, mock.MatchedBy(func(r *models.DiagnosesReq) bool {
+					return r.EncounterID == "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+				})).Return(nil, store.ErrEncounterCancelled)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name:  "DB Error",
 			urlID: validUUID,
@@ -137,6 +207,46 @@ func TestHandleUpdateDiagnoses(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			name:  "Code outside the code set without a name",
+			urlID: validDiagnosisID,
+			body:  []byte(`{"code":"J45.7"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "New code renames the diagnosis",
+			urlID: validDiagnosisID,
+			body:  []byte(`{"code":"J45.9"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+				ds.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateDiagnosesReq) bool {
+					return r.Code == "J45.9" && r.Display == "Asthma, unspecified" &&
+						r.Name == "Asthma, unspecified" && !r.CodeUnverified
+				})).Return(&models.Diagnoses{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "New code with a name keeps the name",
+			urlID: validDiagnosisID,
+			body:  []byte(`{"code":"J45.9","name":"Childhood asthma"}`),
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+				ds.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateDiagnosesReq) bool {
+					return r.Code == "J45.9" && r.Display == "Asthma, unspecified" && r.Name == "Childhood asthma"
+				})).Return(&models.Diagnoses{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Diagnosis not found",
+			urlID: validDiagnosisID,
+			body:  body,
+			mockSetup: func(ds *mocks.DiagnosesStorer) {
+				ds.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrDiagnosisNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 		{
 			name:  "Success",
 			urlID: validDiagnosisID,
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// encounterFieldErrors returns the field errors for a clinical entry whose
+// encounter was rejected by the store, or nil for any other error.
+func encounterFieldErrors(err error) map[string]string {
+	switch {
+	case errors.Is(err, store.ErrEncounterNotFound):
+		return map[string]string{"encounterId": "encounterId is not an encounter of the patient"}
+	case errors.Is(err, store.ErrEncounterCancelled):
+		return map[string]string{"encounterId": "encounterId is a cancelled encounter"}
+	}
+	return nil
+}
+
+// HandleCreateEncounter godoc
+// @Summary Open an encounter
+// @Description Opens a visit for the patient. It starts now and is in progress unless it is planned ahead.
+// @Description The attending doctor defaults to the signed in user and must be a doctor. Diagnoses, conditions,
+// @Description allergies, medications and vitals may then be recorded in it by passing its ID as encounterId.
+// @Tags Encounters
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.CreateEncounterReq true "Encounter"
+// @Success 201 {object} models.SuccessResponse{data=models.Encounter}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/encounters [post]
+func (h *handler) HandleCreateEncounter(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.CreateEncounterReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.AttendingID == "" {
+		req.AttendingID = userID(r)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	if req.Status != clinical.EncounterPlanned && req.StartedAt != nil && req.StartedAt.After(time.Now()) {
+		validationErrorResponse(w, r, map[string]string{"startedAt": "startedAt can't be in the future unless the encounter is planned"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	encounter, err := h.store.Encounters.Create(ctx, &req)
+	if err != nil {
+		h.logger.Info("opening encounter failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrDoctorNotFound):
+			validationErrorResponse(w, r, map[string]string{"attendingId": "attendingId is not a doctor"})
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "encounter opened successfully",
+		Data:    encounter,
+	})
+}
+
+// HandleListEncounters godoc
+// @Summary List a patient's encounters
+// @Description Lists the patient's encounters with the clinical data recorded in each, the most recently
+// @Description started first, as a timeline of the patient's visits.
+// @Tags Encounters
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param status query string false "Encounter status" Enums(planned, in-progress, finished, cancelled)
+// @Param from query string false "Earliest start (RFC 3339)"
+// @Param to query string false "Latest start (RFC 3339)"
+// @Success 200 {object} models.SuccessResponse{data=[]models.EncounterRecord}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/encounters [get]
+func (h *handler) HandleListEncounters(w http.ResponseWriter, r *http.Request) {
+	query, err := h.parseEncounterQuery(r)
+	if err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	encounters, err := h.store.Encounters.List(ctx, query)
+	if err != nil {
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("listing encounters failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "encounters fetched successfully",
+		Data:    encounters,
+	})
+}
+
+// HandleGetEncounter godoc
+// @Summary Get an encounter
+// @Description Returns an encounter with the diagnoses, conditions, allergies, medications and vitals
+// @Description recorded in it.
+// @Tags Encounters
+// @Produce json
+// @Param encounterID path string true "Encounter ID"
+// @Success 200 {object} models.SuccessResponse{data=models.EncounterRecord}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/encounter/{encounterID} [get]
+func (h *handler) HandleGetEncounter(w http.ResponseWriter, r *http.Request) {
+	eID := chi.URLParam(r, "encounterID")
+	if err := h.validate.Var(eID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	encounter, err := h.store.Encounters.Get(ctx, eID)
+	if err != nil {
+		if errors.Is(err, store.ErrEncounterNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching encounter failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "encounter fetched successfully",
+		Data:    encounter,
+	})
+}
+
+// HandleUpdateEncounter godoc
+// @Summary Update an encounter
+// @Description Changes an encounter or moves it on: a planned encounter starts or is cancelled, one in
+// @Description progress finishes or is cancelled. Finishing it sets the end to now unless endedAt is given.
+// @Description A cancelled encounter can't be changed.
+// @Tags Encounters
+// @Accept json
+// @Produce json
+// @Param encounterID path string true "Encounter ID"
+// @Param body body models.UpdateEncounterReq true "Changes"
+// @Success 200 {object} models.SuccessResponse{data=models.Encounter}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/encounter/{encounterID} [put]
+func (h *handler) HandleUpdateEncounter(w http.ResponseWriter, r *http.Request) {
+	eID := chi.URLParam(r, "encounterID")
+	if err := h.validate.Var(eID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.UpdateEncounterReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.EncounterID = eID
+	if req.Reason != nil {
+		*req.Reason = strings.TrimSpace(*req.Reason)
+	}
+
+	if req.Empty() {
+		badRequestResponse(w, r)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	if req.EndedAt != nil && req.EndedAt.After(time.Now()) {
+		validationErrorResponse(w, r, map[string]string{"endedAt": "endedAt can't be in the future"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	encounter, err := h.store.Encounters.Update(ctx, &req)
+	if err != nil {
+		h.logger.Info("updating encounter failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrEncounterNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrEncounterTransition), errors.Is(err, store.ErrEncounterCancelled):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrEndBeforeStart):
+			validationErrorResponse(w, r, map[string]string{"endedAt": "endedAt can't be before startedAt"})
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "encounter updated successfully",
+		Data:    encounter,
+	})
+}
+
+// HandleDeleteEncounter godoc
+// @Summary Delete an encounter
+// @Description Deletes an encounter opened by mistake. An encounter with clinical data recorded in it
+// @Description can't be deleted; cancel it instead.
+// @Tags Encounters
+// @Produce json
+// @Param encounterID path string true "Encounter ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/encounter/{encounterID} [delete]
+func (h *handler) HandleDeleteEncounter(w http.ResponseWriter, r *http.Request) {
+	eID := chi.URLParam(r, "encounterID")
+	if err := h.validate.Var(eID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.Encounters.Delete(ctx, eID); err != nil {
+		h.logger.Info("deleting encounter failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrEncounterNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrEncounterInUse):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "encounter deleted successfully",
+	})
+}
+
+func (h *handler) parseEncounterQuery(r *http.Request) (*models.EncounterQuery, error) {
+	query := &models.EncounterQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+		Status:    r.URL.Query().Get("status"),
+	}
+
+	params := r.URL.Query()
+	if from := params.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, err
+		}
+		query.From = &t
+	}
+	if to := params.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, err
+		}
+		query.To = &t
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		return nil, err
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return nil, errors.New("from is after to")
+	}
+
+	return query, nil
+}
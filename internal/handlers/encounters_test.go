@@ -0,0 +1,415 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleCreateEncounter(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.EncounterStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"type":"outpatient"}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed JSON",
+			urlID:              patientID,
+			body:               []byte(`{"type":}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Unknown type",
+			urlID:              patientID,
+			body:               []byte(`{"type":"drive-through"}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Started in the future",
+			urlID:              patientID,
+			body:               []byte(`{"type":"outpatient","startedAt":"2999-01-01T09:00:00Z"}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Planned ahead",
+			urlID: patientID,
+			body:  []byte(`{"type":"outpatient","status":"planned","startedAt":"2999-01-01T09:00:00Z"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CreateEncounterReq) bool {
+					return r.Status == "planned"
+				})).Return(&models.Encounter{ID: "encounter-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Attended by the signed in doctor",
+			urlID: patientID,
+			body:  []byte(`{"type":"outpatient","reason":"  Fever and cough  "}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CreateEncounterReq) bool {
+					return r.PatientID == patientID && r.AttendingID == doctorID && r.Reason == "Fever and cough"
+				})).Return(&models.Encounter{ID: "encounter-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  []byte(`{"type":"emergency"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "Attending user not a doctor",
+			urlID: patientID,
+			body:  []byte(`{"type":"outpatient"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrDoctorNotFound).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "DB error",
+			urlID: patientID,
+			body:  []byte(`{"type":"emergency"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEncounterStorer(t)
+			tt.mockSetup(es)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Encounters: es},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/encounters", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleCreateEncounter(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleListEncounters(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.EncounterStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Unknown status",
+			query:              "?status=paused",
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "From after to",
+			query:              "?from=2026-10-18T00:00:00Z&to=2026-10-01T00:00:00Z",
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Finished encounters",
+			query: "?status=finished",
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("List", mock.Anything, &models.EncounterQuery{PatientID: patientID, Status: "finished"}).
+					Return([]*models.EncounterRecord{{Encounter: models.Encounter{ID: "encounter-id"}}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Patient not found",
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("List", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "DB error",
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEncounterStorer(t)
+			tt.mockSetup(es)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Encounters: es},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+patientID+"/encounters"+tt.query, "patientID", patientID)
+
+			rr := httptest.NewRecorder()
+			h.HandleListEncounters(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleGetEncounter(t *testing.T) {
+	encounterID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		mockSetup          func(*mocks.EncounterStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Encounter UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Encounter with its data",
+			urlID: encounterID,
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Get", mock.Anything, encounterID).Return(&models.EncounterRecord{
+					Encounter: models.Encounter{ID: encounterID},
+					Diagnoses: []models.Diagnoses{{ID: "diagnosis-id", EncounterID: encounterID}},
+				}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Encounter not found",
+			urlID: encounterID,
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Get", mock.Anything, encounterID).Return(nil, store.ErrEncounterNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEncounterStorer(t)
+			tt.mockSetup(es)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Encounters: es},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/encounter/"+tt.urlID, "encounterID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleGetEncounter(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleUpdateEncounter(t *testing.T) {
+	encounterID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.EncounterStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Encounter UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"status":"finished"}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Empty update",
+			urlID:              encounterID,
+			body:               []byte(`{}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown status",
+			urlID:              encounterID,
+			body:               []byte(`{"status":"paused"}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Ends in the future",
+			urlID:              encounterID,
+			body:               []byte(`{"endedAt":"2999-01-01T09:00:00Z"}`),
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Finished",
+			urlID: encounterID,
+			body:  []byte(`{"status":"finished"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateEncounterReq) bool {
+					return r.EncounterID == encounterID && *r.Status == "finished"
+				})).Return(&models.Encounter{ID: encounterID, Status: "finished"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Already finished",
+			urlID: encounterID,
+			body:  []byte(`{"status":"in-progress"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrEncounterTransition).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Ends before it started",
+			urlID: encounterID,
+			body:  []byte(`{"endedAt":"2020-01-01T09:00:00Z"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrEndBeforeStart).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Encounter not found",
+			urlID: encounterID,
+			body:  []byte(`{"type":"inpatient"}`),
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrEncounterNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEncounterStorer(t)
+			tt.mockSetup(es)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Encounters: es},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/encounter/"+tt.urlID, "encounterID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleUpdateEncounter(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleDeleteEncounter(t *testing.T) {
+	encounterID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		mockSetup          func(*mocks.EncounterStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Encounter UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(es *mocks.EncounterStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Deleted",
+			urlID: encounterID,
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Delete", mock.Anything, encounterID).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Has clinical data",
+			urlID: encounterID,
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Delete", mock.Anything, encounterID).Return(store.ErrEncounterInUse).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Encounter not found",
+			urlID: encounterID,
+			mockSetup: func(es *mocks.EncounterStorer) {
+				es.On("Delete", mock.Anything, encounterID).Return(store.ErrEncounterNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := mocks.NewEncounterStorer(t)
+			tt.mockSetup(es)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Encounters: es},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodDelete, nil, "/v1/encounter/"+tt.urlID, "encounterID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleDeleteEncounter(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 )
 
@@ -42,3 +47,78 @@ func conflictErrorResponse(w http.ResponseWriter, r *http.Request) {
 func forbiddenErrorResponse(w http.ResponseWriter, r *http.Request) {
 	errorResponse(w, r, http.StatusForbidden, "forbidden resource")
 }
+
+func duplicatePatientResponse(w http.ResponseWriter, r *http.Request, candidates []*models.DuplicateCandidate) {
+	render.Status(r, http.StatusConflict)
+	render.JSON(w, r, models.DuplicatePatientRes{
+		Status:     http.StatusConflict,
+		Error:      "probable duplicate patients found",
+		Candidates: candidates,
+	})
+}
+
+func interactionWarningResponse(w http.ResponseWriter, r *http.Request, warnings []models.InteractionWarning) {
+	render.Status(r, http.StatusConflict)
+	render.JSON(w, r, models.InteractionWarningRes{
+		Status:   http.StatusConflict,
+		Error:    "severe interaction warnings need an override reason",
+		Warnings: warnings,
+	})
+}
+
+func validationErrorResponse(w http.ResponseWriter, r *http.Request, fields map[string]string) {
+	render.Status(r, http.StatusBadRequest)
+	render.JSON(w, r, models.ValidationFailureResponse{
+		Status: http.StatusBadRequest,
+		Error:  "invalid request payload",
+		Fields: fields,
+	})
+}
+
+// fieldErrors turns the validation errors of req into a message per field,
+// keyed by the field's JSON name.
+func fieldErrors(req interface{}, err error) map[string]string {
+	fields := make(map[string]string)
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		fields["body"] = err.Error()
+		return fields
+	}
+
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for _, fe := range verrs {
+		name := fe.Field()
+		if sf, ok := t.FieldByName(fe.StructField()); ok {
+			if tag := strings.Split(sf.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+				name = tag
+			}
+		}
+		fields[name] = fieldMessage(name, fe)
+	}
+	return fields
+}
+
+func fieldMessage(name string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return name + " is required"
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", name, fe.Param())
+	case "gte", "min":
+		return fmt.Sprintf("%s must be at least %s", name, fe.Param())
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", name, fe.Param())
+	case "lte", "max":
+		return fmt.Sprintf("%s must be at most %s", name, fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", name, fe.Param())
+	case "uuid":
+		return name + " must be a UUID"
+	}
+	return name + " is invalid"
+}
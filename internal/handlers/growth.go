@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// HandleGrowthCharts godoc
+// @Summary Growth charts of a child
+// @Description Scores the child's weights, heights and BMIs from vitals against the WHO growth standards
+// @Description for their sex and age, with a z-score and percentile for each measurement and the z-score
+// @Description lines to chart them on. Heights under 24 months are taken as lying length. Measurements
+// @Description past the ages the standards cover are left out. Patients whose gender is neither MALE nor
+// @Description FEMALE need the sex parameter.
+// @Tags Vitals
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param indicator query string false "Indicator" Enums(weight-for-age, height-for-age, bmi-for-age)
+// @Param sex query string false "Sex of the chart" Enums(MALE, FEMALE)
+// @Success 200 {object} models.SuccessResponse{data=models.GrowthCharts}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/growth [get]
+func (h *handler) HandleGrowthCharts(w http.ResponseWriter, r *http.Request) {
+	query := &models.GrowthQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+		Indicator: r.URL.Query().Get("indicator"),
+		Sex:       strings.ToUpper(r.URL.Query().Get("sex")),
+	}
+	if err := h.validate.Struct(query); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	charts, err := h.store.Vitals.Growth(ctx, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrGrowthSexRequired):
+			validationErrorResponse(w, r, map[string]string{"sex": "sex is required for patients whose gender is neither MALE nor FEMALE"})
+		default:
+			h.logger.Error("fetching growth charts failed", zap.Error(err))
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "growth charts fetched successfully",
+		Data:    charts,
+	})
+}
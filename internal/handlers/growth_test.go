@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleGrowthCharts(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		query              string
+		mockSetup          func(*mocks.VitalsStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown indicator",
+			urlID:              patientID,
+			query:              "?indicator=head-circumference",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Charts of an indicator",
+			urlID: patientID,
+			query: "?indicator=weight-for-age&sex=female",
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Growth", mock.Anything, mock.MatchedBy(func(q *models.GrowthQuery) bool {
+					return q.PatientID == patientID && q.Indicator == "weight-for-age" && q.Sex == "FEMALE"
+				})).Return(&models.GrowthCharts{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Sex required",
+			urlID: patientID,
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Growth", mock.Anything, mock.Anything).Return(nil, store.ErrGrowthSexRequired).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Growth", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "DB error",
+			urlID: patientID,
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Growth", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVitals := mocks.NewVitalsStorer(t)
+			tt.mockSetup(mockVitals)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Vitals: mockVitals},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+tt.urlID+"/growth"+tt.query, "patientID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleGrowthCharts(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
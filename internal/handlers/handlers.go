@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/go-playground/validator/v10"
+	"github.com/vaidik-bajpai/medibridge/internal/queue"
 	"github.com/vaidik-bajpai/medibridge/internal/store"
 	"go.uber.org/zap"
 )
@@ -10,6 +11,9 @@ type handler struct {
 	validate *validator.Validate
 	logger   *zap.Logger
 	store    *store.Store
+
+	// events tells the queue streams the walk-in queue changed.
+	events *queue.Broker
 }
 
 func NewHandler(v *validator.Validate, l *zap.Logger, store *store.Store) *handler {
@@ -17,5 +21,6 @@ func NewHandler(v *validator.Validate, l *zap.Logger, store *store.Store) *handl
 		validate: v,
 		logger:   l,
 		store:    store,
+		events:   queue.NewBroker(),
 	}
 }
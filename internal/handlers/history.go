@@ -0,0 +1,439 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"github.com/vaidik-bajpai/medibridge/internal/terminology"
+	"go.uber.org/zap"
+)
+
+// historyFieldErrors maps the store's checks of a family or social history
+// to field errors, or returns nil for other errors.
+func historyFieldErrors(err error) map[string]string {
+	switch {
+	case errors.Is(err, store.ErrAgeAtDeathNotDeceased):
+		return map[string]string{"ageAtDeath": "ageAtDeath is only recorded for a deceased relative"}
+	case errors.Is(err, store.ErrOnsetAfterDeath):
+		return map[string]string{"ageAtOnset": "ageAtOnset can't be after ageAtDeath"}
+	case errors.Is(err, store.ErrSmokingAgeTooHigh):
+		return map[string]string{"smokingStartAge": "smoking ages can't be above the patient's age"}
+	}
+	return nil
+}
+
+// HandleAddFamilyHistory godoc
+// @Summary Add to a patient's family history
+// @Description Records a condition of a blood relative of the patient. The condition may be coded with an
+// @Description ICD-10 code from the terminology endpoint; its name defaults to the code's display text.
+// @Description An age at death is only recorded for a deceased relative.
+// @Tags History
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.AddFamilyHistoryReq true "Relative's condition"
+// @Success 201 {object} models.SuccessResponse{data=models.FamilyHistory}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/family-history [post]
+func (h *handler) HandleAddFamilyHistory(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.AddFamilyHistoryReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.RecordedByID = getUserFromCtx(r).ID
+	req.Condition = strings.TrimSpace(req.Condition)
+	req.Notes = strings.TrimSpace(req.Notes)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	if req.Code != "" {
+		code, ok := terminology.LookupICD10(req.Code)
+		if !ok {
+			validationErrorResponse(w, r, map[string]string{"code": unknownICD10(req.Code)})
+			return
+		}
+		req.Code, req.Display = code.Code, code.Display
+		if req.Condition == "" {
+			req.Condition = code.Display
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry, err := h.store.FamilyHistory.Add(ctx, &req)
+	if err != nil {
+		if fields := historyFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("adding family history failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "family history added successfully",
+		Data:    entry,
+	})
+}
+
+// HandleListFamilyHistory godoc
+// @Summary List a patient's family history
+// @Description Lists the conditions of the patient's relatives, oldest first.
+// @Tags History
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.FamilyHistory}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/family-history [get]
+func (h *handler) HandleListFamilyHistory(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entries, err := h.store.FamilyHistory.List(ctx, pID)
+	if err != nil {
+		h.logger.Error("listing family history failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "family history fetched successfully",
+		Data:    entries,
+	})
+}
+
+// HandleUpdateFamilyHistory godoc
+// @Summary Correct a relative's condition
+// @Description Changes the fields given of a relative's condition. A new code replaces the display text of
+// @Description the old one; marking the relative as not deceased clears their age at death.
+// @Tags History
+// @Accept json
+// @Produce json
+// @Param familyHistoryID path string true "Family history ID"
+// @Param body body models.UpdateFamilyHistoryReq true "Fields to change"
+// @Success 200 {object} models.SuccessResponse{data=models.FamilyHistory}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/family-history/{familyHistoryID} [put]
+func (h *handler) HandleUpdateFamilyHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "familyHistoryID")
+	if err := h.validate.Var(id, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.UpdateFamilyHistoryReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.ID = id
+	if req.Condition != nil {
+		*req.Condition = strings.TrimSpace(*req.Condition)
+	}
+	if req.Notes != nil {
+		*req.Notes = strings.TrimSpace(*req.Notes)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	if req.Empty() {
+		badRequestResponse(w, r)
+		return
+	}
+
+	if req.Code != "" {
+		code, ok := terminology.LookupICD10(req.Code)
+		if !ok {
+			validationErrorResponse(w, r, map[string]string{"code": unknownICD10(req.Code)})
+			return
+		}
+		req.Code, req.Display = code.Code, code.Display
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry, err := h.store.FamilyHistory.Update(ctx, &req)
+	if err != nil {
+		if fields := historyFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrFamilyHistoryNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("updating family history failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "family history updated successfully",
+		Data:    entry,
+	})
+}
+
+// HandleDeleteFamilyHistory godoc
+// @Summary Delete a relative's condition
+// @Description Deletes a relative's condition recorded by mistake.
+// @Tags History
+// @Produce json
+// @Param familyHistoryID path string true "Family history ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/family-history/{familyHistoryID} [delete]
+func (h *handler) HandleDeleteFamilyHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "familyHistoryID")
+	if err := h.validate.Var(id, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.FamilyHistory.Delete(ctx, id); err != nil {
+		if errors.Is(err, store.ErrFamilyHistoryNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("deleting family history failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "family history deleted successfully",
+	})
+}
+
+// socialHistoryFieldErrors checks the fields of a social history against
+// each other.
+func socialHistoryFieldErrors(req *models.SetSocialHistoryReq) map[string]string {
+	errs := map[string]string{}
+	switch req.SmokingStatus {
+	case clinical.SmokingNever, clinical.SmokingUnknown:
+		if req.CigarettesPerDay != nil {
+			errs["cigarettesPerDay"] = "cigarettesPerDay is only recorded for current and former smokers"
+		}
+		if req.SmokingStartAge != nil {
+			errs["smokingStartAge"] = "smokingStartAge is only recorded for current and former smokers"
+		}
+	}
+	if req.SmokingQuitAge != nil {
+		if req.SmokingStatus != clinical.SmokingFormer {
+			errs["smokingQuitAge"] = "smokingQuitAge is only recorded for former smokers"
+		} else if req.SmokingStartAge != nil && *req.SmokingStartAge > *req.SmokingQuitAge {
+			errs["smokingQuitAge"] = "smokingQuitAge can't be before smokingStartAge"
+		}
+	}
+	if req.AlcoholUse == clinical.AlcoholNone && req.AlcoholUnitsPerWeek != nil {
+		errs["alcoholUnitsPerWeek"] = "alcoholUnitsPerWeek is not recorded when alcoholUse is none"
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// HandleSetSocialHistory godoc
+// @Summary Record a patient's social history
+// @Description Records the patient's smoking, alcohol use and occupation, replacing the social history
+// @Description recorded before. Pack-years are worked out from the cigarettes a day and the years smoked,
+// @Description up to the quit age for a former smoker or the patient's age for a current one.
+// @Tags History
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.SetSocialHistoryReq true "Social history"
+// @Success 200 {object} models.SuccessResponse{data=models.SocialHistory}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/social-history [put]
+func (h *handler) HandleSetSocialHistory(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.SetSocialHistoryReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.RecordedByID = getUserFromCtx(r).ID
+	req.SmokingStatus = strings.ToLower(strings.TrimSpace(req.SmokingStatus))
+	req.AlcoholUse = strings.ToLower(strings.TrimSpace(req.AlcoholUse))
+	req.Occupation = strings.TrimSpace(req.Occupation)
+	req.Notes = strings.TrimSpace(req.Notes)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	if errs := socialHistoryFieldErrors(&req); errs != nil {
+		validationErrorResponse(w, r, errs)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	history, err := h.store.SocialHistory.Set(ctx, &req)
+	if err != nil {
+		if fields := historyFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("recording social history failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "social history recorded successfully",
+		Data:    history,
+	})
+}
+
+// HandleGetSocialHistory godoc
+// @Summary Get a patient's social history
+// @Description Returns the patient's smoking with pack-years, alcohol use and occupation.
+// @Tags History
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse{data=models.SocialHistory}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/social-history [get]
+func (h *handler) HandleGetSocialHistory(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	history, err := h.store.SocialHistory.Get(ctx, pID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound), errors.Is(err, store.ErrSocialHistoryNotFound):
+			notFoundError(w, r)
+		default:
+			h.logger.Error("fetching social history failed", zap.Error(err))
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "social history fetched successfully",
+		Data:    history,
+	})
+}
+
+// HandleDeleteSocialHistory godoc
+// @Summary Delete a patient's social history
+// @Description Deletes the patient's social history recorded by mistake.
+// @Tags History
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/social-history [delete]
+func (h *handler) HandleDeleteSocialHistory(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.SocialHistory.Delete(ctx, pID); err != nil {
+		if errors.Is(err, store.ErrSocialHistoryNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("deleting social history failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "social history deleted successfully",
+	})
+}
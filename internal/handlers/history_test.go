@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleAddFamilyHistory(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.FamilyHistoryStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"relationship":"mother","condition":"Asthma"}`),
+			mockSetup:          func(fs *mocks.FamilyHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown relationship",
+			urlID:              patientID,
+			body:               []byte(`{"relationship":"neighbour","condition":"Asthma"}`),
+			mockSetup:          func(fs *mocks.FamilyHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Neither code nor condition",
+			urlID:              patientID,
+			body:               []byte(`{"relationship":"mother"}`),
+			mockSetup:          func(fs *mocks.FamilyHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown code",
+			urlID:              patientID,
+			body:               []byte(`{"relationship":"mother","code":"Z99.99"}`),
+			mockSetup:          func(fs *mocks.FamilyHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Coded condition",
+			urlID: patientID,
+			body:  []byte(`{"relationship":"father","code":"e119","ageAtOnset":45}`),
+			mockSetup: func(fs *mocks.FamilyHistoryStorer) {
+				fs.On("Add", mock.Anything, mock.MatchedBy(func(r *models.AddFamilyHistoryReq) bool {
+					return r.PatientID == patientID && r.RecordedByID == doctorID && r.Code == "E11.9" &&
+						r.Condition == "Type 2 diabetes mellitus without complications"
+				})).Return(&models.FamilyHistory{ID: "entry-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Age at death of a living relative",
+			urlID: patientID,
+			body:  []byte(`{"relationship":"mother","condition":"Asthma","ageAtDeath":70}`),
+			mockSetup: func(fs *mocks.FamilyHistoryStorer) {
+				fs.On("Add", mock.Anything, mock.Anything).Return(nil, store.ErrAgeAtDeathNotDeceased).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  []byte(`{"relationship":"mother","condition":"Asthma"}`),
+			mockSetup: func(fs *mocks.FamilyHistoryStorer) {
+				fs.On("Add", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := mocks.NewFamilyHistoryStorer(t)
+			tt.mockSetup(fs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{FamilyHistory: fs},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/family-history", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleAddFamilyHistory(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleUpdateFamilyHistory(t *testing.T) {
+	entryID := "3f1c2b4a-6d5e-4f70-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.FamilyHistoryStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Nothing to change",
+			urlID:              entryID,
+			body:               []byte(`{}`),
+			mockSetup:          func(fs *mocks.FamilyHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Relative died",
+			urlID: entryID,
+			body:  []byte(`{"deceased":true,"ageAtDeath":72}`),
+			mockSetup: func(fs *mocks.FamilyHistoryStorer) {
+				fs.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateFamilyHistoryReq) bool {
+					return r.ID == entryID && *r.Deceased && *r.AgeAtDeath == 72
+				})).Return(&models.FamilyHistory{ID: entryID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Onset after death",
+			urlID: entryID,
+			body:  []byte(`{"ageAtOnset":80}`),
+			mockSetup: func(fs *mocks.FamilyHistoryStorer) {
+				fs.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrOnsetAfterDeath).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Entry not found",
+			urlID: entryID,
+			body:  []byte(`{"notes":"Diagnosed late"}`),
+			mockSetup: func(fs *mocks.FamilyHistoryStorer) {
+				fs.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrFamilyHistoryNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := mocks.NewFamilyHistoryStorer(t)
+			tt.mockSetup(fs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{FamilyHistory: fs},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/family-history/"+tt.urlID, "familyHistoryID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleUpdateFamilyHistory(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleSetSocialHistory(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.SocialHistoryStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Missing smoking status",
+			urlID:              patientID,
+			body:               []byte(`{"alcoholUse":"none"}`),
+			mockSetup:          func(ss *mocks.SocialHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Cigarettes of a non-smoker",
+			urlID:              patientID,
+			body:               []byte(`{"smokingStatus":"never","cigarettesPerDay":10}`),
+			mockSetup:          func(ss *mocks.SocialHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Quit age of a current smoker",
+			urlID:              patientID,
+			body:               []byte(`{"smokingStatus":"current","smokingQuitAge":40}`),
+			mockSetup:          func(ss *mocks.SocialHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Quit before starting",
+			urlID:              patientID,
+			body:               []byte(`{"smokingStatus":"former","smokingStartAge":30,"smokingQuitAge":20}`),
+			mockSetup:          func(ss *mocks.SocialHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Units without alcohol use",
+			urlID:              patientID,
+			body:               []byte(`{"smokingStatus":"never","alcoholUse":"none","alcoholUnitsPerWeek":4}`),
+			mockSetup:          func(ss *mocks.SocialHistoryStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Former smoker",
+			urlID: patientID,
+			body:  []byte(`{"smokingStatus":"Former","cigarettesPerDay":10,"smokingStartAge":18,"smokingQuitAge":38,"occupation":" Stone cutter "}`),
+			mockSetup: func(ss *mocks.SocialHistoryStorer) {
+				ss.On("Set", mock.Anything, mock.MatchedBy(func(r *models.SetSocialHistoryReq) bool {
+					return r.PatientID == patientID && r.RecordedByID == doctorID &&
+						r.SmokingStatus == "former" && r.Occupation == "Stone cutter"
+				})).Return(&models.SocialHistory{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Smoking age above the patient's age",
+			urlID: patientID,
+			body:  []byte(`{"smokingStatus":"current","smokingStartAge":60}`),
+			mockSetup: func(ss *mocks.SocialHistoryStorer) {
+				ss.On("Set", mock.Anything, mock.Anything).Return(nil, store.ErrSmokingAgeTooHigh).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  []byte(`{"smokingStatus":"unknown"}`),
+			mockSetup: func(ss *mocks.SocialHistoryStorer) {
+				ss.On("Set", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := mocks.NewSocialHistoryStorer(t)
+			tt.mockSetup(ss)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{SocialHistory: ss},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/patient/"+tt.urlID+"/social-history", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleSetSocialHistory(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleGetSocialHistory(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		mockSetup          func(*mocks.SocialHistoryStorer)
+		expectedStatusCode int
+	}{
+		{
+			name: "Recorded",
+			mockSetup: func(ss *mocks.SocialHistoryStorer) {
+				ss.On("Get", mock.Anything, patientID).Return(&models.SocialHistory{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Not recorded",
+			mockSetup: func(ss *mocks.SocialHistoryStorer) {
+				ss.On("Get", mock.Anything, patientID).Return(nil, store.ErrSocialHistoryNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := mocks.NewSocialHistoryStorer(t)
+			tt.mockSetup(ss)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{SocialHistory: ss},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+patientID+"/social-history", "patientID", patientID)
+
+			rr := httptest.NewRecorder()
+			h.HandleGetSocialHistory(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
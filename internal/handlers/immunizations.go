@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/immunization"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// HandleGetImmunizationSchedule godoc
+// @Summary Get the immunization schedule
+// @Description Returns the national immunization schedule the clinic follows, with the age each dose is due
+// @Description at and the age after which it can no longer be given.
+// @Tags Immunizations
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=immunization.Schedule}
+// @Router /v1/immunization-schedule [get]
+func (h *handler) HandleGetImmunizationSchedule(w http.ResponseWriter, r *http.Request) {
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "immunization schedule fetched successfully",
+		Data:    immunization.Current(),
+	})
+}
+
+// HandleRecordImmunization godoc
+// @Summary Record a vaccine dose
+// @Description Records a dose given to the patient, at the clinic or elsewhere. Vaccines of the schedule
+// @Description take their name from it; other vaccines need a vaccineName. Unless administeredById or
+// @Description administeredByName is given, the signed in user gave the dose. A dose of a vaccine can only
+// @Description be recorded once.
+// @Tags Immunizations
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.RecordImmunizationReq true "Dose"
+// @Success 201 {object} models.SuccessResponse{data=models.Immunization}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/immunizations [post]
+func (h *handler) HandleRecordImmunization(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.RecordImmunizationReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.RecordedByID = getUserFromCtx(r).ID
+	req.Vaccine = strings.ToUpper(strings.TrimSpace(req.Vaccine))
+	req.VaccineName = strings.TrimSpace(req.VaccineName)
+	req.AdministeredByName = strings.TrimSpace(req.AdministeredByName)
+	req.Notes = strings.TrimSpace(req.Notes)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	if vaccine, ok := immunization.Lookup(req.Vaccine); ok {
+		if req.DoseNumber > len(vaccine.Doses) {
+			validationErrorResponse(w, r, map[string]string{
+				"doseNumber": fmt.Sprintf("%s has %d doses in the schedule", vaccine.Code, len(vaccine.Doses)),
+			})
+			return
+		}
+		req.VaccineName = vaccine.Name
+	} else if req.VaccineName == "" {
+		validationErrorResponse(w, r, map[string]string{"vaccineName": "vaccineName is required for vaccines outside the schedule"})
+		return
+	}
+
+	if req.AdministeredAt != nil && req.AdministeredAt.After(time.Now()) {
+		validationErrorResponse(w, r, map[string]string{"administeredAt": "administeredAt can't be in the future"})
+		return
+	}
+	if req.AdministeredByID == "" && req.AdministeredByName == "" {
+		req.AdministeredByID = req.RecordedByID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dose, err := h.store.Immunizations.Record(ctx, &req)
+	if err != nil {
+		h.logger.Info("recording immunization failed", zap.Error(err))
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrNotFound):
+			validationErrorResponse(w, r, map[string]string{"administeredById": "administeredById is not a user"})
+		case errors.Is(err, store.ErrGivenBeforeBirth):
+			validationErrorResponse(w, r, map[string]string{"administeredAt": "administeredAt is before the patient's date of birth"})
+		case errors.Is(err, store.ErrDoseRecorded):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "immunization recorded successfully",
+		Data:    dose,
+	})
+}
+
+// HandleListImmunizations godoc
+// @Summary List a patient's immunizations
+// @Description Lists the vaccine doses given to the patient, the most recent first.
+// @Tags Immunizations
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Immunization}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/immunizations [get]
+func (h *handler) HandleListImmunizations(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	doses, err := h.store.Immunizations.List(ctx, pID)
+	if err != nil {
+		h.logger.Error("listing immunizations failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "immunizations fetched successfully",
+		Data:    doses,
+	})
+}
+
+// HandleImmunizationStatus godoc
+// @Summary Get a patient's immunization status
+// @Description Works out every dose of the schedule for the patient from their date of birth and the doses
+// @Description given: given, upcoming, due, overdue once the grace period after the due date is over, or
+// @Description lapsed once the patient is too old for it. Doses after one given late are due later.
+// @Tags Immunizations
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse{data=models.ImmunizationStatus}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/immunizations/status [get]
+func (h *handler) HandleImmunizationStatus(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	status, err := h.store.Immunizations.Status(ctx, pID, clinicToday())
+	if err != nil {
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching immunization status failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "immunization status fetched successfully",
+		Data:    status,
+	})
+}
+
+// HandleDeleteImmunization godoc
+// @Summary Delete an immunization
+// @Description Deletes a vaccine dose recorded by mistake.
+// @Tags Immunizations
+// @Produce json
+// @Param immunizationID path string true "Immunization ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/immunization/{immunizationID} [delete]
+func (h *handler) HandleDeleteImmunization(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "immunizationID")
+	if err := h.validate.Var(id, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.Immunizations.Delete(ctx, id); err != nil {
+		if errors.Is(err, store.ErrImmunizationNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("deleting immunization failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "immunization deleted successfully",
+	})
+}
+
+// HandleOverdueImmunizations godoc
+// @Summary List patients with overdue immunizations
+// @Description Lists the patients overdue for a dose of the schedule, those overdue longest first, with their
+// @Description contact number for recall.
+// @Tags Immunizations
+// @Produce json
+// @Param asOf query string false "Report date (YYYY-MM-DD), defaults to today"
+// @Param vaccine query string false "Vaccine code"
+// @Success 200 {object} models.SuccessResponse{data=models.OverdueReport}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/immunizations/overdue [get]
+func (h *handler) HandleOverdueImmunizations(w http.ResponseWriter, r *http.Request) {
+	query := &models.OverdueQuery{
+		AsOf:    clinicToday(),
+		Vaccine: strings.ToUpper(r.URL.Query().Get("vaccine")),
+	}
+	if asOf := r.URL.Query().Get("asOf"); asOf != "" {
+		day, err := parseClinicDate(asOf)
+		if err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		query.AsOf = day
+	}
+	if query.Vaccine != "" {
+		if _, ok := immunization.Lookup(query.Vaccine); !ok {
+			badRequestResponse(w, r)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	report, err := h.store.Immunizations.Overdue(ctx, query)
+	if err != nil {
+		h.logger.Error("fetching overdue immunizations failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "overdue immunizations fetched successfully",
+		Data:    report,
+	})
+}
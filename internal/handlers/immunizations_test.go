@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleRecordImmunization(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(time.RFC3339)
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.ImmunizationStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"vaccine":"BCG","doseNumber":1}`),
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Missing dose number",
+			urlID:              patientID,
+			body:               []byte(`{"vaccine":"BCG"}`),
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Dose beyond the schedule",
+			urlID:              patientID,
+			body:               []byte(`{"vaccine":"PENTA","doseNumber":4}`),
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Vaccine outside the schedule without a name",
+			urlID:              patientID,
+			body:               []byte(`{"vaccine":"TCV","doseNumber":1}`),
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Given in the future",
+			urlID:              patientID,
+			body:               []byte(`{"vaccine":"BCG","doseNumber":1,"administeredAt":"` + tomorrow + `"}`),
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown site",
+			urlID:              patientID,
+			body:               []byte(`{"vaccine":"BCG","doseNumber":1,"site":"hip"}`),
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Both administered by fields",
+			urlID:              patientID,
+			body:               []byte(`{"vaccine":"BCG","doseNumber":1,"administeredById":"` + doctorID + `","administeredByName":"PHC Rampur"}`),
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Given at the clinic",
+			urlID: patientID,
+			body:  []byte(`{"vaccine":"penta","doseNumber":1,"lot":"PV24031","site":"left-thigh"}`),
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Record", mock.Anything, mock.MatchedBy(func(r *models.RecordImmunizationReq) bool {
+					return r.PatientID == patientID && r.Vaccine == "PENTA" && r.VaccineName == "Pentavalent (DPT, Hep B, Hib)" &&
+						r.RecordedByID == doctorID && r.AdministeredByID == doctorID
+				})).Return(&models.Immunization{ID: "dose-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Copied from a vaccination card",
+			urlID: patientID,
+			body:  []byte(`{"vaccine":"TCV","vaccineName":"Typhoid conjugate vaccine","doseNumber":1,"administeredByName":"PHC Rampur"}`),
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Record", mock.Anything, mock.MatchedBy(func(r *models.RecordImmunizationReq) bool {
+					return r.AdministeredByID == "" && r.AdministeredByName == "PHC Rampur"
+				})).Return(&models.Immunization{ID: "dose-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Dose already recorded",
+			urlID: patientID,
+			body:  []byte(`{"vaccine":"BCG","doseNumber":1}`),
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Record", mock.Anything, mock.Anything).Return(nil, store.ErrDoseRecorded).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Given before birth",
+			urlID: patientID,
+			body:  []byte(`{"vaccine":"BCG","doseNumber":1,"administeredAt":"2020-01-01T00:00:00Z"}`),
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Record", mock.Anything, mock.Anything).Return(nil, store.ErrGivenBeforeBirth).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  []byte(`{"vaccine":"BCG","doseNumber":1}`),
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Record", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := mocks.NewImmunizationStorer(t)
+			tt.mockSetup(is)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Immunizations: is},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/immunizations", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleRecordImmunization(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleImmunizationStatus(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		mockSetup          func(*mocks.ImmunizationStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Status as of today",
+			urlID: patientID,
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Status", mock.Anything, patientID, clinicToday()).
+					Return(&models.ImmunizationStatus{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Status", mock.Anything, patientID, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := mocks.NewImmunizationStorer(t)
+			tt.mockSetup(is)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Immunizations: is},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+tt.urlID+"/immunizations/status", "patientID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleImmunizationStatus(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleOverdueImmunizations(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.ImmunizationStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid date",
+			query:              "?asOf=31-01-2026",
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Vaccine outside the schedule",
+			query:              "?vaccine=TCV",
+			mockSetup:          func(is *mocks.ImmunizationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Report for a vaccine as of a date",
+			query: "?asOf=2026-10-01&vaccine=mr",
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Overdue", mock.Anything, mock.MatchedBy(func(q *models.OverdueQuery) bool {
+					return q.Vaccine == "MR" && q.AsOf.Format(time.DateOnly) == "2026-10-01"
+				})).Return(&models.OverdueReport{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := mocks.NewImmunizationStorer(t)
+			tt.mockSetup(is)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Immunizations: is},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/immunizations/overdue"+tt.query, nil)
+
+			rr := httptest.NewRecorder()
+			h.HandleOverdueImmunizations(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleDeleteImmunization(t *testing.T) {
+	doseID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		mockSetup          func(*mocks.ImmunizationStorer)
+		expectedStatusCode int
+	}{
+		{
+			name: "Deleted",
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Delete", mock.Anything, doseID).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Dose not found",
+			mockSetup: func(is *mocks.ImmunizationStorer) {
+				is.On("Delete", mock.Anything, doseID).Return(store.ErrImmunizationNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := mocks.NewImmunizationStorer(t)
+			tt.mockSetup(is)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Immunizations: is},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodDelete, nil, "/v1/immunization/"+doseID, "immunizationID", doseID)
+
+			rr := httptest.NewRecorder()
+			h.HandleDeleteImmunization(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,530 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/labs"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// normalizeLabResults checks each result against its test in the catalogue
+// and converts its value and reported range to the unit of the test. It
+// returns the field errors, or nil when all results are valid.
+func normalizeLabResults(results []models.LabResultInput) map[string]string {
+	fields := make(map[string]string)
+	for i := range results {
+		in := &results[i]
+		field := func(name string) string { return fmt.Sprintf("results[%d].%s", i, name) }
+
+		test, ok := labs.Lookup(in.TestCode)
+		if !ok {
+			fields[field("testCode")] = "testCode is not a lab test"
+			continue
+		}
+
+		if test.Qualitative() {
+			if strings.TrimSpace(in.ValueText) == "" {
+				fields[field("valueText")] = fmt.Sprintf("valueText is required for %s", test.Code)
+			}
+			continue
+		}
+
+		if in.Value == nil {
+			fields[field("value")] = fmt.Sprintf("value is required for %s", test.Code)
+			continue
+		}
+		value, err := test.ToUnit(*in.Value, in.Unit)
+		if err != nil {
+			fields[field("unit")] = err.Error()
+			continue
+		}
+		in.Value = &value
+		if in.ReferenceLow != nil {
+			low, _ := test.ToUnit(*in.ReferenceLow, in.Unit)
+			in.ReferenceLow = &low
+		}
+		if in.ReferenceHigh != nil {
+			high, _ := test.ToUnit(*in.ReferenceHigh, in.Unit)
+			in.ReferenceHigh = &high
+		}
+		in.Unit = test.Unit
+
+		if in.ReferenceLow != nil && in.ReferenceHigh != nil && *in.ReferenceLow > *in.ReferenceHigh {
+			fields[field("referenceHigh")] = "referenceHigh must not be below referenceLow"
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// labResultFieldErrors returns the field errors for a result the store
+// rejected, or nil for any other error.
+func labResultFieldErrors(err error) map[string]string {
+	var rerr *store.LabResultError
+	if !errors.As(err, &rerr) {
+		return nil
+	}
+
+	field := func(name string) string { return fmt.Sprintf("results[%d].%s", rerr.Index, name) }
+	switch {
+	case errors.Is(err, store.ErrPatientNotFound):
+		return map[string]string{field("patientId"): "patientId is not a patient"}
+	case errors.Is(err, store.ErrLabOrderNotFound):
+		return map[string]string{field("orderId"): "orderId is not a lab order of the patient"}
+	case errors.Is(err, store.ErrTestNotOrdered):
+		return map[string]string{field("testCode"): "testCode is not one of the tests of the order"}
+	}
+	return nil
+}
+
+// HandleListLabTests godoc
+// @Summary List the lab test catalogue
+// @Description Lists the tests that can be ordered with their units and reference ranges, and the panels
+// @Description grouping them.
+// @Tags Labs
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=labs.Catalogue}
+// @Router /v1/lab-tests [get]
+func (h *handler) HandleListLabTests(w http.ResponseWriter, r *http.Request) {
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "lab tests fetched successfully",
+		Data:    labs.Current(),
+	})
+}
+
+// HandleOrderLabs godoc
+// @Summary Order lab tests
+// @Description Orders tests of the catalogue for the patient, ordered by the signed in doctor. Panels are
+// @Description expanded to their tests. The order may be placed in one of the patient's encounters.
+// @Tags Labs
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.CreateLabOrderReq true "Order"
+// @Success 201 {object} models.SuccessResponse{data=models.LabOrder}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/lab-orders [post]
+func (h *handler) HandleOrderLabs(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.CreateLabOrderReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.OrderedByID = getUserFromCtx(r).ID
+	req.Notes = strings.TrimSpace(req.Notes)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	tests, unknown := labs.Expand(req.Tests)
+	if unknown != "" {
+		validationErrorResponse(w, r, map[string]string{"tests": unknown + " is not a lab test or panel"})
+		return
+	}
+	req.Tests = tests
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	order, err := h.store.Labs.Order(ctx, &req)
+	if err != nil {
+		h.logger.Info("ordering lab tests failed", zap.Error(err))
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "lab tests ordered successfully",
+		Data:    order,
+	})
+}
+
+// HandleListLabOrders godoc
+// @Summary List a patient's lab orders
+// @Description Lists the patient's lab orders with their results, the most recent first.
+// @Tags Labs
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param status query string false "Order status" Enums(ordered, partial, completed, cancelled)
+// @Success 200 {object} models.SuccessResponse{data=[]models.LabOrder}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/lab-orders [get]
+func (h *handler) HandleListLabOrders(w http.ResponseWriter, r *http.Request) {
+	query := &models.LabOrderQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+		Status:    r.URL.Query().Get("status"),
+	}
+	if err := h.validate.Struct(query); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	orders, err := h.store.Labs.ListOrders(ctx, query)
+	if err != nil {
+		h.logger.Error("listing lab orders failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "lab orders fetched successfully",
+		Data:    orders,
+	})
+}
+
+// HandleGetLabOrder godoc
+// @Summary Get a lab order
+// @Description Returns a lab order with the results received so far.
+// @Tags Labs
+// @Produce json
+// @Param orderID path string true "Lab order ID"
+// @Success 200 {object} models.SuccessResponse{data=models.LabOrder}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/lab-order/{orderID} [get]
+func (h *handler) HandleGetLabOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderID")
+	if err := h.validate.Var(orderID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	order, err := h.store.Labs.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, store.ErrLabOrderNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching lab order failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "lab order fetched successfully",
+		Data:    order,
+	})
+}
+
+// HandleCancelLabOrder godoc
+// @Summary Cancel a lab order
+// @Description Cancels a lab order none of whose tests have a result yet.
+// @Tags Labs
+// @Produce json
+// @Param orderID path string true "Lab order ID"
+// @Success 200 {object} models.SuccessResponse{data=models.LabOrder}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/lab-order/{orderID}/cancel [post]
+func (h *handler) HandleCancelLabOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderID")
+	if err := h.validate.Var(orderID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	order, err := h.store.Labs.CancelOrder(ctx, orderID)
+	if err != nil {
+		h.logger.Info("cancelling lab order failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrLabOrderNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrLabOrderCancelled), errors.Is(err, store.ErrLabOrderHasResults):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "lab order cancelled successfully",
+		Data:    order,
+	})
+}
+
+// HandleEnterLabResults godoc
+// @Summary Enter the results of a lab order
+// @Description Records results of the order's tests by hand. Values may be given in any unit the catalogue
+// @Description converts from and are stored in the unit of the test. Each result is flagged against the
+// @Description range given with it or the catalogue's range for the patient's sex and age. A test of the
+// @Description order can only have one result.
+// @Tags Labs
+// @Accept json
+// @Produce json
+// @Param orderID path string true "Lab order ID"
+// @Param body body models.EnterLabResultsReq true "Results"
+// @Success 201 {object} models.SuccessResponse{data=models.AddLabResultsRes}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/lab-order/{orderID}/results [post]
+func (h *handler) HandleEnterLabResults(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderID")
+	if err := h.validate.Var(orderID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.EnterLabResultsReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	// the order decides the patient
+	for i := range req.Results {
+		req.Results[i].OrderID = orderID
+		req.Results[i].PatientID = ""
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	if fields := normalizeLabResults(req.Results); fields != nil {
+		validationErrorResponse(w, r, fields)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.store.Labs.AddResults(ctx, &models.AddLabResultsReq{
+		Source:      labs.SourceManual,
+		EnteredByID: getUserFromCtx(r).ID,
+		Results:     req.Results,
+	})
+	if err != nil {
+		h.logger.Info("entering lab results failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrLabOrderNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrLabOrderCancelled), errors.Is(err, store.ErrLabResultExists):
+			conflictErrorResponse(w, r)
+		default:
+			if fields := labResultFieldErrors(err); fields != nil {
+				validationErrorResponse(w, r, fields)
+				return
+			}
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "lab results entered successfully",
+		Data:    res,
+	})
+}
+
+// HandleIngestLabResults godoc
+// @Summary Ingest lab results
+// @Description Receives results from a lab system. Each result names its patient and may name the order
+// @Description it answers. Results with an externalId that was already received are skipped, so a batch
+// @Description can be sent again. Values are converted and flagged as for results entered by hand. The
+// @Description batch is stored as a whole or not at all.
+// @Tags Labs
+// @Accept json
+// @Produce json
+// @Param body body models.IngestLabResultsReq true "Results"
+// @Success 201 {object} models.SuccessResponse{data=models.AddLabResultsRes}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/lab-results [post]
+func (h *handler) HandleIngestLabResults(w http.ResponseWriter, r *http.Request) {
+	var req models.IngestLabResultsReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	for i, in := range req.Results {
+		if in.PatientID == "" {
+			validationErrorResponse(w, r, map[string]string{fmt.Sprintf("results[%d].patientId", i): "patientId is required"})
+			return
+		}
+	}
+	if fields := normalizeLabResults(req.Results); fields != nil {
+		validationErrorResponse(w, r, fields)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.store.Labs.AddResults(ctx, &models.AddLabResultsReq{
+		Source:      labs.SourceIngested,
+		EnteredByID: getUserFromCtx(r).ID,
+		Results:     req.Results,
+	})
+	if err != nil {
+		h.logger.Info("ingesting lab results failed", zap.Error(err))
+		if fields := labResultFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrLabOrderCancelled) || errors.Is(err, store.ErrLabResultExists) {
+			conflictErrorResponse(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "lab results ingested successfully",
+		Data:    res,
+	})
+}
+
+// HandleCumulativeLabs godoc
+// @Summary Get a patient's cumulative lab results
+// @Description Returns the patient's results as a table with a row per test, in catalogue order, and a
+// @Description column per collection time, oldest first. Cells are null where a test wasn't done. The
+// @Description tests may be limited to test or panel codes, and the collection time bounded.
+// @Tags Labs
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param tests query string false "Comma separated test or panel codes" example(CBC,GLUF)
+// @Param from query string false "Collected at or after (RFC 3339)"
+// @Param to query string false "Collected at or before (RFC 3339)"
+// @Success 200 {object} models.SuccessResponse{data=models.LabTable}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/labs [get]
+func (h *handler) HandleCumulativeLabs(w http.ResponseWriter, r *http.Request) {
+	query, err := h.parseLabQuery(r)
+	if err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	table, err := h.store.Labs.Cumulative(ctx, query)
+	if err != nil {
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching cumulative lab results failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "lab results fetched successfully",
+		Data:    table,
+	})
+}
+
+func (h *handler) parseLabQuery(r *http.Request) (*models.LabQuery, error) {
+	query := &models.LabQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+	}
+
+	params := r.URL.Query()
+	if tests := params.Get("tests"); tests != "" {
+		codes, unknown := labs.Expand(strings.Split(tests, ","))
+		if unknown != "" {
+			return nil, fmt.Errorf("%s is not a lab test or panel", unknown)
+		}
+		query.Tests = codes
+	}
+	if from := params.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, err
+		}
+		query.From = &t
+	}
+	if to := params.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, err
+		}
+		query.To = &t
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		return nil, err
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return nil, errors.New("from is after to")
+	}
+
+	return query, nil
+}
@@ -0,0 +1,415 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/labs"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleOrderLabs(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.LabStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"tests":["CBC"]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "No tests",
+			urlID:              patientID,
+			body:               []byte(`{"tests":[]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown test",
+			urlID:              patientID,
+			body:               []byte(`{"tests":["CBC","XYZ"]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown priority",
+			urlID:              patientID,
+			body:               []byte(`{"tests":["CBC"],"priority":"asap"}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Panels are expanded",
+			urlID: patientID,
+			body:  []byte(`{"tests":["CBC","NS1","HGB"],"priority":"urgent"}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("Order", mock.Anything, mock.MatchedBy(func(r *models.CreateLabOrderReq) bool {
+					return r.PatientID == patientID && r.OrderedByID == doctorID && r.Priority == "urgent" &&
+						slices.Equal(r.Tests, []string{"HGB", "WBC", "PLT", "NS1"})
+				})).Return(&models.LabOrder{ID: "order-id", Status: labs.StatusOrdered}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Encounter of another patient",
+			urlID: patientID,
+			body:  []byte(`{"tests":["CBC"],"encounterId":"` + doctorID + `"}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("Order", mock.Anything, mock.Anything).Return(nil, store.ErrEncounterNotFound).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  []byte(`{"tests":["CBC"]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("Order", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := mocks.NewLabStorer(t)
+			tt.mockSetup(ls)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Labs: ls},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/lab-orders", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleOrderLabs(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleEnterLabResults(t *testing.T) {
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.LabStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "No results",
+			body:               []byte(`{"results":[]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown test",
+			body:               []byte(`{"results":[{"testCode":"XYZ","value":1}]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Missing value",
+			body:               []byte(`{"results":[{"testCode":"HGB"}]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unsupported unit",
+			body:               []byte(`{"results":[{"testCode":"HGB","value":10.8,"unit":"mmol/L"}]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Qualitative test without text",
+			body:               []byte(`{"results":[{"testCode":"NS1","value":1}]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Values are converted to the unit of the test",
+			body: []byte(`{"results":[{"testCode":"HGB","value":108,"unit":"g/L","referenceLow":120,"referenceHigh":155},{"testCode":"NS1","valueText":"Positive"}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.MatchedBy(func(r *models.AddLabResultsReq) bool {
+					if r.Source != labs.SourceManual || r.EnteredByID != userID || len(r.Results) != 2 {
+						return false
+					}
+					hgb, ns1 := r.Results[0], r.Results[1]
+					return hgb.OrderID == orderID && ns1.OrderID == orderID && hgb.Unit == "g/dL" &&
+						math.Abs(*hgb.Value-10.8) < 1e-9 && math.Abs(*hgb.ReferenceLow-12) < 1e-9 &&
+						math.Abs(*hgb.ReferenceHigh-15.5) < 1e-9 && ns1.ValueText == "Positive"
+				})).Return(&models.AddLabResultsRes{Results: []models.LabResult{{ID: "result-id"}}, Skipped: []string{}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Order not found",
+			body: []byte(`{"results":[{"testCode":"HGB","value":10.8}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.Anything).
+					Return(nil, &store.LabResultError{Index: 0, Err: store.ErrLabOrderNotFound}).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Test not ordered",
+			body: []byte(`{"results":[{"testCode":"HGB","value":10.8}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.Anything).
+					Return(nil, &store.LabResultError{Index: 0, Err: store.ErrTestNotOrdered}).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Test already has a result",
+			body: []byte(`{"results":[{"testCode":"HGB","value":10.8}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.Anything).
+					Return(nil, &store.LabResultError{Index: 0, Err: store.ErrLabResultExists}).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Cancelled order",
+			body: []byte(`{"results":[{"testCode":"HGB","value":10.8}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.Anything).
+					Return(nil, &store.LabResultError{Index: 0, Err: store.ErrLabOrderCancelled}).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := mocks.NewLabStorer(t)
+			tt.mockSetup(ls)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Labs: ls},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/lab-order/"+orderID+"/results", "orderID", orderID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleEnterLabResults(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleIngestLabResults(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.LabStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Missing patient",
+			body:               []byte(`{"results":[{"testCode":"GLUF","value":5.5,"unit":"mmol/L"}]}`),
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Ingested",
+			body: []byte(`{"results":[{"patientId":"` + patientID + `","testCode":"GLUF","value":5.5,"unit":"mmol/L","externalId":"LIS-1"}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.MatchedBy(func(r *models.AddLabResultsReq) bool {
+					in := r.Results[0]
+					return r.Source == labs.SourceIngested && in.PatientID == patientID &&
+						in.Unit == "mg/dL" && math.Abs(*in.Value-5.5*18.016) < 1e-9 && in.ExternalID == "LIS-1"
+				})).Return(&models.AddLabResultsRes{Results: []models.LabResult{}, Skipped: []string{"LIS-1"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Patient not found",
+			body: []byte(`{"results":[{"patientId":"` + patientID + `","testCode":"GLUF","value":99}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.Anything).
+					Return(nil, &store.LabResultError{Index: 0, Err: store.ErrPatientNotFound}).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "DB error",
+			body: []byte(`{"results":[{"patientId":"` + patientID + `","testCode":"GLUF","value":99}]}`),
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("AddResults", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := mocks.NewLabStorer(t)
+			tt.mockSetup(ls)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Labs: ls},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/lab-results", bytes.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleIngestLabResults(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleCumulativeLabs(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.LabStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Unknown test",
+			query:              "?tests=XYZ",
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "From after to",
+			query:              "?from=2026-10-02T00:00:00Z&to=2026-10-01T00:00:00Z",
+			mockSetup:          func(ls *mocks.LabStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Panel codes are expanded",
+			query: "?tests=CBC,GLUF",
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("Cumulative", mock.Anything, mock.MatchedBy(func(q *models.LabQuery) bool {
+					return q.PatientID == patientID && slices.Equal(q.Tests, []string{"HGB", "WBC", "PLT", "GLUF"})
+				})).Return(&models.LabTable{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Patient not found",
+			query: "",
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("Cumulative", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := mocks.NewLabStorer(t)
+			tt.mockSetup(ls)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Labs: ls},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+patientID+"/labs"+tt.query, "patientID", patientID)
+
+			rr := httptest.NewRecorder()
+			h.HandleCumulativeLabs(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleCancelLabOrder(t *testing.T) {
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		mockSetup          func(*mocks.LabStorer)
+		expectedStatusCode int
+	}{
+		{
+			name: "Cancelled",
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("CancelOrder", mock.Anything, orderID).
+					Return(&models.LabOrder{ID: orderID, Status: labs.StatusCancelled}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Order has results",
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("CancelOrder", mock.Anything, orderID).Return(nil, store.ErrLabOrderHasResults).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Order not found",
+			mockSetup: func(ls *mocks.LabStorer) {
+				ls.On("CancelOrder", mock.Anything, orderID).Return(nil, store.ErrLabOrderNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := mocks.NewLabStorer(t)
+			tt.mockSetup(ls)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Labs: ls},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, nil, "/v1/lab-order/"+orderID+"/cancel", "orderID", orderID)
+
+			rr := httptest.NewRecorder()
+			h.HandleCancelLabOrder(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/interactions"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"github.com/vaidik-bajpai/medibridge/internal/terminology"
+	"go.uber.org/zap"
+)
+
+// codeDrug fills in the display text of a coded drug from the substance
+// list. It returns the field errors when the code is unknown or isn't a
+// medication.
+func codeDrug(d *models.CodedSubstance) map[string]string {
+	errs, category := codeSubstance(d, "")
+	if errs != nil {
+		return map[string]string{"drug": errs["substance"]}
+	}
+	if category != terminology.CategoryMedication && category != terminology.CategoryBiologic {
+		return map[string]string{"drug": fmt.Sprintf("%s is not a medication", d.Display)}
+	}
+	return nil
+}
+
+// interactionDrug returns the drug as the interaction checker identifies it.
+func interactionDrug(name string, coded *models.CodedSubstance) interactions.Drug {
+	drug := interactions.Drug{Name: name}
+	if coded != nil && coded.System == terminology.SystemRxNorm {
+		drug.Code = coded.Code
+	}
+	return drug
+}
+
+// checkInteractions checks the drug against the patient's allergies, except
+// the ruled out ones, and current medications other than the medication
+// skipID.
+func (h *handler) checkInteractions(ctx context.Context, patientID string, drug interactions.Drug, skipID string) ([]models.InteractionWarning, error) {
+	allergies, err := h.store.Allergy.List(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	meds, err := h.store.Medications.List(ctx, &models.MedicationQuery{
+		PatientID: patientID,
+		Status:    "current",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var checkedAllergies []interactions.Allergy
+	for _, a := range allergies {
+		if clinical.IsAllergyRuledOut(a.VerificationStatus) {
+			continue
+		}
+		checkedAllergies = append(checkedAllergies, interactions.Allergy{
+			ID:   a.ID,
+			Drug: interactionDrug(a.Name, a.Substance),
+		})
+	}
+	var checkedMeds []interactions.Medication
+	for _, m := range meds {
+		if m.ID == skipID {
+			continue
+		}
+		checkedMeds = append(checkedMeds, interactions.Medication{
+			ID:   m.ID,
+			Drug: interactionDrug(m.DrugName, m.Drug),
+		})
+	}
+
+	found := interactions.Check(drug, checkedAllergies, checkedMeds)
+	warnings := make([]models.InteractionWarning, 0, len(found))
+	for _, w := range found {
+		warnings = append(warnings, models.InteractionWarning{
+			Type:      w.Type,
+			Severity:  w.Severity,
+			Message:   w.Message,
+			SubjectID: w.SubjectID,
+		})
+	}
+	return warnings, nil
+}
+
+// hasSevereWarning reports whether any of the warnings is severe.
+func hasSevereWarning(warnings []models.InteractionWarning) bool {
+	for _, w := range warnings {
+		if w.Severity == interactions.SeveritySevere {
+			return true
+		}
+	}
+	return false
+}
+
+// HandlePrescribeMedication godoc
+// @Summary Prescribe a medication
+// @Description Adds a prescription to the patient's medication list. The drug is coded from the terminology
+// @Description substance list and its name defaults to the drug's display text. Without a stop date a
+// @Description course with a duration stops after it. The prescriber is the signed in doctor.
+// @Description The drug is checked against the patient's allergies and current medications. Severe warnings
+// @Description are returned with a 409 unless an override reason is given; all warnings are kept with the
+// @Description prescription.
+// @Tags Medications
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.PrescribeMedicationReq true "Prescription"
+// @Success 201 {object} models.SuccessResponse{data=models.Medication}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.InteractionWarningRes
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/medications [post]
+func (h *handler) HandlePrescribeMedication(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.PrescribeMedicationReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.PrescriberID = userID(r)
+	req.DrugName = strings.TrimSpace(req.DrugName)
+	req.Instructions = strings.TrimSpace(req.Instructions)
+	req.OverrideReason = strings.TrimSpace(req.OverrideReason)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	if req.Drug != nil {
+		if errs := codeDrug(req.Drug); errs != nil {
+			validationErrorResponse(w, r, errs)
+			return
+		}
+		if req.DrugName == "" {
+			req.DrugName = req.Drug.Display
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	warnings, err := h.checkInteractions(ctx, req.PatientID, interactionDrug(req.DrugName, req.Drug), "")
+	if err != nil {
+		h.logger.Error("checking interactions failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+	if hasSevereWarning(warnings) {
+		if req.OverrideReason == "" {
+			interactionWarningResponse(w, r, warnings)
+			return
+		}
+		h.logger.Warn("severe interaction warning overridden",
+			zap.String("patientID", req.PatientID),
+			zap.String("prescriberID", req.PrescriberID),
+			zap.String("drug", req.DrugName),
+			zap.String("reason", req.OverrideReason),
+			zap.Any("warnings", warnings),
+		)
+	}
+	req.InteractionWarnings = warnings
+
+	med, err := h.store.Medications.Prescribe(ctx, &req)
+	if err != nil {
+		h.logger.Info("prescribing medication failed", zap.Error(err))
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrStopBeforeStart):
+			validationErrorResponse(w, r, map[string]string{"stopDate": "stopDate can't be before startDate"})
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "medication prescribed successfully",
+		Data:    med,
+	})
+}
+
+// HandleListMedications godoc
+// @Summary List a patient's medications
+// @Description Lists the patient's medications, most recently started first. Pass status=current for the
+// @Description active and on-hold medications. A course past its stop date is listed as completed.
+// @Tags Medications
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param status query string false "Medication status" Enums(current, active, on-hold, completed, stopped, entered-in-error)
+// @Success 200 {object} models.SuccessResponse{data=[]models.Medication}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/medications [get]
+func (h *handler) HandleListMedications(w http.ResponseWriter, r *http.Request) {
+	query := models.MedicationQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+		Status:    r.URL.Query().Get("status"),
+	}
+	if err := h.validate.Struct(query); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	meds, err := h.store.Medications.List(ctx, &query)
+	if err != nil {
+		h.logger.Error("listing medications failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "medications fetched successfully",
+		Data:    meds,
+	})
+}
+
+// HandleUpdateMedication godoc
+// @Summary Update a medication
+// @Description Changes a medication's dose or schedule, or its status: put on hold, restart, complete or
+// @Description stop it. Stopping or completing it sets the stop date to today. Completed, stopped and
+// @Description entered-in-error medications can't be changed; prescribe a new one instead.
+// @Description Restarting an on-hold medication checks it against the patient's allergies and other current
+// @Description medications again; severe warnings are returned with a 409 unless an override reason is given.
+// @Tags Medications
+// @Accept json
+// @Produce json
+// @Param medicationID path string true "Medication ID"
+// @Param body body models.UpdateMedicationReq true "Changes"
+// @Success 200 {object} models.SuccessResponse{data=models.Medication}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.InteractionWarningRes
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/medication/{medicationID} [put]
+func (h *handler) HandleUpdateMedication(w http.ResponseWriter, r *http.Request) {
+	mID := chi.URLParam(r, "medicationID")
+	if err := h.validate.Var(mID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.UpdateMedicationReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.MedicationID = mID
+	if req.OverrideReason != nil {
+		reason := strings.TrimSpace(*req.OverrideReason)
+		req.OverrideReason = &reason
+	}
+
+	if req.Empty() {
+		badRequestResponse(w, r)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if req.Status != nil && *req.Status == clinical.MedicationActive {
+		if !h.checkRestart(ctx, w, r, &req) {
+			return
+		}
+	}
+
+	med, err := h.store.Medications.Update(ctx, &req)
+	if err != nil {
+		h.logger.Info("updating medication failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrMedicationNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrMedicationTransition):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrStopBeforeStart):
+			validationErrorResponse(w, r, map[string]string{"stopDate": "stopDate can't be before startDate"})
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "medication updated successfully",
+		Data:    med,
+	})
+}
+
+// checkRestart checks an on-hold medication being restarted by req against
+// the patient's allergies and other current medications, as when it was
+// prescribed, and sets the warnings on req. It writes the response and
+// returns false when the medication can't be restarted.
+func (h *handler) checkRestart(ctx context.Context, w http.ResponseWriter, r *http.Request, req *models.UpdateMedicationReq) bool {
+	current, err := h.store.Medications.Get(ctx, req.MedicationID)
+	if err != nil {
+		h.logger.Info("reading medication failed", zap.Error(err))
+		if errors.Is(err, store.ErrMedicationNotFound) {
+			notFoundError(w, r)
+		} else {
+			serverErrorResponse(w, r)
+		}
+		return false
+	}
+	if current.Status != clinical.MedicationOnHold {
+		return true
+	}
+
+	warnings, err := h.checkInteractions(ctx, current.PatientID, interactionDrug(current.DrugName, current.Drug), current.ID)
+	if err != nil {
+		h.logger.Error("checking interactions failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return false
+	}
+	if hasSevereWarning(warnings) {
+		if req.OverrideReason == nil {
+			interactionWarningResponse(w, r, warnings)
+			return false
+		}
+		h.logger.Warn("severe interaction warning overridden",
+			zap.String("patientID", current.PatientID),
+			zap.String("medicationID", current.ID),
+			zap.String("drug", current.DrugName),
+			zap.String("reason", *req.OverrideReason),
+			zap.Any("warnings", warnings),
+		)
+	}
+	req.InteractionWarnings = warnings
+	return true
+}
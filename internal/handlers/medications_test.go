@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandlePrescribeMedication(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+	body := []byte(`{"drug":{"system":"rxnorm","code":"723"},"doseValue":500,"doseUnit":"mg","route":"oral","frequency":"tds","durationDays":7}`)
+	overridden := []byte(`{"drug":{"system":"rxnorm","code":"723"},"doseValue":500,"doseUnit":"mg","route":"oral","frequency":"tds","overrideReason":"Tolerated amoxicillin in 2024"}`)
+
+	noInteractions := func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+		as.On("List", mock.Anything, patientID).Return([]*models.Allergy{}, nil).Once()
+		ms.On("List", mock.Anything, &models.MedicationQuery{PatientID: patientID, Status: "current"}).Return([]*models.Medication{}, nil).Once()
+	}
+	penicillinAllergy := func(status string) []*models.Allergy {
+		return []*models.Allergy{{ID: "allergy-id", Name: "Penicillin V", VerificationStatus: status}}
+	}
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.MedicationStorer, *mocks.AllergyStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               body,
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed JSON",
+			urlID:              patientID,
+			body:               []byte(`{"doseValue":}`),
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Missing drug",
+			urlID:              patientID,
+			body:               []byte(`{"doseValue":500,"doseUnit":"mg","route":"oral","frequency":"tds"}`),
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown frequency",
+			urlID:              patientID,
+			body:               []byte(`{"drugName":"Paracetamol","doseValue":1,"doseUnit":"g","route":"oral","frequency":"sometimes"}`),
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Drug isn't a medication",
+			urlID:              patientID,
+			body:               []byte(`{"drug":{"system":"snomed","code":"256349002"},"doseValue":1,"doseUnit":"g","route":"oral","frequency":"od"}`),
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Stop before start",
+			urlID: patientID,
+			body:  []byte(`{"drugName":"Paracetamol","doseValue":1,"doseUnit":"g","route":"oral","frequency":"qid","startDate":"2026-10-10","stopDate":"2026-10-01"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				noInteractions(ms, as)
+				ms.On("Prescribe", mock.Anything, mock.Anything).Return(nil, store.ErrStopBeforeStart).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				noInteractions(ms, as)
+				ms.On("Prescribe", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "Coded drug",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				noInteractions(ms, as)
+				ms.On("Prescribe", mock.Anything, mock.MatchedBy(func(r *models.PrescribeMedicationReq) bool {
+					return r.PatientID == patientID && r.PrescriberID == doctorID &&
+						r.DrugName == "Amoxicillin" && *r.DurationDays == 7
+				})).Return(&models.Medication{ID: "medication-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Encounter of another patient",
+			urlID: patientID,
+			body:  []byte(`{"drugName":"Paracetamol","doseValue":1,"doseUnit":"g","route":"oral","frequency":"qid","encounterId":"7c9e6679-7425-40de-944b-e07fc1f90ae7"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				noInteractions(ms, as)
+				ms.On("Prescribe", mock.Anything, mock.Anything).Return(nil, store.ErrEncounterNotFound).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Severe allergy warning without override",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				as.On("List", mock.Anything, patientID).Return(penicillinAllergy("confirmed"), nil).Once()
+				ms.On("List", mock.Anything, mock.Anything).Return([]*models.Medication{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Severe allergy warning overridden",
+			urlID: patientID,
+			body:  overridden,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				as.On("List", mock.Anything, patientID).Return(penicillinAllergy("confirmed"), nil).Once()
+				ms.On("List", mock.Anything, mock.Anything).Return([]*models.Medication{}, nil).Once()
+				ms.On("Prescribe", mock.Anything, mock.MatchedBy(func(r *models.PrescribeMedicationReq) bool {
+					return len(r.InteractionWarnings) == 1 && r.InteractionWarnings[0].Severity == "severe" &&
+						r.InteractionWarnings[0].SubjectID == "allergy-id" && r.OverrideReason == "Tolerated amoxicillin in 2024"
+				})).Return(&models.Medication{ID: "medication-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Refuted allergy isn't checked",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				as.On("List", mock.Anything, patientID).Return(penicillinAllergy("refuted"), nil).Once()
+				ms.On("List", mock.Anything, mock.Anything).Return([]*models.Medication{}, nil).Once()
+				ms.On("Prescribe", mock.Anything, mock.MatchedBy(func(r *models.PrescribeMedicationReq) bool {
+					return len(r.InteractionWarnings) == 0
+				})).Return(&models.Medication{ID: "medication-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Moderate warning doesn't need an override",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				as.On("List", mock.Anything, patientID).Return([]*models.Allergy{}, nil).Once()
+				ms.On("List", mock.Anything, mock.Anything).Return([]*models.Medication{
+					{ID: "current-id", DrugName: "Amoxicillin", Drug: &models.CodedSubstance{System: "rxnorm", Code: "723"}},
+				}, nil).Once()
+				ms.On("Prescribe", mock.Anything, mock.MatchedBy(func(r *models.PrescribeMedicationReq) bool {
+					return len(r.InteractionWarnings) == 1 && r.InteractionWarnings[0].Severity == "moderate"
+				})).Return(&models.Medication{ID: "medication-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Listing allergies fails",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				as.On("List", mock.Anything, patientID).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "DB error",
+			urlID: patientID,
+			body:  body,
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				noInteractions(ms, as)
+				ms.On("Prescribe", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := mocks.NewMedicationStorer(t)
+			as := mocks.NewAllergyStorer(t)
+			tt.mockSetup(ms, as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Medications: ms, Allergy: as},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/medications", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandlePrescribeMedication(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleListMedications(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		status             string
+		mockSetup          func(*mocks.MedicationStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Unknown status",
+			status:             "paused",
+			mockSetup:          func(ms *mocks.MedicationStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Current medications",
+			status: "current",
+			mockSetup: func(ms *mocks.MedicationStorer) {
+				ms.On("List", mock.Anything, &models.MedicationQuery{PatientID: patientID, Status: "current"}).
+					Return([]*models.Medication{{ID: "medication-id"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "DB error",
+			mockSetup: func(ms *mocks.MedicationStorer) {
+				ms.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := mocks.NewMedicationStorer(t)
+			tt.mockSetup(ms)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Medications: ms},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+patientID+"/medications?status="+tt.status, "patientID", patientID)
+
+			rr := httptest.NewRecorder()
+			h.HandleListMedications(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleUpdateMedication(t *testing.T) {
+	medicationID := "550e8400-e29b-41d4-a716-446655440000"
+	patientID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	onHold := func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+		ms.On("Get", mock.Anything, medicationID).Return(&models.Medication{
+			ID: medicationID, PatientID: patientID, DrugName: "Amoxicillin 500 mg capsules", Status: "on-hold",
+		}, nil).Once()
+		as.On("List", mock.Anything, patientID).Return([]*models.Allergy{
+			{ID: "allergy-id", Name: "Penicillin V", VerificationStatus: "confirmed"},
+		}, nil).Once()
+		ms.On("List", mock.Anything, &models.MedicationQuery{PatientID: patientID, Status: "current"}).
+			Return([]*models.Medication{{ID: medicationID, DrugName: "Amoxicillin 500 mg capsules", Status: "on-hold"}}, nil).Once()
+	}
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.MedicationStorer, *mocks.AllergyStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Medication UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"status":"stopped"}`),
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Empty update",
+			urlID:              medicationID,
+			body:               []byte(`{}`),
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Negative dose",
+			urlID:              medicationID,
+			body:               []byte(`{"doseValue":-5}`),
+			mockSetup:          func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Stopped with a reason",
+			urlID: medicationID,
+			body:  []byte(`{"status":"stopped","stopReason":"Rash after second dose"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				ms.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateMedicationReq) bool {
+					return r.MedicationID == medicationID && *r.Status == "stopped" && *r.StopReason == "Rash after second dose"
+				})).Return(&models.Medication{ID: medicationID, Status: "stopped"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Already stopped",
+			urlID: medicationID,
+			body:  []byte(`{"status":"active"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				ms.On("Get", mock.Anything, medicationID).Return(&models.Medication{ID: medicationID, Status: "stopped"}, nil).Once()
+				ms.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrMedicationTransition).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Active medication isn't checked again",
+			urlID: medicationID,
+			body:  []byte(`{"status":"active"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				ms.On("Get", mock.Anything, medicationID).Return(&models.Medication{ID: medicationID, PatientID: patientID, Status: "active"}, nil).Once()
+				ms.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateMedicationReq) bool {
+					return r.InteractionWarnings == nil
+				})).Return(&models.Medication{ID: medicationID, Status: "active"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Restarted with a severe warning",
+			urlID:              medicationID,
+			body:               []byte(`{"status":"active"}`),
+			mockSetup:          onHold,
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Restarted with a severe warning overridden",
+			urlID: medicationID,
+			body:  []byte(`{"status":"active","overrideReason":"Tolerated amoxicillin in 2024"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				onHold(ms, as)
+				ms.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateMedicationReq) bool {
+					// the medication itself isn't a duplicate of the restart
+					return len(r.InteractionWarnings) == 1 && r.InteractionWarnings[0].SubjectID == "allergy-id" &&
+						*r.OverrideReason == "Tolerated amoxicillin in 2024"
+				})).Return(&models.Medication{ID: medicationID, Status: "active"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Restarting a missing medication",
+			urlID: medicationID,
+			body:  []byte(`{"status":"active"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				ms.On("Get", mock.Anything, medicationID).Return(nil, store.ErrMedicationNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "Medication not found",
+			urlID: medicationID,
+			body:  []byte(`{"frequency":"bd"}`),
+			mockSetup: func(ms *mocks.MedicationStorer, as *mocks.AllergyStorer) {
+				ms.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrMedicationNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := mocks.NewMedicationStorer(t)
+			as := mocks.NewAllergyStorer(t)
+			tt.mockSetup(ms, as)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Medications: ms, Allergy: as},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/medication/"+tt.urlID, "medicationID", tt.urlID)
+
+			rr := httptest.NewRecorder()
+			h.HandleUpdateMedication(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// HandleMergePatients godoc
+// @Summary      Merge duplicate patients
+// @Description  Moves diagnoses, conditions, allergies, medications, vitals, encounters, appointments, notes, lab results, immunizations, family history and referrals from the source patient to the target,
+// @Description  moves the source's social history when the target has none, copies the listed demographics from the source
+// @Description  and keeps the source as a redirecting tombstone. Only doctors can merge patients.
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.MergePatientReq  true  "Merge request"
+// @Success      201   {object}  models.SuccessResponse
+// @Failure      400   {object}  models.FailureResponse
+// @Failure      403   {object}  models.FailureResponse
+// @Failure      404   {object}  models.FailureResponse
+// @Failure      409   {object}  models.FailureResponse
+// @Failure      422   {object}  models.FailureResponse
+// @Failure      500   {object}  models.FailureResponse
+// @Router       /v1/patient/merge [post]
+func (h *handler) HandleMergePatients(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromCtx(r)
+
+	var req models.MergePatientReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.MergedByID = user.ID
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	merge, err := h.store.Patient.Merge(ctx, &req)
+	if err != nil {
+		h.logger.Error("patient merge failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrAlreadyMerged):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	h.logger.Info("patients merged successfully",
+		zap.String("source", merge.SourceID),
+		zap.String("target", merge.TargetID))
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "patients merged successfully",
+		Data:    merge,
+	})
+}
+
+// HandleUnmergePatients godoc
+// @Summary      Reverse a patient merge
+// @Description  Moves the records listed in the merge manifest back to the source patient,
+// @Description  restores the target's overwritten demographics and reactivates the source. Only doctors can unmerge patients.
+// @Tags         Patients
+// @Produce      json
+// @Param        mergeID  path      string  true  "Merge ID (UUID)"
+// @Success      200      {object}  models.SuccessResponse
+// @Failure      400      {object}  models.FailureResponse
+// @Failure      403      {object}  models.FailureResponse
+// @Failure      404      {object}  models.FailureResponse
+// @Failure      409      {object}  models.FailureResponse
+// @Failure      500      {object}  models.FailureResponse
+// @Router       /v1/patient/merge/{mergeID}/unmerge [post]
+func (h *handler) HandleUnmergePatients(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromCtx(r)
+
+	req := models.UnmergePatientReq{
+		MergeID:      chi.URLParam(r, "mergeID"),
+		UnmergedByID: user.ID,
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	merge, err := h.store.Patient.Unmerge(ctx, &req)
+	if err != nil {
+		h.logger.Error("patient unmerge failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrMergeNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrAlreadyUnmerged), errors.Is(err, store.ErrMergeOutOfSync):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	h.logger.Info("patients unmerged successfully", zap.String("merge id", merge.ID))
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "patients unmerged successfully",
+		Data:    merge,
+	})
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleMergePatients(t *testing.T) {
+	sourceID := "550e8400-e29b-41d4-a716-446655440000"
+	targetID := "123e4567-e89b-12d3-a456-426614174000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	body, _ := json.Marshal(models.MergePatientReq{
+		SourceID:       sourceID,
+		TargetID:       targetID,
+		KeepFromSource: []string{"contactNo"},
+	})
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.PatientStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Malformed JSON",
+			body:               []byte(`{invalid-json}`),
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Source equals target",
+			body:               []byte(`{"sourceId":"` + sourceID + `","targetId":"` + sourceID + `"}`),
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown demographic field",
+			body:               []byte(`{"sourceId":"` + sourceID + `","targetId":"` + targetID + `","keepFromSource":["bloodGroup"]}`),
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Patient not found",
+			body: body,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Merge", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Already merged",
+			body: body,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Merge", mock.Anything, mock.Anything).Return(nil, store.ErrAlreadyMerged)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "DB Error",
+			body: body,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Merge", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "Success",
+			body: body,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Merge", mock.Anything, mock.MatchedBy(func(r *models.MergePatientReq) bool {
+					return r.SourceID == sourceID && r.TargetID == targetID && r.MergedByID == userID
+				})).Return(&models.PatientMerge{SourceID: sourceID, TargetID: targetID}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := mocks.NewPatientStorer(t)
+			tt.mockSetup(ps)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Patient: ps},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/patient/merge", bytes.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleMergePatients(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleUnmergePatients(t *testing.T) {
+	mergeID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		mockSetup          func(*mocks.PatientStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Merge not found",
+			urlID: mergeID,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Unmerge", mock.Anything, mock.Anything).Return(nil, store.ErrMergeNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "Already unmerged",
+			urlID: mergeID,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Unmerge", mock.Anything, mock.Anything).Return(nil, store.ErrAlreadyUnmerged)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "DB Error",
+			urlID: mergeID,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Unmerge", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "Success",
+			urlID: mergeID,
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Unmerge", mock.Anything, mock.MatchedBy(func(r *models.UnmergePatientReq) bool {
+					return r.MergeID == mergeID && r.UnmergedByID == userID
+				})).Return(&models.PatientMerge{ID: mergeID}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := mocks.NewPatientStorer(t)
+			tt.mockSetup(ps)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Patient: ps},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, nil, "/v1/patient/merge/"+tt.urlID+"/unmerge", "mergeID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: userID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleUnmergePatients(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
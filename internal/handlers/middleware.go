@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
 	dto "github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
 	"go.uber.org/zap"
@@ -21,10 +22,34 @@ func (h *handler) RequirePaginate(next http.Handler) http.Handler {
 
 		var err error
 		var paginate dto.Paginate
-		paginate.Page, err = strconv.ParseInt(page, 10, 64)
-		if err != nil {
-			badRequestResponse(w, r)
-			return
+		paginate.SearchMode = query.Get("searchMode")
+
+		// the presence of the cursor parameter selects keyset pagination
+		_, paginate.UseCursor = query["cursor"]
+		if paginate.UseCursor {
+			paginate.Cursor = query.Get("cursor")
+			if paginate.Cursor != "" {
+				var after dto.Cursor
+				if err := helpers.DecodeCursor(paginate.Cursor, &after); err != nil || after.ID == "" {
+					badRequestResponse(w, r)
+					return
+				}
+				paginate.After = &after
+			}
+
+			if withCount := query.Get("withCount"); withCount != "" {
+				paginate.WithCount, err = strconv.ParseBool(withCount)
+				if err != nil {
+					badRequestResponse(w, r)
+					return
+				}
+			}
+		} else {
+			paginate.Page, err = strconv.ParseInt(page, 10, 64)
+			if err != nil {
+				badRequestResponse(w, r)
+				return
+			}
 		}
 
 		paginate.PageSize, err = strconv.ParseInt(pageSize, 10, 64)
@@ -43,13 +68,123 @@ func (h *handler) RequirePaginate(next http.Handler) http.Handler {
 		h.logger.Debug("parsed pagination data",
 			zap.Int64("page", paginate.Page),
 			zap.Int64("pagesize", paginate.PageSize),
-			zap.String("search term", paginate.SearchTerm))
+			zap.Bool("cursor mode", paginate.UseCursor),
+			zap.String("search term", paginate.SearchTerm),
+			zap.String("search mode", paginate.SearchMode))
 
 		pCtx := context.WithValue(r.Context(), paginateCtx, &paginate)
 		next.ServeHTTP(w, r.WithContext(pCtx))
 	})
 }
 
+// RequirePatientFilter parses the structured patient list filters and
+// ordering from the query string. All of them are optional.
+func (h *handler) RequirePatientFilter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		filter := dto.PatientFilter{
+			Gender:       query.Get("gender"),
+			RegisteredBy: query.Get("registeredBy"),
+			SortBy:       query.Get("sortBy"),
+			SortDir:      query.Get("sortDir"),
+		}
+
+		var err error
+		if filter.MinAge, err = parseOptionalInt(query.Get("minAge")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.MaxAge, err = parseOptionalInt(query.Get("maxAge")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.DOBFrom, err = parseOptionalDate(query.Get("dobFrom")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.DOBTo, err = parseOptionalDate(query.Get("dobTo")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.CreatedFrom, err = parseOptionalDate(query.Get("createdFrom")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.CreatedTo, err = parseOptionalDate(query.Get("createdTo")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.HasAllergy, err = parseOptionalBool(query.Get("hasAllergy")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.HasDiagnosis, err = parseOptionalBool(query.Get("hasDiagnosis")); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+
+		if err := h.validate.Struct(filter); err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+
+		if filter.MinAge != nil && filter.MaxAge != nil && *filter.MinAge > *filter.MaxAge {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.DOBFrom != nil && filter.DOBTo != nil && filter.DOBFrom.After(*filter.DOBTo) {
+			badRequestResponse(w, r)
+			return
+		}
+		if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+			badRequestResponse(w, r)
+			return
+		}
+
+		h.logger.Debug("parsed patient filter",
+			zap.String("gender", filter.Gender),
+			zap.String("sort by", filter.SortBy),
+			zap.String("sort dir", filter.SortDir))
+
+		fCtx := context.WithValue(r.Context(), patientFilterCtx, &filter)
+		next.ServeHTTP(w, r.WithContext(fCtx))
+	})
+}
+
+func parseOptionalInt(s string) (*int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func parseOptionalBool(s string) (*bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func parseOptionalDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
 func (h *handler) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cookie, err := r.Cookie("medibridge-token")
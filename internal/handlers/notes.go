@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/notes"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// HandleListNoteTemplates godoc
+// @Summary List note templates
+// @Description Lists the templates a note can be started from, with the prompts they prefill each SOAP section with.
+// @Tags Notes
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=[]notes.Template}
+// @Router /v1/note-templates [get]
+func (h *handler) HandleListNoteTemplates(w http.ResponseWriter, r *http.Request) {
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "note templates fetched successfully",
+		Data:    notes.Templates(),
+	})
+}
+
+// HandleCreateNote godoc
+// @Summary Start a clinical note
+// @Description Starts a draft SOAP note about the patient, authored by the signed in doctor. A template
+// @Description prefills the sections left blank. The note may be written in one of the patient's
+// @Description encounters. Drafts are only visible to their author until they are signed.
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.CreateNoteReq true "Note"
+// @Success 201 {object} models.SuccessResponse{data=models.ClinicalNote}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/notes [post]
+func (h *handler) HandleCreateNote(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.CreateNoteReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.AuthorID = getUserFromCtx(r).ID
+	req.Title = strings.TrimSpace(req.Title)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	if req.TemplateID != "" {
+		tmpl, ok := notes.Lookup(req.TemplateID)
+		if !ok {
+			validationErrorResponse(w, r, map[string]string{"templateId": "templateId is not a note template"})
+			return
+		}
+		sections := tmpl.Apply(req.Sections())
+		req.Subjective, req.Objective = sections.Subjective, sections.Objective
+		req.Assessment, req.Plan = sections.Assessment, sections.Plan
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	note, err := h.store.Notes.Create(ctx, &req)
+	if err != nil {
+		h.logger.Info("starting note failed", zap.Error(err))
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
+			return
+		}
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "note started successfully",
+		Data:    note,
+	})
+}
+
+// HandleListNotes godoc
+// @Summary List a patient's clinical notes
+// @Description Lists the patient's notes with their amendments, the most recent first. Drafts are only
+// @Description listed for their author.
+// @Tags Notes
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param status query string false "Note status" Enums(draft, signed, amended)
+// @Success 200 {object} models.SuccessResponse{data=[]models.ClinicalNote}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/notes [get]
+func (h *handler) HandleListNotes(w http.ResponseWriter, r *http.Request) {
+	query := &models.NoteQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+		ViewerID:  getUserFromCtx(r).ID,
+		Status:    r.URL.Query().Get("status"),
+	}
+	if err := h.validate.Struct(query); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.store.Notes.List(ctx, query)
+	if err != nil {
+		h.logger.Error("listing notes failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "notes fetched successfully",
+		Data:    list,
+	})
+}
+
+// HandleGetNote godoc
+// @Summary Get a clinical note
+// @Description Returns a note with its amendments. A draft is only returned to its author.
+// @Tags Notes
+// @Produce json
+// @Param noteID path string true "Note ID"
+// @Success 200 {object} models.SuccessResponse{data=models.ClinicalNote}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/note/{noteID} [get]
+func (h *handler) HandleGetNote(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "noteID")
+	if err := h.validate.Var(noteID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	note, err := h.store.Notes.Get(ctx, noteID, getUserFromCtx(r).ID)
+	if err != nil {
+		if errors.Is(err, store.ErrNoteNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching note failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "note fetched successfully",
+		Data:    note,
+	})
+}
+
+// HandleUpdateNote godoc
+// @Summary Edit a draft note
+// @Description Edits the title or sections of a draft note. Only its author can edit a draft; a signed
+// @Description note is immutable and is amended instead.
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Param noteID path string true "Note ID"
+// @Param body body models.UpdateNoteReq true "Changes"
+// @Success 200 {object} models.SuccessResponse{data=models.ClinicalNote}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/note/{noteID} [put]
+func (h *handler) HandleUpdateNote(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "noteID")
+	if err := h.validate.Var(noteID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.UpdateNoteReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.NoteID = noteID
+	req.AuthorID = getUserFromCtx(r).ID
+	if req.Title != nil {
+		*req.Title = strings.TrimSpace(*req.Title)
+	}
+
+	if req.Empty() {
+		badRequestResponse(w, r)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	note, err := h.store.Notes.Update(ctx, &req)
+	if err != nil {
+		h.logger.Info("updating note failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrNoteNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrNoteNotAuthor):
+			forbiddenErrorResponse(w, r)
+		case errors.Is(err, store.ErrNoteSigned):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "note updated successfully",
+		Data:    note,
+	})
+}
+
+// HandleSignNote godoc
+// @Summary Sign a note
+// @Description Signs a draft note of the signed in doctor. The note becomes visible to others and can no
+// @Description longer be edited or deleted, only amended. A note without any text can't be signed.
+// @Tags Notes
+// @Produce json
+// @Param noteID path string true "Note ID"
+// @Success 200 {object} models.SuccessResponse{data=models.ClinicalNote}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/note/{noteID}/sign [post]
+func (h *handler) HandleSignNote(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "noteID")
+	if err := h.validate.Var(noteID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	note, err := h.store.Notes.Sign(ctx, noteID, getUserFromCtx(r).ID)
+	if err != nil {
+		h.logger.Info("signing note failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrNoteNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrNoteNotAuthor):
+			forbiddenErrorResponse(w, r)
+		case errors.Is(err, store.ErrNoteSigned):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrNoteEmpty):
+			validationErrorResponse(w, r, map[string]string{"note": "a note needs text in at least one section to be signed"})
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "note signed successfully",
+		Data:    note,
+	})
+}
+
+// HandleAmendNote godoc
+// @Summary Amend a signed note
+// @Description Appends an amendment with a reason to a signed note, authored by the signed in doctor. The
+// @Description signed text is kept as it was; the amendment holds the sections it corrects or adds to.
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Param noteID path string true "Note ID"
+// @Param body body models.AmendNoteReq true "Amendment"
+// @Success 201 {object} models.SuccessResponse{data=models.ClinicalNote}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/note/{noteID}/amendments [post]
+func (h *handler) HandleAmendNote(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "noteID")
+	if err := h.validate.Var(noteID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.AmendNoteReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.NoteID = noteID
+	req.AuthorID = getUserFromCtx(r).ID
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	if req.Sections().Empty() {
+		validationErrorResponse(w, r, map[string]string{"sections": "an amendment needs text in at least one section"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	note, err := h.store.Notes.Amend(ctx, &req)
+	if err != nil {
+		h.logger.Info("amending note failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrNoteNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrNoteNotSigned):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "note amended successfully",
+		Data:    note,
+	})
+}
+
+// HandleDeleteNote godoc
+// @Summary Discard a draft note
+// @Description Deletes a draft note of the signed in doctor. Signed notes are part of the record and can't
+// @Description be deleted.
+// @Tags Notes
+// @Produce json
+// @Param noteID path string true "Note ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/note/{noteID} [delete]
+func (h *handler) HandleDeleteNote(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "noteID")
+	if err := h.validate.Var(noteID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.Notes.Delete(ctx, noteID, getUserFromCtx(r).ID); err != nil {
+		h.logger.Info("deleting note failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrNoteNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrNoteNotAuthor):
+			forbiddenErrorResponse(w, r)
+		case errors.Is(err, store.ErrNoteSigned):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "note deleted successfully",
+	})
+}
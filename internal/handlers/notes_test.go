@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleCreateNote(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.NoteStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"assessment":"Viral fever"}`),
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed JSON",
+			urlID:              patientID,
+			body:               []byte(`{"assessment":}`),
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Unknown template",
+			urlID:              patientID,
+			body:               []byte(`{"templateId":"no-such-template"}`),
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Template fills the blank sections",
+			urlID: patientID,
+			body:  []byte(`{"templateId":"fever","assessment":"Viral fever"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CreateNoteReq) bool {
+					return r.PatientID == patientID && r.AuthorID == doctorID &&
+						r.Assessment == "Viral fever" && strings.HasPrefix(r.Subjective, "Duration and pattern of fever")
+				})).Return(&models.ClinicalNote{ID: "note-id", Status: "draft"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Encounter of another patient",
+			urlID: patientID,
+			body:  []byte(`{"encounterId":"` + doctorID + `","assessment":"Viral fever"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrEncounterNotFound).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  []byte(`{"assessment":"Viral fever"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "DB error",
+			urlID: patientID,
+			body:  []byte(`{"assessment":"Viral fever"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := mocks.NewNoteStorer(t)
+			tt.mockSetup(ns)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Notes: ns},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/notes", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleCreateNote(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleUpdateNote(t *testing.T) {
+	noteID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.NoteStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "No changes",
+			body:               []byte(`{}`),
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Draft edited by its author",
+			body: []byte(`{"plan":"Review in two days"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateNoteReq) bool {
+					return r.NoteID == noteID && r.AuthorID == doctorID && *r.Plan == "Review in two days"
+				})).Return(&models.ClinicalNote{ID: noteID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Another doctor's draft",
+			body: []byte(`{"plan":"Review in two days"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrNoteNotAuthor).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "Signed note",
+			body: []byte(`{"plan":"Review in two days"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrNoteSigned).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Note not found",
+			body: []byte(`{"plan":"Review in two days"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrNoteNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := mocks.NewNoteStorer(t)
+			tt.mockSetup(ns)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Notes: ns},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/note/"+noteID, "noteID", noteID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleUpdateNote(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleSignNote(t *testing.T) {
+	noteID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		mockSetup          func(*mocks.NoteStorer)
+		expectedStatusCode int
+	}{
+		{
+			name: "Signed",
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Sign", mock.Anything, noteID, doctorID).
+					Return(&models.ClinicalNote{ID: noteID, Status: "signed"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Empty note",
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Sign", mock.Anything, noteID, doctorID).Return(nil, store.ErrNoteEmpty).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Already signed",
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Sign", mock.Anything, noteID, doctorID).Return(nil, store.ErrNoteSigned).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Another doctor's draft",
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Sign", mock.Anything, noteID, doctorID).Return(nil, store.ErrNoteNotAuthor).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := mocks.NewNoteStorer(t)
+			tt.mockSetup(ns)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Notes: ns},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, nil, "/v1/note/"+noteID+"/sign", "noteID", noteID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleSignNote(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleAmendNote(t *testing.T) {
+	noteID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.NoteStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Missing reason",
+			body:               []byte(`{"assessment":"Dengue fever"}`),
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "No sections",
+			body:               []byte(`{"reason":"Dengue NS1 came back positive"}`),
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Amended",
+			body: []byte(`{"reason":" Dengue NS1 came back positive ","assessment":"Dengue fever"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Amend", mock.Anything, &models.AmendNoteReq{
+					NoteID:     noteID,
+					AuthorID:   doctorID,
+					Reason:     "Dengue NS1 came back positive",
+					Assessment: "Dengue fever",
+				}).Return(&models.ClinicalNote{ID: noteID, Status: "amended"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Draft",
+			body: []byte(`{"reason":"Dengue NS1 came back positive","assessment":"Dengue fever"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Amend", mock.Anything, mock.Anything).Return(nil, store.ErrNoteNotSigned).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Note not found",
+			body: []byte(`{"reason":"Dengue NS1 came back positive","assessment":"Dengue fever"}`),
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("Amend", mock.Anything, mock.Anything).Return(nil, store.ErrNoteNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := mocks.NewNoteStorer(t)
+			tt.mockSetup(ns)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Notes: ns},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/note/"+noteID+"/amendments", "noteID", noteID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleAmendNote(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleListNotes(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		status             string
+		mockSetup          func(*mocks.NoteStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown status",
+			urlID:              patientID,
+			status:             "deleted",
+			mockSetup:          func(ns *mocks.NoteStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Listed for the signed-in doctor",
+			urlID:  patientID,
+			status: "signed",
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("List", mock.Anything, &models.NoteQuery{
+					PatientID: patientID,
+					ViewerID:  doctorID,
+					Status:    "signed",
+				}).Return([]*models.ClinicalNote{{ID: "note-id"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "DB error",
+			urlID: patientID,
+			mockSetup: func(ns *mocks.NoteStorer) {
+				ns.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := mocks.NewNoteStorer(t)
+			tt.mockSetup(ns)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Notes: ns},
+				validate: validator.New(),
+			}
+
+			url := "/v1/patient/" + tt.urlID + "/notes"
+			if tt.status != "" {
+				url += "?status=" + tt.status
+			}
+			req := helpers.InjectURLParam(http.MethodGet, nil, url, "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleListNotes(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
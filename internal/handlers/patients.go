@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/vaidik-bajpai/medibridge/internal/helpers"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
 	"go.uber.org/zap"
 )
 
@@ -21,13 +24,15 @@ var (
 
 // HandleRegisterPatient godoc
 // @Summary      Register a new patient
-// @Description  Registers a new patient with the provided details.
+// @Description  Registers a new patient with the provided details. Probable duplicates of existing
+// @Description  patients are rejected with a 409 listing the candidates unless "force" is set.
 // @Tags         Patients
 // @Accept       json
 // @Produce      json
 // @Param        body  body      models.RegPatientReq  true  "Patient registration data"
 // @Success      201   {object}  models.SuccessResponse
 // @Failure      400   {object}  models.FailureResponse
+// @Failure      409   {object}  models.DuplicatePatientRes
 // @Failure      422   {object}  models.FailureResponse
 // @Failure      500   {object}  models.FailureResponse
 // @Router       /v1/patient [post]
@@ -54,6 +59,25 @@ func (h *handler) HandleRegisterPatient(w http.ResponseWriter, r *http.Request)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if !req.Force {
+		candidates, err := h.store.Patient.FindDuplicates(ctx, &req)
+		if err != nil {
+			h.logger.Error("duplicate check failed", zap.Error(err))
+			serverErrorResponse(w, r)
+			return
+		}
+
+		if len(candidates) > 0 {
+			h.logger.Info("probable duplicate patient", zap.Int("candidates", len(candidates)))
+			duplicatePatientResponse(w, r, candidates)
+			return
+		}
+	} else {
+		h.logger.Warn("duplicate check overridden",
+			zap.String("patient name", req.FullName),
+			zap.String("user id", user.ID))
+	}
+
 	p, err := h.store.Patient.Create(ctx, &req)
 	if err != nil {
 		render.Status(r, http.StatusInternalServerError)
@@ -107,6 +131,7 @@ func (h *handler) HandleUpdatePatientDetails(w http.ResponseWriter, r *http.Requ
 
 	req.Sanitize()
 	req.ID = patientID
+	req.EditedByID = userID(r)
 
 	if err := h.validate.Struct(req); err != nil {
 		unprocessableEntityResponse(w, r)
@@ -176,24 +201,75 @@ func (h *handler) HandleDeletePatientDetails(w http.ResponseWriter, r *http.Requ
 
 // HandleListPatients godoc
 // @Summary      List patients
-// @Description  Lists all registered patients with optional pagination and search.
+// @Description  Lists registered patients with pagination, filters and sorting. The search term
+// @Description  matches the patient's name, MRN or phone number; the fuzzy and phonetic search modes match
+// @Description  misspelt or alike-sounding names only and rank the results by relevance. Sending the cursor parameter switches
+// @Description  to keyset pagination ordered by registration time, with next/prev links in the Link header.
 // @Tags         Patients
 // @Accept       json
 // @Produce      json
-// @Param        page        query     int     false  "Page number"
-// @Param        pageSize    query     int     false  "Page size"
-// @Param        searchTerm  query     string  false  "Search term (e.g., name or email)"
-// @Success      200         {object}  models.SuccessResponse
-// @Failure      400         {object}  models.FailureResponse
-// @Failure      500         {object}  models.FailureResponse
+// @Param        page          query     int     false  "Page number"
+// @Param        pageSize      query     int     false  "Page size"
+// @Param        searchTerm    query     string  false  "Search term (name, MRN or phone)"
+// @Param        searchMode    query     string  false  "Name matching mode"  Enums(contains, fuzzy, phonetic)
+// @Param        cursor        query     string  false  "Opaque cursor; send it empty for the first page of cursor mode"
+// @Param        withCount     query     bool    false  "Include an estimated total in cursor mode"
+// @Param        gender        query     string  false  "Gender"  Enums(MALE, FEMALE, OTHER)
+// @Param        minAge        query     int     false  "Minimum age in years"
+// @Param        maxAge        query     int     false  "Maximum age in years"
+// @Param        dobFrom       query     string  false  "Earliest date of birth (YYYY-MM-DD)"
+// @Param        dobTo         query     string  false  "Latest date of birth (YYYY-MM-DD)"
+// @Param        registeredBy  query     string  false  "ID of the registering user"
+// @Param        createdFrom   query     string  false  "Registered on or after (YYYY-MM-DD)"
+// @Param        createdTo     query     string  false  "Registered on or before (YYYY-MM-DD)"
+// @Param        hasAllergy    query     bool    false  "Only patients with (true) or without (false) allergies"
+// @Param        hasDiagnosis  query     bool    false  "Only patients with (true) or without (false) diagnoses"
+// @Param        sortBy        query     string  false  "Sort field"  Enums(name, age, dob, createdAt, updatedAt)
+// @Param        sortDir       query     string  false  "Sort direction"  Enums(asc, desc)
+// @Success      200           {object}  models.SuccessResponse
+// @Header       200           {string}  Link  "next and prev page URLs in cursor mode"
+// @Failure      400           {object}  models.FailureResponse
+// @Failure      500           {object}  models.FailureResponse
 // @Router       /v1/patient [get]
 func (h *handler) HandleListPatients(w http.ResponseWriter, r *http.Request) {
 	paginate := getPaginateFromContext(r)
+	filter := getPatientFilterFromContext(r)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	list, err := h.store.Patient.List(ctx, paginate)
+	if paginate.UseCursor {
+		// keyset pagination only walks the (createdAt, id) index, so it cannot
+		// follow another sort field or a relevance ranking
+		if filter != nil && filter.SortBy != "" && filter.SortBy != "createdAt" {
+			badRequestResponse(w, r)
+			return
+		}
+		if paginate.SearchMode == "fuzzy" || paginate.SearchMode == "phonetic" {
+			badRequestResponse(w, r)
+			return
+		}
+
+		list, err := h.store.Patient.ListByCursor(ctx, paginate, filter)
+		if err != nil {
+			h.logger.Error("cursor list failed", zap.Error(err))
+			serverErrorResponse(w, r)
+			return
+		}
+
+		if link := cursorLinkHeader(r, list.Cursor); link != "" {
+			w.Header().Set("Link", link)
+		}
+
+		helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+			Status:  http.StatusOK,
+			Message: "patients fetched successfully",
+			Data:    list,
+		})
+		return
+	}
+
+	list, err := h.store.Patient.List(ctx, paginate, filter)
 	if err != nil {
 		log.Println(err)
 		if ok := errors.Is(err, ErrPatientNotFound); ok {
@@ -211,6 +287,31 @@ func (h *handler) HandleListPatients(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// cursorLinkHeader builds an RFC 8288 Link header pointing at the next and
+// previous pages, keeping every other query parameter of the request.
+func cursorLinkHeader(r *http.Request, meta *models.CursorMetadata) string {
+	if meta == nil {
+		return ""
+	}
+
+	link := func(cursor, rel string) string {
+		u := *r.URL
+		q := u.Query()
+		q.Set("cursor", cursor)
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+	}
+
+	var links []string
+	if meta.Next != "" {
+		links = append(links, link(meta.Next, "next"))
+	}
+	if meta.Previous != "" {
+		links = append(links, link(meta.Previous, "prev"))
+	}
+	return strings.Join(links, ", ")
+}
+
 // HandleGetPatient godoc
 // @Summary      Get patient details
 // @Description  Retrieves a patient's details using their patient ID.
@@ -219,6 +320,7 @@ func (h *handler) HandleListPatients(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        patientID  path      string  true  "Patient ID (UUID)"
 // @Success      200        {object}  models.SuccessResponse
+// @Success      307        {object}  models.SuccessResponse  "Patient was merged; Location points to the surviving record"
 // @Failure      400        {object}  models.FailureResponse
 // @Failure      404        {object}  models.FailureResponse
 // @Failure      500        {object}  models.FailureResponse
@@ -235,6 +337,16 @@ func (h *handler) HandleGetPatient(w http.ResponseWriter, r *http.Request) {
 	record, err := h.store.Patient.Get(ctx, patientID)
 	if err != nil {
 		log.Println(err)
+		var merged *store.PatientMergedError
+		if errors.As(err, &merged) {
+			w.Header().Set("Location", "/v1/patient/"+merged.TargetID)
+			helpers.WriteJSONResponse(w, r, http.StatusTemporaryRedirect, models.SuccessResponse{
+				Status:  http.StatusTemporaryRedirect,
+				Message: "patient record was merged into another record",
+				Data:    map[string]string{"mergedInto": merged.TargetID},
+			})
+			return
+		}
 		if ok := errors.Is(err, ErrPatientNotFound); ok {
 			notFoundError(w, r)
 			return
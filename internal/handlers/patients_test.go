@@ -34,6 +34,12 @@ func TestHandleRegisterPatient(t *testing.T) {
 	}
 
 	body, _ := json.Marshal(reqBody)
+
+	forcedReq := reqBody
+	forcedReq.Force = true
+	forcedReq.ForceReason = "twins"
+	forcedBody, _ := json.Marshal(forcedReq)
+
 	userID := "123e4567-e89b-12d3-a456-426614174000"
 
 	tests := []struct {
@@ -50,12 +56,50 @@ func TestHandleRegisterPatient(t *testing.T) {
 				ID: userID,
 			},
 			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("FindDuplicates", mock.Anything, mock.Anything).Return(nil, nil)
 				ps.On("Create", mock.Anything, mock.MatchedBy(func(p *models.RegPatientReq) bool {
 					return p.FullName == "Alice Doe" && p.RegByID == userID
 				})).Return(&models.Patient{ID: "some-id"}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "Probable Duplicate",
+			body: body,
+			ctxUser: &models.UserModel{
+				ID: userID,
+			},
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("FindDuplicates", mock.Anything, mock.Anything).Return([]*models.DuplicateCandidate{
+					{Patient: models.ListPatientItem{ID: "existing-id", FullName: "Alice Doe"}, Score: 0.95},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Forced Registration Skips Duplicate Check",
+			body: forcedBody,
+			ctxUser: &models.UserModel{
+				ID: userID,
+			},
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("Create", mock.Anything, mock.MatchedBy(func(p *models.RegPatientReq) bool {
+					return p.Force && p.ForceReason == "twins" && p.RegByID == userID
+				})).Return(&models.Patient{ID: "some-id"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Duplicate Check Error",
+			body: body,
+			ctxUser: &models.UserModel{
+				ID: userID,
+			},
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("FindDuplicates", mock.Anything, mock.Anything).Return(nil, errors.New("db failure"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
 		{
 			name: "Invalid JSON",
 			body: []byte(`{invalid-json}`),
@@ -81,6 +125,7 @@ func TestHandleRegisterPatient(t *testing.T) {
 				ID: userID,
 			},
 			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("FindDuplicates", mock.Anything, mock.Anything).Return(nil, nil)
 				ps.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db failure"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -286,6 +331,174 @@ func TestHandleDeletePatientDetails(t *testing.T) {
 	}
 }
 
+func TestHandleListPatients(t *testing.T) {
+	list := &models.ListPatientRes{
+		Patients: []*models.ListPatientItem{{ID: "patient-id", FullName: "Alice Doe"}},
+		Meta:     &models.ListPatientMetadata{CurrentPage: 1, PageSize: 10, TotalItems: 1, TotalPages: 1},
+	}
+
+	after := models.Cursor{CreatedAt: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC), ID: "patient-id"}
+	cursor, _ := helpers.EncodeCursor(after)
+
+	cursorList := &models.ListPatientRes{
+		Patients: list.Patients,
+		Cursor:   &models.CursorMetadata{PageSize: 10, Next: "next-cursor", HasNext: true},
+	}
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.PatientStorer)
+		expectedStatusCode int
+		expectedLink       string
+	}{
+		{
+			name:  "Success Without Filters",
+			query: "page=1&pageSize=10",
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("List", mock.Anything, mock.Anything, mock.MatchedBy(func(f *models.PatientFilter) bool {
+					return f.Gender == "" && f.MinAge == nil && f.HasAllergy == nil && f.SortBy == ""
+				})).Return(list, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Success With Filters",
+			query: "page=1&pageSize=10&searchTerm=MB-2026&gender=FEMALE&minAge=18&maxAge=65&dobFrom=1960-01-01&hasAllergy=true&sortBy=name&sortDir=asc",
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("List", mock.Anything, mock.MatchedBy(func(p *models.Paginate) bool {
+					return p.SearchTerm == "MB-2026"
+				}), mock.MatchedBy(func(f *models.PatientFilter) bool {
+					return f.Gender == "FEMALE" &&
+						*f.MinAge == 18 && *f.MaxAge == 65 &&
+						f.DOBFrom.Equal(time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+						*f.HasAllergy && f.SortBy == "name" && f.SortDir == "asc"
+				})).Return(list, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Phonetic Search",
+			query: "page=1&pageSize=10&searchTerm=Lakshmi&searchMode=phonetic",
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("List", mock.Anything, mock.MatchedBy(func(p *models.Paginate) bool {
+					return p.SearchTerm == "Lakshmi" && p.SearchMode == "phonetic"
+				}), mock.Anything).Return(list, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Unknown Search Mode",
+			query:              "page=1&pageSize=10&searchTerm=ram&searchMode=regex",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Cursor Mode Rejects Ranked Search",
+			query:              "cursor=&pageSize=10&searchTerm=ram&searchMode=fuzzy",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid Gender",
+			query:              "page=1&pageSize=10&gender=unknown",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Min Age Above Max Age",
+			query:              "page=1&pageSize=10&minAge=60&maxAge=30",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed Date",
+			query:              "page=1&pageSize=10&createdFrom=01-01-2024",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Sort Field Not Allowed",
+			query:              "page=1&pageSize=10&sortBy=address",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "DB Error",
+			query: "page=1&pageSize=10",
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "Cursor Mode First Page",
+			query: "cursor=&pageSize=10",
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("ListByCursor", mock.Anything, mock.MatchedBy(func(p *models.Paginate) bool {
+					return p.UseCursor && p.After == nil && p.Page == 0
+				}), mock.Anything).Return(cursorList, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedLink:       `</v1/patient?cursor=next-cursor&pageSize=10>; rel="next"`,
+		},
+		{
+			name:  "Cursor Mode With Cursor",
+			query: "cursor=" + cursor + "&pageSize=10&withCount=true",
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("ListByCursor", mock.Anything, mock.MatchedBy(func(p *models.Paginate) bool {
+					return p.After != nil && p.After.ID == after.ID && p.After.CreatedAt.Equal(after.CreatedAt) && p.WithCount
+				}), mock.Anything).Return(cursorList, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Malformed Cursor",
+			query:              "cursor=not-a-cursor&pageSize=10",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Cursor Mode Rejects Sort Field",
+			query:              "cursor=&pageSize=10&sortBy=name",
+			mockSetup:          func(ps *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Cursor Mode DB Error",
+			query: "cursor=&pageSize=10",
+			mockSetup: func(ps *mocks.PatientStorer) {
+				ps.On("ListByCursor", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := mocks.NewPatientStorer(t)
+			tt.mockSetup(ps)
+			l, _ := zap.NewDevelopment()
+
+			h := &handler{
+				logger:   l,
+				store:    &store.Store{Patient: ps},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/patient?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			h.RequirePaginate(h.RequirePatientFilter(http.HandlerFunc(h.HandleListPatients))).ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedLink != "" {
+				require.Equal(t, tt.expectedLink, rr.Header().Get("Link"))
+			}
+		})
+	}
+}
+
 func ptrToString(s string) *string {
 	return &s
 }
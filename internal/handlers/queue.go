@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+	"github.com/vaidik-bajpai/medibridge/internal/queue"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// queueHeartbeat is how often an idle queue stream is written to, so that
+// proxies don't close it.
+const queueHeartbeat = 15 * time.Second
+
+// HandleCheckIn godoc
+// @Summary Check a patient in to the walk-in queue
+// @Description Adds a registered patient to today's walk-in queue. The priority defaults to standard until
+// @Description the patient is triaged. A patient can't check in again until their entry is done.
+// @Tags Queue
+// @Accept json
+// @Produce json
+// @Param body body models.CheckInReq true "Check-in"
+// @Success 201 {object} models.SuccessResponse{data=models.QueueEntry}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/queue [post]
+func (h *handler) HandleCheckIn(w http.ResponseWriter, r *http.Request) {
+	var req models.CheckInReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.CheckedInByID = userID(r)
+	req.Complaint = strings.TrimSpace(req.Complaint)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry, err := h.store.Queue.CheckIn(ctx, &req)
+	if err != nil {
+		h.logger.Info("checking in failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrAlreadyQueued):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+	h.events.Publish()
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "patient checked in successfully",
+		Data:    entry,
+	})
+}
+
+// HandleTriage godoc
+// @Summary Triage a patient in the queue
+// @Description Sets the priority of a patient waiting or in consultation. Waiting patients are seen by
+// @Description priority, then in the order they checked in.
+// @Tags Queue
+// @Accept json
+// @Produce json
+// @Param entryID path string true "Queue entry ID"
+// @Param body body models.TriageReq true "Priority"
+// @Success 200 {object} models.SuccessResponse{data=models.QueueEntry}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/queue/{entryID}/triage [put]
+func (h *handler) HandleTriage(w http.ResponseWriter, r *http.Request) {
+	entryID := chi.URLParam(r, "entryID")
+	if err := h.validate.Var(entryID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.TriageReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.EntryID = entryID
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry, err := h.store.Queue.Triage(ctx, &req)
+	if err != nil {
+		h.logger.Info("triaging failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrQueueTransition):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrQueueEntryNotFound):
+			notFoundError(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+	h.events.Publish()
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "patient triaged successfully",
+		Data:    entry,
+	})
+}
+
+// HandleUpdateQueueStatus godoc
+// @Summary Move a patient along the queue
+// @Description Moves a patient from waiting to in-consultation and on to done. A patient in consultation may
+// @Description be sent back to wait, and a waiting patient who left is marked done. Calling a patient in
+// @Description records the doctor, by default the signed in doctor.
+// @Tags Queue
+// @Accept json
+// @Produce json
+// @Param entryID path string true "Queue entry ID"
+// @Param body body models.QueueStatusReq true "Status"
+// @Success 200 {object} models.SuccessResponse{data=models.QueueEntry}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/queue/{entryID}/status [put]
+func (h *handler) HandleUpdateQueueStatus(w http.ResponseWriter, r *http.Request) {
+	entryID := chi.URLParam(r, "entryID")
+	if err := h.validate.Var(entryID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.QueueStatusReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.EntryID = entryID
+	if user := getUserFromCtx(r); req.Status == queue.StatusInConsultation && req.DoctorID == "" &&
+		user != nil && user.Role == string(db.RoleDoctor) {
+		req.DoctorID = user.ID
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry, err := h.store.Queue.UpdateStatus(ctx, &req)
+	if err != nil {
+		h.logger.Info("updating queue status failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrQueueTransition):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrQueueEntryNotFound):
+			notFoundError(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+	h.events.Publish()
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "queue status updated successfully",
+		Data:    entry,
+	})
+}
+
+// HandleQueueBoard godoc
+// @Summary Get today's walk-in queue
+// @Description Returns today's queue: waiting patients in the order they'll be seen with their estimated
+// @Description wait, patients in consultation and patients done. Waits are estimated from the day's
+// @Description average consultation length and the number of doctors seeing patients.
+// @Tags Queue
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.QueueBoard}
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/queue [get]
+func (h *handler) HandleQueueBoard(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	board, err := h.store.Queue.Board(ctx, clinicToday())
+	if err != nil {
+		h.logger.Error("fetching queue board failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "queue fetched successfully",
+		Data:    board,
+	})
+}
+
+// HandleQueueStream godoc
+// @Summary Stream today's walk-in queue
+// @Description Server-Sent Events stream for reception screens. A "board" event carrying today's queue is
+// @Description sent on connecting and after every check-in, triage or status change; a comment is sent
+// @Description every 15 seconds while the queue is idle.
+// @Tags Queue
+// @Produce text/event-stream
+// @Success 200 {object} models.QueueBoard
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/queue/stream [get]
+func (h *handler) HandleQueueStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.logger.Error("streaming unsupported")
+		serverErrorResponse(w, r)
+		return
+	}
+
+	changes, unsubscribe := h.events.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sendBoard := func() {
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		board, err := h.store.Queue.Board(ctx, clinicToday())
+		if err != nil {
+			h.logger.Error("fetching queue board failed", zap.Error(err))
+			return
+		}
+		data, err := json.Marshal(board)
+		if err != nil {
+			h.logger.Error("encoding queue board failed", zap.Error(err))
+			return
+		}
+		fmt.Fprintf(w, "event: board\ndata: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	sendBoard()
+
+	heartbeat := time.NewTicker(queueHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-changes:
+			sendBoard()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/queue"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleCheckIn(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	receptionistID := "3f1c2b7a-8d4e-4b6f-9a0c-1e2d3f4a5b6c"
+
+	tests := []struct {
+		name               string
+		body               []byte
+		mockSetup          func(*mocks.QueueStorer)
+		expectedStatusCode int
+		expectPublish      bool
+	}{
+		{
+			name:               "Malformed JSON",
+			body:               []byte(`{"patientId":}`),
+			mockSetup:          func(qs *mocks.QueueStorer) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "Invalid patient UUID",
+			body:               []byte(`{"patientId":"invalid-uuid"}`),
+			mockSetup:          func(qs *mocks.QueueStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown priority",
+			body:               []byte(`{"patientId":"` + patientID + `","priority":"whenever"}`),
+			mockSetup:          func(qs *mocks.QueueStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Checked in",
+			body: []byte(`{"patientId":"` + patientID + `","complaint":"  Fever since yesterday "}`),
+			mockSetup: func(qs *mocks.QueueStorer) {
+				qs.On("CheckIn", mock.Anything, mock.MatchedBy(func(r *models.CheckInReq) bool {
+					return r.PatientID == patientID && r.CheckedInByID == receptionistID &&
+						r.Complaint == "Fever since yesterday"
+				})).Return(&models.QueueEntry{ID: "entry-id", Status: queue.StatusWaiting}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectPublish:      true,
+		},
+		{
+			name: "Already in the queue",
+			body: []byte(`{"patientId":"` + patientID + `"}`),
+			mockSetup: func(qs *mocks.QueueStorer) {
+				qs.On("CheckIn", mock.Anything, mock.Anything).Return(nil, store.ErrAlreadyQueued).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Patient not found",
+			body: []byte(`{"patientId":"` + patientID + `"}`),
+			mockSetup: func(qs *mocks.QueueStorer) {
+				qs.On("CheckIn", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "DB error",
+			body: []byte(`{"patientId":"` + patientID + `"}`),
+			mockSetup: func(qs *mocks.QueueStorer) {
+				qs.On("CheckIn", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qs := mocks.NewQueueStorer(t)
+			tt.mockSetup(qs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Queue: qs},
+				validate: validator.New(),
+				events:   queue.NewBroker(),
+			}
+			changes, unsubscribe := h.events.Subscribe()
+			defer unsubscribe()
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/queue", strings.NewReader(string(tt.body)))
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: receptionistID, Role: "receptionist"}))
+
+			rr := httptest.NewRecorder()
+			h.HandleCheckIn(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			require.Equal(t, tt.expectPublish, len(changes) == 1)
+		})
+	}
+}
+
+func TestHandleUpdateQueueStatus(t *testing.T) {
+	entryID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.QueueStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid entry UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"status":"done"}`),
+			mockSetup:          func(qs *mocks.QueueStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown status",
+			urlID:              entryID,
+			body:               []byte(`{"status":"lunch"}`),
+			mockSetup:          func(qs *mocks.QueueStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Called in by the signed in doctor",
+			urlID: entryID,
+			body:  []byte(`{"status":"in-consultation"}`),
+			mockSetup: func(qs *mocks.QueueStorer) {
+				qs.On("UpdateStatus", mock.Anything, &models.QueueStatusReq{
+					EntryID:  entryID,
+					Status:   queue.StatusInConsultation,
+					DoctorID: doctorID,
+				}).Return(&models.QueueEntry{ID: entryID, Status: queue.StatusInConsultation}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Already done",
+			urlID: entryID,
+			body:  []byte(`{"status":"waiting"}`),
+			mockSetup: func(qs *mocks.QueueStorer) {
+				qs.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil, store.ErrQueueTransition).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "Entry not found",
+			urlID: entryID,
+			body:  []byte(`{"status":"done"}`),
+			mockSetup: func(qs *mocks.QueueStorer) {
+				qs.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil, store.ErrQueueEntryNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qs := mocks.NewQueueStorer(t)
+			tt.mockSetup(qs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Queue: qs},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPut, tt.body, "/v1/queue/"+tt.urlID+"/status", "entryID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID, Role: "doctor"}))
+
+			rr := httptest.NewRecorder()
+			h.HandleUpdateQueueStatus(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleQueueStream(t *testing.T) {
+	qs := mocks.NewQueueStorer(t)
+	fetched := make(chan struct{}, 2)
+	qs.On("Board", mock.Anything, mock.Anything).
+		Return(&models.QueueBoard{Waiting: []models.QueueEntry{{ID: "entry-id"}}}, nil).
+		Run(func(mock.Arguments) { fetched <- struct{}{} }).
+		Twice()
+
+	h := &handler{
+		logger:   zap.NewNop(),
+		store:    &store.Store{Queue: qs},
+		validate: validator.New(),
+		events:   queue.NewBroker(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/v1/queue/stream", nil).WithContext(ctx)
+	rr := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		h.HandleQueueStream(rr, req)
+		close(done)
+	}()
+
+	// the board is sent on connecting and again after a change
+	<-fetched
+	h.events.Publish()
+	<-fetched
+	cancel()
+	<-done
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	require.Equal(t, 2, strings.Count(rr.Body.String(), "event: board\ndata: "))
+}
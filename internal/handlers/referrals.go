@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// HandleCreateReferral godoc
+// @Summary Refer a patient
+// @Description Refers the patient to a doctor of the clinic or to an outside facility, with the reason and
+// @Description urgency. A snapshot of the patient's record is attached for the recipient. The referral
+// @Description starts pending.
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param body body models.CreateReferralReq true "Referral"
+// @Success 201 {object} models.SuccessResponse{data=models.Referral}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 422 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/referrals [post]
+func (h *handler) HandleCreateReferral(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.CreateReferralReq
+	if err := helpers.DecodeJSON(r, &req); err != nil {
+		h.logger.Info("unprocessable entity", zap.Error(err))
+		unprocessableEntityResponse(w, r)
+		return
+	}
+
+	req.PatientID = pID
+	req.ReferredByID = getUserFromCtx(r).ID
+	req.Specialty = strings.TrimSpace(req.Specialty)
+	req.Reason = strings.TrimSpace(req.Reason)
+	req.Urgency = strings.ToLower(strings.TrimSpace(req.Urgency))
+	if req.Facility != nil {
+		req.Facility.Name = strings.TrimSpace(req.Facility.Name)
+		req.Facility.Contact = strings.TrimSpace(req.Facility.Contact)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	referral, err := h.store.Referrals.Create(ctx, &req)
+	if err != nil {
+		h.logger.Info("creating referral failed", zap.Error(err))
+		var merged *store.PatientMergedError
+		switch {
+		case errors.Is(err, store.ErrPatientNotFound):
+			notFoundError(w, r)
+		case errors.As(err, &merged):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrNotFound):
+			validationErrorResponse(w, r, map[string]string{"toDoctorId": "toDoctorId is not a user"})
+		case errors.Is(err, store.ErrRecipientNotDoctor):
+			validationErrorResponse(w, r, map[string]string{"toDoctorId": "toDoctorId is not a doctor"})
+		case errors.Is(err, store.ErrSelfReferral):
+			validationErrorResponse(w, r, map[string]string{"toDoctorId": "a doctor can't refer a patient to themselves"})
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "referral created successfully",
+		Data:    referral,
+	})
+}
+
+// HandleListReferrals godoc
+// @Summary List a patient's referrals
+// @Description Lists the referrals of the patient, newest first, without the record snapshots.
+// @Tags Referrals
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Referral}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/referrals [get]
+func (h *handler) HandleListReferrals(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	referrals, err := h.store.Referrals.List(ctx, pID)
+	if err != nil {
+		h.logger.Error("listing referrals failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "referrals fetched successfully",
+		Data:    referrals,
+	})
+}
+
+// HandleGetReferral godoc
+// @Summary Get a referral
+// @Description Returns a referral with the snapshot of the patient's record taken when it was made.
+// @Tags Referrals
+// @Produce json
+// @Param referralID path string true "Referral ID"
+// @Success 200 {object} models.SuccessResponse{data=models.Referral}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/referral/{referralID} [get]
+func (h *handler) HandleGetReferral(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "referralID")
+	if err := h.validate.Var(id, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	referral, err := h.store.Referrals.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrReferralNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching referral failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "referral fetched successfully",
+		Data:    referral,
+	})
+}
+
+// HandleReferralInbox godoc
+// @Summary Referral inbox
+// @Description Lists the referrals made to the signed in doctor, or with box=sent the ones they made,
+// @Description newest first.
+// @Tags Referrals
+// @Produce json
+// @Param box query string false "Received or sent referrals" Enums(received, sent) default(received)
+// @Param status query string false "Status" Enums(pending, accepted, declined, completed, cancelled)
+// @Param urgency query string false "Urgency" Enums(routine, urgent, emergency)
+// @Param limit query int false "Maximum number of referrals" default(50)
+// @Success 200 {object} models.SuccessResponse{data=[]models.Referral}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/referrals/inbox [get]
+func (h *handler) HandleReferralInbox(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	req := models.ReferralQuery{
+		UserID:  getUserFromCtx(r).ID,
+		Box:     query.Get("box"),
+		Status:  query.Get("status"),
+		Urgency: query.Get("urgency"),
+		Limit:   50,
+	}
+	if req.Box == "" {
+		req.Box = "received"
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			badRequestResponse(w, r)
+			return
+		}
+		req.Limit = n
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	referrals, err := h.store.Referrals.Inbox(ctx, &req)
+	if err != nil {
+		h.logger.Error("listing referral inbox failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "referrals fetched successfully",
+		Data:    referrals,
+	})
+}
+
+// HandleAcceptReferral godoc
+// @Summary Accept a referral
+// @Description Accepts a pending referral. The doctor referred to accepts it; for an outside facility the
+// @Description referring doctor records that the facility accepted it.
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Param referralID path string true "Referral ID"
+// @Param body body models.ReferralActionReq false "Note"
+// @Success 200 {object} models.SuccessResponse{data=models.Referral}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/referral/{referralID}/accept [post]
+func (h *handler) HandleAcceptReferral(w http.ResponseWriter, r *http.Request) {
+	h.transitionReferral(w, r, clinical.ReferralAccepted, "referral accepted successfully")
+}
+
+// HandleDeclineReferral godoc
+// @Summary Decline a referral
+// @Description Declines a pending referral with the reason in the note. The doctor referred to declines it;
+// @Description for an outside facility the referring doctor records that the facility declined it.
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Param referralID path string true "Referral ID"
+// @Param body body models.ReferralActionReq true "Reason for declining"
+// @Success 200 {object} models.SuccessResponse{data=models.Referral}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/referral/{referralID}/decline [post]
+func (h *handler) HandleDeclineReferral(w http.ResponseWriter, r *http.Request) {
+	h.transitionReferral(w, r, clinical.ReferralDeclined, "referral declined successfully")
+}
+
+// HandleCompleteReferral godoc
+// @Summary Complete a referral
+// @Description Completes an accepted referral once the patient was seen, with the outcome in the note.
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Param referralID path string true "Referral ID"
+// @Param body body models.ReferralActionReq false "Outcome"
+// @Success 200 {object} models.SuccessResponse{data=models.Referral}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/referral/{referralID}/complete [post]
+func (h *handler) HandleCompleteReferral(w http.ResponseWriter, r *http.Request) {
+	h.transitionReferral(w, r, clinical.ReferralCompleted, "referral completed successfully")
+}
+
+// HandleCancelReferral godoc
+// @Summary Cancel a referral
+// @Description Cancels a pending or accepted referral. Only the referring doctor can cancel it.
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Param referralID path string true "Referral ID"
+// @Param body body models.ReferralActionReq false "Reason for cancelling"
+// @Success 200 {object} models.SuccessResponse{data=models.Referral}
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/referral/{referralID}/cancel [post]
+func (h *handler) HandleCancelReferral(w http.ResponseWriter, r *http.Request) {
+	h.transitionReferral(w, r, clinical.ReferralCancelled, "referral cancelled successfully")
+}
+
+// transitionReferral moves the referral of the URL to the status, with the
+// note of the optional request body.
+func (h *handler) transitionReferral(w http.ResponseWriter, r *http.Request, status, message string) {
+	id := chi.URLParam(r, "referralID")
+	if err := h.validate.Var(id, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	var req models.ReferralActionReq
+	if r.ContentLength > 0 {
+		if err := helpers.DecodeJSON(r, &req); err != nil {
+			h.logger.Info("unprocessable entity", zap.Error(err))
+			unprocessableEntityResponse(w, r)
+			return
+		}
+	}
+
+	req.ReferralID = id
+	req.UserID = getUserFromCtx(r).ID
+	req.Status = status
+	req.Note = strings.TrimSpace(req.Note)
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+	if status == clinical.ReferralDeclined && req.Note == "" {
+		validationErrorResponse(w, r, map[string]string{"note": "note is required to decline a referral"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	referral, err := h.store.Referrals.Transition(ctx, &req)
+	if err != nil {
+		h.logger.Info("changing referral failed", zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrReferralNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrNotReferralParty):
+			forbiddenErrorResponse(w, r)
+		case errors.Is(err, store.ErrReferralTransition):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: message,
+		Data:    referral,
+	})
+}
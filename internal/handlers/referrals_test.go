@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleCreateReferral(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+	toDoctorID := "7c3e5a2b-1d4f-4e6a-9b8c-0d1e2f3a4b5c"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		mockSetup          func(*mocks.ReferralStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			body:               []byte(`{"toDoctorId":"` + toDoctorID + `","reason":"Exertional chest pain"}`),
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Neither doctor nor facility",
+			urlID:              patientID,
+			body:               []byte(`{"reason":"Exertional chest pain"}`),
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Both doctor and facility",
+			urlID:              patientID,
+			body:               []byte(`{"toDoctorId":"` + toDoctorID + `","facility":{"name":"District Hospital"},"reason":"Exertional chest pain"}`),
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown urgency",
+			urlID:              patientID,
+			body:               []byte(`{"toDoctorId":"` + toDoctorID + `","reason":"Exertional chest pain","urgency":"asap"}`),
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Referral to a doctor",
+			urlID: patientID,
+			body:  []byte(`{"toDoctorId":"` + toDoctorID + `","specialty":"Cardiology","reason":" Exertional chest pain ","urgency":"Urgent"}`),
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CreateReferralReq) bool {
+					return r.PatientID == patientID && r.ReferredByID == doctorID && r.ToDoctorID == toDoctorID &&
+						r.Reason == "Exertional chest pain" && r.Urgency == clinical.UrgencyUrgent
+				})).Return(&models.Referral{ID: "referral-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Referral to a facility",
+			urlID: patientID,
+			body:  []byte(`{"facility":{"name":" District Hospital ","contact":"05862-242345"},"reason":"Needs CT head"}`),
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CreateReferralReq) bool {
+					return r.ToDoctorID == "" && r.Facility.Name == "District Hospital"
+				})).Return(&models.Referral{ID: "referral-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Recipient not a doctor",
+			urlID: patientID,
+			body:  []byte(`{"toDoctorId":"` + toDoctorID + `","reason":"Exertional chest pain"}`),
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrRecipientNotDoctor).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Self referral",
+			urlID: patientID,
+			body:  []byte(`{"toDoctorId":"` + doctorID + `","reason":"Exertional chest pain"}`),
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrSelfReferral).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			body:  []byte(`{"toDoctorId":"` + toDoctorID + `","reason":"Exertional chest pain"}`),
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "Patient merged",
+			urlID: patientID,
+			body:  []byte(`{"toDoctorId":"` + toDoctorID + `","reason":"Exertional chest pain"}`),
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Create", mock.Anything, mock.Anything).Return(nil, &store.PatientMergedError{}).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := mocks.NewReferralStorer(t)
+			tt.mockSetup(rs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Referrals: rs},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/patient/"+tt.urlID+"/referrals", "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleCreateReferral(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleReferralActions(t *testing.T) {
+	referralID := "3f1c2b4a-6d5e-4f70-8a9b-0c1d2e3f4a5b"
+	doctorID := "7c3e5a2b-1d4f-4e6a-9b8c-0d1e2f3a4b5c"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		body               []byte
+		handle             func(*handler) http.HandlerFunc
+		mockSetup          func(*mocks.ReferralStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Referral UUID",
+			urlID:              "invalid-uuid",
+			handle:             func(h *handler) http.HandlerFunc { return h.HandleAcceptReferral },
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Accept without a body",
+			urlID:  referralID,
+			handle: func(h *handler) http.HandlerFunc { return h.HandleAcceptReferral },
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Transition", mock.Anything, mock.MatchedBy(func(r *models.ReferralActionReq) bool {
+					return r.ReferralID == referralID && r.UserID == doctorID && r.Status == clinical.ReferralAccepted
+				})).Return(&models.Referral{ID: referralID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Decline without a note",
+			urlID:              referralID,
+			body:               []byte(`{"note":"  "}`),
+			handle:             func(h *handler) http.HandlerFunc { return h.HandleDeclineReferral },
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Decline with a note",
+			urlID:  referralID,
+			body:   []byte(`{"note":"Not my specialty"}`),
+			handle: func(h *handler) http.HandlerFunc { return h.HandleDeclineReferral },
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Transition", mock.Anything, mock.MatchedBy(func(r *models.ReferralActionReq) bool {
+					return r.Status == clinical.ReferralDeclined && r.Note == "Not my specialty"
+				})).Return(&models.Referral{ID: referralID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Complete by someone else",
+			urlID:  referralID,
+			handle: func(h *handler) http.HandlerFunc { return h.HandleCompleteReferral },
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Transition", mock.Anything, mock.Anything).Return(nil, store.ErrNotReferralParty).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Cancel a completed referral",
+			urlID:  referralID,
+			handle: func(h *handler) http.HandlerFunc { return h.HandleCancelReferral },
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Transition", mock.Anything, mock.Anything).Return(nil, store.ErrReferralTransition).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "Referral not found",
+			urlID:  referralID,
+			handle: func(h *handler) http.HandlerFunc { return h.HandleAcceptReferral },
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Transition", mock.Anything, mock.Anything).Return(nil, store.ErrReferralNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := mocks.NewReferralStorer(t)
+			tt.mockSetup(rs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Referrals: rs},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodPost, tt.body, "/v1/referral/"+tt.urlID, "referralID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			tt.handle(h)(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestHandleReferralInbox(t *testing.T) {
+	doctorID := "7c3e5a2b-1d4f-4e6a-9b8c-0d1e2f3a4b5c"
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.ReferralStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:  "Received by default",
+			query: "",
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Inbox", mock.Anything, mock.MatchedBy(func(q *models.ReferralQuery) bool {
+					return q.UserID == doctorID && q.Box == "received" && q.Limit == 50
+				})).Return([]*models.Referral{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Sent and pending",
+			query: "?box=sent&status=pending&urgency=emergency&limit=10",
+			mockSetup: func(rs *mocks.ReferralStorer) {
+				rs.On("Inbox", mock.Anything, mock.MatchedBy(func(q *models.ReferralQuery) bool {
+					return q.Box == "sent" && q.Status == clinical.ReferralPending &&
+						q.Urgency == clinical.UrgencyEmergency && q.Limit == 10
+				})).Return([]*models.Referral{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Unknown box",
+			query:              "?box=archive",
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Limit too high",
+			query:              "?limit=500",
+			mockSetup:          func(rs *mocks.ReferralStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := mocks.NewReferralStorer(t)
+			tt.mockSetup(rs)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Referrals: rs},
+				validate: validator.New(),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/referrals/inbox"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleReferralInbox(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -39,23 +39,65 @@ func (h *handler) Router() http.Handler {
 
 		r.Route("/patient", func(r chi.Router) {
 			r.Use(h.RequireAuth)
-			r.With(h.RequirePaginate).Get("/", h.HandleListPatients)
+			r.With(h.RequirePaginate, h.RequirePatientFilter).Get("/", h.HandleListPatients)
 			r.Post("/", h.HandleRegisterPatient)
+			r.With(h.RequireRole(db.RoleDoctor)).Post("/merge", h.HandleMergePatients)
+			r.With(h.RequireRole(db.RoleDoctor)).Post("/merge/{mergeID}/unmerge", h.HandleUnmergePatients)
 
 			r.Route("/{patientID}", func(r chi.Router) {
 				r.Get("/", h.HandleGetPatient)
 				r.Put("/", h.HandleUpdatePatientDetails)
 				r.Delete("/", h.HandleDeletePatientDetails)
 
+				r.Get("/timeline", h.HandleGetTimeline)
+				r.Get("/care-team", h.HandleListCareTeam)
+
+				r.Get("/vitals", h.HandleListVitals)
+				r.Get("/vitals/latest", h.HandleLatestVitals)
+				r.Get("/vitals/series/{metric}", h.HandleVitalSeries)
+				r.Get("/growth", h.HandleGrowthCharts)
+
+				r.Get("/medications", h.HandleListMedications)
+				r.Get("/encounters", h.HandleListEncounters)
+				r.Get("/notes", h.HandleListNotes)
+				r.Get("/lab-orders", h.HandleListLabOrders)
+				r.Get("/labs", h.HandleCumulativeLabs)
+				r.Get("/immunizations", h.HandleListImmunizations)
+				r.Get("/immunizations/status", h.HandleImmunizationStatus)
+				r.Get("/family-history", h.HandleListFamilyHistory)
+				r.Get("/social-history", h.HandleGetSocialHistory)
+				r.Get("/referrals", h.HandleListReferrals)
+
+				r.Get("/appointments", h.HandleListAppointments)
+				r.Post("/appointments", h.HandleBookAppointment)
+
 				r.Group(func(r chi.Router) {
 					r.Use(h.RequireRole(db.RoleDoctor))
 					r.Post("/condition", h.HandleAddCondition)
 					r.Post("/allergy", h.HandleRecordAllergy)
+					r.Put("/no-known-allergies", h.HandleAssertNoKnownAllergies)
 					r.Post("/diagnoses", h.HandleAddDiagnoses)
 
 					r.Post("/vitals", h.HandleCaptureVitals)
-					r.Put("/vitals", h.HandleUpdatingVitals)
-					r.Delete("/vitals", h.HandleDeleteVitals)
+
+					r.Post("/medications", h.HandlePrescribeMedication)
+
+					r.Post("/encounters", h.HandleCreateEncounter)
+
+					r.Post("/notes", h.HandleCreateNote)
+
+					r.Post("/lab-orders", h.HandleOrderLabs)
+
+					r.Post("/immunizations", h.HandleRecordImmunization)
+
+					r.Post("/family-history", h.HandleAddFamilyHistory)
+					r.Put("/social-history", h.HandleSetSocialHistory)
+					r.Delete("/social-history", h.HandleDeleteSocialHistory)
+
+					r.Post("/referrals", h.HandleCreateReferral)
+
+					r.Post("/care-team", h.HandleAddCareTeamMember)
+					r.Delete("/care-team/{userID}", h.HandleRemoveCareTeamMember)
 				})
 			})
 		})
@@ -63,6 +105,7 @@ func (h *handler) Router() http.Handler {
 		r.Route("/condition/{conditionID}", func(r chi.Router) {
 			r.Use(h.RequireAuth)
 			r.Use(h.RequireRole(db.RoleDoctor))
+			r.Put("/", h.HandleUpdateCondition)
 			r.Delete("/", h.HandleInactiveCondition)
 		})
 
@@ -73,6 +116,118 @@ func (h *handler) Router() http.Handler {
 			r.Delete("/", h.HandleDeleteAllergy)
 		})
 
+		r.Route("/medication/{medicationID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Use(h.RequireRole(db.RoleDoctor))
+			r.Put("/", h.HandleUpdateMedication)
+		})
+
+		r.Route("/encounter/{encounterID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Get("/", h.HandleGetEncounter)
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.RequireRole(db.RoleDoctor))
+				r.Put("/", h.HandleUpdateEncounter)
+				r.Delete("/", h.HandleDeleteEncounter)
+			})
+		})
+
+		r.Route("/note/{noteID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Get("/", h.HandleGetNote)
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.RequireRole(db.RoleDoctor))
+				r.Put("/", h.HandleUpdateNote)
+				r.Delete("/", h.HandleDeleteNote)
+				r.Post("/sign", h.HandleSignNote)
+				r.Post("/amendments", h.HandleAmendNote)
+			})
+		})
+
+		r.Route("/lab-order/{orderID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Get("/", h.HandleGetLabOrder)
+			r.Post("/results", h.HandleEnterLabResults)
+			r.With(h.RequireRole(db.RoleDoctor)).Post("/cancel", h.HandleCancelLabOrder)
+		})
+
+		r.Route("/immunization/{immunizationID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Use(h.RequireRole(db.RoleDoctor))
+			r.Delete("/", h.HandleDeleteImmunization)
+		})
+
+		r.Route("/family-history/{familyHistoryID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Use(h.RequireRole(db.RoleDoctor))
+			r.Put("/", h.HandleUpdateFamilyHistory)
+			r.Delete("/", h.HandleDeleteFamilyHistory)
+		})
+
+		r.Route("/referral/{referralID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Get("/", h.HandleGetReferral)
+			r.Group(func(r chi.Router) {
+				r.Use(h.RequireRole(db.RoleDoctor))
+				r.Post("/accept", h.HandleAcceptReferral)
+				r.Post("/decline", h.HandleDeclineReferral)
+				r.Post("/complete", h.HandleCompleteReferral)
+				r.Post("/cancel", h.HandleCancelReferral)
+			})
+		})
+
+		r.With(h.RequireAuth, h.RequireRole(db.RoleDoctor)).Get("/referrals/inbox", h.HandleReferralInbox)
+
+		r.Route("/doctor/{doctorID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Get("/working-hours", h.HandleGetWorkingHours)
+			r.Put("/working-hours", h.HandleSetWorkingHours)
+			r.Post("/exceptions", h.HandleAddScheduleException)
+			r.Delete("/exceptions/{exceptionID}", h.HandleDeleteScheduleException)
+			r.Get("/slots", h.HandleListSlots)
+			r.Get("/schedule", h.HandleDoctorSchedule)
+		})
+
+		r.Route("/queue", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Get("/", h.HandleQueueBoard)
+			r.Post("/", h.HandleCheckIn)
+			r.Get("/stream", h.HandleQueueStream)
+			r.Put("/{entryID}/triage", h.HandleTriage)
+			r.Put("/{entryID}/status", h.HandleUpdateQueueStatus)
+		})
+
+		r.Route("/appointment/{appointmentID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Put("/reschedule", h.HandleRescheduleAppointment)
+			r.Post("/cancel", h.HandleCancelAppointment)
+		})
+
+		r.Route("/vitals/{vitalID}", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Use(h.RequireRole(db.RoleDoctor))
+			r.Put("/", h.HandleUpdatingVitals)
+			r.Delete("/", h.HandleDeleteVitals)
+		})
+
+		r.With(h.RequireAuth, h.RequireRole(db.RoleDoctor)).Get("/news2", h.HandleWardNEWS2)
+		r.With(h.RequireAuth).Get("/terminology/icd10", h.HandleSearchICD10)
+		r.With(h.RequireAuth).Get("/terminology/substances", h.HandleSearchSubstances)
+		r.With(h.RequireAuth).Get("/note-templates", h.HandleListNoteTemplates)
+		r.With(h.RequireAuth).Get("/lab-tests", h.HandleListLabTests)
+		r.With(h.RequireAuth).Post("/lab-results", h.HandleIngestLabResults)
+		r.With(h.RequireAuth).Get("/immunization-schedule", h.HandleGetImmunizationSchedule)
+		r.With(h.RequireAuth).Get("/immunizations/overdue", h.HandleOverdueImmunizations)
+
+		r.Route("/alerts", func(r chi.Router) {
+			r.Use(h.RequireAuth)
+			r.Get("/", h.HandleListAlerts)
+			r.Post("/{alertID}/acknowledge", h.HandleAcknowledgeAlert)
+			r.Post("/{alertID}/resolve", h.HandleResolveAlert)
+		})
+
 		r.Route("/diagnoses/{diagnosesID}", func(r chi.Router) {
 			r.Use(h.RequireAuth)
 			r.Use(h.RequireRole(db.RoleDoctor))
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/terminology"
+)
+
+// HandleSearchICD10 godoc
+// @Summary      Search ICD-10 codes
+// @Description  Autocompletes ICD-10 codes for coding diagnoses. Codes starting with the query come first,
+// @Description  then codes whose display text has a word starting with it, then any other text match.
+// @Tags         Diagnoses
+// @Produce      json
+// @Param        q      query     string  true   "Code or text to search for"
+// @Param        limit  query     int     false  "Maximum number of codes (default 20, at most 50)"
+// @Success      200    {object}  models.SuccessResponse{data=[]terminology.Code}
+// @Failure      400    {object}  models.FailureResponse
+// @Router       /v1/terminology/icd10 [get]
+func (h *handler) HandleSearchICD10(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if err := h.validate.Var(q, "required,max=100"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > 50 {
+			badRequestResponse(w, r)
+			return
+		}
+		limit = n
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "codes fetched successfully",
+		Data:    terminology.SearchICD10(q, limit),
+	})
+}
+
+// HandleSearchSubstances godoc
+// @Summary      Search allergy substances
+// @Description  Autocompletes coded substances for recording allergies: RxNorm medication ingredients and
+// @Description  SNOMED CT food, environmental and biologic substances. Substances with a word starting with
+// @Description  the query come first, then any other text match.
+// @Tags         Allergy
+// @Produce      json
+// @Param        q         query     string  true   "Code or text to search for"
+// @Param        category  query     string  false  "Substance category" Enums(food, medication, environment, biologic)
+// @Param        limit     query     int     false  "Maximum number of substances (default 20, at most 50)"
+// @Success      200       {object}  models.SuccessResponse{data=[]terminology.Substance}
+// @Failure      400       {object}  models.FailureResponse
+// @Router       /v1/terminology/substances [get]
+func (h *handler) HandleSearchSubstances(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if err := h.validate.Var(q, "required,max=100"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	category := r.URL.Query().Get("category")
+	if err := h.validate.Var(category, "omitempty,oneof=food medication environment biologic"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > 50 {
+			badRequestResponse(w, r)
+			return
+		}
+		limit = n
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "substances fetched successfully",
+		Data:    terminology.SearchSubstances(q, category, limit),
+	})
+}
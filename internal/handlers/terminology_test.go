@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleSearchICD10(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+		expectedFirst      string
+	}{
+		{
+			name:               "Missing query",
+			query:              "",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid limit",
+			query:              "q=asthma&limit=0",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Code prefix",
+			query:              "q=j459",
+			expectedStatusCode: http.StatusOK,
+			expectedFirst:      "J45.9",
+		},
+		{
+			name:               "Display text",
+			query:              "q=asthma&limit=5",
+			expectedStatusCode: http.StatusOK,
+			expectedFirst:      "J45.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(validator.New(), zap.NewNop(), &store.Store{})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/terminology/icd10?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			h.HandleSearchICD10(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+			if tt.expectedFirst == "" {
+				return
+			}
+
+			var res struct {
+				Data []struct {
+					Code string `json:"code"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+			require.NotEmpty(t, res.Data)
+			require.Equal(t, tt.expectedFirst, res.Data[0].Code)
+		})
+	}
+}
+
+func TestHandleSearchSubstances(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+		expectedFirst      string
+	}{
+		{
+			name:               "Missing query",
+			query:              "category=food",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown category",
+			query:              "q=pea&category=drug",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Display text",
+			query:              "q=amox",
+			expectedStatusCode: http.StatusOK,
+			expectedFirst:      "Amoxicillin",
+		},
+		{
+			name:               "Category filter",
+			query:              "q=pe&category=food",
+			expectedStatusCode: http.StatusOK,
+			expectedFirst:      "Peanut",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(validator.New(), zap.NewNop(), &store.Store{})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/terminology/substances?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			h.HandleSearchSubstances(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+			if tt.expectedFirst == "" {
+				return
+			}
+
+			var res struct {
+				Data []struct {
+					Display string `json:"display"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+			require.NotEmpty(t, res.Data)
+			require.Equal(t, tt.expectedFirst, res.Data[0].Display)
+		})
+	}
+}
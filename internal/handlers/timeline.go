@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+// HandleGetTimeline godoc
+// @Summary Timeline of a patient's record
+// @Description Merges the patient's registration, encounters, vitals readings, diagnoses, conditions and their
+// @Description status changes, allergies, prescriptions, lab results, immunizations, notes and their amendments,
+// @Description referrals, and the edits of the patient's details, diagnoses, allergies and vitals into one list
+// @Description of events, newest first, with the name of the user who caused each event where it's recorded.
+// @Description Draft notes are only shown to their author.
+// @Tags Patients
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param types query string false "Comma-separated event types (registration, vitals, diagnosis, condition, condition-status, allergy, note, note-amendment, referral, edit, encounter, medication, lab-result, immunization)"
+// @Param from query string false "Earliest event time (RFC 3339)"
+// @Param to query string false "Latest event time (RFC 3339)"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Events per page" default(20)
+// @Success 200 {object} models.SuccessResponse{data=models.Timeline}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/timeline [get]
+func (h *handler) HandleGetTimeline(w http.ResponseWriter, r *http.Request) {
+	query, err := h.parseTimelineQuery(r)
+	if err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	timeline, err := h.store.Patient.Timeline(ctx, query)
+	if err != nil {
+		if errors.Is(err, store.ErrPatientNotFound) {
+			notFoundError(w, r)
+			return
+		}
+		h.logger.Error("fetching timeline failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "timeline fetched successfully",
+		Data:    timeline,
+	})
+}
+
+func (h *handler) parseTimelineQuery(r *http.Request) (*models.TimelineQuery, error) {
+	query := &models.TimelineQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+		ViewerID:  userID(r),
+		Page:      1,
+		PageSize:  20,
+	}
+
+	params := r.URL.Query()
+	if types := params.Get("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			query.Types = append(query.Types, strings.TrimSpace(t))
+		}
+	}
+	if from := params.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, err
+		}
+		query.From = &t
+	}
+	if to := params.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, err
+		}
+		query.To = &t
+	}
+	if page := params.Get("page"); page != "" {
+		n, err := strconv.ParseInt(page, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		query.Page = n
+	}
+	if pageSize := params.Get("pageSize"); pageSize != "" {
+		n, err := strconv.ParseInt(pageSize, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		query.PageSize = n
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		return nil, err
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return nil, errors.New("from is after to")
+	}
+
+	return query, nil
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/mocks"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestHandleGetTimeline(t *testing.T) {
+	patientID := "550e8400-e29b-41d4-a716-446655440000"
+	doctorID := "9b2f6d1e-2c4a-4f7e-8a3b-5d6e7f809a1b"
+
+	tests := []struct {
+		name               string
+		urlID              string
+		query              string
+		mockSetup          func(*mocks.PatientStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid Patient UUID",
+			urlID:              "invalid-uuid",
+			mockSetup:          func(m *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown event type",
+			urlID:              patientID,
+			query:              "?types=vitals,billing",
+			mockSetup:          func(m *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "From after to",
+			urlID:              patientID,
+			query:              "?from=2026-10-18T00:00:00Z&to=2026-10-01T00:00:00Z",
+			mockSetup:          func(m *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Page size too large",
+			urlID:              patientID,
+			query:              "?pageSize=500",
+			mockSetup:          func(m *mocks.PatientStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "First page by default",
+			urlID: patientID,
+			mockSetup: func(m *mocks.PatientStorer) {
+				m.On("Timeline", mock.Anything, mock.MatchedBy(func(q *models.TimelineQuery) bool {
+					// the viewer's own draft notes are shown to them alone
+					return q.PatientID == patientID && q.ViewerID == doctorID &&
+						q.Page == 1 && q.PageSize == 20 && q.Types == nil
+				})).Return(&models.Timeline{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Filtered page",
+			urlID: patientID,
+			query: "?types=diagnosis,%20edit&from=2026-01-01T00:00:00Z&page=2&pageSize=10",
+			mockSetup: func(m *mocks.PatientStorer) {
+				m.On("Timeline", mock.Anything, mock.MatchedBy(func(q *models.TimelineQuery) bool {
+					return len(q.Types) == 2 && q.Types[1] == "edit" && q.From != nil && q.To == nil &&
+						q.Page == 2 && q.PageSize == 10
+				})).Return(&models.Timeline{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Clinical activity",
+			urlID: patientID,
+			query: "?types=encounter,medication,lab-result,immunization",
+			mockSetup: func(m *mocks.PatientStorer) {
+				m.On("Timeline", mock.Anything, mock.MatchedBy(func(q *models.TimelineQuery) bool {
+					return len(q.Types) == 4 && q.Types[2] == "lab-result"
+				})).Return(&models.Timeline{PatientID: patientID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Patient not found",
+			urlID: patientID,
+			mockSetup: func(m *mocks.PatientStorer) {
+				m.On("Timeline", mock.Anything, mock.Anything).Return(nil, store.ErrPatientNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "DB error",
+			urlID: patientID,
+			mockSetup: func(m *mocks.PatientStorer) {
+				m.On("Timeline", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPatient := mocks.NewPatientStorer(t)
+			tt.mockSetup(mockPatient)
+
+			h := &handler{
+				logger:   zap.NewNop(),
+				store:    &store.Store{Patient: mockPatient},
+				validate: validator.New(),
+			}
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+tt.urlID+"/timeline"+tt.query, "patientID", tt.urlID)
+			req = req.WithContext(context.WithValue(req.Context(), userCtx, &models.UserModel{ID: doctorID}))
+
+			rr := httptest.NewRecorder()
+			h.HandleGetTimeline(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
@@ -5,27 +5,41 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
 	"github.com/vaidik-bajpai/medibridge/internal/helpers"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/store"
 	"go.uber.org/zap"
 )
 
+// measuredAtSkew is how far in the future a measurement time may be, to
+// allow for clock differences between devices and the server.
+const measuredAtSkew = 5 * time.Minute
+
 // HandleCaptureVitals godoc
 // @Summary Capture patient's vitals
-// @Description Captures the vitals of a patient, including details like blood pressure, temperature, etc.
+// @Description Records a new vitals observation for a patient. Earlier observations are kept, so
+// @Description the patient's vitals form a time series. Values may be sent in other units (lb, in, °F, kPa)
+// @Description by naming them in "units"; they are stored as cm, kg, °C and mmHg. The BMI is computed from
+// @Description height and weight, and a supplied BMI that disagrees with them is rejected. Physiologically
+// @Description impossible values are rejected with a message per field, and the response flags every value
+// @Description as low, normal, high or critical for the patient's age and sex. When respiratory rate, SpO2,
+// @Description supplemental oxygen, systolic pressure, pulse, consciousness and temperature are all present the
+// @Description NEWS2 score is computed and stored with the observation.
 // @Tags Vitals
 // @Accept json
 // @Produce json
 // @Param patientID path string true "Patient ID"
 // @Param body body models.CreateVitalReq true "Vital Information"
-// @Success 201 {object} map[string]string
-// @Failure 400 {object} models.FailureResponse
+// @Param units query string false "Units of the response values" Enums(metric, imperial, si)
+// @Success 201 {object} models.SuccessResponse
+// @Failure 400 {object} models.ValidationFailureResponse
 // @Failure 409 {object} models.FailureResponse
 // @Failure 422 {object} models.FailureResponse
 // @Failure 500 {object} models.FailureResponse
@@ -45,12 +59,38 @@ func (h *handler) HandleCaptureVitals(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fmt.Println("request: ", req)
-
 	req.PatientID = pID
+	if user := getUserFromCtx(r); user != nil {
+		req.RecordedByID = user.ID
+	}
+
+	system, err := unitSystem(r)
+	if err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	// values are validated once converted to the stored units
+	if err := req.Normalise(); err != nil {
+		h.logger.Info("unsupported units", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
 
 	if err := h.validate.Struct(req); err != nil {
 		h.logger.Info("unprocessable entity", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	if problems := clinical.CheckPlausible(req.Values()); len(problems) > 0 {
+		h.logger.Info("implausible vitals", zap.Any("fields", problems))
+		validationErrorResponse(w, r, problems)
+		return
+	}
+
+	if req.MeasuredAt != nil && req.MeasuredAt.After(time.Now().Add(measuredAtSkew)) {
+		h.logger.Info("measurement time in the future", zap.Time("measuredAt", *req.MeasuredAt))
 		badRequestResponse(w, r)
 		return
 	}
@@ -58,88 +98,313 @@ func (h *handler) HandleCaptureVitals(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := h.store.Vitals.Create(ctx, &req); err != nil {
+	vital, err := h.store.Vitals.Create(ctx, &req)
+	if err != nil {
 		h.logger.Info("internal server error", zap.Error(err))
-		if ok := errors.Is(err, store.ErrUniqueConstraintViolated); ok {
-			conflictErrorResponse(w, r)
+		if fields := encounterFieldErrors(err); fields != nil {
+			validationErrorResponse(w, r, fields)
 			return
 		}
+		switch {
+		case errors.Is(err, store.ErrUniqueConstraintViolated):
+			conflictErrorResponse(w, r)
+		case errors.Is(err, store.ErrInconsistentBMI), errors.Is(err, store.ErrBMIUnverifiable):
+			validationErrorResponse(w, r, map[string]string{"bmi": err.Error()})
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	h.raiseAlerts(vital.PatientID, userID(r), vitalEvents(vital)...)
+
+	if err := vital.ConvertTo(system); err != nil {
 		serverErrorResponse(w, r)
 		return
 	}
 
 	h.logger.Info("vitals captured successfully")
 
-	helpers.WriteJSONResponse(w, r, http.StatusOK, map[string]string{
-		"message": "vitals captured successfully",
+	helpers.WriteJSONResponse(w, r, http.StatusCreated, &models.SuccessResponse{
+		Status:  http.StatusCreated,
+		Message: "vitals captured successfully",
+		Data:    vital,
+	})
+}
+
+// HandleListVitals godoc
+// @Summary List patient's vitals
+// @Description Lists a patient's vitals observations, newest first, optionally within a time range.
+// @Tags Vitals
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param from query string false "Earliest measurement time (RFC 3339)"
+// @Param to query string false "Latest measurement time (RFC 3339)"
+// @Param limit query int false "Maximum number of observations"
+// @Param units query string false "Units of the response values" Enums(metric, imperial, si)
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/vitals [get]
+func (h *handler) HandleListVitals(w http.ResponseWriter, r *http.Request) {
+	query, err := h.parseVitalsQuery(r)
+	if err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	system, err := unitSystem(r)
+	if err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	vitals, err := h.store.Vitals.List(ctx, query)
+	if err != nil {
+		h.logger.Error("listing vitals failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	for _, v := range vitals {
+		if err := v.ConvertTo(system); err != nil {
+			serverErrorResponse(w, r)
+			return
+		}
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "vitals fetched successfully",
+		Data:    vitals,
+	})
+}
+
+// HandleLatestVitals godoc
+// @Summary Latest value of each vital
+// @Description Returns the most recent reading of every metric recorded for the patient.
+// @Tags Vitals
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Param units query string false "Units of the response values" Enums(metric, imperial, si)
+// @Router /v1/patient/{patientID}/vitals/latest [get]
+func (h *handler) HandleLatestVitals(w http.ResponseWriter, r *http.Request) {
+	pID := chi.URLParam(r, "patientID")
+	if err := h.validate.Var(pID, "required,uuid"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	system, err := unitSystem(r)
+	if err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	latest, err := h.store.Vitals.Latest(ctx, pID)
+	if err != nil {
+		h.logger.Error("fetching latest vitals failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	if err := latest.ConvertTo(system); err != nil {
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "latest vitals fetched successfully",
+		Data:    latest,
+	})
+}
+
+// HandleVitalSeries godoc
+// @Summary Series of a single vital
+// @Description Returns the readings of one metric over time, oldest first, for charting trends.
+// @Tags Vitals
+// @Produce json
+// @Param patientID path string true "Patient ID"
+// @Param metric path string true "Metric" Enums(heightCm, weightKg, bmi, temperatureC, pulse, respiratoryRate, bloodPressureSystolic, bloodPressureDiastolic, oxygenSaturation)
+// @Param from query string false "Earliest measurement time (RFC 3339)"
+// @Param to query string false "Latest measurement time (RFC 3339)"
+// @Param limit query int false "Maximum number of readings"
+// @Param units query string false "Units of the response values" Enums(metric, imperial, si)
+// @Success 200 {object} models.SuccessResponse{data=models.VitalSeries}
+// @Failure 400 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/patient/{patientID}/vitals/series/{metric} [get]
+func (h *handler) HandleVitalSeries(w http.ResponseWriter, r *http.Request) {
+	query, err := h.parseVitalsQuery(r)
+	if err != nil {
+		h.logger.Info("bad request", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
+
+	query.Metric = chi.URLParam(r, "metric")
+	if err := h.validate.Var(query.Metric, "required"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+	if err := h.validate.Struct(query); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	system, err := unitSystem(r)
+	if err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	series, err := h.store.Vitals.Series(ctx, query)
+	if err != nil {
+		h.logger.Error("fetching vitals series failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	if err := series.ConvertTo(system); err != nil {
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "vitals series fetched successfully",
+		Data:    series,
 	})
 }
 
 // HandleUpdatingVitals godoc
-// @Summary Update patient's vitals
-// @Description Updates the vitals information of a patient.
+// @Summary Correct a vitals observation
+// @Description Corrects the values of a single vitals observation.
 // @Tags Vitals
 // @Accept json
 // @Produce json
-// @Param patientID path string true "Patient ID"
+// @Param vitalID path string true "Vital ID"
 // @Param body body models.UpdateVitalReq true "Updated Vital Information"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} models.FailureResponse
+// @Param units query string false "Units of the response values" Enums(metric, imperial, si)
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ValidationFailureResponse
+// @Failure 404 {object} models.FailureResponse
+// @Failure 409 {object} models.FailureResponse
 // @Failure 422 {object} models.FailureResponse
 // @Failure 500 {object} models.FailureResponse
-// @Router /v1/patient/{patientID}/vitals [put]
+// @Router /v1/vitals/{vitalID} [put]
 func (h *handler) HandleUpdatingVitals(w http.ResponseWriter, r *http.Request) {
-	patientID := chi.URLParam(r, "patientID")
-	h.logger.Info("identifier", zap.String("patient", patientID))
-	if err := h.validate.Var(patientID, "required,uuid"); err != nil {
+	vitalID := chi.URLParam(r, "vitalID")
+	h.logger.Info("identifier", zap.String("vital", vitalID))
+	if err := h.validate.Var(vitalID, "required,uuid"); err != nil {
 		unprocessableEntityResponse(w, r)
 		return
 	}
 
 	var req models.UpdateVitalReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Println("decode error:", err)
+		h.logger.Info("unprocessable entity", zap.Error(err))
 		unprocessableEntityResponse(w, r)
 		return
 	}
 
-	req.PatientID = patientID
+	req.ID = vitalID
+	req.EditedByID = userID(r)
+
+	system, err := unitSystem(r)
+	if err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	if err := req.Normalise(); err != nil {
+		h.logger.Info("unsupported units", zap.Error(err))
+		badRequestResponse(w, r)
+		return
+	}
 
 	if err := h.validate.Struct(req); err != nil {
-		log.Println("validation error:", err)
+		h.logger.Info("bad request", zap.Error(err))
+		validationErrorResponse(w, r, fieldErrors(req, err))
+		return
+	}
+
+	if problems := clinical.CheckPlausible(req.Values()); len(problems) > 0 {
+		validationErrorResponse(w, r, problems)
+		return
+	}
+
+	if req.MeasuredAt != nil && req.MeasuredAt.After(time.Now().Add(measuredAtSkew)) {
+		h.logger.Info("measurement time in the future", zap.Time("measuredAt", *req.MeasuredAt))
 		badRequestResponse(w, r)
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
-	if err := h.store.Vitals.Update(ctx, &req); err != nil {
-		log.Println("update error:", err)
+
+	vital, err := h.store.Vitals.Update(ctx, &req)
+	if err != nil {
+		h.logger.Info("updating vitals failed", zap.String("vital", vitalID), zap.Error(err))
+		switch {
+		case errors.Is(err, store.ErrVitalNotFound):
+			notFoundError(w, r)
+		case errors.Is(err, store.ErrInconsistentBMI), errors.Is(err, store.ErrBMIUnverifiable):
+			validationErrorResponse(w, r, map[string]string{"bmi": err.Error()})
+		case errors.Is(err, store.ErrBloodPressureInverted):
+			validationErrorResponse(w, r, map[string]string{"bloodPressureDiastolic": err.Error()})
+		case errors.Is(err, store.ErrVitalChanged):
+			conflictErrorResponse(w, r)
+		default:
+			serverErrorResponse(w, r)
+		}
+		return
+	}
+
+	h.raiseAlerts(vital.PatientID, userID(r), vitalEvents(vital)...)
+
+	if err := vital.ConvertTo(system); err != nil {
 		serverErrorResponse(w, r)
 		return
 	}
 
 	h.logger.Info("vitals updated successfully")
-	helpers.WriteJSONResponse(w, r, http.StatusOK, map[string]string{
-		"message": "vitals updated successfully",
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "vitals updated successfully",
+		Data:    vital,
 	})
 }
 
 // HandleDeleteVitals godoc
-// @Summary Delete patient's vitals
-// @Description Deletes the vitals of a patient.
+// @Summary Delete a vitals observation
+// @Description Deletes a single vitals observation, e.g. one recorded against the wrong patient.
 // @Tags Vitals
 // @Produce json
-// @Param patientID path string true "Patient ID"
-// @Success 200 {object} map[string]string
+// @Param vitalID path string true "Vital ID"
+// @Success 200 {object} models.SuccessResponse
 // @Failure 400 {object} models.FailureResponse
-// @Failure 422 {object} models.FailureResponse
+// @Failure 404 {object} models.FailureResponse
 // @Failure 500 {object} models.FailureResponse
-// @Router /v1/patient/{patientID}/vitals [delete]
+// @Router /v1/vitals/{vitalID} [delete]
 func (h *handler) HandleDeleteVitals(w http.ResponseWriter, r *http.Request) {
-	pID := chi.URLParam(r, "patientID")
-	if err := h.validate.Var(pID, "required,uuid"); err != nil {
-		log.Println(err)
+	vID := chi.URLParam(r, "vitalID")
+	if err := h.validate.Var(vID, "required,uuid"); err != nil {
+		h.logger.Info("bad request", zap.Error(err))
 		badRequestResponse(w, r)
 		return
 	}
@@ -147,15 +412,110 @@ func (h *handler) HandleDeleteVitals(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := h.store.Vitals.Delete(ctx, pID); err != nil {
-		log.Println(err)
+	if err := h.store.Vitals.Delete(ctx, vID); err != nil {
+		h.logger.Info("deleting vitals failed", zap.String("vital", vID), zap.Error(err))
+		if ok := errors.Is(err, store.ErrVitalNotFound); ok {
+			notFoundError(w, r)
+			return
+		}
 		serverErrorResponse(w, r)
 		return
 	}
 
 	h.logger.Info("vitals deleted successfully")
 
-	helpers.WriteJSONResponse(w, r, http.StatusOK, map[string]string{
-		"message": "vitals deleted successfully",
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "vitals deleted successfully",
+	})
+}
+
+// parseVitalsQuery reads the patient ID and the optional from, to and limit
+// query parameters shared by the vitals read endpoints.
+func (h *handler) parseVitalsQuery(r *http.Request) (*models.VitalsQuery, error) {
+	query := &models.VitalsQuery{
+		PatientID: chi.URLParam(r, "patientID"),
+	}
+
+	params := r.URL.Query()
+	if from := params.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, err
+		}
+		query.From = &t
+	}
+	if to := params.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, err
+		}
+		query.To = &t
+	}
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+		query.Limit = n
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		return nil, err
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return nil, errors.New("from is after to")
+	}
+
+	return query, nil
+}
+
+// unitSystem returns the unit system requested with the units query
+// parameter, metric by default.
+func unitSystem(r *http.Request) (string, error) {
+	system := r.URL.Query().Get("units")
+	if system == "" {
+		return clinical.UnitSystemMetric, nil
+	}
+	if _, ok := clinical.UnitSystems[system]; !ok {
+		return "", fmt.Errorf("unknown unit system %q", system)
+	}
+	return system, nil
+}
+
+// HandleWardNEWS2 godoc
+// @Summary NEWS2 scores of a ward
+// @Description Lists the patients on a ward by the NEWS2 early warning score of their latest fully scored
+// @Description observation, highest first, so that deteriorating patients are seen first. Patients without
+// @Description a score are listed last. Only doctors can list a ward.
+// @Tags Vitals
+// @Produce json
+// @Param ward query string true "Ward"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.FailureResponse
+// @Failure 403 {object} models.FailureResponse
+// @Failure 500 {object} models.FailureResponse
+// @Router /v1/news2 [get]
+func (h *handler) HandleWardNEWS2(w http.ResponseWriter, r *http.Request) {
+	ward := strings.TrimSpace(r.URL.Query().Get("ward"))
+	if err := h.validate.Var(ward, "required,max=50"); err != nil {
+		badRequestResponse(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	items, err := h.store.Vitals.WardNEWS2(ctx, ward)
+	if err != nil {
+		h.logger.Error("listing ward NEWS2 scores failed", zap.Error(err))
+		serverErrorResponse(w, r)
+		return
+	}
+
+	helpers.WriteJSONResponse(w, r, http.StatusOK, &models.SuccessResponse{
+		Status:  http.StatusOK,
+		Message: "ward NEWS2 scores fetched successfully",
+		Data:    items,
 	})
 }
@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
 	"github.com/vaidik-bajpai/medibridge/internal/mocks"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 
@@ -24,29 +28,37 @@ func TestHandleDeleteVitals(t *testing.T) {
 
 	tests := []struct {
 		name               string
-		patientID          string
+		vitalID            string
 		mockSetup          func(*mocks.VitalsStorer)
 		expectedStatusCode int
 	}{
 		{
-			name:      "Invalid UUID",
-			patientID: "not-a-uuid",
+			name:    "Invalid UUID",
+			vitalID: "not-a-uuid",
 			mockSetup: func(ps *mocks.VitalsStorer) {
 				// Should not be called
 			},
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			name:      "Vitals deleted successfully",
-			patientID: validUUID,
+			name:    "Vitals deleted successfully",
+			vitalID: validUUID,
 			mockSetup: func(ps *mocks.VitalsStorer) {
 				ps.On("Delete", mock.Anything, validUUID).Return(nil).Once()
 			},
 			expectedStatusCode: http.StatusOK,
 		},
 		{
-			name:      "Vitals delete DB error",
-			patientID: validUUID,
+			name:    "Vitals not found",
+			vitalID: validUUID,
+			mockSetup: func(ps *mocks.VitalsStorer) {
+				ps.On("Delete", mock.Anything, validUUID).Return(store.ErrVitalNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "Vitals delete DB error",
+			vitalID: validUUID,
 			mockSetup: func(ps *mocks.VitalsStorer) {
 				ps.On("Delete", mock.Anything, validUUID).Return(errors.New("db error")).Once()
 			},
@@ -65,10 +77,10 @@ func TestHandleDeleteVitals(t *testing.T) {
 				Vitals: mockVitals,
 			})
 
-			req := httptest.NewRequest(http.MethodDelete, "/v1/patients/"+tt.patientID+"/vitals", nil)
+			req := httptest.NewRequest(http.MethodDelete, "/v1/vitals/"+tt.vitalID, nil)
 
 			routeCtx := chi.NewRouteContext()
-			routeCtx.URLParams.Add("patientID", tt.patientID)
+			routeCtx.URLParams.Add("vitalID", tt.vitalID)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
 
 			rec := httptest.NewRecorder()
@@ -98,49 +110,94 @@ func TestHandleUpdatingVitals(t *testing.T) {
 
 	tests := []struct {
 		name               string
-		patientID          string
+		vitalID            string
 		body               []byte
 		mockSetup          func(*mocks.VitalsStorer)
 		expectedStatusCode int
 	}{
 		{
 			name:               "Invalid UUID",
-			patientID:          "invalid-uuid",
+			vitalID:            "invalid-uuid",
 			body:               validBody,
 			mockSetup:          func(ps *mocks.VitalsStorer) {},
 			expectedStatusCode: http.StatusUnprocessableEntity,
 		},
 		{
 			name:               "Malformed JSON",
-			patientID:          validUUID,
+			vitalID:            validUUID,
 			body:               []byte(`{"heightCm":}`),
 			mockSetup:          func(ps *mocks.VitalsStorer) {},
 			expectedStatusCode: http.StatusUnprocessableEntity,
 		},
 		{
 			name:               "Validation fails on models",
-			patientID:          validUUID,
+			vitalID:            validUUID,
 			body:               []byte(`{"heightCm": -1}`),
 			mockSetup:          func(ps *mocks.VitalsStorer) {},
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			name:      "Vitals update DB error",
-			patientID: validUUID,
-			body:      validBody,
+			name:    "Vitals not found",
+			vitalID: validUUID,
+			body:    validBody,
+			mockSetup: func(ps *mocks.VitalsStorer) {
+				ps.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrVitalNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "Corrected pressure inverts the stored one",
+			vitalID: validUUID,
+			body:    []byte(`{"bloodPressureDiastolic": 90}`),
+			mockSetup: func(ps *mocks.VitalsStorer) {
+				ps.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrBloodPressureInverted).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Corrected time in the future",
+			vitalID:            validUUID,
+			body:               []byte(`{"measuredAt": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`),
+			mockSetup:          func(ps *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Corrected time within the clock skew",
+			vitalID: validUUID,
+			body:    []byte(`{"measuredAt": "` + time.Now().Add(time.Minute).Format(time.RFC3339) + `"}`),
+			mockSetup: func(ps *mocks.VitalsStorer) {
+				ps.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateVitalReq) bool {
+					return r.MeasuredAt != nil && r.MeasuredAt.After(time.Now())
+				})).Return(&models.VitalModel{ID: validUUID}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "Changed by someone else meanwhile",
+			vitalID: validUUID,
+			body:    []byte(`{"weightKg": 70}`),
 			mockSetup: func(ps *mocks.VitalsStorer) {
-				ps.On("Update", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+				ps.On("Update", mock.Anything, mock.Anything).Return(nil, store.ErrVitalChanged).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:    "Vitals update DB error",
+			vitalID: validUUID,
+			body:    validBody,
+			mockSetup: func(ps *mocks.VitalsStorer) {
+				ps.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
 		{
-			name:      "Vitals update success",
-			patientID: validUUID,
-			body:      validBody,
+			name:    "Vitals update success",
+			vitalID: validUUID,
+			body:    validBody,
 			mockSetup: func(ps *mocks.VitalsStorer) {
 				ps.On("Update", mock.Anything, mock.MatchedBy(func(r *models.UpdateVitalReq) bool {
-					return r.PatientID == validUUID && *r.BMI == 22.5
-				})).Return(nil).Once()
+					return r.ID == validUUID && *r.BMI == 22.5
+				})).Return(&models.VitalModel{ID: validUUID}, nil).Once()
 			},
 			expectedStatusCode: http.StatusOK,
 		},
@@ -157,10 +214,10 @@ func TestHandleUpdatingVitals(t *testing.T) {
 				Vitals: mockVitals,
 			})
 
-			req := httptest.NewRequest(http.MethodPut, "/v1/patient/"+tt.patientID+"/vitals", bytes.NewReader(tt.body))
+			req := httptest.NewRequest(http.MethodPut, "/v1/vitals/"+tt.vitalID, bytes.NewReader(tt.body))
 
 			routeCtx := chi.NewRouteContext()
-			routeCtx.URLParams.Add("patientID", tt.patientID)
+			routeCtx.URLParams.Add("vitalID", tt.vitalID)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
 
 			rec := httptest.NewRecorder()
@@ -193,6 +250,7 @@ func TestHandleCaptureVitals(t *testing.T) {
 		patientID          string
 		body               []byte
 		setupMock          func(*mocks.VitalsStorer)
+		setupAlerts        func(*mocks.AlertStorer)
 		expectedStatusCode int
 	}{
 		{
@@ -216,12 +274,69 @@ func TestHandleCaptureVitals(t *testing.T) {
 			setupMock:          func(m *mocks.VitalsStorer) {},
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			name:      "Imperial units are stored converted",
+			patientID: validUUID,
+			body:      []byte(`{"weightKg": 154, "temperatureC": 98.6, "bloodPressureSystolic": 16, "units": {"weight": "lb", "temperature": "F", "bloodPressure": "kPa"}}`),
+			setupMock: func(m *mocks.VitalsStorer) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CreateVitalReq) bool {
+					return math.Abs(*r.WeightKg-69.85) < 0.01 &&
+						math.Abs(*r.TemperatureC-37) < 0.01 &&
+						math.Abs(*r.BloodPressureSystolic-120) < 0.1
+				})).Return(&models.VitalModel{ID: "vital-id"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Unsupported unit",
+			patientID:          validUUID,
+			body:               []byte(`{"weightKg": 10, "units": {"weight": "stone"}}`),
+			setupMock:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Inconsistent BMI",
+			patientID: validUUID,
+			body:      []byte(`{"heightCm": 170, "weightKg": 60, "bmi": 30}`),
+			setupMock: func(m *mocks.VitalsStorer) {
+				m.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrInconsistentBMI).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Measured in the future",
+			patientID:          validUUID,
+			body:               []byte(`{"pulse": 80, "measuredAt": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`),
+			setupMock:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Diastolic above systolic",
+			patientID:          validUUID,
+			body:               []byte(`{"bloodPressureSystolic": 80, "bloodPressureDiastolic": 120}`),
+			setupMock:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown consciousness level",
+			patientID:          validUUID,
+			body:               []byte(`{"pulse": 80, "consciousness": "drowsy"}`),
+			setupMock:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Implausible pulse",
+			patientID:          validUUID,
+			body:               []byte(`{"pulse": 900}`),
+			setupMock:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name:      "Unique constraint violation",
 			patientID: validUUID,
 			body:      validBody,
 			setupMock: func(m *mocks.VitalsStorer) {
-				m.On("Create", mock.Anything, mock.Anything).Return(store.ErrUniqueConstraintViolated).Once()
+				m.On("Create", mock.Anything, mock.Anything).Return(nil, store.ErrUniqueConstraintViolated).Once()
 			},
 			expectedStatusCode: http.StatusConflict,
 		},
@@ -230,7 +345,7 @@ func TestHandleCaptureVitals(t *testing.T) {
 			patientID: validUUID,
 			body:      validBody,
 			setupMock: func(m *mocks.VitalsStorer) {
-				m.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+				m.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -241,9 +356,30 @@ func TestHandleCaptureVitals(t *testing.T) {
 			setupMock: func(m *mocks.VitalsStorer) {
 				m.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CreateVitalReq) bool {
 					return r.PatientID == validUUID && *r.BMI == 20.8
-				})).Return(nil).Once()
+				})).Return(&models.VitalModel{ID: "vital-id", PatientID: validUUID}, nil).Once()
 			},
-			expectedStatusCode: http.StatusOK,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:      "Critical value raises an alert",
+			patientID: validUUID,
+			body:      []byte(`{"pulse": 190}`),
+			setupMock: func(m *mocks.VitalsStorer) {
+				pulse := 190
+				m.On("Create", mock.Anything, mock.Anything).Return(&models.VitalModel{
+					ID:        "vital-id",
+					PatientID: validUUID,
+					Pulse:     &pulse,
+					Flags:     map[string]string{"pulse": "critical"},
+				}, nil).Once()
+			},
+			setupAlerts: func(m *mocks.AlertStorer) {
+				m.On("Raise", mock.Anything, mock.MatchedBy(func(r *models.RaiseAlertsReq) bool {
+					return r.PatientID == validUUID && len(r.Alerts) == 1 &&
+						r.Alerts[0].RuleID == "vital-critical" && r.Alerts[0].Message == "Critical pulse of 190"
+				})).Return([]*models.Alert{{ID: "alert-id"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
 		},
 	}
 
@@ -252,8 +388,14 @@ func TestHandleCaptureVitals(t *testing.T) {
 			mockVitals := mocks.NewVitalsStorer(t)
 			tt.setupMock(mockVitals)
 
+			mockAlerts := mocks.NewAlertStorer(t)
+			if tt.setupAlerts != nil {
+				tt.setupAlerts(mockAlerts)
+			}
+
 			h := NewHandler(validator.New(), zap.NewNop(), &store.Store{
 				Vitals: mockVitals,
+				Alerts: mockAlerts,
 			})
 
 			req := httptest.NewRequest(http.MethodPost, "/v1/patient/"+tt.patientID+"/vitals", bytes.NewReader(tt.body))
@@ -270,3 +412,258 @@ func TestHandleCaptureVitals(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleListVitals(t *testing.T) {
+	validUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		patientID          string
+		query              string
+		mockSetup          func(*mocks.VitalsStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid UUID",
+			patientID:          "not-a-uuid",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed range",
+			patientID:          validUUID,
+			query:              "from=yesterday",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown unit system",
+			patientID:          validUUID,
+			query:              "units=cubits",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Vitals converted to imperial",
+			patientID: validUUID,
+			query:     "units=imperial",
+			mockSetup: func(m *mocks.VitalsStorer) {
+				weight := 70.0
+				m.On("List", mock.Anything, mock.Anything).Return([]*models.VitalModel{{ID: "vital-id", WeightKg: &weight}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Range from after to",
+			patientID:          validUUID,
+			query:              "from=2026-10-02T00:00:00Z&to=2026-10-01T00:00:00Z",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Vitals listed within range",
+			patientID: validUUID,
+			query:     "from=2026-10-01T00:00:00Z&to=2026-10-02T00:00:00Z&limit=50",
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("List", mock.Anything, mock.MatchedBy(func(q *models.VitalsQuery) bool {
+					return q.PatientID == validUUID && q.Limit == 50 &&
+						q.From.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) &&
+						q.To.Equal(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC))
+				})).Return([]*models.VitalModel{{ID: "vital-id"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "DB error",
+			patientID: validUUID,
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVitals := mocks.NewVitalsStorer(t)
+			tt.mockSetup(mockVitals)
+
+			h := NewHandler(validator.New(), zap.NewNop(), &store.Store{
+				Vitals: mockVitals,
+			})
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+tt.patientID+"/vitals?"+tt.query, "patientID", tt.patientID)
+
+			rec := httptest.NewRecorder()
+			h.HandleListVitals(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestHandleLatestVitals(t *testing.T) {
+	validUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		patientID          string
+		mockSetup          func(*mocks.VitalsStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Invalid UUID",
+			patientID:          "not-a-uuid",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Latest vitals fetched",
+			patientID: validUUID,
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Latest", mock.Anything, validUUID).Return(models.LatestVitals{
+					"pulse": {VitalID: "vital-id", Value: 72},
+				}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "DB error",
+			patientID: validUUID,
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Latest", mock.Anything, validUUID).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVitals := mocks.NewVitalsStorer(t)
+			tt.mockSetup(mockVitals)
+
+			h := NewHandler(validator.New(), zap.NewNop(), &store.Store{
+				Vitals: mockVitals,
+			})
+
+			req := helpers.InjectURLParam(http.MethodGet, nil, "/v1/patient/"+tt.patientID+"/vitals/latest", "patientID", tt.patientID)
+
+			rec := httptest.NewRecorder()
+			h.HandleLatestVitals(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestHandleVitalSeries(t *testing.T) {
+	validUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name               string
+		metric             string
+		query              string
+		mockSetup          func(*mocks.VitalsStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Unknown metric",
+			metric:             "mood",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Series fetched",
+			metric: "bloodPressureSystolic",
+			query:  "from=2026-01-01T00:00:00Z",
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Series", mock.Anything, mock.MatchedBy(func(q *models.VitalsQuery) bool {
+					return q.PatientID == validUUID && q.Metric == "bloodPressureSystolic" && q.From != nil && q.To == nil
+				})).Return(&models.VitalSeries{Metric: "bloodPressureSystolic"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "DB error",
+			metric: "pulse",
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("Series", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVitals := mocks.NewVitalsStorer(t)
+			tt.mockSetup(mockVitals)
+
+			h := NewHandler(validator.New(), zap.NewNop(), &store.Store{
+				Vitals: mockVitals,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/patient/"+validUUID+"/vitals/series/"+tt.metric+"?"+tt.query, nil)
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("patientID", validUUID)
+			routeCtx.URLParams.Add("metric", tt.metric)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+			rec := httptest.NewRecorder()
+			h.HandleVitalSeries(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestHandleWardNEWS2(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(*mocks.VitalsStorer)
+		expectedStatusCode int
+	}{
+		{
+			name:               "Missing ward",
+			query:              "",
+			mockSetup:          func(m *mocks.VitalsStorer) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Ward listed",
+			query: "ward=Ward%203",
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("WardNEWS2", mock.Anything, "Ward 3").Return([]*models.WardNEWS2Item{
+					{PatientID: "p1", NEWS2: &clinical.NEWS2Score{Total: 7, Risk: clinical.NEWS2RiskHigh}},
+					{PatientID: "p2"},
+				}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "DB error",
+			query: "ward=ICU",
+			mockSetup: func(m *mocks.VitalsStorer) {
+				m.On("WardNEWS2", mock.Anything, "ICU").Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVitals := mocks.NewVitalsStorer(t)
+			tt.mockSetup(mockVitals)
+
+			h := NewHandler(validator.New(), zap.NewNop(), &store.Store{
+				Vitals: mockVitals,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/news2?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			h.HandleWardNEWS2(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+		})
+	}
+}
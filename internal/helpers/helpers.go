@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -71,3 +73,36 @@ func CalculateAge(dob time.Time) int {
 	}
 	return age
 }
+
+// GenerateMRN returns a new medical record number such as "MB-20261018-3F9A1C".
+func GenerateMRN() (string, error) {
+	return GenerateMRNAt(time.Now())
+}
+
+// GenerateMRNAt returns a new medical record number for a patient registered
+// at t, for numbering patients registered before MRNs were assigned.
+func GenerateMRNAt(t time.Time) (string, error) {
+	bytes := make([]byte, 3)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "MB-" + t.Format("20060102") + "-" + strings.ToUpper(hex.EncodeToString(bytes)), nil
+}
+
+// EncodeCursor serialises v into an opaque, URL safe pagination cursor.
+func EncodeCursor(v interface{}) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor reverses EncodeCursor.
+func DecodeCursor(cursor string, into interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, into)
+}
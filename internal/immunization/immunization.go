@@ -0,0 +1,327 @@
+// Package immunization holds the national immunization schedule and works
+// out which doses a patient has due from their date of birth and the doses
+// already given.
+package immunization
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Statuses of a scheduled dose. A dose is upcoming until its due date, or
+// while an earlier dose of the vaccine is outstanding, due from then,
+// overdue once the grace period after the due date is over and lapsed once
+// the patient is too old to be given it.
+const (
+	StatusGiven    = "given"
+	StatusUpcoming = "upcoming"
+	StatusDue      = "due"
+	StatusOverdue  = "overdue"
+	StatusLapsed   = "lapsed"
+)
+
+// Age is an age or an interval of years, months, weeks and days, written
+// like "6w", "9m", "1y6m" or "0d".
+type Age struct {
+	Years, Months, Days int
+}
+
+var agePattern = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)w)?(?:(\d+)d)?$`)
+
+// ParseAge reads an age like "1y6m" or "10w".
+func ParseAge(s string) (Age, error) {
+	m := agePattern.FindStringSubmatch(s)
+	if m == nil || s == "" {
+		return Age{}, fmt.Errorf("invalid age %q", s)
+	}
+
+	n := func(i int) int {
+		v, _ := strconv.Atoi(m[i])
+		return v
+	}
+	return Age{Years: n(1), Months: n(2), Days: n(3)*7 + n(4)}, nil
+}
+
+// After returns the date the age is reached by someone born on t.
+func (a Age) After(t time.Time) time.Time {
+	return t.AddDate(a.Years, a.Months, a.Days)
+}
+
+func (a *Age) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	age, err := ParseAge(s)
+	if err != nil {
+		return err
+	}
+	*a = age
+	return nil
+}
+
+func (a Age) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a Age) String() string {
+	var s string
+	if a.Years > 0 {
+		s += fmt.Sprintf("%dy", a.Years)
+	}
+	if a.Months > 0 {
+		s += fmt.Sprintf("%dm", a.Months)
+	}
+	switch {
+	case a.Days > 0 && a.Days%7 == 0:
+		s += fmt.Sprintf("%dw", a.Days/7)
+	case a.Days > 0:
+		s += fmt.Sprintf("%dd", a.Days)
+	}
+	if s == "" {
+		return "0d"
+	}
+	return s
+}
+
+// Dose is a dose of a vaccine in the schedule.
+type Dose struct {
+	// Number counts the doses of the vaccine from 1.
+	Number int    `json:"number" example:"1"`
+	Label  string `json:"label" example:"Pentavalent 1"`
+
+	// Age is when the dose is due and MaxAge when it can no longer be given.
+	Age    Age `json:"age" swaggertype:"string" example:"6w"`
+	MaxAge Age `json:"maxAge" swaggertype:"string" example:"1y"`
+
+	// MinInterval is the least time after the previous dose of the vaccine.
+	MinInterval *Age `json:"minInterval,omitempty" swaggertype:"string" example:"4w"`
+}
+
+// Vaccine is a vaccine of the schedule with its doses in order.
+type Vaccine struct {
+	Code  string `json:"code" example:"PENTA"`
+	Name  string `json:"name" example:"Pentavalent (DPT, Hep B, Hib)"`
+	Doses []Dose `json:"doses"`
+}
+
+// Schedule is a national immunization schedule.
+type Schedule struct {
+	Name string `json:"name" example:"India Universal Immunization Programme"`
+
+	// OverdueAfter is the grace period after the due date of a dose.
+	OverdueAfter Age `json:"overdueAfter" swaggertype:"string" example:"4w"`
+
+	Vaccines []Vaccine `json:"vaccines"`
+}
+
+//go:embed uip.json
+var defaultSchedule []byte
+
+var (
+	mu       sync.RWMutex
+	schedule *Schedule
+)
+
+func init() {
+	s, err := ParseSchedule(bytes.NewReader(defaultSchedule))
+	if err != nil {
+		panic(fmt.Sprintf("immunization: invalid embedded schedule: %v", err))
+	}
+	schedule = s
+}
+
+// ParseSchedule reads a schedule in the format of uip.json. The doses of
+// each vaccine must be numbered from 1 in order.
+func ParseSchedule(r io.Reader) (*Schedule, error) {
+	var s Schedule
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, v := range s.Vaccines {
+		if v.Code == "" || v.Name == "" || len(v.Doses) == 0 {
+			return nil, fmt.Errorf("vaccine without a code, a name or doses")
+		}
+		if seen[v.Code] {
+			return nil, fmt.Errorf("vaccine %s is listed twice", v.Code)
+		}
+		seen[v.Code] = true
+
+		for i, d := range v.Doses {
+			if d.Number != i+1 {
+				return nil, fmt.Errorf("dose %d of %s is numbered %d", i+1, v.Code, d.Number)
+			}
+			if !d.MaxAge.After(time.Time{}).After(d.Age.After(time.Time{})) {
+				return nil, fmt.Errorf("dose %d of %s has maxAge %s before age %s", d.Number, v.Code, d.MaxAge, d.Age)
+			}
+		}
+	}
+	return &s, nil
+}
+
+// LoadScheduleFile replaces the built-in schedule with the one in the file
+// at path.
+func LoadScheduleFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s, err := ParseSchedule(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	schedule = s
+	mu.Unlock()
+	return nil
+}
+
+// Current returns the schedule.
+func Current() *Schedule {
+	mu.RLock()
+	defer mu.RUnlock()
+	return schedule
+}
+
+// Lookup returns the vaccine of the schedule with the code.
+func Lookup(code string) (Vaccine, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, v := range schedule.Vaccines {
+		if v.Code == code {
+			return v, true
+		}
+	}
+	return Vaccine{}, false
+}
+
+// Eligible returns the earliest date of birth of a patient who could still
+// be given a dose of the schedule on day.
+func Eligible(day time.Time) time.Time {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	day = Date(day)
+	earliest := day
+	for _, v := range schedule.Vaccines {
+		for _, d := range v.Doses {
+			if born := day.AddDate(-d.MaxAge.Years, -d.MaxAge.Months, -d.MaxAge.Days); born.Before(earliest) {
+				earliest = born
+			}
+		}
+	}
+	return earliest
+}
+
+// Given is a dose recorded for the patient.
+type Given struct {
+	Vaccine string
+	Dose    int
+	On      time.Time
+}
+
+// DoseStatus is a scheduled dose as it stands for a patient.
+type DoseStatus struct {
+	Vaccine     string `json:"vaccine" example:"PENTA"`
+	VaccineName string `json:"vaccineName" example:"Pentavalent (DPT, Hep B, Hib)"`
+	Dose        int    `json:"dose" example:"2"`
+	Label       string `json:"label" example:"Pentavalent 2"`
+
+	// Status is given, upcoming, due, overdue or lapsed.
+	Status string `json:"status" example:"overdue"`
+
+	// DueDate is when the dose is due, later than the schedule's age when an
+	// earlier dose was given late. OverdueDate ends the grace period and
+	// LastDate is the last day it can be given.
+	DueDate     time.Time `json:"dueDate"`
+	OverdueDate time.Time `json:"overdueDate"`
+	LastDate    time.Time `json:"lastDate"`
+
+	// GivenOn is set once the dose was given.
+	GivenOn *time.Time `json:"givenOn,omitempty"`
+}
+
+// Date returns the calendar date of t as midnight UTC.
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Evaluate works out every dose of the schedule for a patient born on dob,
+// as of day. Only the calendar dates of the times are compared.
+func Evaluate(dob time.Time, given []Given, day time.Time) []DoseStatus {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	dob, day = Date(dob), Date(day)
+
+	var statuses []DoseStatus
+	for _, v := range schedule.Vaccines {
+		// the date the previous dose was given, or is expected to be
+		var previous time.Time
+		previousOutstanding := false
+
+		for i, d := range v.Doses {
+			due := d.Age.After(dob)
+			if i > 0 && d.MinInterval != nil {
+				if next := d.MinInterval.After(previous); next.After(due) {
+					due = next
+				}
+			}
+
+			status := DoseStatus{
+				Vaccine:     v.Code,
+				VaccineName: v.Name,
+				Dose:        d.Number,
+				Label:       d.Label,
+				DueDate:     due,
+				OverdueDate: schedule.OverdueAfter.After(due),
+				LastDate:    d.MaxAge.After(dob).AddDate(0, 0, -1),
+			}
+
+			on, ok := givenOn(given, v.Code, d.Number)
+			switch {
+			case ok:
+				status.Status = StatusGiven
+				status.GivenOn = &on
+			case day.After(status.LastDate):
+				status.Status = StatusLapsed
+			case previousOutstanding || day.Before(due):
+				status.Status = StatusUpcoming
+			case day.Before(status.OverdueDate):
+				status.Status = StatusDue
+			default:
+				status.Status = StatusOverdue
+			}
+			statuses = append(statuses, status)
+
+			previous = due
+			if ok {
+				previous = on
+			}
+			previousOutstanding = status.Status != StatusGiven && status.Status != StatusLapsed
+		}
+	}
+	return statuses
+}
+
+func givenOn(given []Given, vaccine string, dose int) (time.Time, bool) {
+	for _, g := range given {
+		if g.Vaccine == vaccine && g.Dose == dose {
+			return Date(g.On), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,210 @@
+package immunization
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testSchedule gives three doses of PENTA at 6, 10 and 14 weeks, at least
+// 4 weeks apart, until the first birthday.
+const testSchedule = `{
+	"name": "Test",
+	"overdueAfter": "4w",
+	"vaccines": [
+		{"code": "PENTA", "name": "Pentavalent", "doses": [
+			{"number": 1, "label": "Pentavalent 1", "age": "6w", "maxAge": "1y"},
+			{"number": 2, "label": "Pentavalent 2", "age": "10w", "maxAge": "1y", "minInterval": "4w"},
+			{"number": 3, "label": "Pentavalent 3", "age": "14w", "maxAge": "1y", "minInterval": "4w"}
+		]}
+	]
+}`
+
+// withSchedule replaces the schedule for the test.
+func withSchedule(t *testing.T, doc string) {
+	t.Helper()
+	s, err := ParseSchedule(strings.NewReader(doc))
+	require.NoError(t, err)
+
+	mu.Lock()
+	previous := schedule
+	schedule = s
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		schedule = previous
+		mu.Unlock()
+	})
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestEvaluate(t *testing.T) {
+	withSchedule(t, testSchedule)
+	// dose 1 is due on 12 Feb, overdue from 12 Mar; dose 2 is due on 12 Mar
+	dob := date("2026-01-01")
+
+	tests := []struct {
+		name   string
+		given  []Given
+		day    string
+		dose   int
+		status string
+		due    string
+	}{
+		{name: "Before the due date", day: "2026-02-11", dose: 1, status: StatusUpcoming, due: "2026-02-12"},
+		{name: "On the due date", day: "2026-02-12", dose: 1, status: StatusDue, due: "2026-02-12"},
+		{name: "Last day of the grace period", day: "2026-03-11", dose: 1, status: StatusDue, due: "2026-02-12"},
+		{name: "After the grace period", day: "2026-03-12", dose: 1, status: StatusOverdue, due: "2026-02-12"},
+		{
+			name:   "Given",
+			given:  []Given{{Vaccine: "PENTA", Dose: 1, On: date("2026-02-14")}},
+			day:    "2026-03-20",
+			dose:   1,
+			status: StatusGiven,
+			due:    "2026-02-12",
+		},
+		{
+			// dose 2 waits for dose 1, even once past its own due date
+			name:   "Earlier dose outstanding",
+			day:    "2026-03-20",
+			dose:   2,
+			status: StatusUpcoming,
+			due:    "2026-03-12",
+		},
+		{
+			name:   "Earlier dose given on time",
+			given:  []Given{{Vaccine: "PENTA", Dose: 1, On: date("2026-02-12")}},
+			day:    "2026-03-12",
+			dose:   2,
+			status: StatusDue,
+			due:    "2026-03-12",
+		},
+		{
+			// dose 1 given late on 1 Apr moves dose 2 to 4 weeks after it
+			name:   "Min interval after a late dose",
+			given:  []Given{{Vaccine: "PENTA", Dose: 1, On: date("2026-04-01")}},
+			day:    "2026-04-20",
+			dose:   2,
+			status: StatusUpcoming,
+			due:    "2026-04-29",
+		},
+		{
+			name:   "Due after the min interval",
+			given:  []Given{{Vaccine: "PENTA", Dose: 1, On: date("2026-04-01")}},
+			day:    "2026-04-29",
+			dose:   2,
+			status: StatusDue,
+			due:    "2026-04-29",
+		},
+		{
+			// the interval counts from when dose 2 is expected, not given
+			name: "Min interval chained through an expected dose",
+			given: []Given{
+				{Vaccine: "PENTA", Dose: 1, On: date("2026-04-01")},
+			},
+			day:    "2026-05-01",
+			dose:   3,
+			status: StatusUpcoming,
+			due:    "2026-05-27",
+		},
+		{name: "Last day before maxAge", day: "2026-12-31", dose: 1, status: StatusOverdue, due: "2026-02-12"},
+		{name: "Lapsed at maxAge", day: "2027-01-01", dose: 1, status: StatusLapsed, due: "2026-02-12"},
+		{
+			// a lapsed dose doesn't hold back the ones after it
+			name:   "Next dose after a lapsed one",
+			day:    "2027-01-01",
+			dose:   2,
+			status: StatusLapsed,
+			due:    "2026-03-12",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := Evaluate(dob, tt.given, date(tt.day))
+			require.Len(t, statuses, 3)
+
+			s := statuses[tt.dose-1]
+			require.Equal(t, tt.dose, s.Dose)
+			require.Equal(t, tt.status, s.Status)
+			require.Equal(t, date(tt.due), s.DueDate)
+			require.Equal(t, date("2026-12-31"), s.LastDate)
+			require.Equal(t, tt.status == StatusGiven, s.GivenOn != nil)
+		})
+	}
+}
+
+func TestEvaluateComparesDates(t *testing.T) {
+	withSchedule(t, testSchedule)
+
+	// born late in the evening and checked early in the morning of the due
+	// date: only the calendar dates count
+	statuses := Evaluate(
+		time.Date(2026, time.January, 1, 23, 30, 0, 0, time.UTC),
+		nil,
+		time.Date(2026, time.February, 12, 0, 5, 0, 0, time.UTC),
+	)
+	require.Equal(t, StatusDue, statuses[0].Status)
+	require.Equal(t, date("2026-03-12"), statuses[0].OverdueDate)
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Age
+	}{
+		{"0d", Age{}},
+		{"6w", Age{Days: 42}},
+		{"9m", Age{Months: 9}},
+		{"1y6m", Age{Years: 1, Months: 6}},
+		{"1y2m3w4d", Age{Years: 1, Months: 2, Days: 25}},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, in := range []string{"", "6", "w6", "1m1y", "six weeks"} {
+		_, err := ParseAge(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+	}{
+		{"Vaccine without doses", `{"vaccines": [{"code": "BCG", "name": "BCG", "doses": []}]}`},
+		{"Vaccine listed twice", `{"vaccines": [
+			{"code": "BCG", "name": "BCG", "doses": [{"number": 1, "age": "0d", "maxAge": "1y"}]},
+			{"code": "BCG", "name": "BCG", "doses": [{"number": 1, "age": "0d", "maxAge": "1y"}]}
+		]}`},
+		{"Doses out of order", `{"vaccines": [{"code": "OPV", "name": "OPV", "doses": [
+			{"number": 2, "age": "6w", "maxAge": "2y"}
+		]}]}`},
+		{"maxAge before age", `{"vaccines": [{"code": "MR", "name": "MR", "doses": [
+			{"number": 1, "age": "9m", "maxAge": "6m"}
+		]}]}`},
+		{"Invalid age", `{"vaccines": [{"code": "MR", "name": "MR", "doses": [
+			{"number": 1, "age": "nine months", "maxAge": "5y"}
+		]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchedule(strings.NewReader(tt.schedule))
+			require.Error(t, err)
+		})
+	}
+}
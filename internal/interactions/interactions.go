@@ -0,0 +1,376 @@
+// Package interactions checks a drug being prescribed against the patient's
+// allergies and current medications using a locally bundled dataset of drug
+// classes, allergy cross-sensitivities and drug–drug interactions.
+package interactions
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Warning severities, from the least to the most serious. Severe warnings
+// need an override reason to prescribe.
+const (
+	SeverityMinor    = "minor"
+	SeverityModerate = "moderate"
+	SeveritySevere   = "severe"
+)
+
+// Warning types.
+const (
+	TypeDrugAllergy      = "drug-allergy"
+	TypeDrugDrug         = "drug-drug"
+	TypeDuplicateTherapy = "duplicate-therapy"
+)
+
+var severityRank = map[string]int{SeverityMinor: 1, SeverityModerate: 2, SeveritySevere: 3}
+
+// Class is a group of drugs, matched by RxNorm code or by name.
+type Class struct {
+	Name  string   `json:"name"`
+	Codes []string `json:"codes,omitempty"`
+	Names []string `json:"names"`
+
+	// DuplicateTherapy warns when two drugs of the class are prescribed
+	// together.
+	DuplicateTherapy bool `json:"duplicateTherapy,omitempty"`
+}
+
+// CrossSensitivity warns when a drug of class Drug is prescribed to a
+// patient allergic to a drug of class Allergy.
+type CrossSensitivity struct {
+	Allergy  string `json:"allergy"`
+	Drug     string `json:"drug"`
+	Severity string `json:"severity"`
+
+	// Message may refer to the prescribed drug as {{drug}} and the allergen
+	// as {{other}}.
+	Message string `json:"message"`
+}
+
+// Interaction warns when drugs of the two classes are taken together.
+type Interaction struct {
+	Drugs    [2]string `json:"drugs"`
+	Severity string    `json:"severity"`
+
+	// Message may refer to the prescribed drug as {{drug}} and the current
+	// medication as {{other}}.
+	Message string `json:"message"`
+}
+
+// Dataset is the interaction data checked against.
+type Dataset struct {
+	Classes            map[string]Class   `json:"classes"`
+	CrossSensitivities []CrossSensitivity `json:"crossSensitivities"`
+	Interactions       []Interaction      `json:"interactions"`
+}
+
+// Drug identifies a drug by its RxNorm code, when coded, and its name.
+type Drug struct {
+	Code string
+	Name string
+}
+
+// Allergy is an allergy of the patient to a drug.
+type Allergy struct {
+	ID string
+	Drug
+}
+
+// Medication is a medication the patient is currently taking.
+type Medication struct {
+	ID string
+	Drug
+}
+
+// Warning is a problem found with prescribing a drug.
+type Warning struct {
+	// Type is drug-allergy, drug-drug or duplicate-therapy.
+	Type string `json:"type" example:"drug-allergy"`
+
+	// Severity is minor, moderate or severe.
+	Severity string `json:"severity" example:"severe"`
+	Message  string `json:"message" example:"Amoxicillin is a penicillin and the patient is allergic to Penicillin V"`
+
+	// SubjectID is the allergy or medication the drug conflicts with.
+	SubjectID string `json:"subjectId"`
+}
+
+//go:embed dataset.json
+var bundledDataset []byte
+
+var (
+	mu      sync.RWMutex
+	dataset *Dataset
+)
+
+func init() {
+	ds, err := ParseDataset(bytes.NewReader(bundledDataset))
+	if err != nil {
+		panic(fmt.Sprintf("interactions: invalid bundled dataset: %v", err))
+	}
+	dataset = ds
+}
+
+// ParseDataset reads a dataset in the format of dataset.json.
+func ParseDataset(r io.Reader) (*Dataset, error) {
+	var ds Dataset
+	if err := json.NewDecoder(r).Decode(&ds); err != nil {
+		return nil, err
+	}
+
+	for id, class := range ds.Classes {
+		if class.Name == "" || len(class.Codes)+len(class.Names) == 0 {
+			return nil, fmt.Errorf("class %q needs a name and codes or names", id)
+		}
+		for i, name := range class.Names {
+			class.Names[i] = normaliseName(name)
+		}
+	}
+
+	check := func(what, severity string, classes ...string) error {
+		if _, ok := severityRank[severity]; !ok {
+			return fmt.Errorf("%s has unknown severity %q", what, severity)
+		}
+		for _, c := range classes {
+			if _, ok := ds.Classes[c]; !ok {
+				return fmt.Errorf("%s refers to unknown class %q", what, c)
+			}
+		}
+		return nil
+	}
+	for _, cs := range ds.CrossSensitivities {
+		if err := check("cross-sensitivity "+cs.Allergy+"/"+cs.Drug, cs.Severity, cs.Allergy, cs.Drug); err != nil {
+			return nil, err
+		}
+	}
+	for _, in := range ds.Interactions {
+		if err := check("interaction "+in.Drugs[0]+"/"+in.Drugs[1], in.Severity, in.Drugs[0], in.Drugs[1]); err != nil {
+			return nil, err
+		}
+	}
+	return &ds, nil
+}
+
+// LoadDatasetFile replaces the bundled dataset with the one in the file at
+// path.
+func LoadDatasetFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ds, err := ParseDataset(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	dataset = ds
+	mu.Unlock()
+	return nil
+}
+
+// Check returns the warnings for prescribing drug to a patient with the
+// allergies who takes the medications, the most severe first.
+func Check(drug Drug, allergies []Allergy, medications []Medication) []Warning {
+	mu.RLock()
+	defer mu.RUnlock()
+	return dataset.Check(drug, allergies, medications)
+}
+
+// Check returns the warnings for prescribing drug, at most one per allergy
+// or medication, the most severe first.
+func (ds *Dataset) Check(drug Drug, allergies []Allergy, medications []Medication) []Warning {
+	warnings := []Warning{}
+	drugClasses := ds.classesOf(drug)
+
+	for _, a := range allergies {
+		if same(drug, a.Drug) {
+			warnings = append(warnings, Warning{
+				Type:      TypeDrugAllergy,
+				Severity:  SeveritySevere,
+				Message:   fmt.Sprintf("The patient is allergic to %s", a.Name),
+				SubjectID: a.ID,
+			})
+			continue
+		}
+
+		var worst *Warning
+		allergyClasses := ds.classesOf(a.Drug)
+		for _, cs := range ds.CrossSensitivities {
+			if allergyClasses[cs.Allergy] && drugClasses[cs.Drug] {
+				worst = worse(worst, &Warning{
+					Type:      TypeDrugAllergy,
+					Severity:  cs.Severity,
+					Message:   render(cs.Message, drug.Name, a.Name),
+					SubjectID: a.ID,
+				})
+			}
+		}
+		if worst != nil {
+			warnings = append(warnings, *worst)
+		}
+	}
+
+	for _, m := range medications {
+		if same(drug, m.Drug) {
+			warnings = append(warnings, Warning{
+				Type:      TypeDuplicateTherapy,
+				Severity:  SeverityModerate,
+				Message:   fmt.Sprintf("%s is already prescribed", m.Name),
+				SubjectID: m.ID,
+			})
+			continue
+		}
+
+		var worst *Warning
+		medClasses := ds.classesOf(m.Drug)
+		for _, in := range ds.Interactions {
+			a, b := in.Drugs[0], in.Drugs[1]
+			if (drugClasses[a] && medClasses[b]) || (drugClasses[b] && medClasses[a]) {
+				worst = worse(worst, &Warning{
+					Type:      TypeDrugDrug,
+					Severity:  in.Severity,
+					Message:   render(in.Message, drug.Name, m.Name),
+					SubjectID: m.ID,
+				})
+			}
+		}
+		// in the order of the class IDs, so that the same class names the
+		// duplication every time
+		for _, id := range slices.Sorted(maps.Keys(drugClasses)) {
+			if class := ds.Classes[id]; class.DuplicateTherapy && medClasses[id] {
+				worst = worse(worst, &Warning{
+					Type:      TypeDuplicateTherapy,
+					Severity:  SeverityModerate,
+					Message:   fmt.Sprintf("%s and %s are both %s", drug.Name, m.Name, class.Name),
+					SubjectID: m.ID,
+				})
+			}
+		}
+		if worst != nil {
+			warnings = append(warnings, *worst)
+		}
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return severityRank[warnings[i].Severity] > severityRank[warnings[j].Severity]
+	})
+	return warnings
+}
+
+// classesOf returns the IDs of the classes the drug belongs to. A drug
+// named by free text belongs to a class when one of the class's names is
+// among its ingredients, so that "Amoxicillin 500 mg capsules" is a
+// penicillin.
+func (ds *Dataset) classesOf(d Drug) map[string]bool {
+	words := ingredientWords(d.Name)
+	classes := make(map[string]bool)
+	for id, class := range ds.Classes {
+		if d.Code != "" && contains(class.Codes, d.Code) {
+			classes[id] = true
+			continue
+		}
+		for _, name := range class.Names {
+			if containsPhrase(words, ingredientWords(name)) {
+				classes[id] = true
+				break
+			}
+		}
+	}
+	return classes
+}
+
+// same reports whether the drugs are the same: the same code when both are
+// coded, otherwise the same ingredients whatever the strength and form.
+func same(a, b Drug) bool {
+	if a.Code != "" && b.Code != "" {
+		return a.Code == b.Code
+	}
+	ia, ib := ingredientWords(a.Name), ingredientWords(b.Name)
+	return len(ia) > 0 && slices.Equal(ia, ib)
+}
+
+// doseWords are the words of a drug name that give its strength or form
+// rather than its ingredients.
+var doseWords = map[string]bool{
+	"mg": true, "g": true, "mcg": true, "ug": true, "µg": true, "ml": true, "l": true,
+	"iu": true, "unit": true, "units": true, "dose": true, "puff": true, "%": true,
+	"tab": true, "tabs": true, "tablet": true, "tablets": true,
+	"cap": true, "caps": true, "capsule": true, "capsules": true,
+	"syrup": true, "suspension": true, "solution": true, "injection": true, "inj": true,
+	"cream": true, "ointment": true, "gel": true, "drops": true, "inhaler": true,
+	"sachet": true, "sachets": true, "patch": true, "patches": true,
+	"oral": true, "iv": true, "im": true, "sc": true,
+	"mr": true, "sr": true, "xl": true, "er": true,
+}
+
+// ingredientWords splits a drug name into lower-case words and drops the
+// words giving its strength or form, e.g. "Amoxicillin 500mg Capsules"
+// becomes [amoxicillin].
+func ingredientWords(name string) []string {
+	fields := strings.FieldsFunc(normaliseName(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '%' && r != '.'
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.Trim(f, "-.")
+		if f == "" || doseWords[f] || unicode.IsDigit([]rune(f)[0]) {
+			continue
+		}
+		words = append(words, f)
+	}
+	return words
+}
+
+// containsPhrase reports whether the words of phrase appear next to each
+// other in words.
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// worse returns the more severe of the warnings, keeping w on a tie.
+func worse(w, other *Warning) *Warning {
+	if w == nil || severityRank[other.Severity] > severityRank[w.Severity] {
+		return other
+	}
+	return w
+}
+
+func render(message, drug, other string) string {
+	return strings.NewReplacer("{{drug}}", drug, "{{other}}", other).Replace(message)
+}
+
+func normaliseName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
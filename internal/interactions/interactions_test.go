@@ -0,0 +1,265 @@
+package interactions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func bundled(t *testing.T) *Dataset {
+	t.Helper()
+	ds, err := ParseDataset(bytes.NewReader(bundledDataset))
+	require.NoError(t, err)
+	return ds
+}
+
+func TestCheckAllergies(t *testing.T) {
+	ds := bundled(t)
+
+	tests := []struct {
+		name      string
+		drug      Drug
+		allergies []Allergy
+		severity  string
+		message   string
+	}{
+		{
+			name:      "Same drug",
+			drug:      Drug{Name: "Amoxicillin"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "amoxicillin"}}},
+			severity:  SeveritySevere,
+			message:   "The patient is allergic to amoxicillin",
+		},
+		{
+			name:      "Same drug with a strength and form",
+			drug:      Drug{Name: "Amoxicillin 500 mg capsules"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "Amoxicillin"}}},
+			severity:  SeveritySevere,
+			message:   "The patient is allergic to Amoxicillin",
+		},
+		{
+			name:      "Same coded drug under another name",
+			drug:      Drug{Code: "723", Name: "Amoxil"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Code: "723", Name: "Amoxicillin"}}},
+			severity:  SeveritySevere,
+			message:   "The patient is allergic to Amoxicillin",
+		},
+		{
+			name:      "Class cross-sensitivity",
+			drug:      Drug{Name: "Amoxicillin 250mg/5ml oral suspension"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "Penicillin V"}}},
+			severity:  SeveritySevere,
+			message:   "Amoxicillin 250mg/5ml oral suspension is a penicillin and the patient is allergic to Penicillin V",
+		},
+		{
+			name:      "Weaker cross-sensitivity",
+			drug:      Drug{Name: "Cefalexin 500mg"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "Penicillin"}}},
+			severity:  SeverityModerate,
+		},
+		{
+			name:      "Coded class member",
+			drug:      Drug{Code: "2193", Name: "Keflex"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "Cephalexin"}}},
+			severity:  SeveritySevere,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := ds.Check(tt.drug, tt.allergies, nil)
+			require.Len(t, warnings, 1)
+			require.Equal(t, TypeDrugAllergy, warnings[0].Type)
+			require.Equal(t, tt.severity, warnings[0].Severity)
+			require.Equal(t, "a1", warnings[0].SubjectID)
+			if tt.message != "" {
+				require.Equal(t, tt.message, warnings[0].Message)
+			}
+		})
+	}
+}
+
+func TestCheckNoWarnings(t *testing.T) {
+	ds := bundled(t)
+
+	tests := []struct {
+		name        string
+		drug        Drug
+		allergies   []Allergy
+		medications []Medication
+	}{
+		{
+			name:      "Unrelated allergy",
+			drug:      Drug{Name: "Paracetamol 500 mg"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "Penicillin"}}},
+		},
+		{
+			name:        "Unrelated medication",
+			drug:        Drug{Name: "Amoxicillin"},
+			medications: []Medication{{ID: "m1", Drug: Drug{Name: "Lisinopril 10 mg"}}},
+		},
+		{
+			// a name only sharing part of a word isn't the same ingredient
+			name:      "Partial word",
+			drug:      Drug{Name: "Cefuroxime"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "Cef"}}},
+		},
+		{
+			name:      "Strength only",
+			drug:      Drug{Name: "500 mg"},
+			allergies: []Allergy{{ID: "a1", Drug: Drug{Name: "250 mg"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Empty(t, ds.Check(tt.drug, tt.allergies, tt.medications))
+		})
+	}
+}
+
+func TestCheckMedications(t *testing.T) {
+	ds := bundled(t)
+
+	tests := []struct {
+		name        string
+		drug        Drug
+		medications []Medication
+		typ         string
+		severity    string
+		message     string
+	}{
+		{
+			name:        "Interaction",
+			drug:        Drug{Name: "Ibuprofen 400mg tablets"},
+			medications: []Medication{{ID: "m1", Drug: Drug{Name: "Warfarin 5 mg"}}},
+			typ:         TypeDrugDrug,
+			severity:    SeveritySevere,
+			message:     "Ibuprofen 400mg tablets with Warfarin 5 mg raises the risk of serious bleeding",
+		},
+		{
+			name:        "Interaction either way round",
+			drug:        Drug{Name: "Warfarin"},
+			medications: []Medication{{ID: "m1", Drug: Drug{Name: "Metronidazole 400mg"}}},
+			typ:         TypeDrugDrug,
+			severity:    SeveritySevere,
+		},
+		{
+			name:        "Already prescribed",
+			drug:        Drug{Name: "Ramipril 5 mg"},
+			medications: []Medication{{ID: "m1", Drug: Drug{Name: "ramipril 2.5mg capsules"}}},
+			typ:         TypeDuplicateTherapy,
+			severity:    SeverityModerate,
+			message:     "ramipril 2.5mg capsules is already prescribed",
+		},
+		{
+			name:        "Duplicate therapy",
+			drug:        Drug{Name: "Naproxen"},
+			medications: []Medication{{ID: "m1", Drug: Drug{Name: "Ibuprofen"}}},
+			typ:         TypeDuplicateTherapy,
+			severity:    SeverityModerate,
+			message:     "Naproxen and Ibuprofen are both NSAIDs",
+		},
+		{
+			// clarithromycin is in two macrolide classes, but the
+			// medication gets a single warning
+			name:        "One warning per medication",
+			drug:        Drug{Name: "Clarithromycin"},
+			medications: []Medication{{ID: "m1", Drug: Drug{Name: "Carbamazepine 200 mg MR"}}},
+			typ:         TypeDrugDrug,
+			severity:    SeveritySevere,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := ds.Check(tt.drug, nil, tt.medications)
+			require.Len(t, warnings, 1)
+			require.Equal(t, tt.typ, warnings[0].Type)
+			require.Equal(t, tt.severity, warnings[0].Severity)
+			require.Equal(t, "m1", warnings[0].SubjectID)
+			if tt.message != "" {
+				require.Equal(t, tt.message, warnings[0].Message)
+			}
+		})
+	}
+}
+
+func TestCheckDuplicateTherapyDeterministic(t *testing.T) {
+	ds, err := ParseDataset(strings.NewReader(`{"classes": {
+		"statins": {"name": "statins", "names": ["atorvastatin", "simvastatin"], "duplicateTherapy": true},
+		"lipid-lowering": {"name": "lipid-lowering drugs", "names": ["atorvastatin", "simvastatin"], "duplicateTherapy": true}
+	}}`))
+	require.NoError(t, err)
+
+	// both classes are duplicated; the one with the first ID names it
+	for range 20 {
+		warnings := ds.Check(Drug{Name: "Atorvastatin"}, nil, []Medication{{ID: "m1", Drug: Drug{Name: "Simvastatin"}}})
+		require.Len(t, warnings, 1)
+		require.Equal(t, "Atorvastatin and Simvastatin are both lipid-lowering drugs", warnings[0].Message)
+	}
+}
+
+func TestCheckOrdersBySeverity(t *testing.T) {
+	ds := bundled(t)
+
+	warnings := ds.Check(
+		Drug{Name: "Ibuprofen"},
+		[]Allergy{{ID: "a1", Drug: Drug{Name: "Aspirin"}}},
+		[]Medication{
+			{ID: "m1", Drug: Drug{Name: "Lisinopril"}},
+			{ID: "m2", Drug: Drug{Name: "Warfarin"}},
+		},
+	)
+
+	require.Len(t, warnings, 3)
+	require.Equal(t, SeveritySevere, warnings[0].Severity)
+	require.Equal(t, "a1", warnings[0].SubjectID)
+	require.Equal(t, SeveritySevere, warnings[1].Severity)
+	require.Equal(t, "m2", warnings[1].SubjectID)
+	require.Equal(t, SeverityModerate, warnings[2].Severity)
+	require.Equal(t, "m1", warnings[2].SubjectID)
+}
+
+func TestIngredientWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Amoxicillin", []string{"amoxicillin"}},
+		{"Amoxicillin 500 mg", []string{"amoxicillin"}},
+		{"Amoxicillin 500mg Capsules", []string{"amoxicillin"}},
+		{"Amoxicillin/Clavulanate 625 mg", []string{"amoxicillin", "clavulanate"}},
+		{"Co-amoxiclav 625mg tablets", []string{"co-amoxiclav"}},
+		{"Mefenamic acid 250 mg", []string{"mefenamic", "acid"}},
+		{"Hydrocortisone 1% cream", []string{"hydrocortisone"}},
+		{"Salbutamol 100mcg/dose inhaler", []string{"salbutamol"}},
+		{"", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ingredientWords(tt.name))
+		})
+	}
+}
+
+func TestParseDatasetInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataset string
+	}{
+		{"Class without names", `{"classes": {"c": {"name": "c"}}}`},
+		{"Unknown class", `{"classes": {"c": {"name": "c", "names": ["x"]}}, "interactions": [{"drugs": ["c", "d"], "severity": "minor", "message": "m"}]}`},
+		{"Unknown severity", `{"classes": {"c": {"name": "c", "names": ["x"]}}, "crossSensitivities": [{"allergy": "c", "drug": "c", "severity": "fatal", "message": "m"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDataset(strings.NewReader(tt.dataset))
+			require.Error(t, err)
+		})
+	}
+}
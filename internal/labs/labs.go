@@ -0,0 +1,267 @@
+// Package labs holds the laboratory test catalogue and interprets results
+// against the reference ranges in it.
+package labs
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+)
+
+// Statuses of an order. An order is partial once some of its tests have a
+// result and completed once all of them have. Only an order without results
+// can be cancelled.
+const (
+	StatusOrdered   = "ordered"
+	StatusPartial   = "partial"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+)
+
+// Priorities of an order.
+const (
+	PriorityRoutine = "routine"
+	PriorityUrgent  = "urgent"
+	PriorityStat    = "stat"
+)
+
+// Sources of a result.
+const (
+	SourceManual   = "manual"
+	SourceIngested = "ingested"
+)
+
+// FlagAbnormal marks a qualitative result other than the expected ones.
+const FlagAbnormal clinical.Flag = "abnormal"
+
+// Test is a test that can be ordered. A quantitative test has a unit and
+// reference ranges; a qualitative one lists its normal values instead.
+type Test struct {
+	Code     string `json:"code" example:"HGB"`
+	Name     string `json:"name" example:"Haemoglobin"`
+	Specimen string `json:"specimen,omitempty" example:"Whole blood (EDTA)"`
+
+	// Unit is the unit results are stored and reported in.
+	Unit string `json:"unit,omitempty" example:"g/dL"`
+
+	// Conversions maps another unit results may be reported in to the factor
+	// turning a value in it into Unit.
+	Conversions map[string]float64 `json:"conversions,omitempty"`
+
+	Ranges []clinical.ReferenceRange `json:"ranges,omitempty"`
+
+	// NormalValues are the expected results of a qualitative test.
+	NormalValues []string `json:"normalValues,omitempty" example:"negative"`
+}
+
+// Qualitative reports whether results of the test are text.
+func (t Test) Qualitative() bool {
+	return t.Unit == ""
+}
+
+// ToUnit converts a value reported in unit to the unit of the test. An empty
+// unit is the test's own.
+func (t Test) ToUnit(value float64, unit string) (float64, error) {
+	if unit == "" || unit == t.Unit {
+		return value, nil
+	}
+	factor, ok := t.Conversions[unit]
+	if !ok {
+		return 0, fmt.Errorf("%s is reported in %s", t.Code, t.unitNames())
+	}
+	return value * factor, nil
+}
+
+func (t Test) unitNames() string {
+	units := []string{t.Unit}
+	for u := range t.Conversions {
+		units = append(units, u)
+	}
+	slices.Sort(units[1:])
+	return strings.Join(units, ", ")
+}
+
+// Range returns the reference range of the test for a patient of the given
+// sex and age in years, or nil when none applies.
+func (t Test) Range(sex string, age float64) *clinical.ReferenceRange {
+	return clinical.SelectRange(t.Ranges, t.Code, sex, age)
+}
+
+// Interpret flags a qualitative result. It returns "" when the test lists
+// no normal values.
+func (t Test) Interpret(value string) clinical.Flag {
+	if len(t.NormalValues) == 0 {
+		return ""
+	}
+	for _, v := range t.NormalValues {
+		if strings.EqualFold(v, strings.TrimSpace(value)) {
+			return clinical.FlagNormal
+		}
+	}
+	return FlagAbnormal
+}
+
+// Panel is a group of tests ordered together.
+type Panel struct {
+	Code  string   `json:"code" example:"CBC"`
+	Name  string   `json:"name" example:"Complete blood count"`
+	Tests []string `json:"tests" example:"HGB,WBC,PLT"`
+}
+
+// Catalogue is the tests and panels that can be ordered.
+type Catalogue struct {
+	Tests  []Test  `json:"tests"`
+	Panels []Panel `json:"panels"`
+}
+
+//go:embed catalogue.json
+var defaultCatalogue []byte
+
+var (
+	mu        sync.RWMutex
+	catalogue *Catalogue
+)
+
+func init() {
+	c, err := ParseCatalogue(bytes.NewReader(defaultCatalogue))
+	if err != nil {
+		panic(fmt.Sprintf("labs: invalid embedded catalogue: %v", err))
+	}
+	catalogue = c
+}
+
+// ParseCatalogue reads a test catalogue in the format of catalogue.json.
+// The ranges of a test don't name a metric; they are given its code.
+func ParseCatalogue(r io.Reader) (*Catalogue, error) {
+	var c Catalogue
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]bool)
+	for i := range c.Tests {
+		t := &c.Tests[i]
+		if t.Code == "" || t.Name == "" {
+			return nil, fmt.Errorf("lab test without a code or a name")
+		}
+		if codes[t.Code] {
+			return nil, fmt.Errorf("lab test %s is listed twice", t.Code)
+		}
+		codes[t.Code] = true
+
+		if t.Qualitative() && (len(t.Ranges) > 0 || len(t.Conversions) > 0) {
+			return nil, fmt.Errorf("lab test %s has ranges or conversions but no unit", t.Code)
+		}
+		for u, factor := range t.Conversions {
+			if factor <= 0 {
+				return nil, fmt.Errorf("lab test %s converts %s with a factor of %g", t.Code, u, factor)
+			}
+		}
+		for j := range t.Ranges {
+			rr := &t.Ranges[j]
+			rr.Metric = t.Code
+			if rr.MaxAge != 0 && rr.MaxAge <= rr.MinAge {
+				return nil, fmt.Errorf("reference range for %s has maxAge %g <= minAge %g", t.Code, rr.MaxAge, rr.MinAge)
+			}
+		}
+	}
+
+	for _, p := range c.Panels {
+		if p.Code == "" || p.Name == "" || len(p.Tests) == 0 {
+			return nil, fmt.Errorf("lab panel without a code, a name or tests")
+		}
+		if codes[p.Code] {
+			return nil, fmt.Errorf("lab panel %s has the code of another test or panel", p.Code)
+		}
+		codes[p.Code] = true
+		for _, code := range p.Tests {
+			if !slices.ContainsFunc(c.Tests, func(t Test) bool { return t.Code == code }) {
+				return nil, fmt.Errorf("lab panel %s lists unknown test %s", p.Code, code)
+			}
+		}
+	}
+	return &c, nil
+}
+
+// LoadCatalogueFile replaces the built-in test catalogue with the one in
+// the file at path.
+func LoadCatalogueFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	c, err := ParseCatalogue(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	catalogue = c
+	mu.Unlock()
+	return nil
+}
+
+// Current returns the test catalogue.
+func Current() *Catalogue {
+	mu.RLock()
+	defer mu.RUnlock()
+	return catalogue
+}
+
+// Lookup returns the test with the code.
+func Lookup(code string) (Test, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, t := range catalogue.Tests {
+		if t.Code == code {
+			return t, true
+		}
+	}
+	return Test{}, false
+}
+
+// Position returns the place of the test with the code in the catalogue,
+// or -1 when it isn't in it. Results are tabulated in that order.
+func Position(code string) int {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.IndexFunc(catalogue.Tests, func(t Test) bool { return t.Code == code })
+}
+
+// Expand resolves the test and panel codes of an order to the distinct
+// tests, in the order they were given. It returns the first unknown code.
+func Expand(codes []string) ([]string, string) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var tests []string
+	add := func(code string) {
+		if !slices.Contains(tests, code) {
+			tests = append(tests, code)
+		}
+	}
+	for _, code := range codes {
+		if slices.ContainsFunc(catalogue.Tests, func(t Test) bool { return t.Code == code }) {
+			add(code)
+			continue
+		}
+		i := slices.IndexFunc(catalogue.Panels, func(p Panel) bool { return p.Code == code })
+		if i < 0 {
+			return nil, code
+		}
+		for _, t := range catalogue.Panels[i].Tests {
+			add(t)
+		}
+	}
+	return tests, ""
+}
@@ -0,0 +1,188 @@
+package labs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+)
+
+const testCatalogue = `{
+	"tests": [
+		{"code": "HGB", "name": "Haemoglobin", "unit": "g/dL", "conversions": {"g/L": 0.1, "mmol/L": 1.611},
+			"ranges": [
+				{"minAge": 0, "maxAge": 12, "low": 11, "high": 14.5},
+				{"minAge": 12, "low": 12, "high": 15.5},
+				{"sex": "MALE", "minAge": 12, "low": 13, "high": 17, "criticalLow": 7}
+			]},
+		{"code": "WBC", "name": "White cell count", "unit": "10^9/L"},
+		{"code": "PLT", "name": "Platelets", "unit": "10^9/L"},
+		{"code": "GLU", "name": "Glucose", "unit": "mg/dL"},
+		{"code": "UPRO", "name": "Urine protein", "normalValues": ["negative", "trace"]},
+		{"code": "CULT", "name": "Culture"}
+	],
+	"panels": [
+		{"code": "CBC", "name": "Complete blood count", "tests": ["HGB", "WBC", "PLT"]},
+		{"code": "ANAEMIA", "name": "Anaemia screen", "tests": ["HGB", "GLU"]}
+	]
+}`
+
+// withCatalogue replaces the test catalogue for the test.
+func withCatalogue(t *testing.T, doc string) {
+	t.Helper()
+	c, err := ParseCatalogue(strings.NewReader(doc))
+	require.NoError(t, err)
+
+	mu.Lock()
+	previous := catalogue
+	catalogue = c
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		catalogue = previous
+		mu.Unlock()
+	})
+}
+
+func lookup(t *testing.T, code string) Test {
+	t.Helper()
+	test, ok := Lookup(code)
+	require.True(t, ok, code)
+	return test
+}
+
+func TestToUnit(t *testing.T) {
+	withCatalogue(t, testCatalogue)
+	hgb := lookup(t, "HGB")
+
+	tests := []struct {
+		value float64
+		unit  string
+		want  float64
+	}{
+		{13.5, "", 13.5},
+		{13.5, "g/dL", 13.5},
+		{135, "g/L", 13.5},
+		{8.38, "mmol/L", 13.50018},
+	}
+	for _, tt := range tests {
+		got, err := hgb.ToUnit(tt.value, tt.unit)
+		require.NoError(t, err, tt.unit)
+		require.InDelta(t, tt.want, got, 1e-9, tt.unit)
+	}
+
+	_, err := hgb.ToUnit(13.5, "mg/dL")
+	require.EqualError(t, err, "HGB is reported in g/dL, g/L, mmol/L")
+}
+
+func TestRange(t *testing.T) {
+	withCatalogue(t, testCatalogue)
+	hgb := lookup(t, "HGB")
+
+	// the ranges are given the code of the test as their metric
+	rr := hgb.Range("MALE", 30)
+	require.NotNil(t, rr)
+	require.Equal(t, "HGB", rr.Metric)
+	require.Equal(t, 13.0, *rr.Low)
+	require.Equal(t, clinical.FlagLow, rr.Flag(12.5))
+	require.Equal(t, clinical.FlagCritical, rr.Flag(7))
+
+	require.Equal(t, 12.0, *hgb.Range("FEMALE", 30).Low)
+	require.Equal(t, 11.0, *hgb.Range("MALE", 11).Low)
+	require.Nil(t, lookup(t, "WBC").Range("MALE", 30))
+}
+
+func TestInterpret(t *testing.T) {
+	withCatalogue(t, testCatalogue)
+	upro := lookup(t, "UPRO")
+
+	tests := []struct {
+		value string
+		want  clinical.Flag
+	}{
+		{"negative", clinical.FlagNormal},
+		{"Trace", clinical.FlagNormal},
+		{"  NEGATIVE ", clinical.FlagNormal},
+		{"2+", FlagAbnormal},
+		{"", FlagAbnormal},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, upro.Interpret(tt.value), "%q", tt.value)
+	}
+
+	// a test without normal values isn't interpreted
+	require.Equal(t, clinical.Flag(""), lookup(t, "CULT").Interpret("E. coli"))
+}
+
+func TestExpand(t *testing.T) {
+	withCatalogue(t, testCatalogue)
+
+	tests := []struct {
+		name    string
+		codes   []string
+		want    []string
+		unknown string
+	}{
+		{"Tests", []string{"GLU", "HGB"}, []string{"GLU", "HGB"}, ""},
+		{"Panel", []string{"CBC"}, []string{"HGB", "WBC", "PLT"}, ""},
+		{"Test in a panel ordered too", []string{"HGB", "CBC"}, []string{"HGB", "WBC", "PLT"}, ""},
+		{"Overlapping panels", []string{"CBC", "ANAEMIA", "UPRO"}, []string{"HGB", "WBC", "PLT", "GLU", "UPRO"}, ""},
+		{"Same code twice", []string{"GLU", "GLU"}, []string{"GLU"}, ""},
+		{"Unknown code", []string{"CBC", "LFT", "TSH"}, nil, "LFT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown := Expand(tt.codes)
+			require.Equal(t, tt.unknown, unknown)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPosition(t *testing.T) {
+	withCatalogue(t, testCatalogue)
+
+	require.Equal(t, 0, Position("HGB"))
+	require.Equal(t, 3, Position("GLU"))
+	require.Equal(t, -1, Position("CBC"))
+}
+
+func TestParseCatalogueInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		catalogue string
+	}{
+		{"Test without a name", `{"tests": [{"code": "HGB", "unit": "g/dL"}]}`},
+		{"Test listed twice", `{"tests": [
+			{"code": "HGB", "name": "Haemoglobin", "unit": "g/dL"},
+			{"code": "HGB", "name": "Hemoglobin", "unit": "g/dL"}
+		]}`},
+		{"Ranges without a unit", `{"tests": [
+			{"code": "UPRO", "name": "Urine protein", "ranges": [{"minAge": 0, "high": 1}]}
+		]}`},
+		{"Conversions without a unit", `{"tests": [
+			{"code": "UPRO", "name": "Urine protein", "conversions": {"g/L": 0.1}}
+		]}`},
+		{"Non-positive factor", `{"tests": [
+			{"code": "HGB", "name": "Haemoglobin", "unit": "g/dL", "conversions": {"g/L": 0}}
+		]}`},
+		{"maxAge not above minAge", `{"tests": [
+			{"code": "HGB", "name": "Haemoglobin", "unit": "g/dL", "ranges": [{"minAge": 12, "maxAge": 6}]}
+		]}`},
+		{"Panel without tests", `{"tests": [{"code": "HGB", "name": "Haemoglobin", "unit": "g/dL"}],
+			"panels": [{"code": "CBC", "name": "Complete blood count", "tests": []}]}`},
+		{"Panel with the code of a test", `{"tests": [{"code": "HGB", "name": "Haemoglobin", "unit": "g/dL"}],
+			"panels": [{"code": "HGB", "name": "Haemoglobin panel", "tests": ["HGB"]}]}`},
+		{"Panel with an unknown test", `{"tests": [{"code": "HGB", "name": "Haemoglobin", "unit": "g/dL"}],
+			"panels": [{"code": "CBC", "name": "Complete blood count", "tests": ["HGB", "WBC"]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCatalogue(strings.NewReader(tt.catalogue))
+			require.Error(t, err)
+		})
+	}
+}
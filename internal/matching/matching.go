@@ -0,0 +1,260 @@
+package matching
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DuplicateThreshold is the minimum score at which an existing patient is
+// reported as a probable duplicate of a new registration.
+const DuplicateThreshold = 0.75
+
+const (
+	nameWeight    = 0.40
+	dobWeight     = 0.30
+	contactWeight = 0.20
+	genderWeight  = 0.10
+)
+
+var honorifics = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "miss": true, "dr": true,
+	"shri": true, "sri": true, "smt": true, "kumari": true, "km": true,
+	"master": true, "baby": true, "late": true,
+}
+
+// Demographics holds the fields used to compare two patients.
+type Demographics struct {
+	FullName      string
+	DOB           time.Time
+	Gender        string
+	ContactNumber string
+}
+
+// Result is the outcome of comparing two sets of demographics.
+type Result struct {
+	Score   float64
+	Reasons []string
+}
+
+// NormaliseName lowercases a name, strips punctuation and honorifics and
+// collapses whitespace so that "Dr. Ram  Kumar" and "ram kumar" compare equal.
+func NormaliseName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	var tokens []string
+	for _, t := range strings.Fields(b.String()) {
+		if honorifics[t] {
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// Compare scores how likely it is that a and b describe the same person.
+// The score is a weighted sum in the range [0, 1].
+func Compare(a, b Demographics) Result {
+	var res Result
+
+	name := nameSimilarity(a.FullName, b.FullName)
+	dob := dobSimilarity(a.DOB, b.DOB)
+	contact := contactSimilarity(a.ContactNumber, b.ContactNumber)
+	gender := 0.0
+	if strings.EqualFold(a.Gender, b.Gender) {
+		gender = 1
+	}
+
+	res.Score = name*nameWeight + dob*dobWeight + contact*contactWeight + gender*genderWeight
+
+	if name >= 0.9 {
+		res.Reasons = append(res.Reasons, "name")
+	}
+	if dob == 1 {
+		res.Reasons = append(res.Reasons, "dob")
+	}
+	if contact == 1 {
+		res.Reasons = append(res.Reasons, "contactNo")
+	}
+	if gender == 1 {
+		res.Reasons = append(res.Reasons, "gender")
+	}
+
+	return res
+}
+
+func nameSimilarity(a, b string) float64 {
+	na, nb := NormaliseName(a), NormaliseName(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+
+	jw := JaroWinkler(na, nb)
+	if sorted := JaroWinkler(sortTokens(na), sortTokens(nb)); sorted > jw {
+		jw = sorted
+	}
+
+	phonetic := jaccard(PhoneticKeys(na), PhoneticKeys(nb))
+
+	return 0.6*jw + 0.4*phonetic
+}
+
+func dobSimilarity(a, b time.Time) float64 {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+
+	switch {
+	case ay == by && am == bm && ad == bd:
+		return 1
+	case ay == by && int(am) == bd && ad == int(bm):
+		// day and month transposed on entry
+		return 0.8
+	case am == bm && ad == bd && abs(ay-by) == 1:
+		return 0.5
+	case ay == by && am == bm:
+		return 0.4
+	}
+	return 0
+}
+
+func contactSimilarity(a, b string) float64 {
+	a, b = lastDigits(a, 10), lastDigits(b, 10)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	if len(a) == len(b) && hamming(a, b) == 1 {
+		return 0.6
+	}
+	return 0
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of two strings in [0, 1].
+func JaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		lo, hi := max(0, i-window), min(len(rb), i+window+1)
+		for j := lo; j < hi; j++ {
+			if matchedB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+func sortTokens(s string) string {
+	tokens := strings.Fields(s)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := make(map[string]bool, len(a))
+	for _, k := range a {
+		set[k] = true
+	}
+	inter, union := 0, len(set)
+	seen := make(map[string]bool, len(b))
+	for _, k := range b {
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if set[k] {
+			inter++
+		} else {
+			union++
+		}
+	}
+	return float64(inter) / float64(union)
+}
+
+func lastDigits(s string, n int) string {
+	var digits []rune
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) > n {
+		digits = digits[len(digits)-n:]
+	}
+	return string(digits)
+}
+
+func hamming(a, b string) int {
+	d := 0
+	for i := range a {
+		if a[i] != b[i] {
+			d++
+		}
+	}
+	return d
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
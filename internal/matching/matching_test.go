@@ -0,0 +1,147 @@
+package matching
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		// reference pairs from Winkler (1990)
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.840},
+		{"dixon", "dicksonx", 0.813},
+		{"jones", "johnson", 0.832},
+		{"abroms", "abrams", 0.922},
+
+		{"ram", "ram", 1},
+		{"", "", 1},
+		{"ram", "", 0},
+		{"abc", "xyz", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			got := JaroWinkler(tt.a, tt.b)
+			require.InDelta(t, tt.want, got, 0.001)
+			require.InDelta(t, got, JaroWinkler(tt.b, tt.a), 1e-9)
+		})
+	}
+}
+
+func TestSoundex(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"Robert", "R163"},
+		{"Rupert", "R163"},
+		{"Rubin", "R150"},
+		{"Ashcraft", "A261"},
+		{"Ashcroft", "A261"},
+		{"Tymczak", "T522"},
+		{"Pfister", "P236"},
+		{"Honeyman", "H555"},
+		{"Lee", "L000"},
+		{"O'Hara", "O600"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			require.Equal(t, tt.want, Soundex(tt.word))
+		})
+	}
+}
+
+func TestNormaliseName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Dr. Ram  Kumar", "ram kumar"},
+		{"SMT. Sunita Devi", "sunita devi"},
+		{"Late Shri R.K. Sharma", "r k sharma"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, NormaliseName(tt.name))
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	dob := time.Date(1985, time.March, 4, 0, 0, 0, 0, time.UTC)
+	patient := Demographics{
+		FullName:      "Ramesh Kumar",
+		DOB:           dob,
+		Gender:        "male",
+		ContactNumber: "9876543210",
+	}
+
+	tests := []struct {
+		name      string
+		other     Demographics
+		duplicate bool
+		reasons   []string
+	}{
+		{
+			name:      "Same person",
+			other:     patient,
+			duplicate: true,
+			reasons:   []string{"name", "dob", "contactNo", "gender"},
+		},
+		{
+			name: "Honorific, token order and country code",
+			other: Demographics{
+				FullName:      "Mr. Kumar Ramesh",
+				DOB:           dob,
+				Gender:        "Male",
+				ContactNumber: "+91 98765 43210",
+			},
+			duplicate: true,
+			reasons:   []string{"name", "dob", "contactNo", "gender"},
+		},
+		{
+			name: "Day and month transposed, one digit of the number off",
+			other: Demographics{
+				FullName:      "Ramesh Kumar",
+				DOB:           time.Date(1985, time.April, 3, 0, 0, 0, 0, time.UTC),
+				Gender:        "male",
+				ContactNumber: "9876543211",
+			},
+			duplicate: true,
+			reasons:   []string{"name", "gender"},
+		},
+		{
+			name: "Different person born the same day",
+			other: Demographics{
+				FullName:      "Sunita Devi",
+				DOB:           dob,
+				Gender:        "female",
+				ContactNumber: "9123456780",
+			},
+			duplicate: false,
+			reasons:   []string{"dob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Compare(patient, tt.other)
+			require.Equal(t, tt.duplicate, res.Score >= DuplicateThreshold, "score %.3f", res.Score)
+			require.Equal(t, tt.reasons, res.Reasons)
+			require.False(t, math.IsNaN(res.Score))
+			require.LessOrEqual(t, res.Score, 1.0)
+		})
+	}
+}
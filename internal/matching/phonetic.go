@@ -0,0 +1,91 @@
+package matching
+
+import "strings"
+
+var soundexCodes = map[rune]byte{
+	'b': '1', 'f': '1', 'p': '1', 'v': '1',
+	'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+	'd': '3', 't': '3',
+	'l': '4',
+	'm': '5', 'n': '5',
+	'r': '6',
+}
+
+// Soundex returns the American Soundex code of a single word, e.g. "R163"
+// for both "Robert" and "Rupert". Non-letters are ignored.
+func Soundex(word string) string {
+	word = strings.ToLower(word)
+
+	var code []byte
+	var last byte
+	for _, r := range word {
+		if r < 'a' || r > 'z' {
+			continue
+		}
+		c := soundexCodes[r]
+		if len(code) == 0 {
+			code = append(code, byte(r-'a'+'A'))
+			last = c
+			continue
+		}
+		switch {
+		case c == 0 && r != 'h' && r != 'w':
+			// vowels separate repeated codes, h and w do not
+			last = 0
+		case c != 0 && c != last:
+			code = append(code, c)
+			last = c
+		}
+		if len(code) == 4 {
+			break
+		}
+	}
+	if len(code) == 0 {
+		return ""
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// transliterations folds the common alternative romanisations of Indian
+// names onto one spelling. Soundex already ignores vowels and most aspirates
+// after the first letter, so these mainly matter at the start of a word.
+var transliterations = strings.NewReplacer(
+	"ksh", "x",
+	"bh", "b",
+	"ch", "c",
+	"dh", "d",
+	"gh", "g",
+	"jh", "j",
+	"kh", "k",
+	"ph", "f",
+	"sh", "s",
+	"th", "t",
+	"w", "v",
+	"z", "j",
+	"q", "k",
+)
+
+// Transliterate normalises the spelling of a single lowercase name token, so
+// that e.g. "Phani"/"Fani", "Wasim"/"Vasim" and "Zoya"/"Joya" agree.
+func Transliterate(token string) string {
+	return transliterations.Replace(strings.ToLower(token))
+}
+
+// PhoneticKeys returns the Soundex code of every token in a normalised and
+// transliterated name. Duplicate codes are dropped.
+func PhoneticKeys(name string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, t := range strings.Fields(NormaliseName(name)) {
+		k := Soundex(Transliterate(t))
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,106 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Phani", "Fani"},
+		{"Wasim", "Vasim"},
+		{"Zoya", "Joya"},
+		{"Bharat", "Barat"},
+		{"Chandra", "Candra"},
+		{"Dhruv", "Druv"},
+		{"Ghanshyam", "Ganshyam"},
+		{"Jhansi", "Jansi"},
+		{"Khan", "Kan"},
+		{"Shiv", "Siv"},
+		{"Thakur", "Takur"},
+		{"Qadir", "Kadir"},
+		{"Lakshmi", "Laxmi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			require.Equal(t, Transliterate(tt.a), Transliterate(tt.b))
+		})
+	}
+}
+
+func TestPhoneticKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Robert", []string{"R163"}},
+		{"Ram Kumar", []string{"R500", "K560"}},
+		{"Dr. Ram Kumar", []string{"R500", "K560"}},
+		{"Kumar Ram", []string{"K560", "R500"}},
+		// the repeated key is dropped
+		{"Ram Ram", []string{"R500"}},
+		{"Phani Bhushan", []string{"F500", "B250"}},
+		{"", nil},
+		{"Mr.", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, PhoneticKeys(tt.name))
+		})
+	}
+}
+
+func TestPhoneticKeysAgree(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Phani Bhushan", "Fani Bushan"},
+		{"Wasim Akram", "Vasim Akram"},
+		{"Zoya Khan", "Joya Kan"},
+		{"Lakshmi Narayan", "Laxmi Narayan"},
+		{"Mohammed Shaikh", "Muhammad Sheikh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			require.Equal(t, PhoneticKeys(tt.a), PhoneticKeys(tt.b))
+		})
+	}
+}
+
+// Duplicate detection weighs the phonetic keys into the name similarity, so
+// alternative romanisations of a name are still reported.
+func TestCompareTransliteratedNames(t *testing.T) {
+	dob := time.Date(1990, time.July, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		a, b string
+	}{
+		{"Phani Bhushan", "Fani Bushan"},
+		{"Wasim Akram", "Vasim Akram"},
+		{"Lakshmi Narayan", "Laxmi Narayan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			// only the name and date of birth match, the number differs
+			res := Compare(
+				Demographics{FullName: tt.a, DOB: dob, Gender: "male", ContactNumber: "9876543210"},
+				Demographics{FullName: tt.b, DOB: dob, Gender: "male", ContactNumber: "9123456780"},
+			)
+			require.GreaterOrEqual(t, res.Score, DuplicateThreshold)
+			require.Contains(t, res.Reasons, "dob")
+		})
+	}
+
+	// without the phonetic keys the name score alone drops below the
+	// threshold for the same pair
+	jw := JaroWinkler(NormaliseName("Phani Bhushan"), NormaliseName("Fani Bushan"))
+	require.Less(t, jw*nameWeight+dobWeight+genderWeight, DuplicateThreshold)
+}
@@ -14,6 +14,36 @@ type AllergyStorer struct {
 	mock.Mock
 }
 
+// AssertNoKnownAllergies provides a mock function with given fields: ctx, req
+func (_m *AllergyStorer) AssertNoKnownAllergies(ctx context.Context, req *models.AssertNKAReq) (*models.NoKnownAllergies, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssertNoKnownAllergies")
+	}
+
+	var r0 *models.NoKnownAllergies
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AssertNKAReq) (*models.NoKnownAllergies, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AssertNKAReq) *models.NoKnownAllergies); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NoKnownAllergies)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AssertNKAReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, aID
 func (_m *AllergyStorer) Delete(ctx context.Context, aID string) error {
 	ret := _m.Called(ctx, aID)
@@ -32,6 +62,36 @@ func (_m *AllergyStorer) Delete(ctx context.Context, aID string) error {
 	return r0
 }
 
+// List provides a mock function with given fields: ctx, pID
+func (_m *AllergyStorer) List(ctx context.Context, pID string) ([]*models.Allergy, error) {
+	ret := _m.Called(ctx, pID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Allergy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Allergy, error)); ok {
+		return rf(ctx, pID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Allergy); ok {
+		r0 = rf(ctx, pID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Allergy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Record provides a mock function with given fields: ctx, req
 func (_m *AllergyStorer) Record(ctx context.Context, req *models.RegAllergyReq) (*models.Allergy, error) {
 	ret := _m.Called(ctx, req)
@@ -44,22 +44,34 @@ func (_m *ConditionStorer) Add(ctx context.Context, req *models.AddConditionReq)
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, pID
-func (_m *ConditionStorer) Delete(ctx context.Context, pID string) error {
-	ret := _m.Called(ctx, pID)
+// Update provides a mock function with given fields: ctx, req
+func (_m *ConditionStorer) Update(ctx context.Context, req *models.UpdateConditionReq) (*models.Condition, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, pID)
+	var r0 *models.Condition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateConditionReq) (*models.Condition, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateConditionReq) *models.Condition); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Condition)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UpdateConditionReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewConditionStorer creates a new instance of ConditionStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -62,6 +62,36 @@ func (_m *PatientStorer) Delete(ctx context.Context, pID string) error {
 	return r0
 }
 
+// FindDuplicates provides a mock function with given fields: ctx, req
+func (_m *PatientStorer) FindDuplicates(ctx context.Context, req *models.RegPatientReq) ([]*models.DuplicateCandidate, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicates")
+	}
+
+	var r0 []*models.DuplicateCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RegPatientReq) ([]*models.DuplicateCandidate, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RegPatientReq) []*models.DuplicateCandidate); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DuplicateCandidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.RegPatientReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, pID
 func (_m *PatientStorer) Get(ctx context.Context, pID string) (*models.Record, error) {
 	ret := _m.Called(ctx, pID)
@@ -92,9 +122,9 @@ func (_m *PatientStorer) Get(ctx context.Context, pID string) (*models.Record, e
 	return r0, r1
 }
 
-// List provides a mock function with given fields: ctx, req
-func (_m *PatientStorer) List(ctx context.Context, req *models.Paginate) (*models.ListPatientRes, error) {
-	ret := _m.Called(ctx, req)
+// List provides a mock function with given fields: ctx, req, filter
+func (_m *PatientStorer) List(ctx context.Context, req *models.Paginate, filter *models.PatientFilter) (*models.ListPatientRes, error) {
+	ret := _m.Called(ctx, req, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -102,18 +132,138 @@ func (_m *PatientStorer) List(ctx context.Context, req *models.Paginate) (*model
 
 	var r0 *models.ListPatientRes
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Paginate) (*models.ListPatientRes, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Paginate, *models.PatientFilter) (*models.ListPatientRes, error)); ok {
+		return rf(ctx, req, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Paginate, *models.PatientFilter) *models.ListPatientRes); ok {
+		r0 = rf(ctx, req, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ListPatientRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Paginate, *models.PatientFilter) error); ok {
+		r1 = rf(ctx, req, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByCursor provides a mock function with given fields: ctx, req, filter
+func (_m *PatientStorer) ListByCursor(ctx context.Context, req *models.Paginate, filter *models.PatientFilter) (*models.ListPatientRes, error) {
+	ret := _m.Called(ctx, req, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByCursor")
+	}
+
+	var r0 *models.ListPatientRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Paginate, *models.PatientFilter) (*models.ListPatientRes, error)); ok {
+		return rf(ctx, req, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Paginate, *models.PatientFilter) *models.ListPatientRes); ok {
+		r0 = rf(ctx, req, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ListPatientRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Paginate, *models.PatientFilter) error); ok {
+		r1 = rf(ctx, req, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: ctx, req
+func (_m *PatientStorer) Merge(ctx context.Context, req *models.MergePatientReq) (*models.PatientMerge, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 *models.PatientMerge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.MergePatientReq) (*models.PatientMerge, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Paginate) *models.ListPatientRes); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *models.MergePatientReq) *models.PatientMerge); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ListPatientRes)
+			r0 = ret.Get(0).(*models.PatientMerge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.MergePatientReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Timeline provides a mock function with given fields: ctx, req
+func (_m *PatientStorer) Timeline(ctx context.Context, req *models.TimelineQuery) (*models.Timeline, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Timeline")
+	}
+
+	var r0 *models.Timeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TimelineQuery) (*models.Timeline, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TimelineQuery) *models.Timeline); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Timeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TimelineQuery) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unmerge provides a mock function with given fields: ctx, req
+func (_m *PatientStorer) Unmerge(ctx context.Context, req *models.UnmergePatientReq) (*models.PatientMerge, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unmerge")
+	}
+
+	var r0 *models.PatientMerge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UnmergePatientReq) (*models.PatientMerge, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UnmergePatientReq) *models.PatientMerge); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PatientMerge)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.Paginate) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UnmergePatientReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -15,26 +15,38 @@ type VitalsStorer struct {
 }
 
 // Create provides a mock function with given fields: ctx, req
-func (_m *VitalsStorer) Create(ctx context.Context, req *models.CreateVitalReq) error {
+func (_m *VitalsStorer) Create(ctx context.Context, req *models.CreateVitalReq) (*models.VitalModel, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateVitalReq) error); ok {
+	var r0 *models.VitalModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateVitalReq) (*models.VitalModel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateVitalReq) *models.VitalModel); ok {
 		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VitalModel)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateVitalReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, pID
-func (_m *VitalsStorer) Delete(ctx context.Context, pID string) error {
-	ret := _m.Called(ctx, pID)
+// Delete provides a mock function with given fields: ctx, vID
+func (_m *VitalsStorer) Delete(ctx context.Context, vID string) error {
+	ret := _m.Called(ctx, vID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
@@ -42,7 +54,7 @@ func (_m *VitalsStorer) Delete(ctx context.Context, pID string) error {
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, pID)
+		r0 = rf(ctx, vID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -50,22 +62,184 @@ func (_m *VitalsStorer) Delete(ctx context.Context, pID string) error {
 	return r0
 }
 
+// Growth provides a mock function with given fields: ctx, req
+func (_m *VitalsStorer) Growth(ctx context.Context, req *models.GrowthQuery) (*models.GrowthCharts, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Growth")
+	}
+
+	var r0 *models.GrowthCharts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GrowthQuery) (*models.GrowthCharts, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GrowthQuery) *models.GrowthCharts); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GrowthCharts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GrowthQuery) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Latest provides a mock function with given fields: ctx, pID
+func (_m *VitalsStorer) Latest(ctx context.Context, pID string) (models.LatestVitals, error) {
+	ret := _m.Called(ctx, pID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 models.LatestVitals
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.LatestVitals, error)); ok {
+		return rf(ctx, pID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.LatestVitals); ok {
+		r0 = rf(ctx, pID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.LatestVitals)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, req
+func (_m *VitalsStorer) List(ctx context.Context, req *models.VitalsQuery) ([]*models.VitalModel, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.VitalModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.VitalsQuery) ([]*models.VitalModel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.VitalsQuery) []*models.VitalModel); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.VitalModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.VitalsQuery) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Series provides a mock function with given fields: ctx, req
+func (_m *VitalsStorer) Series(ctx context.Context, req *models.VitalsQuery) (*models.VitalSeries, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Series")
+	}
+
+	var r0 *models.VitalSeries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.VitalsQuery) (*models.VitalSeries, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.VitalsQuery) *models.VitalSeries); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VitalSeries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.VitalsQuery) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, req
-func (_m *VitalsStorer) Update(ctx context.Context, req *models.UpdateVitalReq) error {
+func (_m *VitalsStorer) Update(ctx context.Context, req *models.UpdateVitalReq) (*models.VitalModel, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateVitalReq) error); ok {
+	var r0 *models.VitalModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateVitalReq) (*models.VitalModel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateVitalReq) *models.VitalModel); ok {
 		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VitalModel)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UpdateVitalReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WardNEWS2 provides a mock function with given fields: ctx, ward
+func (_m *VitalsStorer) WardNEWS2(ctx context.Context, ward string) ([]*models.WardNEWS2Item, error) {
+	ret := _m.Called(ctx, ward)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WardNEWS2")
+	}
+
+	var r0 []*models.WardNEWS2Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.WardNEWS2Item, error)); ok {
+		return rf(ctx, ward)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.WardNEWS2Item); ok {
+		r0 = rf(ctx, ward)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WardNEWS2Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ward)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewVitalsStorer creates a new instance of VitalsStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+// Alert is a clinical alert raised by a rule.
+// @Description Clinical alert about a patient, with its acknowledge/resolve lifecycle.
+type Alert struct {
+	ID        string `json:"id"`
+	PatientID string `json:"patientId"`
+	RuleID    string `json:"ruleId" example:"vital-critical"`
+
+	// Severity is critical, warning or info.
+	Severity string `json:"severity" example:"critical"`
+
+	// Source is vital, news2, allergy or diagnosis and SubjectID the
+	// record that raised the alert.
+	Source    string `json:"source" example:"vital"`
+	SubjectID string `json:"subjectId"`
+	Message   string `json:"message" example:"Critical pulse of 182"`
+
+	// Status is open, acknowledged or resolved.
+	Status     string   `json:"status" example:"open"`
+	AssignedTo []string `json:"assignedTo"`
+
+	RaisedByID       string     `json:"raisedById,omitempty"`
+	RaisedAt         time.Time  `json:"raisedAt"`
+	AcknowledgedByID string     `json:"acknowledgedById,omitempty"`
+	AcknowledgedAt   *time.Time `json:"acknowledgedAt,omitempty"`
+	ResolvedByID     string     `json:"resolvedById,omitempty"`
+	ResolvedAt       *time.Time `json:"resolvedAt,omitempty"`
+	ResolutionNote   string     `json:"resolutionNote,omitempty"`
+}
+
+// NewAlert is an alert triggered by a rule, waiting to be stored.
+type NewAlert struct {
+	RuleID    string
+	Severity  string
+	Source    string
+	SubjectID string
+	Message   string
+	DedupKey  string
+}
+
+// RaiseAlertsReq stores the alerts triggered by a change to a patient's record.
+type RaiseAlertsReq struct {
+	PatientID  string
+	RaisedByID string
+	Alerts     []NewAlert
+}
+
+// AlertQuery filters the alert list.
+type AlertQuery struct {
+	// UserID is the requesting user; with Mine only alerts assigned to
+	// them are listed.
+	UserID string `validate:"required"`
+	Mine   bool
+
+	PatientID string `validate:"omitempty,uuid"`
+	Status    string `validate:"omitempty,oneof=open acknowledged resolved"`
+	Severity  string `validate:"omitempty,oneof=critical warning info"`
+	Limit     int    `validate:"gte=1,lte=200"`
+}
+
+// AlertActionReq acknowledges or resolves an alert.
+// @Description Request payload to acknowledge or resolve an alert.
+type AlertActionReq struct {
+	AlertID string `json:"-" validate:"required,uuid"`
+	UserID  string `json:"-"`
+
+	// Note explains how the alert was handled. Only kept on resolve.
+	// optional: true
+	// max length: 500
+	Note string `json:"note" validate:"omitempty,max=500" example:"Repeat obs normal, reviewed by registrar"`
+}
+
+// CareTeamMember is a user looking after a patient.
+type CareTeamMember struct {
+	ID        string    `json:"id"`
+	PatientID string    `json:"patientId"`
+	UserID    string    `json:"userId"`
+	FullName  string    `json:"fullName,omitempty"`
+	Role      string    `json:"role" example:"attending"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AddCareTeamMemberReq adds a user to a patient's care team.
+// @Description Request payload to add a user to a patient's care team.
+type AddCareTeamMemberReq struct {
+	PatientID string `json:"-" swaggerignore:"true"`
+
+	// UserID is the user joining the care team.
+	// required: true
+	UserID string `json:"userId" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Role is the member's role in the team, such as attending or nurse.
+	// required: true
+	// max length: 50
+	Role string `json:"role" validate:"required,min=2,max=50" example:"attending"`
+}
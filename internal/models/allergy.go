@@ -1,14 +1,81 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Allergy struct {
-	ID        string    `json:"id"`
-	PatientID string    `json:"-" validate:"required,uuid4"`
-	Name      string    `json:"name" validate:"required,min=2,max=100"`
-	Severity  string    `json:"severity" validate:"required,oneof=mild moderate severe"`
-	Reaction  string    `json:"reaction" validate:"required,min=2,max=255"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID        string `json:"id"`
+	PatientID string `json:"-" validate:"required,uuid4"`
+	Name      string `json:"name" validate:"required,min=2,max=100"`
+
+	// Severity and Reaction summarise Reactions: the worst severity and the
+	// manifestations.
+	Severity string `json:"severity"`
+	Reaction string `json:"reaction"`
+
+	Category           string            `json:"category,omitempty" example:"medication"`
+	Substance          *CodedSubstance   `json:"substance,omitempty"`
+	Criticality        string            `json:"criticality" example:"high"`
+	VerificationStatus string            `json:"verificationStatus" example:"confirmed"`
+	Reactions          []AllergyReaction `json:"reactions"`
+	EncounterID        string            `json:"encounterId,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// CodedSubstance is a substance from the terminology substance list.
+type CodedSubstance struct {
+	// System is the code system of Code.
+	// allowed values: rxnorm, snomed
+	System string `json:"system" validate:"required,oneof=rxnorm snomed" example:"rxnorm"`
+
+	// Code is the substance's code in System.
+	Code string `json:"code" validate:"required,max=20" example:"723"`
+
+	// Display is filled in from the substance list.
+	Display string `json:"display" example:"Amoxicillin"`
+}
+
+// AllergyReaction is one reaction a patient had to the substance.
+type AllergyReaction struct {
+	// Manifestation is what happened, e.g. urticaria or wheeze.
+	// required: true
+	// min length: 2
+	// max length: 255
+	Manifestation string `json:"manifestation" validate:"required,min=2,max=255" example:"Urticaria"`
+
+	// Severity of the reaction.
+	// optional: true
+	// allowed values: mild, moderate, severe
+	Severity string `json:"severity,omitempty" validate:"omitempty,oneof=mild moderate severe" example:"moderate"`
+
+	// OnsetDate is when the reaction happened.
+	// optional: true
+	// format: YYYY-MM-DD
+	OnsetDate *DateOnly `json:"onsetDate,omitempty" swaggertype:"string" example:"2021-06-14"`
+
+	// Note is free text about the reaction.
+	// optional: true
+	// max length: 500
+	Note string `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+var severityRank = map[string]int{"mild": 1, "moderate": 2, "severe": 3}
+
+// SummariseReactions returns the manifestations of the reactions joined for
+// the free-text reaction field, and their worst severity.
+func SummariseReactions(reactions []AllergyReaction) (summary, worst string) {
+	manifestations := make([]string, 0, len(reactions))
+	for _, r := range reactions {
+		manifestations = append(manifestations, r.Manifestation)
+		if severityRank[r.Severity] > severityRank[worst] {
+			worst = r.Severity
+		}
+	}
+	return strings.Join(manifestations, "; "), worst
 }
 
 // RegAllergyReq represents the request body for registering a new allergy
@@ -21,17 +88,53 @@ type RegAllergyReq struct {
 	// @example "a4b6d789-60e0-4c93-876d-7a8b62e3b883"
 	PatientID string `json:"-" validate:"required,uuid4"`
 
-	// @Param name query string true "Allergy Name" validate:"required,min=2,max=100"
+	// @Param name query string false "Allergy Name, defaults to the substance's display text" validate:"required_without=Substance,omitempty,min=2,max=100"
 	// @example "Peanut"
-	Name string `json:"name" validate:"required,min=2,max=100"`
+	Name string `json:"name" validate:"required_without=Substance,omitempty,min=2,max=100"`
 
-	// @Param severity query string true "Severity of the allergy" validate:"required,oneof=mild moderate severe"
+	// @Param severity query string false "Severity of the allergy, defaults to the worst reaction's" validate:"omitempty,oneof=mild moderate severe"
 	// @example "mild"
-	Severity string `json:"severity" validate:"required,oneof=mild moderate severe"`
+	Severity string `json:"severity" validate:"omitempty,oneof=mild moderate severe"`
 
-	// @Param reaction query string true "Reaction to the allergy" validate:"required,min=2,max=255"
+	// @Param reaction query string false "Reaction to the allergy, recorded as a single reaction when reactions is empty" validate:"omitempty,min=2,max=255"
 	// @example "Swelling"
-	Reaction string `json:"reaction" validate:"required,min=2,max=255"`
+	Reaction string `json:"reaction" validate:"omitempty,min=2,max=255"`
+
+	// @Param category query string false "Category, defaults to the substance's" validate:"omitempty,oneof=food medication environment biologic"
+	// @example "food"
+	Category string `json:"category" validate:"omitempty,oneof=food medication environment biologic"`
+
+	// Substance is the coded substance from the terminology substance list.
+	Substance *CodedSubstance `json:"substance"`
+
+	// @Param criticality query string false "Risk of a future life-threatening reaction" validate:"omitempty,oneof=low high unable-to-assess"
+	// @example "high"
+	Criticality string `json:"criticality" validate:"omitempty,oneof=low high unable-to-assess"`
+
+	// @Param verificationStatus query string false "Verification status, defaults to confirmed" validate:"omitempty,oneof=unconfirmed confirmed refuted entered-in-error"
+	// @example "confirmed"
+	VerificationStatus string `json:"verificationStatus" validate:"omitempty,oneof=unconfirmed confirmed refuted entered-in-error"`
+
+	// Reactions the patient had to the substance, at most 10.
+	Reactions []AllergyReaction `json:"reactions" validate:"omitempty,max=10,dive"`
+
+	// EncounterID is the encounter the allergy is recorded in. It must be an
+	// encounter of the patient that wasn't cancelled.
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
+}
+
+// Normalise records a free-text reaction as the only reaction and fills in
+// the summary fields from the reactions.
+func (r *RegAllergyReq) Normalise() {
+	if len(r.Reactions) == 0 && r.Reaction != "" {
+		r.Reactions = []AllergyReaction{{Manifestation: r.Reaction, Severity: r.Severity}}
+	}
+
+	summary, worst := SummariseReactions(r.Reactions)
+	r.Reaction = summary
+	if r.Severity == "" {
+		r.Severity = worst
+	}
 }
 
 // UpdateAllergyReq represents the request body for updating an allergy record
@@ -44,6 +147,9 @@ type UpdateAllergyReq struct {
 	// @Param allergy_id query string true "Allergy ID"
 	AllergyID string `json:"-"`
 
+	// EditedByID is the signed in user. It's a server-side value.
+	EditedByID string `json:"-"`
+
 	// @example "Peanut"
 	// @Param name query string false "Updated name of the allergy" validate:"omitempty,min=2,max=100"
 	Name *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
@@ -53,6 +159,58 @@ type UpdateAllergyReq struct {
 	Severity *string `json:"severity,omitempty" validate:"omitempty,oneof=mild moderate severe"`
 
 	// @example "swelling"
-	// @Param reaction query string false "Updated reaction to the allergy" validate:"omitempty,min=2,max=255"
+	// @Param reaction query string false "Updated reaction to the allergy, replaces the reactions when reactions isn't given" validate:"omitempty,min=2,max=255"
 	Reaction *string `json:"reaction,omitempty" validate:"omitempty,min=2,max=255"`
+
+	// @example "food"
+	// @Param category query string false "Updated category" validate:"omitempty,oneof=food medication environment biologic"
+	Category *string `json:"category,omitempty" validate:"omitempty,oneof=food medication environment biologic"`
+
+	// Substance is the corrected coded substance.
+	Substance *CodedSubstance `json:"substance,omitempty"`
+
+	// @example "high"
+	// @Param criticality query string false "Updated criticality" validate:"omitempty,oneof=low high unable-to-assess"
+	Criticality *string `json:"criticality,omitempty" validate:"omitempty,oneof=low high unable-to-assess"`
+
+	// @example "refuted"
+	// @Param verificationStatus query string false "Updated verification status" validate:"omitempty,oneof=unconfirmed confirmed refuted entered-in-error"
+	VerificationStatus *string `json:"verificationStatus,omitempty" validate:"omitempty,oneof=unconfirmed confirmed refuted entered-in-error"`
+
+	// Reactions replaces the allergy's reactions when given.
+	Reactions []AllergyReaction `json:"reactions,omitempty" validate:"omitempty,max=10,dive"`
+}
+
+// Normalise records a free-text reaction as the only reaction and, when
+// the reactions are replaced, fills in the summary fields from them.
+func (r *UpdateAllergyReq) Normalise() {
+	if r.Reactions == nil && r.Reaction != nil {
+		reaction := AllergyReaction{Manifestation: *r.Reaction}
+		if r.Severity != nil {
+			reaction.Severity = *r.Severity
+		}
+		r.Reactions = []AllergyReaction{reaction}
+	}
+	if r.Reactions == nil {
+		return
+	}
+
+	summary, worst := SummariseReactions(r.Reactions)
+	r.Reaction = &summary
+	if r.Severity == nil && worst != "" {
+		r.Severity = &worst
+	}
+}
+
+// NoKnownAllergies is a clinician's assertion that a patient has no known
+// allergies.
+type NoKnownAllergies struct {
+	AssertedByID string    `json:"assertedById,omitempty"`
+	AssertedAt   time.Time `json:"assertedAt"`
+}
+
+// AssertNKAReq asserts that a patient has no known allergies.
+type AssertNKAReq struct {
+	PatientID string `validate:"required,uuid"`
+	UserID    string
 }
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/scheduling"
+)
+
+// WorkingHours is a period of a doctor's weekly template.
+// @Description Weekly working period of a doctor, in the clinic's time zone.
+type WorkingHours struct {
+	ID       string `json:"id"`
+	DoctorID string `json:"doctorId"`
+
+	// Weekday is 0 for Sunday to 6 for Saturday.
+	Weekday     int    `json:"weekday" example:"1"`
+	Start       string `json:"start" example:"09:00"`
+	End         string `json:"end" example:"13:00"`
+	SlotMinutes int    `json:"slotMinutes" example:"15"`
+}
+
+// WorkingHoursPeriod is a period of a weekly template in a request.
+type WorkingHoursPeriod struct {
+	// Weekday is 0 for Sunday to 6 for Saturday.
+	// required: true
+	Weekday int `json:"weekday" validate:"gte=0,lte=6" example:"1"`
+
+	// Start and End are wall-clock times in the clinic's time zone.
+	// required: true
+	// format: HH:MM
+	Start string `json:"start" validate:"required,len=5" example:"09:00"`
+	End   string `json:"end" validate:"required,len=5" example:"13:00"`
+
+	// SlotMinutes is the length of an appointment. It defaults to 15.
+	// optional: true
+	// allowed values: 5, 10, 15, 20, 30, 45, 60
+	SlotMinutes int `json:"slotMinutes" validate:"omitempty,oneof=5 10 15 20 30 45 60" example:"15"`
+}
+
+// SetWorkingHoursReq represents the request body replacing a doctor's
+// weekly template.
+// @Description Request payload to replace a doctor's weekly working hours.
+type SetWorkingHoursReq struct {
+	// DoctorID is taken from the URL.
+	DoctorID string `json:"-"`
+
+	// Hours are the working periods of the week. An empty list clears the
+	// template.
+	// max items: 50
+	Hours []WorkingHoursPeriod `json:"hours" validate:"max=50,dive"`
+
+	// Template is Hours parsed by the handler.
+	Template []scheduling.WorkingHours `json:"-"`
+}
+
+// ScheduleException blocks out part of a doctor's working hours.
+// @Description Leave or other time a doctor isn't available for appointments.
+type ScheduleException struct {
+	ID          string    `json:"id"`
+	DoctorID    string    `json:"doctorId"`
+	StartsAt    time.Time `json:"startsAt" example:"2026-10-20T00:00:00+05:30"`
+	EndsAt      time.Time `json:"endsAt" example:"2026-10-23T00:00:00+05:30"`
+	Reason      string    `json:"reason,omitempty" example:"Annual leave"`
+	CreatedByID string    `json:"createdById,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// ConflictingAppointments are the booked appointments during the
+	// exception, returned when it is added so they can be rescheduled.
+	ConflictingAppointments []Appointment `json:"conflictingAppointments,omitempty"`
+}
+
+// AddScheduleExceptionReq represents the request body for blocking out a
+// doctor's time.
+// @Description Request payload to block out a doctor's time.
+type AddScheduleExceptionReq struct {
+	// DoctorID is taken from the URL and CreatedByID is the signed in user.
+	DoctorID    string `json:"-"`
+	CreatedByID string `json:"-"`
+
+	// StartsAt and EndsAt bound the exception. A whole day off runs from
+	// midnight to midnight.
+	// required: true
+	StartsAt time.Time `json:"startsAt" validate:"required" example:"2026-10-20T00:00:00+05:30"`
+	EndsAt   time.Time `json:"endsAt" validate:"required,gtfield=StartsAt" example:"2026-10-23T00:00:00+05:30"`
+
+	// Reason for the exception.
+	// optional: true
+	// max length: 255
+	Reason string `json:"reason" validate:"omitempty,max=255" example:"Annual leave"`
+}
+
+// Appointment is a patient booked into one of a doctor's slots.
+// @Description Appointment of a patient with a doctor.
+type Appointment struct {
+	ID        string    `json:"id"`
+	PatientID string    `json:"patientId"`
+	DoctorID  string    `json:"doctorId"`
+	StartsAt  time.Time `json:"startsAt" example:"2026-10-19T09:00:00+05:30"`
+	EndsAt    time.Time `json:"endsAt" example:"2026-10-19T09:15:00+05:30"`
+
+	// Status is booked, cancelled or rescheduled.
+	Status     string `json:"status" example:"booked"`
+	Reason     string `json:"reason,omitempty" example:"Follow-up of blood pressure"`
+	BookedByID string `json:"bookedById,omitempty"`
+
+	CancelledByID      string     `json:"cancelledById,omitempty"`
+	CancelledAt        *time.Time `json:"cancelledAt,omitempty"`
+	CancellationReason string     `json:"cancellationReason,omitempty" example:"Patient travelling"`
+
+	// RescheduledFromID is the appointment this one replaced.
+	RescheduledFromID string `json:"rescheduledFromId,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// BookAppointmentReq represents the request body for booking an
+// appointment.
+// @Description Request payload to book a patient into a doctor's slot.
+type BookAppointmentReq struct {
+	// PatientID is taken from the URL and BookedByID is the signed in user.
+	PatientID  string `json:"-"`
+	BookedByID string `json:"-"`
+
+	// DoctorID is the doctor the patient is booked with.
+	// required: true
+	DoctorID string `json:"doctorId" validate:"required,uuid"`
+
+	// StartsAt is the start of a free slot of the doctor.
+	// required: true
+	StartsAt time.Time `json:"startsAt" validate:"required" example:"2026-10-19T09:00:00+05:30"`
+
+	// Reason for the visit.
+	// optional: true
+	// max length: 500
+	Reason string `json:"reason" validate:"omitempty,max=500" example:"Follow-up of blood pressure"`
+}
+
+// RescheduleAppointmentReq represents the request body for moving an
+// appointment to another slot.
+// @Description Request payload to move an appointment to another slot of the same doctor.
+type RescheduleAppointmentReq struct {
+	// AppointmentID is taken from the URL and RescheduledByID is the signed
+	// in user.
+	AppointmentID   string `json:"-"`
+	RescheduledByID string `json:"-"`
+
+	// StartsAt is the start of a free slot of the doctor.
+	// required: true
+	StartsAt time.Time `json:"startsAt" validate:"required" example:"2026-10-21T10:30:00+05:30"`
+
+	// Reason for moving the appointment.
+	// optional: true
+	// max length: 500
+	Reason string `json:"reason" validate:"omitempty,max=500" example:"Doctor on leave"`
+}
+
+// CancelAppointmentReq represents the request body for cancelling an
+// appointment.
+// @Description Request payload to cancel an appointment.
+type CancelAppointmentReq struct {
+	// AppointmentID is taken from the URL and CancelledByID is the signed in
+	// user.
+	AppointmentID string `json:"-"`
+	CancelledByID string `json:"-"`
+
+	// Reason for the cancellation.
+	// required: true
+	// min length: 3
+	// max length: 500
+	Reason string `json:"reason" validate:"required,min=3,max=500" example:"Patient travelling"`
+}
+
+// SlotQuery selects the period free slots are listed for.
+type SlotQuery struct {
+	DoctorID string `validate:"required,uuid"`
+
+	// From and To bound the start of the slots, To excluded.
+	From time.Time
+	To   time.Time
+}
+
+// ScheduleQuery selects a doctor's day or week.
+type ScheduleQuery struct {
+	DoctorID string `validate:"required,uuid"`
+
+	// View is day or week; a week starts on Monday.
+	View string `validate:"required,oneof=day week"`
+
+	// Date is a day of the view, midnight in the clinic's time zone.
+	Date time.Time
+}
+
+// WorkingPeriod is a period a doctor works on a day.
+type WorkingPeriod struct {
+	Start time.Time `json:"start" example:"2026-10-19T09:00:00+05:30"`
+	End   time.Time `json:"end" example:"2026-10-19T13:00:00+05:30"`
+}
+
+// ScheduleDay is a doctor's day: when they work, who is booked and which
+// slots are still free.
+// @Description A day of a doctor's schedule.
+type ScheduleDay struct {
+	Date         DateOnly            `json:"date" swaggertype:"string" example:"2026-10-19"`
+	Hours        []WorkingPeriod     `json:"hours"`
+	Exceptions   []ScheduleException `json:"exceptions"`
+	Appointments []Appointment       `json:"appointments"`
+	FreeSlots    []scheduling.Slot   `json:"freeSlots"`
+}
+
+// DoctorSchedule is a doctor's day or week view.
+// @Description A doctor's schedule for a day or a week.
+type DoctorSchedule struct {
+	DoctorID string        `json:"doctorId"`
+	View     string        `json:"view" example:"week"`
+	Days     []ScheduleDay `json:"days"`
+}
@@ -3,10 +3,35 @@ package models
 import "time"
 
 type Condition struct {
-	ID        string    `json:"id"`
-	PatientID string    `json:"patientID"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID        string `json:"id"`
+	PatientID string `json:"patientID"`
+	Name      string `json:"name"`
+
+	// ClinicalStatus is active, recurrence, remission, resolved or inactive.
+	ClinicalStatus string `json:"clinicalStatus" example:"active"`
+	// VerificationStatus is unconfirmed, provisional, differential,
+	// confirmed, refuted or entered-in-error.
+	VerificationStatus string    `json:"verificationStatus" example:"confirmed"`
+	OnsetDate          *DateOnly `json:"onsetDate,omitempty" swaggertype:"string" example:"2024-03-01"`
+	AbatementDate      *DateOnly `json:"abatementDate,omitempty" swaggertype:"string" example:"2026-10-01"`
+	Note               string    `json:"note,omitempty"`
+	EncounterID        string    `json:"encounterId,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ConditionTransition is a recorded change of a condition's status.
+type ConditionTransition struct {
+	ID                     string    `json:"id"`
+	ConditionID            string    `json:"conditionId"`
+	FromClinicalStatus     string    `json:"fromClinicalStatus" example:"active"`
+	ToClinicalStatus       string    `json:"toClinicalStatus" example:"resolved"`
+	FromVerificationStatus string    `json:"fromVerificationStatus" example:"confirmed"`
+	ToVerificationStatus   string    `json:"toVerificationStatus" example:"confirmed"`
+	Note                   string    `json:"note,omitempty"`
+	ChangedByID            string    `json:"changedById,omitempty"`
+	ChangedAt              time.Time `json:"changedAt"`
 }
 
 // AddConditionReq represents the request body for adding a condition.
@@ -18,7 +43,84 @@ type AddConditionReq struct {
 	// max length: 30
 	Condition string `json:"condition" validate:"required,min=2,max=30"`
 
+	// ClinicalStatus defaults to active. A condition recorded from the
+	// patient's history may already be resolved or in remission.
+	// optional: true
+	// allowed values: active, remission, resolved, inactive
+	ClinicalStatus string `json:"clinicalStatus" validate:"omitempty,oneof=active remission resolved inactive" example:"active"`
+
+	// VerificationStatus defaults to confirmed.
+	// optional: true
+	// allowed values: unconfirmed, provisional, differential, confirmed
+	VerificationStatus string `json:"verificationStatus" validate:"omitempty,oneof=unconfirmed provisional differential confirmed" example:"confirmed"`
+
+	// OnsetDate is when the condition began.
+	// optional: true
+	// format: YYYY-MM-DD
+	OnsetDate *DateOnly `json:"onsetDate" swaggertype:"string" example:"2024-03-01"`
+
+	// AbatementDate is when the condition stopped being active. Only allowed
+	// with a status other than active.
+	// optional: true
+	// format: YYYY-MM-DD
+	AbatementDate *DateOnly `json:"abatementDate" swaggertype:"string" example:"2026-10-01"`
+
+	// Note is free text about the condition.
+	// optional: true
+	// max length: 1000
+	Note string `json:"note" validate:"omitempty,max=1000"`
+
+	// EncounterID is the encounter the entry is recorded in. It must be an
+	// encounter of the patient that wasn't cancelled.
+	// optional: true
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
+
 	// PatientID is excluded from the API payload.
 	// It's not included in the JSON body.
 	PatientID string `json:"-"`
 }
+
+// UpdateConditionReq represents the request body for updating a condition.
+// A change of clinical or verification status is recorded as a transition.
+// swagger:parameters updateConditionReq
+type UpdateConditionReq struct {
+	// CID is excluded from the API payload and not included in the JSON body.
+	// It's a server-side value used to identify the condition being updated.
+	CID string `json:"-"`
+
+	// ChangedByID is the signed in user. It's a server-side value.
+	ChangedByID string `json:"-"`
+
+	// ClinicalStatus is the new clinical status. A condition that comes back
+	// after it stopped being active is a recurrence.
+	// optional: true
+	// allowed values: active, recurrence, remission, resolved, inactive
+	ClinicalStatus string `json:"clinicalStatus" validate:"omitempty,oneof=active recurrence remission resolved inactive" example:"resolved"`
+
+	// VerificationStatus is the new verification status.
+	// optional: true
+	// allowed values: unconfirmed, provisional, differential, confirmed, refuted, entered-in-error
+	VerificationStatus string `json:"verificationStatus" validate:"omitempty,oneof=unconfirmed provisional differential confirmed refuted entered-in-error" example:"confirmed"`
+
+	// OnsetDate is the corrected onset date.
+	// optional: true
+	// format: YYYY-MM-DD
+	OnsetDate *DateOnly `json:"onsetDate" swaggertype:"string" example:"2024-03-01"`
+
+	// AbatementDate is when the condition stopped being active. It defaults
+	// to today when the condition stops being active.
+	// optional: true
+	// format: YYYY-MM-DD
+	AbatementDate *DateOnly `json:"abatementDate" swaggertype:"string" example:"2026-10-01"`
+
+	// Note replaces the condition's note and is kept on the transition.
+	// optional: true
+	// max length: 1000
+	Note string `json:"note" validate:"omitempty,max=1000"`
+}
+
+// Empty reports whether the request changes nothing.
+func (r *UpdateConditionReq) Empty() bool {
+	return r.ClinicalStatus == "" && r.VerificationStatus == "" &&
+		r.OnsetDate == nil && r.AbatementDate == nil && r.Note == ""
+}
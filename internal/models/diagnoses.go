@@ -3,10 +3,21 @@ package models
 import "time"
 
 type Diagnoses struct {
-	ID        string    `json:"id"`
-	PatientID string    `json:"patientID"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID        string `json:"id"`
+	PatientID string `json:"patientID"`
+	Name      string `json:"name"`
+	Code      string `json:"code,omitempty" example:"J45.9"`
+	Display   string `json:"display,omitempty" example:"Asthma, unspecified"`
+	// CodeUnverified is set when the code isn't in the bundled code set and
+	// only its format was checked.
+	CodeUnverified bool      `json:"codeUnverified,omitempty"`
+	Type           string    `json:"type,omitempty" example:"primary"`
+	OnsetDate      *DateOnly `json:"onsetDate,omitempty" swaggertype:"string" example:"2026-09-01"`
+
+	ClinicianID string     `json:"clinicianId,omitempty"`
+	EncounterID string     `json:"encounterId,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
 }
 
 // DiagnosesReq represents the request body for adding a diagnosis.
@@ -16,11 +27,43 @@ type DiagnosesReq struct {
 	// It's a server-side value.
 	PID string `json:"-"`
 
-	// Name represents the name of the diagnosis.
-	// required: true
+	// Code is the ICD-10 code of the diagnosis. A well formed code missing
+	// from the code set is kept as unverified.
+	// optional: true
+	Code string `json:"code" validate:"omitempty,min=3,max=8" example:"J45.9"`
+
+	// Display is the display text of Code. It's filled in from the code set.
+	Display string `json:"-"`
+
+	// CodeUnverified is set when Code isn't in the code set. It's a
+	// server-side value.
+	CodeUnverified bool `json:"-"`
+
+	// Name represents the name of the diagnosis. It defaults to the display
+	// text of the code, and is required with an unverified code.
+	// required: without code
 	// min length: 2
-	// max length: 30
-	Name string `json:"name" validate:"required,min=2,max=30"`
+	// max length: 255
+	Name string `json:"name" validate:"required_without=Code,omitempty,min=2,max=255"`
+
+	// Type is the role of the diagnosis in the patient's care.
+	// optional: true
+	// allowed values: primary, secondary, provisional
+	Type string `json:"type" validate:"omitempty,oneof=primary secondary provisional" example:"primary"`
+
+	// OnsetDate is when the condition began.
+	// optional: true
+	// format: YYYY-MM-DD
+	OnsetDate *DateOnly `json:"onsetDate" swaggertype:"string" example:"2026-09-01"`
+
+	// ClinicianID is the clinician making the diagnosis, the signed in user.
+	// It's a server-side value.
+	ClinicianID string `json:"-"`
+
+	// EncounterID is the encounter the entry is recorded in. It must be an
+	// encounter of the patient that wasn't cancelled.
+	// optional: true
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
 }
 
 // UpdateDiagnosesReq represents the request body for updating a diagnosis.
@@ -30,9 +73,42 @@ type UpdateDiagnosesReq struct {
 	// It's a server-side value used to identify the diagnosis being updated.
 	DID string `json:"-"`
 
-	// Name represents the updated name of the diagnosis.
-	// required: true
+	// EditedByID is the signed in user. It's a server-side value.
+	EditedByID string `json:"-"`
+
+	// Name represents the updated name of the diagnosis. It defaults to the
+	// display text of an updated code, and is required with an unverified
+	// code.
+	// optional: true
 	// min length: 2
-	// max length: 30
-	Name string `json:"name" validate:"required,min=2,max=30"`
+	// max length: 255
+	Name string `json:"name" validate:"omitempty,min=2,max=255"`
+
+	// Code is the corrected ICD-10 code. A well formed code missing from
+	// the code set is kept as unverified.
+	// optional: true
+	Code string `json:"code" validate:"omitempty,min=3,max=8" example:"J45.0"`
+
+	// Display is the display text of Code. It's filled in from the code set.
+	Display string `json:"-"`
+
+	// CodeUnverified is set when Code isn't in the code set. It's a
+	// server-side value.
+	CodeUnverified bool `json:"-"`
+
+	// Type is the updated role of the diagnosis, e.g. to confirm a
+	// provisional diagnosis.
+	// optional: true
+	// allowed values: primary, secondary, provisional
+	Type string `json:"type" validate:"omitempty,oneof=primary secondary provisional" example:"primary"`
+
+	// OnsetDate is the corrected onset date.
+	// optional: true
+	// format: YYYY-MM-DD
+	OnsetDate *DateOnly `json:"onsetDate" swaggertype:"string" example:"2026-09-01"`
+}
+
+// Empty reports whether the request changes nothing.
+func (r *UpdateDiagnosesReq) Empty() bool {
+	return r.Name == "" && r.Code == "" && r.Type == "" && r.OnsetDate == nil
 }
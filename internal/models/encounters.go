@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+// Encounter is a visit of a patient: an outpatient consultation, an
+// admission, an emergency visit and so on.
+// @Description Patient visit with its type, attending doctor, reason and status.
+type Encounter struct {
+	ID        string `json:"id"`
+	PatientID string `json:"patientId"`
+
+	// Type is outpatient, inpatient, emergency, teleconsultation or
+	// home-visit.
+	Type string `json:"type" example:"outpatient"`
+
+	// Status is planned, in-progress, finished or cancelled.
+	Status      string     `json:"status" example:"in-progress"`
+	Reason      string     `json:"reason,omitempty" example:"Fever and cough for three days"`
+	AttendingID string     `json:"attendingId,omitempty"`
+	StartedAt   time.Time  `json:"startedAt"`
+	EndedAt     *time.Time `json:"endedAt,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// EncounterRecord is an encounter with the clinical data recorded during it.
+// Draft notes aren't included.
+// @Description Encounter with its diagnoses, conditions, allergies, medications, vitals, signed notes, lab orders and immunizations.
+type EncounterRecord struct {
+	Encounter
+
+	Diagnoses   []Diagnoses    `json:"diagnoses"`
+	Conditions  []Condition    `json:"conditions"`
+	Allergies   []Allergy      `json:"allergies"`
+	Medications []Medication   `json:"medications"`
+	Vitals      []VitalModel   `json:"vitals"`
+	Notes       []ClinicalNote `json:"notes"`
+	LabOrders   []LabOrder     `json:"labOrders"`
+
+	Immunizations []Immunization `json:"immunizations"`
+}
+
+// CreateEncounterReq represents the request body for opening an encounter.
+// @Description Request payload to open an encounter.
+type CreateEncounterReq struct {
+	// PatientID is taken from the URL.
+	PatientID string `json:"-"`
+
+	// Type is the kind of visit.
+	// required: true
+	// allowed values: outpatient, inpatient, emergency, teleconsultation, home-visit
+	Type string `json:"type" validate:"required,oneof=outpatient inpatient emergency teleconsultation home-visit" example:"outpatient"`
+
+	// Status defaults to in-progress; a visit booked ahead is planned.
+	// optional: true
+	// allowed values: planned, in-progress
+	Status string `json:"status" validate:"omitempty,oneof=planned in-progress" example:"in-progress"`
+
+	// Reason is why the patient came.
+	// optional: true
+	// max length: 500
+	Reason string `json:"reason" validate:"omitempty,max=500" example:"Fever and cough for three days"`
+
+	// AttendingID is the doctor responsible for the encounter. It defaults
+	// to the signed in user.
+	// optional: true
+	AttendingID string `json:"attendingId" validate:"omitempty,uuid"`
+
+	// StartedAt defaults to now.
+	// optional: true
+	StartedAt *time.Time `json:"startedAt" example:"2026-10-18T09:30:00Z"`
+}
+
+// UpdateEncounterReq represents the request body for updating an encounter.
+// Only the fields that are set are changed.
+// @Description Request payload to change an encounter or move it to another status.
+type UpdateEncounterReq struct {
+	// EncounterID is taken from the URL.
+	EncounterID string `json:"-"`
+
+	Type        *string `json:"type,omitempty" validate:"omitempty,oneof=outpatient inpatient emergency teleconsultation home-visit" example:"inpatient"`
+	Reason      *string `json:"reason,omitempty" validate:"omitempty,max=500"`
+	AttendingID *string `json:"attendingId,omitempty" validate:"omitempty,uuid"`
+
+	// Status is the new status. Finished and cancelled are final.
+	// allowed values: planned, in-progress, finished, cancelled
+	Status *string `json:"status,omitempty" validate:"omitempty,oneof=planned in-progress finished cancelled" example:"finished"`
+
+	// StartedAt corrects when the encounter started.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// EndedAt is when the encounter ended. It defaults to now when the
+	// encounter is finished.
+	EndedAt *time.Time `json:"endedAt,omitempty"`
+}
+
+// Empty reports whether the request changes nothing.
+func (r *UpdateEncounterReq) Empty() bool {
+	return r.Type == nil && r.Reason == nil && r.AttendingID == nil &&
+		r.Status == nil && r.StartedAt == nil && r.EndedAt == nil
+}
+
+// EncounterQuery filters a patient's encounters.
+type EncounterQuery struct {
+	PatientID string `validate:"required,uuid"`
+
+	// Status restricts the list to encounters with the status.
+	Status string `validate:"omitempty,oneof=planned in-progress finished cancelled"`
+
+	// From and To bound the start of the encounter, inclusive.
+	From *time.Time
+	To   *time.Time
+}
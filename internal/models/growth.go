@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/growth"
+)
+
+// GrowthQuery selects the growth charts of a patient.
+type GrowthQuery struct {
+	PatientID string `validate:"required,uuid"`
+
+	// Indicator restricts the charts to one indicator; all are returned
+	// without it.
+	Indicator string `validate:"omitempty,oneof=weight-for-age height-for-age bmi-for-age"`
+
+	// Sex picks the charts for patients whose gender is neither MALE nor
+	// FEMALE.
+	Sex string `validate:"omitempty,oneof=MALE FEMALE"`
+}
+
+// GrowthCharts are a child's measurements plotted against the growth
+// standards.
+// @Description Weight, height and BMI of a child scored against the WHO growth standards, with the z-score lines to draw them on.
+type GrowthCharts struct {
+	PatientID   string    `json:"patientId"`
+	Sex         string    `json:"sex" example:"FEMALE"`
+	DateOfBirth time.Time `json:"dateOfBirth"`
+	Standards   string    `json:"standards" example:"WHO Child Growth Standards (0-5 years) and WHO Growth Reference (5-19 years), abridged"`
+
+	Charts []GrowthChart `json:"charts"`
+}
+
+// GrowthChart is the chart of one indicator.
+type GrowthChart struct {
+	Indicator string `json:"indicator" example:"weight-for-age"`
+	Name      string `json:"name" example:"Weight-for-age"`
+	Unit      string `json:"unit" example:"kg"`
+
+	// Points are the child's measurements, oldest first.
+	Points []GrowthPoint `json:"points"`
+
+	// Curves are the z-score lines from birth to the child's age.
+	Curves []growth.Curve `json:"curves"`
+}
+
+// GrowthPoint is a measurement with its z-score and percentile.
+type GrowthPoint struct {
+	VitalID    string    `json:"vitalId"`
+	MeasuredAt time.Time `json:"measuredAt"`
+	AgeMonths  float64   `json:"ageMonths" example:"14.3"`
+	Value      float64   `json:"value" example:"9.2"`
+	ZScore     float64   `json:"zScore" example:"-1.12"`
+	Percentile float64   `json:"percentile" example:"13.1"`
+}
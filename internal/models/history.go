@@ -0,0 +1,153 @@
+package models
+
+import "time"
+
+// FamilyHistory is a condition of a blood relative of the patient.
+// @Description Condition of a relative with their age at onset and whether they died.
+type FamilyHistory struct {
+	ID           string `json:"id"`
+	PatientID    string `json:"patientId"`
+	Relationship string `json:"relationship" example:"mother"`
+	Condition    string `json:"condition" example:"Type 2 diabetes"`
+	Code         string `json:"code,omitempty" example:"E11.9"`
+	Display      string `json:"display,omitempty" example:"Type 2 diabetes mellitus without complications"`
+	AgeAtOnset   *int   `json:"ageAtOnset,omitempty" example:"45"`
+	Deceased     bool   `json:"deceased" example:"false"`
+	AgeAtDeath   *int   `json:"ageAtDeath,omitempty"`
+	Notes        string `json:"notes,omitempty"`
+
+	RecordedByID string     `json:"recordedById,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+}
+
+// AddFamilyHistoryReq represents the request body for adding a relative's
+// condition.
+// @Description Request payload to record a condition of a blood relative.
+type AddFamilyHistoryReq struct {
+	// PatientID is taken from the URL and RecordedByID is the signed in user.
+	PatientID    string `json:"-"`
+	RecordedByID string `json:"-"`
+
+	// Relationship of the relative to the patient.
+	// required: true
+	Relationship string `json:"relationship" validate:"required,oneof=mother father sister brother daughter son maternal-grandmother maternal-grandfather paternal-grandmother paternal-grandfather aunt uncle cousin other" example:"mother"`
+
+	// Code is the ICD-10 code of the condition. It must be a known code.
+	// optional: true
+	Code string `json:"code" validate:"omitempty,min=3,max=8" example:"E11.9"`
+
+	// Display is the display text of Code. It's filled in from the code set.
+	Display string `json:"-"`
+
+	// Condition defaults to the display text of the code.
+	// required: without code
+	Condition string `json:"condition" validate:"required_without=Code,omitempty,min=2,max=255" example:"Type 2 diabetes"`
+
+	// AgeAtOnset is the relative's age when the condition began.
+	// optional: true
+	AgeAtOnset *int `json:"ageAtOnset" validate:"omitempty,gte=0,lte=120" example:"45"`
+
+	// Deceased tells whether the relative has died; AgeAtDeath is only
+	// recorded for a relative who has.
+	// optional: true
+	Deceased   bool `json:"deceased" example:"false"`
+	AgeAtDeath *int `json:"ageAtDeath" validate:"omitempty,gte=0,lte=120"`
+
+	// Notes about the relative or the condition.
+	// optional: true
+	// max length: 500
+	Notes string `json:"notes" validate:"omitempty,max=500"`
+}
+
+// UpdateFamilyHistoryReq represents the request body for correcting a
+// relative's condition. Only the fields given are changed.
+// @Description Request payload to correct a condition of a relative.
+type UpdateFamilyHistoryReq struct {
+	// ID is taken from the URL.
+	ID string `json:"-"`
+
+	Relationship *string `json:"relationship" validate:"omitempty,oneof=mother father sister brother daughter son maternal-grandmother maternal-grandfather paternal-grandmother paternal-grandfather aunt uncle cousin other" example:"mother"`
+
+	// Code is the corrected ICD-10 code. It must be a known code.
+	Code    string `json:"code" validate:"omitempty,min=3,max=8" example:"E11.9"`
+	Display string `json:"-"`
+
+	Condition  *string `json:"condition" validate:"omitempty,min=2,max=255"`
+	AgeAtOnset *int    `json:"ageAtOnset" validate:"omitempty,gte=0,lte=120"`
+
+	// Deceased set to false clears AgeAtDeath.
+	Deceased   *bool   `json:"deceased"`
+	AgeAtDeath *int    `json:"ageAtDeath" validate:"omitempty,gte=0,lte=120"`
+	Notes      *string `json:"notes" validate:"omitempty,max=500"`
+}
+
+// Empty reports whether the request changes nothing.
+func (r *UpdateFamilyHistoryReq) Empty() bool {
+	return r.Relationship == nil && r.Code == "" && r.Condition == nil && r.AgeAtOnset == nil &&
+		r.Deceased == nil && r.AgeAtDeath == nil && r.Notes == nil
+}
+
+// SocialHistory is the patient's smoking, alcohol use and occupation.
+// @Description Smoking with pack-years, alcohol use and occupation of the patient.
+type SocialHistory struct {
+	ID        string `json:"id"`
+	PatientID string `json:"patientId"`
+
+	SmokingStatus    string `json:"smokingStatus" example:"former"`
+	CigarettesPerDay *int   `json:"cigarettesPerDay,omitempty" example:"10"`
+	SmokingStartAge  *int   `json:"smokingStartAge,omitempty" example:"18"`
+	SmokingQuitAge   *int   `json:"smokingQuitAge,omitempty" example:"38"`
+
+	// PackYears is the packs of 20 smoked a day times the years smoked, up
+	// to the quit age or today. It is left out without the daily amount and
+	// the start age.
+	PackYears *float64 `json:"packYears,omitempty" example:"10"`
+
+	AlcoholUse          string   `json:"alcoholUse,omitempty" example:"occasional"`
+	AlcoholUnitsPerWeek *float64 `json:"alcoholUnitsPerWeek,omitempty" example:"4"`
+
+	Occupation string `json:"occupation,omitempty" example:"Stone cutter"`
+	Notes      string `json:"notes,omitempty"`
+
+	RecordedByID string    `json:"recordedById,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// SetSocialHistoryReq represents the request body for recording the
+// patient's social history. It replaces the one recorded before.
+// @Description Request payload to record or replace the patient's social history.
+type SetSocialHistoryReq struct {
+	// PatientID is taken from the URL and RecordedByID is the signed in user.
+	PatientID    string `json:"-"`
+	RecordedByID string `json:"-"`
+
+	// SmokingStatus of the patient.
+	// required: true
+	SmokingStatus string `json:"smokingStatus" validate:"required,oneof=never former current unknown" example:"former"`
+
+	// CigarettesPerDay and SmokingStartAge are recorded for current and
+	// former smokers, SmokingQuitAge for former smokers only.
+	// optional: true
+	CigarettesPerDay *int `json:"cigarettesPerDay" validate:"omitempty,gt=0,lte=200" example:"10"`
+	SmokingStartAge  *int `json:"smokingStartAge" validate:"omitempty,gte=0,lte=120" example:"18"`
+	SmokingQuitAge   *int `json:"smokingQuitAge" validate:"omitempty,gte=0,lte=120" example:"38"`
+
+	// AlcoholUse of the patient.
+	// optional: true
+	AlcoholUse string `json:"alcoholUse" validate:"omitempty,oneof=none occasional moderate heavy former" example:"occasional"`
+
+	// AlcoholUnitsPerWeek is not recorded when AlcoholUse is none.
+	// optional: true
+	AlcoholUnitsPerWeek *float64 `json:"alcoholUnitsPerWeek" validate:"omitempty,gte=0,lte=500" example:"4"`
+
+	// Occupation, current or the main one before retiring.
+	// optional: true
+	Occupation string `json:"occupation" validate:"omitempty,max=100" example:"Stone cutter"`
+
+	// Notes such as occupational exposures.
+	// optional: true
+	// max length: 500
+	Notes string `json:"notes" validate:"omitempty,max=500"`
+}
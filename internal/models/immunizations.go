@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/immunization"
+)
+
+// Immunization is a vaccine dose given to a patient.
+// @Description Vaccine dose with its lot, site, date and who gave it.
+type Immunization struct {
+	ID          string `json:"id"`
+	PatientID   string `json:"patientId"`
+	EncounterID string `json:"encounterId,omitempty"`
+	Vaccine     string `json:"vaccine" example:"PENTA"`
+	VaccineName string `json:"vaccineName" example:"Pentavalent (DPT, Hep B, Hib)"`
+	DoseNumber  int    `json:"doseNumber" example:"1"`
+	Lot         string `json:"lot,omitempty" example:"PV24031"`
+	Site        string `json:"site,omitempty" example:"left-thigh"`
+
+	AdministeredAt     time.Time `json:"administeredAt"`
+	AdministeredByID   string    `json:"administeredById,omitempty"`
+	AdministeredByName string    `json:"administeredByName,omitempty" example:"PHC Rampur"`
+
+	RecordedByID string    `json:"recordedById"`
+	Notes        string    `json:"notes,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// RecordImmunizationReq represents the request body for recording a dose.
+// @Description Request payload to record a vaccine dose given at the clinic or copied from a vaccination card.
+type RecordImmunizationReq struct {
+	// PatientID is taken from the URL and RecordedByID is the signed in user.
+	PatientID    string `json:"-"`
+	RecordedByID string `json:"-"`
+
+	// EncounterID is the encounter the dose was given in.
+	// optional: true
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
+
+	// Vaccine is the code of a vaccine of the schedule, or of another one.
+	// required: true
+	Vaccine string `json:"vaccine" validate:"required,max=32" example:"PENTA"`
+
+	// VaccineName is required for vaccines outside the schedule.
+	// optional: true
+	VaccineName string `json:"vaccineName" validate:"omitempty,max=100" example:"Typhoid conjugate vaccine"`
+
+	// DoseNumber counts the doses of the vaccine from 1.
+	// required: true
+	DoseNumber int `json:"doseNumber" validate:"required,gte=1,lte=10" example:"1"`
+
+	// Lot is the batch number on the vial.
+	// optional: true
+	Lot string `json:"lot" validate:"omitempty,max=50" example:"PV24031"`
+
+	// Site the dose was given at.
+	// optional: true
+	Site string `json:"site" validate:"omitempty,oneof=left-arm right-arm left-thigh right-thigh oral nasal" example:"left-thigh"`
+
+	// AdministeredAt defaults to now.
+	// optional: true
+	AdministeredAt *time.Time `json:"administeredAt"`
+
+	// AdministeredByID is the user who gave the dose and AdministeredByName
+	// the person or facility that gave a dose elsewhere. Without either the
+	// signed in user gave it.
+	// optional: true
+	AdministeredByID   string `json:"administeredById" validate:"omitempty,uuid,excluded_with=AdministeredByName"`
+	AdministeredByName string `json:"administeredByName" validate:"omitempty,max=100" example:"PHC Rampur"`
+
+	// Notes such as a reaction after the dose.
+	// optional: true
+	// max length: 500
+	Notes string `json:"notes" validate:"omitempty,max=500"`
+}
+
+// ImmunizationStatus is the schedule as it stands for a patient.
+// @Description Every dose of the schedule for the patient with its due date and status.
+type ImmunizationStatus struct {
+	PatientID string    `json:"patientId"`
+	AsOf      time.Time `json:"asOf"`
+	Schedule  string    `json:"schedule" example:"India Universal Immunization Programme"`
+
+	// Due and Overdue count the doses in those statuses.
+	Due     int `json:"due" example:"1"`
+	Overdue int `json:"overdue" example:"2"`
+
+	Doses []immunization.DoseStatus `json:"doses"`
+}
+
+// OverdueQuery selects the clinic-wide overdue report.
+type OverdueQuery struct {
+	AsOf time.Time
+
+	// Vaccine restricts the report to a vaccine of the schedule.
+	Vaccine string
+}
+
+// OverdueReport lists the patients with overdue doses.
+// @Description Patients with overdue doses, those overdue longest first.
+type OverdueReport struct {
+	AsOf     time.Time        `json:"asOf"`
+	Patients []OverduePatient `json:"patients"`
+}
+
+// OverduePatient is a patient with the doses they are overdue for.
+type OverduePatient struct {
+	PatientID     string                    `json:"patientId"`
+	MRN           string                    `json:"mrn,omitempty"`
+	FullName      string                    `json:"fullName"`
+	DateOfBirth   time.Time                 `json:"dateOfBirth"`
+	ContactNumber string                    `json:"contactNumber"`
+	Doses         []immunization.DoseStatus `json:"doses"`
+}
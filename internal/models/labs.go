@@ -0,0 +1,195 @@
+package models
+
+import "time"
+
+// LabOrder is a request for lab tests for a patient.
+// @Description Lab tests ordered for a patient with the results received so far.
+type LabOrder struct {
+	ID          string `json:"id"`
+	PatientID   string `json:"patientId"`
+	EncounterID string `json:"encounterId,omitempty"`
+	OrderedByID string `json:"orderedById"`
+
+	// Tests are the codes of the ordered tests, with panels expanded.
+	Tests []string `json:"tests" example:"HGB,WBC,PLT"`
+
+	// Priority is routine, urgent or stat.
+	Priority string `json:"priority" example:"routine"`
+	Notes    string `json:"notes,omitempty" example:"Fever for three days"`
+
+	// Status is ordered, partial, completed or cancelled.
+	Status string `json:"status" example:"partial"`
+
+	Results []LabResult `json:"results"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// LabResult is the result of a lab test.
+// @Description Result of a lab test with its unit, the reference range it was flagged against and the flag.
+type LabResult struct {
+	ID        string `json:"id"`
+	PatientID string `json:"patientId"`
+	OrderID   string `json:"orderId,omitempty"`
+	TestCode  string `json:"testCode" example:"HGB"`
+	TestName  string `json:"testName" example:"Haemoglobin"`
+
+	// Value is set for quantitative tests and ValueText for qualitative ones.
+	Value     *float64 `json:"value,omitempty" example:"10.8"`
+	ValueText string   `json:"valueText,omitempty"`
+	Unit      string   `json:"unit,omitempty" example:"g/dL"`
+
+	ReferenceLow  *float64 `json:"referenceLow,omitempty" example:"12"`
+	ReferenceHigh *float64 `json:"referenceHigh,omitempty" example:"15.5"`
+	ReferenceText string   `json:"referenceText,omitempty"`
+
+	// Flag is low, normal, high, critical or abnormal, or empty when no
+	// reference range applies.
+	Flag string `json:"flag,omitempty" example:"low"`
+
+	// Source is manual or ingested.
+	Source      string `json:"source" example:"manual"`
+	ExternalID  string `json:"externalId,omitempty"`
+	EnteredByID string `json:"enteredById,omitempty"`
+
+	CollectedAt time.Time `json:"collectedAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// CreateLabOrderReq represents the request body for ordering lab tests.
+// @Description Request payload to order tests or panels of the lab catalogue.
+type CreateLabOrderReq struct {
+	// PatientID is taken from the URL and OrderedByID is the signed in user.
+	PatientID   string `json:"-"`
+	OrderedByID string `json:"-"`
+
+	// EncounterID is the encounter the tests are ordered in.
+	// optional: true
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
+
+	// Tests are codes of tests or panels of the catalogue.
+	// required: true
+	Tests []string `json:"tests" validate:"required,min=1,max=50,dive,required" example:"CBC,NS1"`
+
+	// Priority defaults to routine.
+	// optional: true
+	Priority string `json:"priority" validate:"omitempty,oneof=routine urgent stat" example:"urgent"`
+
+	// Notes for the lab.
+	// optional: true
+	// max length: 500
+	Notes string `json:"notes" validate:"omitempty,max=500" example:"Fever for three days"`
+}
+
+// LabOrderQuery selects a patient's lab orders.
+type LabOrderQuery struct {
+	PatientID string `validate:"required,uuid"`
+	Status    string `validate:"omitempty,oneof=ordered partial completed cancelled"`
+}
+
+// LabResultInput is one result to record.
+// @Description Result of one test. The value may be given in any unit the catalogue converts from.
+type LabResultInput struct {
+	// PatientID and OrderID are taken from the URL when results of an order
+	// are entered by hand.
+	PatientID string `json:"patientId" validate:"omitempty,uuid"`
+	OrderID   string `json:"orderId" validate:"omitempty,uuid"`
+
+	// TestCode is the code of a test of the catalogue.
+	// required: true
+	TestCode string `json:"testCode" validate:"required,max=32" example:"HGB"`
+
+	// Value of a quantitative test, in Unit.
+	Value *float64 `json:"value" example:"108"`
+
+	// ValueText of a qualitative test.
+	// max length: 200
+	ValueText string `json:"valueText" validate:"omitempty,max=200"`
+
+	// Unit defaults to the unit of the test.
+	// optional: true
+	Unit string `json:"unit" validate:"omitempty,max=20" example:"g/L"`
+
+	// ReferenceLow and ReferenceHigh are the range the lab reported the
+	// result with, in Unit. They replace the range of the catalogue.
+	// optional: true
+	ReferenceLow  *float64 `json:"referenceLow"`
+	ReferenceHigh *float64 `json:"referenceHigh"`
+
+	// CollectedAt is when the specimen was collected. It defaults to now.
+	// optional: true
+	CollectedAt *time.Time `json:"collectedAt"`
+
+	// ExternalID is the ID of the result in the lab system it comes from.
+	// Results already received with the ID are skipped.
+	// optional: true
+	ExternalID string `json:"externalId" validate:"omitempty,max=100"`
+}
+
+// EnterLabResultsReq represents the request body for entering the results
+// of a lab order by hand.
+// @Description Request payload with results of the tests of an order.
+type EnterLabResultsReq struct {
+	Results []LabResultInput `json:"results" validate:"required,min=1,max=50,dive"`
+}
+
+// IngestLabResultsReq represents the request body for results sent by a
+// lab system.
+// @Description Request payload with results from a lab system. Each names its patient, and optionally its order.
+type IngestLabResultsReq struct {
+	Results []LabResultInput `json:"results" validate:"required,min=1,max=500,dive"`
+}
+
+// AddLabResultsReq is the results to store, entered by hand or ingested.
+type AddLabResultsReq struct {
+	// Source is manual or ingested.
+	Source      string
+	EnteredByID string
+	Results     []LabResultInput
+}
+
+// AddLabResultsRes lists the stored results.
+// @Description Stored results and the external IDs of the results that were already received.
+type AddLabResultsRes struct {
+	Results []LabResult `json:"results"`
+	Skipped []string    `json:"skipped"`
+}
+
+// LabQuery selects the results of a patient's cumulative table.
+type LabQuery struct {
+	PatientID string `validate:"required,uuid"`
+
+	// Tests restricts the table to the test codes.
+	Tests []string `validate:"omitempty,max=50"`
+
+	// From and To bound the collection time, inclusive.
+	From *time.Time
+	To   *time.Time
+}
+
+// LabTable is a patient's cumulative results: a row per test and a column
+// per collection time.
+// @Description Cumulative results with a row per test, in catalogue order, and a column per collection time, oldest first.
+type LabTable struct {
+	PatientID string        `json:"patientId"`
+	Columns   []time.Time   `json:"columns"`
+	Rows      []LabTableRow `json:"rows"`
+}
+
+// LabTableRow holds the results of a test. Cells line up with the columns
+// of the table and are null where the test wasn't done.
+type LabTableRow struct {
+	TestCode string          `json:"testCode" example:"HGB"`
+	TestName string          `json:"testName" example:"Haemoglobin"`
+	Unit     string          `json:"unit,omitempty" example:"g/dL"`
+	Cells    []*LabTableCell `json:"cells"`
+}
+
+// LabTableCell is a result in the cumulative table.
+type LabTableCell struct {
+	ResultID  string   `json:"resultId"`
+	Value     *float64 `json:"value,omitempty" example:"10.8"`
+	ValueText string   `json:"valueText,omitempty"`
+	Flag      string   `json:"flag,omitempty" example:"low"`
+}
@@ -0,0 +1,192 @@
+package models
+
+import "time"
+
+// Medication is a prescription on a patient's medication list.
+// @Description Prescribed medication with its dose, route, frequency and status.
+type Medication struct {
+	ID        string `json:"id"`
+	PatientID string `json:"patientId"`
+
+	DrugName string          `json:"drugName" example:"Amoxicillin"`
+	Drug     *CodedSubstance `json:"drug,omitempty"`
+
+	DoseValue    float64 `json:"doseValue" example:"500"`
+	DoseUnit     string  `json:"doseUnit" example:"mg"`
+	Route        string  `json:"route" example:"oral"`
+	Frequency    string  `json:"frequency" example:"tds"`
+	DurationDays *int    `json:"durationDays,omitempty" example:"7"`
+	Instructions string  `json:"instructions,omitempty" example:"After food"`
+
+	PrescriberID string    `json:"prescriberId,omitempty"`
+	EncounterID  string    `json:"encounterId,omitempty"`
+	StartDate    DateOnly  `json:"startDate" swaggertype:"string" example:"2026-10-18"`
+	StopDate     *DateOnly `json:"stopDate,omitempty" swaggertype:"string" example:"2026-10-25"`
+
+	// Status is active, on-hold, completed, stopped or entered-in-error.
+	Status     string `json:"status" example:"active"`
+	StopReason string `json:"stopReason,omitempty"`
+
+	// InteractionWarnings are the warnings raised when the medication was
+	// prescribed or last restarted, and OverrideReason why a severe one was
+	// overridden.
+	InteractionWarnings []InteractionWarning `json:"interactionWarnings"`
+	OverrideReason      string               `json:"overrideReason,omitempty" example:"Tolerated amoxicillin in 2024 without reaction"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// InteractionWarning is a problem the interaction check found with
+// prescribing a drug.
+type InteractionWarning struct {
+	// Type is drug-allergy, drug-drug or duplicate-therapy.
+	Type string `json:"type" example:"drug-allergy"`
+
+	// Severity is minor, moderate or severe.
+	Severity string `json:"severity" example:"severe"`
+	Message  string `json:"message" example:"Amoxicillin is a penicillin and the patient is allergic to Penicillin V"`
+
+	// SubjectID is the allergy or medication the drug conflicts with.
+	SubjectID string `json:"subjectId"`
+}
+
+// PrescribeMedicationReq represents the request body for prescribing a
+// medication.
+// @Description Request payload to prescribe a medication.
+type PrescribeMedicationReq struct {
+	// PatientID and PrescriberID are server-side values and not included in
+	// the JSON body.
+	PatientID    string `json:"-"`
+	PrescriberID string `json:"-"`
+
+	// DrugName is the name of the drug. It defaults to the display text of
+	// Drug.
+	// required: without drug
+	// min length: 2
+	// max length: 100
+	DrugName string `json:"drugName" validate:"required_without=Drug,omitempty,min=2,max=100" example:"Amoxicillin"`
+
+	// Drug is the coded drug from the terminology substance list.
+	// optional: true
+	Drug *CodedSubstance `json:"drug"`
+
+	// DoseValue is the amount given each time, in DoseUnit.
+	// required: true
+	DoseValue float64 `json:"doseValue" validate:"required,gt=0,lte=100000" example:"500"`
+
+	// DoseUnit is the unit of DoseValue.
+	// required: true
+	// allowed values: mg, g, mcg, ml, units, tablet, capsule, puff, drop, sachet, patch
+	DoseUnit string `json:"doseUnit" validate:"required,oneof=mg g mcg ml units tablet capsule puff drop sachet patch" example:"mg"`
+
+	// Route is how the drug is given.
+	// required: true
+	// allowed values: oral, sublingual, buccal, iv, im, sc, intradermal, topical, transdermal, inhaled, nasal, ophthalmic, otic, rectal, vaginal
+	Route string `json:"route" validate:"required,oneof=oral sublingual buccal iv im sc intradermal topical transdermal inhaled nasal ophthalmic otic rectal vaginal" example:"oral"`
+
+	// Frequency is how often the drug is given.
+	// required: true
+	// allowed values: od, bd, tds, qid, qhs, q4h, q6h, q8h, q12h, weekly, prn, stat
+	Frequency string `json:"frequency" validate:"required,oneof=od bd tds qid qhs q4h q6h q8h q12h weekly prn stat" example:"tds"`
+
+	// DurationDays is how long the course lasts. The stop date defaults to
+	// the start date plus the duration.
+	// optional: true
+	DurationDays *int `json:"durationDays" validate:"omitempty,gte=1,lte=3650" example:"7"`
+
+	// Instructions for the patient.
+	// optional: true
+	// max length: 500
+	Instructions string `json:"instructions" validate:"omitempty,max=500" example:"After food"`
+
+	// StartDate defaults to today.
+	// optional: true
+	// format: YYYY-MM-DD
+	StartDate *DateOnly `json:"startDate" swaggertype:"string" example:"2026-10-18"`
+
+	// StopDate is when the course ends.
+	// optional: true
+	// format: YYYY-MM-DD
+	StopDate *DateOnly `json:"stopDate" swaggertype:"string" example:"2026-10-25"`
+
+	// OverrideReason explains why the drug is prescribed despite a severe
+	// interaction warning. It is required when there is one.
+	// optional: true
+	// min length: 5
+	// max length: 500
+	OverrideReason string `json:"overrideReason" validate:"omitempty,min=5,max=500" example:"Tolerated amoxicillin in 2024 without reaction"`
+
+	// EncounterID is the encounter the medication is prescribed in. It must
+	// be an encounter of the patient that wasn't cancelled.
+	// optional: true
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
+
+	// InteractionWarnings are set by the server from the interaction check.
+	InteractionWarnings []InteractionWarning `json:"-"`
+}
+
+// UpdateMedicationReq represents the request body for updating a
+// medication. Only the fields that are set are changed.
+// @Description Request payload to change a medication's dose, schedule or status.
+type UpdateMedicationReq struct {
+	// MedicationID is taken from the URL.
+	MedicationID string `json:"-"`
+
+	DoseValue    *float64 `json:"doseValue,omitempty" validate:"omitempty,gt=0,lte=100000" example:"250"`
+	DoseUnit     *string  `json:"doseUnit,omitempty" validate:"omitempty,oneof=mg g mcg ml units tablet capsule puff drop sachet patch" example:"mg"`
+	Route        *string  `json:"route,omitempty" validate:"omitempty,oneof=oral sublingual buccal iv im sc intradermal topical transdermal inhaled nasal ophthalmic otic rectal vaginal" example:"oral"`
+	Frequency    *string  `json:"frequency,omitempty" validate:"omitempty,oneof=od bd tds qid qhs q4h q6h q8h q12h weekly prn stat" example:"bd"`
+	DurationDays *int     `json:"durationDays,omitempty" validate:"omitempty,gte=1,lte=3650" example:"5"`
+	Instructions *string  `json:"instructions,omitempty" validate:"omitempty,max=500"`
+
+	// StopDate is when the course ends. It defaults to today when the
+	// medication is stopped or completed.
+	// format: YYYY-MM-DD
+	StopDate *DateOnly `json:"stopDate,omitempty" swaggertype:"string" example:"2026-10-20"`
+
+	// Status is the new status. Completed, stopped and entered-in-error are
+	// final.
+	// allowed values: active, on-hold, completed, stopped, entered-in-error
+	Status *string `json:"status,omitempty" validate:"omitempty,oneof=active on-hold completed stopped entered-in-error" example:"stopped"`
+
+	// StopReason explains why the medication was stopped or put on hold.
+	// max length: 500
+	StopReason *string `json:"stopReason,omitempty" validate:"omitempty,max=500" example:"Rash after second dose"`
+
+	// OverrideReason explains why an on-hold medication is restarted
+	// despite a severe interaction warning. It is required when there is
+	// one.
+	// min length: 5
+	// max length: 500
+	OverrideReason *string `json:"overrideReason,omitempty" validate:"omitempty,min=5,max=500" example:"Warfarin dose reduced, INR monitored"`
+
+	// InteractionWarnings are set by the server from the interaction check
+	// when an on-hold medication is restarted.
+	InteractionWarnings []InteractionWarning `json:"-"`
+}
+
+// Empty reports whether the request changes nothing.
+func (r *UpdateMedicationReq) Empty() bool {
+	return r.DoseValue == nil && r.DoseUnit == nil && r.Route == nil && r.Frequency == nil &&
+		r.DurationDays == nil && r.Instructions == nil && r.StopDate == nil &&
+		r.Status == nil && r.StopReason == nil
+}
+
+// MedicationQuery filters a patient's medication list.
+type MedicationQuery struct {
+	PatientID string `validate:"required,uuid"`
+
+	// Status is a medication status, or current for active and on-hold
+	// medications.
+	Status string `validate:"omitempty,oneof=current active on-hold completed stopped entered-in-error"`
+}
+
+// InteractionWarningRes is returned with a 409 when a prescription has severe
+// interaction warnings and no override reason.
+// @Description Conflict response listing the interaction warnings to override.
+type InteractionWarningRes struct {
+	Status   int                  `json:"status" example:"409"`
+	Error    string               `json:"error" example:"severe interaction warnings need an override reason"`
+	Warnings []InteractionWarning `json:"warnings"`
+}
@@ -0,0 +1,115 @@
+package models
+
+import "time"
+
+// MergePatientReq represents the request body for merging a duplicate patient into another.
+// swagger:parameters mergePatientReq
+type MergePatientReq struct {
+	// SourceID is the duplicate patient that becomes a tombstone.
+	// required: true
+	SourceID string `json:"sourceId" validate:"required,uuid"`
+
+	// TargetID is the surviving patient that receives the source's records.
+	// required: true
+	TargetID string `json:"targetId" validate:"required,uuid,nefield=SourceID"`
+
+	// KeepFromSource lists the demographic fields whose source value replaces
+	// the target value. All other fields keep the target value.
+	// optional: true
+	// allowed values: fullname, gender, dob, contactNo, address, emergencyName, emergencyRelation, emergencyPhone
+	KeepFromSource []string `json:"keepFromSource" validate:"omitempty,dive,oneof=fullname gender dob contactNo address emergencyName emergencyRelation emergencyPhone"`
+
+	// MergedByID is the ID of the user performing the merge.
+	// It's not included in the API payload.
+	MergedByID string `json:"-"`
+}
+
+// UnmergePatientReq identifies a merge to be reversed.
+type UnmergePatientReq struct {
+	// MergeID is the identifier of the merge to reverse.
+	// It's taken from the URL.
+	MergeID string `json:"-" validate:"required,uuid"`
+
+	// UnmergedByID is the ID of the user reversing the merge.
+	// It's not included in the API payload.
+	UnmergedByID string `json:"-"`
+}
+
+// MergeManifest records everything a merge changed so that it can be reversed.
+type MergeManifest struct {
+	// Diagnoses, Conditions and Allergies hold the IDs moved from the source to the target.
+	Diagnoses  []string `json:"diagnoses"`
+	Conditions []string `json:"conditions"`
+	Allergies  []string `json:"allergies"`
+
+	// Medications are the IDs of the prescriptions moved to the target.
+	Medications []string `json:"medications"`
+
+	// Vitals are the IDs of the vitals observations moved to the target.
+	Vitals []string `json:"vitals"`
+
+	// Encounters are the IDs of the encounters moved to the target.
+	Encounters []string `json:"encounters"`
+
+	// Appointments are the IDs of the appointments moved to the target.
+	Appointments []string `json:"appointments"`
+
+	// Notes are the IDs of the clinical notes moved to the target.
+	Notes []string `json:"notes"`
+
+	// LabOrders and LabResults are the IDs of the lab orders and results
+	// moved to the target.
+	LabOrders  []string `json:"labOrders"`
+	LabResults []string `json:"labResults"`
+
+	// Immunizations are the IDs of the vaccine doses moved to the target;
+	// doses the target already has a record of stay with the source.
+	Immunizations []string `json:"immunizations"`
+
+	// FamilyHistory are the IDs of the relatives' conditions moved to the
+	// target.
+	FamilyHistory []string `json:"familyHistory"`
+
+	// SocialHistory is the ID of the source's social history, moved to the
+	// target only when the target had none.
+	SocialHistory string `json:"socialHistory,omitempty"`
+
+	// Referrals are the IDs of the referrals moved to the target.
+	Referrals []string `json:"referrals"`
+
+	// Alerts are the IDs of the alerts moved to the target. Their keys are
+	// rewritten to the target and back on unmerge; the ones duplicating an
+	// alert open on the target are resolved and stay resolved.
+	Alerts []string `json:"alerts"`
+
+	// CareTeam are the IDs of the care team members moved to the target;
+	// members already on the target's team stay with the source.
+	CareTeam []string `json:"careTeam"`
+
+	// QueueEntries are the IDs of the queue entries moved to the target; an
+	// entry still in the queue stays with the source when the target is in
+	// the queue too.
+	QueueEntries []string `json:"queueEntries"`
+
+	// Edits are the IDs of the recorded edits of the moved entries; the
+	// edits of the source's own details stay with the source.
+	Edits []string `json:"edits"`
+
+	// KeptFromSource lists the demographic fields taken from the source.
+	KeptFromSource []string `json:"keptFromSource"`
+
+	// TargetBefore holds the target's values for the overwritten fields.
+	TargetBefore UpdatePatientReq `json:"targetBefore"`
+}
+
+// PatientMerge represents a merge of two patient records.
+type PatientMerge struct {
+	ID           string        `json:"id"`
+	SourceID     string        `json:"sourceId"`
+	TargetID     string        `json:"targetId"`
+	MergedByID   string        `json:"mergedById"`
+	MergedAt     time.Time     `json:"mergedAt"`
+	UnmergedByID string        `json:"unmergedById,omitempty"`
+	UnmergedAt   *time.Time    `json:"unmergedAt,omitempty"`
+	Manifest     MergeManifest `json:"manifest"`
+}
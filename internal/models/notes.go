@@ -0,0 +1,145 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/notes"
+)
+
+// ClinicalNote is a doctor's SOAP note about a patient.
+// @Description Narrative note with subjective, objective, assessment and plan sections, and the amendments made after it was signed.
+type ClinicalNote struct {
+	ID          string `json:"id"`
+	PatientID   string `json:"patientId"`
+	EncounterID string `json:"encounterId,omitempty"`
+	AuthorID    string `json:"authorId"`
+	TemplateID  string `json:"templateId,omitempty" example:"fever"`
+	Title       string `json:"title,omitempty" example:"Fever, day 3"`
+
+	notes.Sections
+
+	// Status is draft, signed or amended.
+	Status   string     `json:"status" example:"signed"`
+	SignedAt *time.Time `json:"signedAt,omitempty"`
+
+	// Amendments are oldest first.
+	Amendments []NoteAmendment `json:"amendments"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// NoteAmendment corrects or adds to a signed note. Only the sections it
+// changes are set.
+// @Description Amendment appended to a signed note with the reason for it.
+type NoteAmendment struct {
+	ID       string `json:"id"`
+	NoteID   string `json:"noteId"`
+	AuthorID string `json:"authorId"`
+	Reason   string `json:"reason" example:"Dengue NS1 came back positive"`
+
+	Subjective string `json:"subjective,omitempty"`
+	Objective  string `json:"objective,omitempty"`
+	Assessment string `json:"assessment,omitempty" example:"Dengue fever without warning signs"`
+	Plan       string `json:"plan,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateNoteReq represents the request body for starting a note.
+// @Description Request payload to start a draft note, optionally from a template.
+type CreateNoteReq struct {
+	// PatientID is taken from the URL and AuthorID is the signed in user.
+	PatientID string `json:"-"`
+	AuthorID  string `json:"-"`
+
+	// EncounterID is the encounter the note is written in.
+	// optional: true
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
+
+	// TemplateID prefills the sections left blank.
+	// optional: true
+	TemplateID string `json:"templateId" validate:"omitempty,max=64" example:"fever"`
+
+	// Title of the note.
+	// optional: true
+	// max length: 200
+	Title string `json:"title" validate:"omitempty,max=200" example:"Fever, day 3"`
+
+	// The SOAP sections.
+	// optional: true
+	// max length: 10000
+	Subjective string `json:"subjective" validate:"omitempty,max=10000" example:"Fever for three days with body ache."`
+	Objective  string `json:"objective" validate:"omitempty,max=10000" example:"Temp 38.9 C, pulse 104, no rash."`
+	Assessment string `json:"assessment" validate:"omitempty,max=10000" example:"Acute febrile illness, likely viral."`
+	Plan       string `json:"plan" validate:"omitempty,max=10000" example:"CBC and dengue NS1. Paracetamol. Review in two days."`
+}
+
+// Sections returns the SOAP sections of the request.
+func (r *CreateNoteReq) Sections() notes.Sections {
+	return notes.Sections{
+		Subjective: r.Subjective,
+		Objective:  r.Objective,
+		Assessment: r.Assessment,
+		Plan:       r.Plan,
+	}
+}
+
+// UpdateNoteReq represents the request body for editing a draft note.
+// Fields left out are unchanged.
+// @Description Request payload to edit a draft note.
+type UpdateNoteReq struct {
+	// NoteID is taken from the URL and AuthorID is the signed in user.
+	NoteID   string `json:"-"`
+	AuthorID string `json:"-"`
+
+	Title      *string `json:"title,omitempty" validate:"omitempty,max=200"`
+	Subjective *string `json:"subjective,omitempty" validate:"omitempty,max=10000"`
+	Objective  *string `json:"objective,omitempty" validate:"omitempty,max=10000"`
+	Assessment *string `json:"assessment,omitempty" validate:"omitempty,max=10000"`
+	Plan       *string `json:"plan,omitempty" validate:"omitempty,max=10000"`
+}
+
+func (r *UpdateNoteReq) Empty() bool {
+	return r.Title == nil && r.Subjective == nil && r.Objective == nil &&
+		r.Assessment == nil && r.Plan == nil
+}
+
+// AmendNoteReq represents the request body for amending a signed note.
+// @Description Request payload to append an amendment to a signed note.
+type AmendNoteReq struct {
+	// NoteID is taken from the URL and AuthorID is the signed in user.
+	NoteID   string `json:"-"`
+	AuthorID string `json:"-"`
+
+	// Reason for the amendment.
+	// required: true
+	// min length: 5
+	// max length: 500
+	Reason string `json:"reason" validate:"required,min=5,max=500" example:"Dengue NS1 came back positive"`
+
+	// The sections the amendment adds to; at least one is required.
+	// max length: 10000
+	Subjective string `json:"subjective" validate:"omitempty,max=10000"`
+	Objective  string `json:"objective" validate:"omitempty,max=10000"`
+	Assessment string `json:"assessment" validate:"omitempty,max=10000" example:"Dengue fever without warning signs"`
+	Plan       string `json:"plan" validate:"omitempty,max=10000"`
+}
+
+// Sections returns the SOAP sections of the amendment.
+func (r *AmendNoteReq) Sections() notes.Sections {
+	return notes.Sections{
+		Subjective: r.Subjective,
+		Objective:  r.Objective,
+		Assessment: r.Assessment,
+		Plan:       r.Plan,
+	}
+}
+
+// NoteQuery selects a patient's notes. Drafts are only listed for their
+// author.
+type NoteQuery struct {
+	PatientID string `validate:"required,uuid"`
+	ViewerID  string
+	Status    string `validate:"omitempty,oneof=draft signed amended"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Paginate represents the parameters used for pagination in the API.
 // swagger:parameters paginateRequest
 type Paginate struct {
@@ -9,12 +11,81 @@ type Paginate struct {
 	PageSize int64 `json:"pageSize" validate:"required,gt=0"`
 
 	// Offset is the number of items to skip before starting to collect the result set.
-	// required: true
+	// Not used in cursor mode.
 	// example: 20
-	Page int64 `json:"page" validate:"required,gte=1"`
+	Page int64 `json:"page" validate:"required_unless=UseCursor true,omitempty,gte=1"`
 
 	// SearchTerm is a keyword or phrase used to filter the results.
 	// This is optional.
 	// example: "john doe"
 	SearchTerm string `json:"searchTerm"`
+
+	// SearchMode selects how SearchTerm is matched against names: "contains"
+	// (the default) also matches MRN and phone, "fuzzy" tolerates typos and
+	// "phonetic" matches names that sound alike.
+	// example: "fuzzy"
+	SearchMode string `json:"searchMode" validate:"omitempty,oneof=contains fuzzy phonetic"`
+
+	// Cursor is the opaque position returned by a previous cursor-mode page.
+	// Sending the cursor parameter, even empty, switches to keyset pagination.
+	// example: "eyJjIjoiMjAyNi0xMC0xOFQxMDowMDowMFoiLCJpIjoiYWJjIn0"
+	Cursor string `json:"cursor"`
+
+	// WithCount requests an estimated total in cursor mode.
+	// This is optional.
+	WithCount bool `json:"withCount"`
+
+	// UseCursor is set when the request uses keyset pagination.
+	UseCursor bool `json:"-"`
+
+	// After is the decoded Cursor, nil for the first page.
+	After *Cursor `json:"-"`
+}
+
+// Cursor marks a position in a keyset-paginated list. Clients only ever see
+// it encoded as an opaque string.
+type Cursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+
+	// Backward is set on cursors that page towards the start of the list.
+	Backward bool `json:"b,omitempty"`
+}
+
+// PatientFilter holds the structured filters and ordering applied when listing patients.
+// swagger:parameters patientFilter
+type PatientFilter struct {
+	// Gender restricts the list to a single gender.
+	// allowed values: MALE, FEMALE, OTHER
+	Gender string `json:"gender" validate:"omitempty,oneof=MALE FEMALE OTHER"`
+
+	// MinAge and MaxAge bound the patient's current age in years, inclusive.
+	MinAge *int `json:"minAge" validate:"omitempty,gte=0,lte=150"`
+	MaxAge *int `json:"maxAge" validate:"omitempty,gte=0,lte=150"`
+
+	// DOBFrom and DOBTo bound the date of birth, inclusive.
+	DOBFrom *time.Time `json:"dobFrom"`
+	DOBTo   *time.Time `json:"dobTo"`
+
+	// RegisteredBy restricts the list to patients registered by a user.
+	RegisteredBy string `json:"registeredBy" validate:"omitempty,uuid"`
+
+	// CreatedFrom and CreatedTo bound the registration date, inclusive.
+	CreatedFrom *time.Time `json:"createdFrom"`
+	CreatedTo   *time.Time `json:"createdTo"`
+
+	// HasAllergy and HasDiagnosis keep only patients with (true) or
+	// without (false) any recorded allergy or diagnosis.
+	HasAllergy   *bool `json:"hasAllergy"`
+	HasDiagnosis *bool `json:"hasDiagnosis"`
+
+	// SortBy is the field the list is ordered by.
+	// allowed values: name, age, dob, createdAt, updatedAt
+	// example: createdAt
+	SortBy string `json:"sortBy" validate:"omitempty,oneof=name age dob createdAt updatedAt"`
+
+	// SortDir is the direction of the ordering.
+	// allowed values: asc, desc
+	// example: desc
+	SortDir string `json:"sortDir" validate:"omitempty,oneof=asc desc"`
 }
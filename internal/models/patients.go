@@ -3,16 +3,20 @@ package models
 import (
 	"strings"
 	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
 )
 
 type Patient struct {
 	ID                string     `json:"id"`
+	MRN               string     `json:"mrn,omitempty"`
 	FullName          string     `json:"fullname"`
 	Gender            string     `json:"gender"`
 	DOB               DateOnly   `json:"dob"`
 	Age               int        `json:"age"`
 	ContactNumber     string     `json:"contactNo"`
 	Address           string     `json:"address"`
+	Ward              string     `json:"ward,omitempty"`
 	EmergencyName     string     `json:"emergencyName"`
 	EmergencyPhone    string     `json:"emergencyPhone"`
 	EmergencyRelation string     `json:"emergencyRelation"`
@@ -21,6 +25,10 @@ type Patient struct {
 	CreatedAt         time.Time  `json:"createdAt"`
 	UpdatedAt         *time.Time `json:"updatedAt,omitempty"`
 	Version           int        `json:"version,omitempty"`
+
+	DuplicateOverrideByID   string     `json:"duplicateOverrideById,omitempty"`
+	DuplicateOverrideAt     *time.Time `json:"duplicateOverrideAt,omitempty"`
+	DuplicateOverrideReason string     `json:"duplicateOverrideReason,omitempty"`
 }
 
 // RegPatientReq represents the request body for registering a new patient.
@@ -60,6 +68,11 @@ type RegPatientReq struct {
 	// max length: 255
 	Address string `json:"address" validate:"required,min=5,max=255"`
 
+	// Ward is the ward the patient is admitted to.
+	// optional: true
+	// max length: 50
+	Ward string `json:"ward" validate:"omitempty,max=50"`
+
 	// EmergencyName is the name of the emergency contact person.
 	// required: true
 	EmergencyName string `json:"emergencyName" validate:"required"`
@@ -77,11 +90,55 @@ type RegPatientReq struct {
 	// RegByID is the ID of the user who registered the patient.
 	// It's not included in the API payload.
 	RegByID string `json:"-"`
+
+	// Force registers the patient even when probable duplicates exist.
+	// The user forcing the registration is recorded on the patient.
+	// optional: true
+	Force bool `json:"force"`
+
+	// ForceReason explains why a probable duplicate was registered anyway.
+	// optional: true
+	// max length: 255
+	ForceReason string `json:"forceReason" validate:"omitempty,max=255"`
+}
+
+// DuplicateCandidate is an existing patient that probably matches a new registration.
+type DuplicateCandidate struct {
+	// Patient is the existing patient record.
+	Patient ListPatientItem `json:"patient"`
+
+	// ContactNumber is the existing patient's contact number.
+	ContactNumber string `json:"contactNo"`
+
+	// Score is the match probability in the range [0, 1].
+	Score float64 `json:"score" example:"0.92"`
+
+	// MatchedOn lists the fields that matched exactly or near exactly.
+	MatchedOn []string `json:"matchedOn"`
+}
+
+// DuplicatePatientRes is returned with a 409 when a registration matches existing patients.
+// @Description Conflict response listing probable duplicate patients.
+type DuplicatePatientRes struct {
+	Status     int                   `json:"status" example:"409"`
+	Error      string                `json:"error" example:"probable duplicate patients found"`
+	Candidates []*DuplicateCandidate `json:"candidates"`
 }
 
 type ListPatientRes struct {
 	Patients []*ListPatientItem   `json:"patients"`
-	Meta     *ListPatientMetadata `json:"meta"`
+	Meta     *ListPatientMetadata `json:"meta,omitempty"`
+	Cursor   *CursorMetadata      `json:"cursor,omitempty"`
+}
+
+// CursorMetadata describes a page fetched in cursor mode.
+type CursorMetadata struct {
+	PageSize    int64  `json:"pageSize"`                 // e.g., 10
+	Next        string `json:"next,omitempty"`           // cursor of the next page
+	Previous    string `json:"previous,omitempty"`       // cursor of the previous page
+	HasNext     bool   `json:"hasNext"`                  // true if next page exists
+	HasPrevious bool   `json:"hasPrevious"`              // true if previous page exists
+	Estimated   *int64 `json:"estimatedTotal,omitempty"` // planner estimate, only with withCount
 }
 
 type ListPatientMetadata struct {
@@ -101,6 +158,9 @@ type ListPatientItem struct {
 	// ID is the unique identifier of the patient.
 	ID string `json:"id"`
 
+	// MRN is the patient's medical record number.
+	MRN string `json:"mrn,omitempty"`
+
 	// Username is the username of the patient.
 	FullName string `json:"fullName"`
 
@@ -112,6 +172,13 @@ type ListPatientItem struct {
 
 	// DOB is the patient's date of birth.
 	DOB time.Time `json:"dob"`
+
+	// CreatedAt is when the patient was registered.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Score is the search relevance in the range [0, 1], only set for fuzzy
+	// and phonetic searches.
+	Score *float64 `json:"score,omitempty" example:"0.82"`
 }
 
 // UpdatePatientReq represents the request body for updating patient details.
@@ -121,6 +188,9 @@ type UpdatePatientReq struct {
 	// It's excluded from the API payload.
 	ID string `json:"-"`
 
+	// EditedByID is the signed in user. It's a server-side value.
+	EditedByID string `json:"-"`
+
 	// FullName is the updated full name of the patient.
 	// optional: true
 	// min length: 2
@@ -154,6 +224,12 @@ type UpdatePatientReq struct {
 	// max length: 255
 	Address *string `json:"address" validate:"omitempty,min=5,max=255"`
 
+	// Ward is the ward the patient is moved to; an empty ward discharges
+	// the patient from their ward.
+	// optional: true
+	// max length: 50
+	Ward *string `json:"ward" validate:"omitempty,max=50"`
+
 	// EmergencyName is the updated emergency contact name.
 	// optional: true
 	EmergencyName *string `json:"emergencyName" validate:"omitempty"`
@@ -174,9 +250,11 @@ func (r *RegPatientReq) Sanitize() {
 	r.Gender = strings.ToUpper(strings.TrimSpace(r.Gender))
 	r.ContactNumber = strings.TrimSpace(r.ContactNumber)
 	r.Address = strings.TrimSpace(r.Address)
+	r.Ward = strings.TrimSpace(r.Ward)
 	r.EmergencyName = strings.TrimSpace(r.EmergencyName)
 	r.EmergencyRelation = strings.TrimSpace(r.EmergencyRelation)
 	r.EmergencyPhone = strings.TrimSpace(r.EmergencyPhone)
+	r.ForceReason = strings.TrimSpace(r.ForceReason)
 }
 
 func (p *UpdatePatientReq) Sanitize() {
@@ -203,6 +281,11 @@ func (p *UpdatePatientReq) Sanitize() {
 		p.Address = &trimmed
 	}
 
+	if p.Ward != nil {
+		trimmed := strings.TrimSpace(*p.Ward)
+		p.Ward = &trimmed
+	}
+
 	if p.EmergencyName != nil {
 		trimmed := strings.TrimSpace(*p.EmergencyName)
 		p.EmergencyName = &trimmed
@@ -220,11 +303,20 @@ func (p *UpdatePatientReq) Sanitize() {
 }
 
 type Record struct {
-	Patient    Patient          `json:"patient"`
-	Allergies  []AllergyModel   `json:"allergies"`
-	Conditions []ConditionModel `json:"conditions"`
-	Diagnoses  []DiagnosesModel `json:"diagnoses"`
-	Vitals     VitalModel       `json:"vitals"`
+	Patient   Patient        `json:"patient"`
+	Allergies []AllergyModel `json:"allergies"`
+	// NoKnownAllergies is set when the patient was asserted to have no
+	// known allergies and none were recorded since.
+	NoKnownAllergies *NoKnownAllergies `json:"noKnownAllergies,omitempty"`
+	Conditions       []ConditionModel  `json:"conditions"`
+	Diagnoses        []DiagnosesModel  `json:"diagnoses"`
+	// Medications are the patient's current (active and on-hold) medications.
+	Medications []Medication `json:"medications"`
+	Vitals      VitalModel   `json:"vitals"`
+
+	FamilyHistory []FamilyHistory `json:"familyHistory"`
+	// SocialHistory is left out until one is recorded.
+	SocialHistory *SocialHistory `json:"socialHistory,omitempty"`
 }
 
 type PatientModel struct {
@@ -244,43 +336,80 @@ type PatientModel struct {
 }
 
 type AllergyModel struct {
-	ID         string     `json:"id"`
-	PatientID  string     `json:"patientID"`
-	Name       string     `json:"name"`
-	Reaction   string     `json:"reaction"`
-	Severity   string     `json:"severity"`
-	RecordedAt time.Time  `json:"recordedAt"`
-	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+	ID                 string            `json:"id"`
+	PatientID          string            `json:"patientID"`
+	Name               string            `json:"name"`
+	Reaction           string            `json:"reaction"`
+	Severity           string            `json:"severity"`
+	Category           string            `json:"category,omitempty"`
+	Substance          *CodedSubstance   `json:"substance,omitempty"`
+	Criticality        string            `json:"criticality"`
+	VerificationStatus string            `json:"verificationStatus"`
+	Reactions          []AllergyReaction `json:"reactions"`
+	EncounterID        string            `json:"encounterId,omitempty"`
+	RecordedAt         time.Time         `json:"recordedAt"`
+	UpdatedAt          *time.Time        `json:"updatedAt,omitempty"`
 }
 
 type ConditionModel struct {
-	ID        string     `json:"id"`
-	PatientID string     `json:"patientID"`
-	Name      string     `json:"name"`
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	ID                 string     `json:"id"`
+	PatientID          string     `json:"patientID"`
+	Name               string     `json:"name"`
+	ClinicalStatus     string     `json:"clinicalStatus"`
+	VerificationStatus string     `json:"verificationStatus"`
+	OnsetDate          *DateOnly  `json:"onsetDate,omitempty" swaggertype:"string"`
+	AbatementDate      *DateOnly  `json:"abatementDate,omitempty" swaggertype:"string"`
+	Note               string     `json:"note,omitempty"`
+	EncounterID        string     `json:"encounterId,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          *time.Time `json:"updatedAt,omitempty"`
+
+	// History lists the condition's status changes, oldest first.
+	History []ConditionTransition `json:"history"`
 }
 
 type DiagnosesModel struct {
-	ID        string     `json:"id"`
-	PatientID string     `json:"patientID"`
-	Name      string     `json:"name"`
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	ID          string     `json:"id"`
+	PatientID   string     `json:"patientID"`
+	Name        string     `json:"name"`
+	Code        string     `json:"code,omitempty"`
+	Display     string     `json:"display,omitempty"`
+	Type        string     `json:"type,omitempty"`
+	EncounterID string     `json:"encounterId,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
 }
 
 type VitalModel struct {
 	ID                     string     `json:"id,omitempty"`
 	PatientID              string     `json:"patientID,omitempty"`
+	RecordedByID           string     `json:"recordedById,omitempty"`
+	MeasuredAt             *time.Time `json:"measuredAt,omitempty"`
+	Source                 string     `json:"source,omitempty"`
+	DeviceID               string     `json:"deviceId,omitempty"`
+	EncounterID            string     `json:"encounterId,omitempty"`
 	HeightCm               *float64   `json:"height_cm,omitempty"`
 	WeightKg               *float64   `json:"weight_kg,omitempty"`
 	BMI                    *float64   `json:"bmi,omitempty"`
 	TemperatureC           *float64   `json:"temperature_c,omitempty"`
 	Pulse                  *int       `json:"pulse,omitempty"`
 	RespiratoryRate        *int       `json:"respiratory_rate,omitempty"`
-	BloodPressureSystolic  *int       `json:"blood_pressure_systolic,omitempty"`
-	BloodPressureDiastolic *int       `json:"blood_pressure_diastolic,omitempty"`
+	BloodPressureSystolic  *float64   `json:"blood_pressure_systolic,omitempty"`
+	BloodPressureDiastolic *float64   `json:"blood_pressure_diastolic,omitempty"`
 	OxygenSaturation       *float64   `json:"oxygen_saturation,omitempty"`
 	CreatedAt              *time.Time `json:"createdAt,omitempty"`
 	UpdatedAt              *time.Time `json:"updatedAt,omitempty"`
+
+	// Units are the units of the measurement values above.
+	Units *VitalUnits `json:"units,omitempty"`
+
+	// Flags classifies each measurement as low, normal, high or critical
+	// for the patient's age and sex.
+	Flags map[string]string `json:"flags,omitempty"`
+
+	Consciousness      string `json:"consciousness,omitempty"`
+	SupplementalOxygen *bool  `json:"supplementalOxygen,omitempty"`
+
+	// NEWS2 is only set when every parameter of the score was observed.
+	NEWS2 *clinical.NEWS2Score `json:"news2,omitempty"`
 }
@@ -0,0 +1,98 @@
+package models
+
+import "time"
+
+// QueueEntry is a patient in the walk-in queue.
+// @Description Patient checked in to the walk-in queue with their triage priority and status.
+type QueueEntry struct {
+	ID          string `json:"id"`
+	PatientID   string `json:"patientId"`
+	PatientName string `json:"patientName" example:"Asha Verma"`
+	MRN         string `json:"mrn,omitempty" example:"MB-20261018-3FA2C1"`
+
+	// Priority is immediate, urgent, standard or non-urgent.
+	Priority string `json:"priority" example:"standard"`
+
+	// Status is waiting, in-consultation or done.
+	Status    string `json:"status" example:"waiting"`
+	Complaint string `json:"complaint,omitempty" example:"Fever since yesterday"`
+
+	DoctorID      string     `json:"doctorId,omitempty"`
+	CheckedInByID string     `json:"checkedInById,omitempty"`
+	CheckedInAt   time.Time  `json:"checkedInAt"`
+	CalledAt      *time.Time `json:"calledAt,omitempty"`
+	CompletedAt   *time.Time `json:"completedAt,omitempty"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty"`
+
+	// Position and EstimatedWaitMinutes are set for waiting patients on the
+	// board; the first patient to be seen is at position 1.
+	Position             int  `json:"position,omitempty" example:"3"`
+	EstimatedWaitMinutes *int `json:"estimatedWaitMinutes,omitempty" example:"30"`
+}
+
+// CheckInReq represents the request body for checking a patient in to the
+// walk-in queue.
+// @Description Request payload to check a registered patient in to the walk-in queue.
+type CheckInReq struct {
+	// CheckedInByID is the signed in user.
+	CheckedInByID string `json:"-"`
+
+	// PatientID is the registered patient checking in.
+	// required: true
+	PatientID string `json:"patientId" validate:"required,uuid"`
+
+	// Priority defaults to standard until the patient is triaged.
+	// optional: true
+	// allowed values: immediate, urgent, standard, non-urgent
+	Priority string `json:"priority" validate:"omitempty,oneof=immediate urgent standard non-urgent" example:"standard"`
+
+	// Complaint is why the patient came.
+	// optional: true
+	// max length: 500
+	Complaint string `json:"complaint" validate:"omitempty,max=500" example:"Fever since yesterday"`
+}
+
+// TriageReq represents the request body for setting a queued patient's
+// priority.
+// @Description Request payload to triage a patient in the queue.
+type TriageReq struct {
+	// EntryID is taken from the URL.
+	EntryID string `json:"-"`
+
+	// Priority assigned at triage.
+	// required: true
+	// allowed values: immediate, urgent, standard, non-urgent
+	Priority string `json:"priority" validate:"required,oneof=immediate urgent standard non-urgent" example:"urgent"`
+}
+
+// QueueStatusReq represents the request body for moving a queued patient
+// along.
+// @Description Request payload to change the status of a patient in the queue.
+type QueueStatusReq struct {
+	// EntryID is taken from the URL.
+	EntryID string `json:"-"`
+
+	// Status is the new status.
+	// required: true
+	// allowed values: waiting, in-consultation, done
+	Status string `json:"status" validate:"required,oneof=waiting in-consultation done" example:"in-consultation"`
+
+	// DoctorID is the doctor seeing the patient when the status is
+	// in-consultation. It defaults to the signed in doctor.
+	// optional: true
+	DoctorID string `json:"doctorId" validate:"omitempty,uuid"`
+}
+
+// QueueBoard is the walk-in queue of a day.
+// @Description Walk-in queue of the day: who is waiting in the order they'll be seen, who is with a doctor and who is done.
+type QueueBoard struct {
+	Date DateOnly `json:"date" swaggertype:"string" example:"2026-10-18"`
+
+	// AverageConsultationMinutes is the mean length of the day's finished
+	// consultations that wait estimates are based on.
+	AverageConsultationMinutes int `json:"averageConsultationMinutes" example:"12"`
+
+	Waiting        []QueueEntry `json:"waiting"`
+	InConsultation []QueueEntry `json:"inConsultation"`
+	Done           []QueueEntry `json:"done"`
+}
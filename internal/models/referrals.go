@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// Referral sends a patient to another doctor of the clinic or to an outside
+// facility.
+// @Description Referral of a patient with its status and, when fetched on its own, a snapshot of the patient's record.
+type Referral struct {
+	ID          string `json:"id"`
+	PatientID   string `json:"patientId"`
+	PatientName string `json:"patientName,omitempty"`
+
+	ReferredByID   string `json:"referredById"`
+	ReferredByName string `json:"referredByName,omitempty"`
+
+	// ToDoctorID is set for a referral within the clinic and Facility for
+	// one to an outside facility.
+	ToDoctorID   string            `json:"toDoctorId,omitempty"`
+	ToDoctorName string            `json:"toDoctorName,omitempty"`
+	Facility     *ReferralFacility `json:"facility,omitempty"`
+
+	Specialty string `json:"specialty,omitempty" example:"Cardiology"`
+	Reason    string `json:"reason" example:"Exertional chest pain, TMT advised"`
+	Urgency   string `json:"urgency" example:"urgent"`
+
+	// Summary is the patient's record when the referral was made.
+	Summary *Record `json:"summary,omitempty"`
+
+	// Status is pending, accepted, declined, completed or cancelled.
+	Status       string     `json:"status" example:"pending"`
+	ResponseNote string     `json:"responseNote,omitempty"`
+	RespondedAt  *time.Time `json:"respondedAt,omitempty"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ReferralFacility is the outside facility a patient is referred to.
+type ReferralFacility struct {
+	// Name of the facility.
+	// required: true
+	Name string `json:"name" validate:"required,min=2,max=150" example:"District Hospital, Sitapur"`
+
+	// Contact is a phone number or address of the facility.
+	// optional: true
+	Contact string `json:"contact,omitempty" validate:"omitempty,max=150" example:"05862-242345"`
+}
+
+// CreateReferralReq represents the request body for referring a patient.
+// @Description Request payload to refer a patient to a doctor of the clinic or to an outside facility.
+type CreateReferralReq struct {
+	// PatientID is taken from the URL and ReferredByID is the signed in user.
+	PatientID    string `json:"-"`
+	ReferredByID string `json:"-"`
+
+	// ToDoctorID is the doctor of the clinic the patient is referred to.
+	// required: without facility
+	ToDoctorID string `json:"toDoctorId" validate:"required_without=Facility,excluded_with=Facility,omitempty,uuid"`
+
+	// Facility is the outside facility the patient is referred to.
+	// required: without toDoctorId
+	Facility *ReferralFacility `json:"facility" validate:"required_without=ToDoctorID"`
+
+	// Specialty the patient is referred for.
+	// optional: true
+	Specialty string `json:"specialty" validate:"omitempty,max=100" example:"Cardiology"`
+
+	// Reason for the referral.
+	// required: true
+	Reason string `json:"reason" validate:"required,min=5,max=1000" example:"Exertional chest pain, TMT advised"`
+
+	// Urgency defaults to routine.
+	// optional: true
+	Urgency string `json:"urgency" validate:"omitempty,oneof=routine urgent emergency" example:"urgent"`
+}
+
+// ReferralActionReq accepts, declines, completes or cancels a referral.
+// @Description Request payload to answer or close a referral.
+type ReferralActionReq struct {
+	// ReferralID is taken from the URL, UserID is the signed in user and
+	// Status is the status the action moves the referral to.
+	ReferralID string `json:"-"`
+	UserID     string `json:"-"`
+	Status     string `json:"-"`
+
+	// Note to the other doctor, such as the reason for declining or the
+	// outcome. It's required to decline.
+	// optional: true
+	// max length: 1000
+	Note string `json:"note" validate:"omitempty,max=1000" example:"Seen, TMT negative, continue medical management"`
+}
+
+// ReferralQuery filters the referral inbox of a user.
+type ReferralQuery struct {
+	UserID string `validate:"required"`
+
+	// Box is received for the referrals to the user and sent for the ones
+	// they made.
+	Box     string `validate:"oneof=received sent"`
+	Status  string `validate:"omitempty,oneof=pending accepted declined completed cancelled"`
+	Urgency string `validate:"omitempty,oneof=routine urgent emergency"`
+	Limit   int    `validate:"gte=1,lte=200"`
+}
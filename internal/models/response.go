@@ -14,3 +14,12 @@ type FailureResponse struct {
 	Status int    `json:"status" example:"400"`
 	Error  string `json:"error" example:"Bad Request"`
 }
+
+// ValidationFailureResponse is a failure response that explains which
+// request fields are invalid.
+// @Description Validation error response with a message for every invalid field.
+type ValidationFailureResponse struct {
+	Status int               `json:"status" example:"400"`
+	Error  string            `json:"error" example:"Bad Request"`
+	Fields map[string]string `json:"fields"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// TimelineEvent is one event of a patient's record: an entry recorded, a
+// status changed or an entry edited.
+// @Description Event of the patient's record with who caused it.
+type TimelineEvent struct {
+	// Type of the event: registration, vitals, diagnosis, condition,
+	// condition-status, allergy, note, note-amendment, referral, edit,
+	// encounter, medication, lab-result or immunization.
+	Type       string    `json:"type" example:"diagnosis"`
+	OccurredAt time.Time `json:"occurredAt"`
+
+	// Entity and EntityID name the entry the event is about.
+	Entity   string `json:"entity" example:"diagnosis"`
+	EntityID string `json:"entityId"`
+
+	Summary string `json:"summary" example:"Diagnosed with Type 2 diabetes mellitus without complications (E11.9)"`
+
+	// Fields are the fields changed by an edit.
+	Fields []string `json:"fields,omitempty"`
+
+	// ActorID and ActorName are the user who caused the event. They are
+	// left out when it isn't recorded.
+	ActorID   string `json:"actorId,omitempty"`
+	ActorName string `json:"actorName,omitempty" example:"Dr. Asha Rao"`
+}
+
+// TimelineQuery selects a page of a patient's timeline, newest first.
+type TimelineQuery struct {
+	PatientID string `validate:"required,uuid"`
+	// ViewerID is the user reading the timeline, who alone sees their
+	// draft notes.
+	ViewerID string
+
+	// Types keeps only the events of the types given.
+	Types []string `validate:"dive,oneof=registration vitals diagnosis condition condition-status allergy note note-amendment referral edit encounter medication lab-result immunization"`
+
+	// From and To bound the time of the events, inclusive.
+	From *time.Time
+	To   *time.Time
+
+	Page     int64 `validate:"gte=1"`
+	PageSize int64 `validate:"gte=1,lte=100"`
+}
+
+// Timeline is a page of a patient's timeline.
+type Timeline struct {
+	PatientID string               `json:"patientId"`
+	Events    []*TimelineEvent     `json:"events"`
+	Meta      *ListPatientMetadata `json:"meta"`
+}
@@ -1,31 +1,363 @@
 package models
 
-// CreateVitalReq represents the request body for creating a patient's vitals.
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+)
+
+// CreateVitalReq represents the request body for recording a vitals observation.
 // @Description Request payload to capture new vital signs of a patient.
 type CreateVitalReq struct {
 	PatientID              string   `json:"-" swaggerignore:"true"`
+	RecordedByID           string   `json:"-" swaggerignore:"true"`
 	HeightCm               *float64 `json:"heightCm" validate:"omitempty,gt=0" example:"170"`
 	WeightKg               *float64 `json:"weightKg" validate:"omitempty,gt=0" example:"65"`
 	BMI                    *float64 `json:"bmi" validate:"omitempty,gt=0" example:"22.5"`
 	TemperatureC           *float64 `json:"temperatureC" validate:"omitempty,gt=29,lte=45" example:"36.5"`
 	Pulse                  *int     `json:"pulse" validate:"omitempty,gt=0" example:"72"`
 	RespiratoryRate        *int     `json:"respiratoryRate" validate:"omitempty,gt=0" example:"18"`
-	BloodPressureSystolic  *int     `json:"bloodPressureSystolic" validate:"omitempty,gt=0" example:"120"`
-	BloodPressureDiastolic *int     `json:"bloodPressureDiastolic" validate:"omitempty,gt=0" example:"80"`
+	BloodPressureSystolic  *float64 `json:"bloodPressureSystolic" validate:"omitempty,gt=0" example:"120"`
+	BloodPressureDiastolic *float64 `json:"bloodPressureDiastolic" validate:"omitempty,gt=0" example:"80"`
 	OxygenSaturation       *float64 `json:"oxygenSaturation" validate:"omitempty,gt=0,lte=100" example:"98.0"`
+
+	// Consciousness is the level of consciousness on the ACVPU scale.
+	// allowed values: alert, confusion, voice, pain, unresponsive
+	Consciousness string `json:"consciousness" validate:"omitempty,oneof=alert confusion voice pain unresponsive" example:"alert"`
+
+	// SupplementalOxygen tells whether the patient was on oxygen when the
+	// saturation was measured.
+	SupplementalOxygen *bool `json:"supplementalOxygen" example:"false"`
+
+	// MeasuredAt is when the observation was taken; it defaults to now and
+	// cannot be in the future.
+	MeasuredAt *time.Time `json:"measuredAt" example:"2026-10-18T09:30:00Z"`
+
+	// Source tells where the observation came from.
+	// allowed values: manual, device, import
+	Source string `json:"source" validate:"omitempty,oneof=manual device import" example:"manual"`
+
+	// DeviceID identifies the monitor that produced a device reading.
+	DeviceID string `json:"deviceId" validate:"omitempty,max=100" example:"ward3-monitor-07"`
+
+	// EncounterID is the encounter the observation is taken in. It must be
+	// an encounter of the patient that wasn't cancelled.
+	EncounterID string `json:"encounterId" validate:"omitempty,uuid"`
+
+	// Units gives the units of the values in this request when they are not
+	// cm, kg, °C and mmHg. Values are stored converted.
+	Units *VitalUnits `json:"units"`
 }
 
-// UpdateVitalReq represents the request body for updating a patient's vitals.
-// @Description Request payload to update existing vital signs of a patient. All fields are optional.
+// UpdateVitalReq represents the request body for correcting a vitals observation.
+// @Description Request payload to correct an existing vitals observation. All fields are optional.
 type UpdateVitalReq struct {
-	PatientID              string   `json:"-" swaggerignore:"true"`
-	HeightCm               *float64 `json:"heightCm" validate:"omitempty,gte=0" example:"172"`
-	WeightKg               *float64 `json:"weightKg" validate:"omitempty,gte=0" example:"68"`
-	BMI                    *float64 `json:"bmi" validate:"omitempty,gte=0" example:"23.0"`
-	TemperatureC           *float64 `json:"temperatureC" validate:"omitempty,gte=30,lte=45" example:"37.0"`
-	Pulse                  *int     `json:"pulse" validate:"omitempty,gte=0" example:"75"`
-	RespiratoryRate        *int     `json:"respiratoryRate" validate:"omitempty,gte=0" example:"20"`
-	BloodPressureSystolic  *int     `json:"bloodPressureSystolic" validate:"omitempty,gte=0" example:"122"`
-	BloodPressureDiastolic *int     `json:"bloodPressureDiastolic" validate:"omitempty,gte=0" example:"82"`
-	OxygenSaturation       *float64 `json:"oxygenSaturation" validate:"omitempty,gte=0,lte=100" example:"97.0"`
+	ID                     string      `json:"-" swaggerignore:"true"`
+	EditedByID             string      `json:"-" swaggerignore:"true"`
+	HeightCm               *float64    `json:"heightCm" validate:"omitempty,gte=0" example:"172"`
+	WeightKg               *float64    `json:"weightKg" validate:"omitempty,gte=0" example:"68"`
+	BMI                    *float64    `json:"bmi" validate:"omitempty,gte=0" example:"23.0"`
+	TemperatureC           *float64    `json:"temperatureC" validate:"omitempty,gte=30,lte=45" example:"37.0"`
+	Pulse                  *int        `json:"pulse" validate:"omitempty,gte=0" example:"75"`
+	RespiratoryRate        *int        `json:"respiratoryRate" validate:"omitempty,gte=0" example:"20"`
+	BloodPressureSystolic  *float64    `json:"bloodPressureSystolic" validate:"omitempty,gte=0" example:"122"`
+	BloodPressureDiastolic *float64    `json:"bloodPressureDiastolic" validate:"omitempty,gte=0" example:"82"`
+	OxygenSaturation       *float64    `json:"oxygenSaturation" validate:"omitempty,gte=0,lte=100" example:"97.0"`
+	Consciousness          *string     `json:"consciousness" validate:"omitempty,oneof=alert confusion voice pain unresponsive" example:"alert"`
+	SupplementalOxygen     *bool       `json:"supplementalOxygen" example:"false"`
+	MeasuredAt             *time.Time  `json:"measuredAt" example:"2026-10-18T09:30:00Z"`
+	Units                  *VitalUnits `json:"units"`
+}
+
+// VitalUnits names the unit of each kind of measurement in a request or
+// response. Empty fields mean the default unit.
+type VitalUnits struct {
+	Height        string `json:"height,omitempty" validate:"omitempty,oneof=cm m in" example:"cm"`
+	Weight        string `json:"weight,omitempty" validate:"omitempty,oneof=kg lb" example:"kg"`
+	Temperature   string `json:"temperature,omitempty" validate:"omitempty,oneof=C F" example:"C"`
+	BloodPressure string `json:"bloodPressure,omitempty" validate:"omitempty,oneof=mmHg kPa" example:"mmHg"`
+}
+
+// CanonicalVitalUnits returns the units vitals are stored in.
+func CanonicalVitalUnits() *VitalUnits {
+	return newVitalUnits(clinical.Canonical)
+}
+
+func newVitalUnits(u clinical.Units) *VitalUnits {
+	return &VitalUnits{
+		Height:        u.Height,
+		Weight:        u.Weight,
+		Temperature:   u.Temperature,
+		BloodPressure: u.BloodPressure,
+	}
+}
+
+func (u *VitalUnits) units() clinical.Units {
+	if u == nil {
+		return clinical.Canonical
+	}
+	return clinical.Units{
+		Height:        u.Height,
+		Weight:        u.Weight,
+		Temperature:   u.Temperature,
+		BloodPressure: u.BloodPressure,
+	}
+}
+
+// toCanonical converts *v in place from the unit of kind in u.
+func toCanonical(kind string, v *float64, u clinical.Units) error {
+	if v == nil {
+		return nil
+	}
+	converted, err := clinical.ToCanonical(kind, *v, u.Unit(kind))
+	if err != nil {
+		return err
+	}
+	*v = converted
+	return nil
+}
+
+// fromCanonical converts *v in place to the unit of kind in u.
+func fromCanonical(kind string, v *float64, u clinical.Units, places int) error {
+	if v == nil {
+		return nil
+	}
+	converted, err := clinical.FromCanonical(kind, *v, u.Unit(kind))
+	if err != nil {
+		return err
+	}
+	*v = clinical.Round(converted, places)
+	return nil
+}
+
+// Normalise converts the values of the request to cm, kg, °C and mmHg.
+func (r *CreateVitalReq) Normalise() error {
+	u := r.Units.units()
+	return errors.Join(
+		toCanonical("height", r.HeightCm, u),
+		toCanonical("weight", r.WeightKg, u),
+		toCanonical("temperature", r.TemperatureC, u),
+		toCanonical("bloodPressure", r.BloodPressureSystolic, u),
+		toCanonical("bloodPressure", r.BloodPressureDiastolic, u),
+	)
+}
+
+// Normalise converts the values of the request to cm, kg, °C and mmHg.
+func (r *UpdateVitalReq) Normalise() error {
+	u := r.Units.units()
+	return errors.Join(
+		toCanonical("height", r.HeightCm, u),
+		toCanonical("weight", r.WeightKg, u),
+		toCanonical("temperature", r.TemperatureC, u),
+		toCanonical("bloodPressure", r.BloodPressureSystolic, u),
+		toCanonical("bloodPressure", r.BloodPressureDiastolic, u),
+	)
+}
+
+// Values returns the measurements present in the request keyed by metric.
+func (r *CreateVitalReq) Values() map[string]float64 {
+	return vitalValues(r.HeightCm, r.WeightKg, r.BMI, r.TemperatureC, r.Pulse, r.RespiratoryRate,
+		r.BloodPressureSystolic, r.BloodPressureDiastolic, r.OxygenSaturation)
+}
+
+// Values returns the measurements present in the request keyed by metric.
+func (r *UpdateVitalReq) Values() map[string]float64 {
+	return vitalValues(r.HeightCm, r.WeightKg, r.BMI, r.TemperatureC, r.Pulse, r.RespiratoryRate,
+		r.BloodPressureSystolic, r.BloodPressureDiastolic, r.OxygenSaturation)
+}
+
+func vitalValues(height, weight, bmi, temperature *float64, pulse, rr *int, systolic, diastolic, spo2 *float64) map[string]float64 {
+	values := make(map[string]float64)
+	set := func(metric string, v *float64) {
+		if v != nil {
+			values[metric] = *v
+		}
+	}
+	setInt := func(metric string, v *int) {
+		if v != nil {
+			values[metric] = float64(*v)
+		}
+	}
+
+	set("heightCm", height)
+	set("weightKg", weight)
+	set("bmi", bmi)
+	set("temperatureC", temperature)
+	setInt("pulse", pulse)
+	setInt("respiratoryRate", rr)
+	set("bloodPressureSystolic", systolic)
+	set("bloodPressureDiastolic", diastolic)
+	set("oxygenSaturation", spo2)
+	return values
+}
+
+// ApplyFlags flags every measurement against the reference range for a
+// patient of the given sex and date of birth. It must run before ConvertTo,
+// as the ranges are in the stored units.
+func (v *VitalModel) ApplyFlags(sex string, dob time.Time) {
+	measuredAt := time.Now()
+	if v.MeasuredAt != nil {
+		measuredAt = *v.MeasuredAt
+	}
+	age := clinical.AgeAt(dob, measuredAt)
+
+	values := vitalValues(v.HeightCm, v.WeightKg, v.BMI, v.TemperatureC, v.Pulse, v.RespiratoryRate,
+		v.BloodPressureSystolic, v.BloodPressureDiastolic, v.OxygenSaturation)
+	for metric, value := range values {
+		if flag := clinical.Classify(metric, value, sex, age); flag != "" {
+			if v.Flags == nil {
+				v.Flags = make(map[string]string)
+			}
+			v.Flags[metric] = string(flag)
+		}
+	}
+}
+
+// ConvertTo converts the stored values of the observation to a unit system
+// and records the units used.
+func (v *VitalModel) ConvertTo(system string) error {
+	u, ok := clinical.UnitSystems[system]
+	if !ok {
+		return fmt.Errorf("unknown unit system %q", system)
+	}
+
+	heightPlaces := 1
+	if u.Height == "m" {
+		heightPlaces = 2
+	}
+
+	err := errors.Join(
+		fromCanonical("height", v.HeightCm, u, heightPlaces),
+		fromCanonical("weight", v.WeightKg, u, 1),
+		fromCanonical("temperature", v.TemperatureC, u, 1),
+		fromCanonical("bloodPressure", v.BloodPressureSystolic, u, 1),
+		fromCanonical("bloodPressure", v.BloodPressureDiastolic, u, 1),
+	)
+	if err != nil {
+		return err
+	}
+	v.Units = newVitalUnits(u)
+	return nil
+}
+
+// VitalsQuery selects observations of a patient, optionally within a time range.
+type VitalsQuery struct {
+	PatientID string `validate:"required,uuid"`
+
+	// Metric is only used for series queries.
+	Metric string `validate:"omitempty,oneof=heightCm weightKg bmi temperatureC pulse respiratoryRate bloodPressureSystolic bloodPressureDiastolic oxygenSaturation"`
+
+	// From and To bound the measurement time, inclusive.
+	From *time.Time
+	To   *time.Time
+
+	// Limit caps the number of observations returned.
+	Limit int `validate:"omitempty,gt=0,lte=1000"`
+}
+
+// VitalReading is the value of one metric at one point in time.
+type VitalReading struct {
+	VitalID    string    `json:"vitalId"`
+	Value      float64   `json:"value" example:"72"`
+	Unit       string    `json:"unit,omitempty" example:"bpm"`
+	MeasuredAt time.Time `json:"measuredAt"`
+
+	// Flag is low, normal, high or critical against the reference range.
+	Flag string `json:"flag,omitempty" example:"normal"`
+}
+
+// ApplyFlag flags a stored reading of metric for a patient of the given sex
+// and date of birth.
+func (r *VitalReading) ApplyFlag(metric, sex string, dob time.Time) {
+	r.Flag = string(clinical.Classify(metric, r.Value, sex, clinical.AgeAt(dob, r.MeasuredAt)))
+}
+
+// convert converts the value of a stored reading of metric to the units u.
+func (r *VitalReading) convert(metric string, u clinical.Units) error {
+	kind := clinical.MetricKind(metric)
+	if kind == "" {
+		return nil
+	}
+
+	places := 1
+	if u.Unit(kind) == "m" {
+		places = 2
+	}
+	return fromCanonical(kind, &r.Value, u, places)
+}
+
+// metricUnit returns the unit of metric in the unit set.
+func metricUnit(metric string, u clinical.Units) string {
+	if kind := clinical.MetricKind(metric); kind != "" {
+		return u.Unit(kind)
+	}
+	return fixedUnits[metric]
+}
+
+// fixedUnits are the units of metrics that are never converted.
+var fixedUnits = map[string]string{
+	"bmi":              "kg/m2",
+	"pulse":            "bpm",
+	"respiratoryRate":  "breaths/min",
+	"oxygenSaturation": "%",
+}
+
+// VitalSeries is the history of a single metric, oldest first.
+type VitalSeries struct {
+	Metric   string          `json:"metric" example:"pulse"`
+	Unit     string          `json:"unit" example:"bpm"`
+	Readings []*VitalReading `json:"readings"`
+}
+
+// ConvertTo converts the readings to a unit system.
+func (s *VitalSeries) ConvertTo(system string) error {
+	u, ok := clinical.UnitSystems[system]
+	if !ok {
+		return fmt.Errorf("unknown unit system %q", system)
+	}
+
+	s.Unit = metricUnit(s.Metric, u)
+	for _, r := range s.Readings {
+		if err := r.convert(s.Metric, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LatestVitals maps each metric to its most recent reading. Metrics that were
+// never recorded are absent.
+type LatestVitals map[string]*VitalReading
+
+// ConvertTo converts every reading to a unit system.
+func (l LatestVitals) ConvertTo(system string) error {
+	u, ok := clinical.UnitSystems[system]
+	if !ok {
+		return fmt.Errorf("unknown unit system %q", system)
+	}
+
+	for metric, r := range l {
+		if err := r.convert(metric, u); err != nil {
+			return err
+		}
+		r.Unit = metricUnit(metric, u)
+	}
+	return nil
+}
+
+// WardNEWS2Item is a patient on a ward with their latest NEWS2 score.
+// @Description Patient on a ward with the NEWS2 score of their latest fully scored observation.
+type WardNEWS2Item struct {
+	PatientID string `json:"patientId"`
+	MRN       string `json:"mrn,omitempty"`
+	FullName  string `json:"fullName"`
+	Ward      string `json:"ward"`
+
+	// VitalID, MeasuredAt and NEWS2 are empty when the patient has no
+	// scored observation yet.
+	VitalID    string               `json:"vitalId,omitempty"`
+	MeasuredAt *time.Time           `json:"measuredAt,omitempty"`
+	NEWS2      *clinical.NEWS2Score `json:"news2,omitempty"`
 }
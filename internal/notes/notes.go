@@ -0,0 +1,146 @@
+// Package notes holds the states of clinical notes and the templates they
+// are started from.
+package notes
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Statuses of a note. A draft can be edited by its author until it is
+// signed; a signed note is immutable and is only added to by amendments,
+// after which it is amended.
+const (
+	StatusDraft   = "draft"
+	StatusSigned  = "signed"
+	StatusAmended = "amended"
+)
+
+// Visible reports whether a note in the status written by authorID may be
+// seen by viewerID. A draft is only seen by its author.
+func Visible(status, authorID, viewerID string) bool {
+	return status != StatusDraft || authorID == viewerID
+}
+
+// CanAmend reports whether a note in the status may be amended.
+func CanAmend(status string) bool {
+	return status == StatusSigned || status == StatusAmended
+}
+
+// Sections are the SOAP sections of a note.
+type Sections struct {
+	Subjective string `json:"subjective"`
+	Objective  string `json:"objective"`
+	Assessment string `json:"assessment"`
+	Plan       string `json:"plan"`
+}
+
+// Empty reports whether every section is blank.
+func (s Sections) Empty() bool {
+	return s.Subjective == "" && s.Objective == "" && s.Assessment == "" && s.Plan == ""
+}
+
+// Template prefills the sections of a new note.
+type Template struct {
+	ID          string   `json:"id" example:"fever"`
+	Name        string   `json:"name" example:"Acute fever"`
+	Description string   `json:"description,omitempty" example:"Fever of less than two weeks"`
+	Sections    Sections `json:"sections"`
+}
+
+//go:embed templates.json
+var defaultTemplates []byte
+
+var (
+	mu        sync.RWMutex
+	templates []Template
+)
+
+func init() {
+	t, err := ParseTemplates(bytes.NewReader(defaultTemplates))
+	if err != nil {
+		panic(fmt.Sprintf("notes: invalid embedded templates: %v", err))
+	}
+	templates = t
+}
+
+// ParseTemplates reads note templates in the format of templates.json.
+func ParseTemplates(r io.Reader) ([]Template, error) {
+	var t []Template
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, tmpl := range t {
+		if tmpl.ID == "" || tmpl.Name == "" {
+			return nil, fmt.Errorf("note template without an id or a name")
+		}
+		if seen[tmpl.ID] {
+			return nil, fmt.Errorf("note template %s is listed twice", tmpl.ID)
+		}
+		seen[tmpl.ID] = true
+	}
+	return t, nil
+}
+
+// LoadTemplatesFile replaces the built-in note templates with those in the
+// file at path.
+func LoadTemplatesFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	t, err := ParseTemplates(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	templates = t
+	mu.Unlock()
+	return nil
+}
+
+// Templates returns the note templates.
+func Templates() []Template {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Template(nil), templates...)
+}
+
+// Lookup returns the template with the ID.
+func Lookup(id string) (Template, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, t := range templates {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// Apply fills the blank sections of s from the template.
+func (t Template) Apply(s Sections) Sections {
+	if s.Subjective == "" {
+		s.Subjective = t.Sections.Subjective
+	}
+	if s.Objective == "" {
+		s.Objective = t.Sections.Objective
+	}
+	if s.Assessment == "" {
+		s.Assessment = t.Sections.Assessment
+	}
+	if s.Plan == "" {
+		s.Plan = t.Sections.Plan
+	}
+	return s
+}
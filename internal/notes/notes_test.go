@@ -0,0 +1,29 @@
+package notes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisible(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		author string
+		viewer string
+		want   bool
+	}{
+		{"Own draft", StatusDraft, "u1", "u1", true},
+		{"Another author's draft", StatusDraft, "u1", "u2", false},
+		{"Draft without a viewer", StatusDraft, "u1", "", false},
+		{"Another author's signed note", StatusSigned, "u1", "u2", true},
+		{"Another author's amended note", StatusAmended, "u1", "u2", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Visible(tt.status, tt.author, tt.viewer))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package queue
+
+import "sync"
+
+// Broker tells subscribed streams the queue changed. It is in-process: an
+// instance of the API only hears of the changes made through it.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel that receives after every change and a
+// function that unsubscribes it. Changes made while the subscriber is busy
+// are coalesced into one.
+func (b *Broker) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+	}
+}
+
+// Publish notifies the subscribers of a change. It never blocks and does
+// nothing on a nil Broker.
+func (b *Broker) Publish() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
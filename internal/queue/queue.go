@@ -0,0 +1,102 @@
+// Package queue orders the walk-in queue of an outpatient day by triage
+// priority and estimates how long waiting patients will wait.
+package queue
+
+import (
+	"sort"
+	"time"
+)
+
+// Triage priorities, the most urgent first.
+const (
+	PriorityImmediate = "immediate"
+	PriorityUrgent    = "urgent"
+	PriorityStandard  = "standard"
+	PriorityNonUrgent = "non-urgent"
+)
+
+// Statuses of a queue entry. Done is final; a patient who left without
+// being seen is done too.
+const (
+	StatusWaiting        = "waiting"
+	StatusInConsultation = "in-consultation"
+	StatusDone           = "done"
+)
+
+// DefaultConsultation is the length of a consultation assumed until one
+// has finished on the day.
+const DefaultConsultation = 15 * time.Minute
+
+var ranks = map[string]int{
+	PriorityImmediate: 0,
+	PriorityUrgent:    1,
+	PriorityStandard:  2,
+	PriorityNonUrgent: 3,
+}
+
+var transitions = map[string][]string{
+	StatusWaiting:        {StatusInConsultation, StatusDone},
+	StatusInConsultation: {StatusWaiting, StatusDone},
+}
+
+// CanTransition reports whether an entry may move from one status to
+// another. A patient in consultation may be sent back to wait, e.g. for a
+// test result.
+func CanTransition(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Waiting is a patient waiting to be seen.
+type Waiting struct {
+	Priority    string
+	CheckedInAt time.Time
+}
+
+// Order returns the order waiting patients are seen in: by priority, then
+// by check-in time. The result holds indexes into waiting.
+func Order(waiting []Waiting) []int {
+	order := make([]int, len(waiting))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := waiting[order[i]], waiting[order[j]]
+		if ranks[a.Priority] != ranks[b.Priority] {
+			return ranks[a.Priority] < ranks[b.Priority]
+		}
+		return a.CheckedInAt.Before(b.CheckedInAt)
+	})
+	return order
+}
+
+// AverageConsultation returns the mean length of the consultations, or
+// DefaultConsultation without any.
+func AverageConsultation(lengths []time.Duration) time.Duration {
+	if len(lengths) == 0 {
+		return DefaultConsultation
+	}
+	var total time.Duration
+	for _, l := range lengths {
+		total += l
+	}
+	return total / time.Duration(len(lengths))
+}
+
+// EstimateWaits estimates the wait of each of n patients in the order they
+// are seen. doctors see patients in parallel, busy of them are in a
+// consultation now, and a consultation takes average.
+func EstimateWaits(n int, average time.Duration, doctors, busy int) []time.Duration {
+	if doctors < 1 {
+		doctors = 1
+	}
+	waits := make([]time.Duration, n)
+	for i := range waits {
+		waits[i] = time.Duration((i+busy)/doctors) * average
+	}
+	return waits
+}
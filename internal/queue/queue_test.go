@@ -0,0 +1,132 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder(t *testing.T) {
+	start := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return start.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	tests := []struct {
+		name    string
+		waiting []Waiting
+		want    []int
+	}{
+		{
+			name:    "Nobody waiting",
+			waiting: nil,
+			want:    []int{},
+		},
+		{
+			name: "By check-in time",
+			waiting: []Waiting{
+				{Priority: PriorityStandard, CheckedInAt: at(10)},
+				{Priority: PriorityStandard, CheckedInAt: at(0)},
+				{Priority: PriorityStandard, CheckedInAt: at(5)},
+			},
+			want: []int{1, 2, 0},
+		},
+		{
+			name: "By priority first",
+			waiting: []Waiting{
+				{Priority: PriorityNonUrgent, CheckedInAt: at(0)},
+				{Priority: PriorityStandard, CheckedInAt: at(1)},
+				{Priority: PriorityImmediate, CheckedInAt: at(30)},
+				{Priority: PriorityUrgent, CheckedInAt: at(20)},
+			},
+			want: []int{2, 3, 1, 0},
+		},
+		{
+			name: "Same priority by check-in time",
+			waiting: []Waiting{
+				{Priority: PriorityUrgent, CheckedInAt: at(15)},
+				{Priority: PriorityStandard, CheckedInAt: at(0)},
+				{Priority: PriorityUrgent, CheckedInAt: at(5)},
+			},
+			want: []int{2, 0, 1},
+		},
+		{
+			// patients checked in at the same time keep the order given
+			name: "Ties",
+			waiting: []Waiting{
+				{Priority: PriorityStandard, CheckedInAt: at(0)},
+				{Priority: PriorityStandard, CheckedInAt: at(0)},
+			},
+			want: []int{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Order(tt.waiting))
+		})
+	}
+}
+
+func TestEstimateWaits(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		average time.Duration
+		doctors int
+		busy    int
+		want    []time.Duration
+	}{
+		{
+			name:    "Nobody waiting",
+			n:       0,
+			average: 15 * time.Minute,
+			doctors: 1,
+			want:    []time.Duration{},
+		},
+		{
+			name:    "One free doctor",
+			n:       3,
+			average: 15 * time.Minute,
+			doctors: 1,
+			want:    []time.Duration{0, 15 * time.Minute, 30 * time.Minute},
+		},
+		{
+			name:    "One busy doctor",
+			n:       2,
+			average: 10 * time.Minute,
+			doctors: 1,
+			busy:    1,
+			want:    []time.Duration{10 * time.Minute, 20 * time.Minute},
+		},
+		{
+			name:    "Doctors in parallel",
+			n:       5,
+			average: 20 * time.Minute,
+			doctors: 2,
+			busy:    1,
+			want:    []time.Duration{0, 20 * time.Minute, 20 * time.Minute, 40 * time.Minute, 40 * time.Minute},
+		},
+		{
+			// a day without a doctor yet is estimated as if one sees
+			// everyone
+			name:    "No doctor yet",
+			n:       2,
+			average: DefaultConsultation,
+			doctors: 0,
+			want:    []time.Duration{0, DefaultConsultation},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, EstimateWaits(tt.n, tt.average, tt.doctors, tt.busy))
+		})
+	}
+}
+
+func TestAverageConsultation(t *testing.T) {
+	require.Equal(t, DefaultConsultation, AverageConsultation(nil))
+	require.Equal(t, 15*time.Minute, AverageConsultation([]time.Duration{10 * time.Minute, 20 * time.Minute}))
+}
@@ -0,0 +1,207 @@
+// Package scheduling turns doctors' weekly working hours into bookable
+// appointment slots. Working hours are wall-clock times in the clinic's time
+// zone; slots and appointments are instants.
+package scheduling
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	_ "time/tzdata"
+)
+
+// Statuses of an appointment. A rescheduled appointment was replaced by a
+// new one at another time.
+const (
+	AppointmentBooked      = "booked"
+	AppointmentCancelled   = "cancelled"
+	AppointmentRescheduled = "rescheduled"
+)
+
+// MaxRange is the longest period slots are generated for at once.
+const MaxRange = 31 * 24 * time.Hour
+
+var (
+	mu       sync.RWMutex
+	location = time.UTC
+)
+
+// LoadLocation sets the clinic's time zone, e.g. Asia/Kolkata. It is UTC
+// until set.
+func LoadLocation(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	location = loc
+	mu.Unlock()
+	return nil
+}
+
+// Location returns the clinic's time zone.
+func Location() *time.Location {
+	mu.RLock()
+	defer mu.RUnlock()
+	return location
+}
+
+// WorkingHours is a weekly template: on Weekday the doctor sees patients
+// from Start to End, in minutes after midnight, in slots of SlotMinutes.
+type WorkingHours struct {
+	Weekday     time.Weekday
+	Start       int
+	End         int
+	SlotMinutes int
+}
+
+// Interval is a period of time, End excluded.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps reports whether the intervals share any time.
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End)
+}
+
+// Slot is a bookable period of a doctor's working hours.
+type Slot struct {
+	Start time.Time `json:"start" example:"2026-10-19T09:00:00+05:30"`
+	End   time.Time `json:"end" example:"2026-10-19T09:15:00+05:30"`
+}
+
+// ParseClock parses a wall-clock time as HH:MM into minutes after midnight.
+// 24:00 is allowed as the end of a day.
+func ParseClock(s string) (int, error) {
+	var h, m int
+	if len(s) != 5 || s[2] != ':' {
+		return 0, fmt.Errorf("%q is not a HH:MM time", s)
+	}
+	if _, err := fmt.Sscanf(s, "%02d:%02d", &h, &m); err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", s)
+	}
+	if m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("%q is not a HH:MM time", s)
+	}
+	return h*60 + m, nil
+}
+
+// FormatClock formats minutes after midnight as HH:MM.
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ValidateHours checks a weekly template: every period must hold at least
+// one slot and periods on the same weekday must not overlap.
+func ValidateHours(hours []WorkingHours) error {
+	byDay := make(map[time.Weekday][]WorkingHours)
+	for _, h := range hours {
+		if h.SlotMinutes <= 0 {
+			return errors.New("slot length must be positive")
+		}
+		if h.Start < 0 || h.End > 24*60 || h.End-h.Start < h.SlotMinutes {
+			return fmt.Errorf("%s %s-%s doesn't hold a slot of %d minutes",
+				h.Weekday, FormatClock(h.Start), FormatClock(h.End), h.SlotMinutes)
+		}
+		byDay[h.Weekday] = append(byDay[h.Weekday], h)
+	}
+
+	for day, periods := range byDay {
+		sort.Slice(periods, func(i, j int) bool { return periods[i].Start < periods[j].Start })
+		for i := 1; i < len(periods); i++ {
+			if periods[i].Start < periods[i-1].End {
+				return fmt.Errorf("%s %s-%s overlaps %s-%s", day,
+					FormatClock(periods[i].Start), FormatClock(periods[i].End),
+					FormatClock(periods[i-1].Start), FormatClock(periods[i-1].End))
+			}
+		}
+	}
+	return nil
+}
+
+// Slots returns the slots of the template that start in [from, to) and
+// don't overlap any of the blocked intervals, in order.
+func Slots(hours []WorkingHours, from, to time.Time, blocked []Interval) []Slot {
+	loc := Location()
+	slots := []Slot{}
+
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, s := range daySlots(hours, day) {
+			if s.Start.Before(from) || !s.Start.Before(to) || isBlocked(s, blocked) {
+				continue
+			}
+			slots = append(slots, s)
+		}
+	}
+	return slots
+}
+
+// SlotAt returns the slot of the template that starts at start.
+func SlotAt(hours []WorkingHours, start time.Time) (Slot, bool) {
+	local := start.In(Location())
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	for _, s := range daySlots(hours, day) {
+		if s.Start.Equal(start) {
+			return s, true
+		}
+	}
+	return Slot{}, false
+}
+
+// DayHours returns the working periods of the template on the day, which
+// is midnight in the clinic's time zone.
+func DayHours(hours []WorkingHours, day time.Time) []Interval {
+	var periods []Interval
+	for _, h := range hours {
+		if h.Weekday != day.Weekday() {
+			continue
+		}
+		periods = append(periods, Interval{
+			Start: wallClock(day, h.Start),
+			End:   wallClock(day, h.End),
+		})
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+	return periods
+}
+
+// daySlots returns all the slots of the template on the day, in order.
+func daySlots(hours []WorkingHours, day time.Time) []Slot {
+	var slots []Slot
+	for _, h := range hours {
+		if h.Weekday != day.Weekday() {
+			continue
+		}
+		for m := h.Start; m+h.SlotMinutes <= h.End; m += h.SlotMinutes {
+			slots = append(slots, Slot{
+				Start: wallClock(day, m),
+				End:   wallClock(day, m+h.SlotMinutes),
+			})
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	return slots
+}
+
+// wallClock returns the instant minutes after midnight of the day, as read
+// on a clock in the clinic.
+func wallClock(day time.Time, minutes int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, minutes, 0, 0, day.Location())
+}
+
+func isBlocked(s Slot, blocked []Interval) bool {
+	slot := Interval{Start: s.Start, End: s.End}
+	for _, b := range blocked {
+		if slot.Overlaps(b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// inLocation sets the clinic's time zone for the test.
+func inLocation(t *testing.T, name string) {
+	t.Helper()
+	require.NoError(t, LoadLocation(name))
+	t.Cleanup(func() {
+		mu.Lock()
+		location = time.UTC
+		mu.Unlock()
+	})
+}
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func starts(slots []Slot) []time.Time {
+	out := make([]time.Time, 0, len(slots))
+	for _, s := range slots {
+		out = append(out, s.Start.UTC())
+	}
+	return out
+}
+
+func TestSlots(t *testing.T) {
+	inLocation(t, "Asia/Kolkata")
+	// Monday 09:00-12:00 in hourly slots, i.e. 03:30-06:30 UTC
+	hours := []WorkingHours{{Weekday: time.Monday, Start: 9 * 60, End: 12 * 60, SlotMinutes: 60}}
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		blocked []Interval
+		want    []time.Time
+	}{
+		{
+			name: "Whole day",
+			from: "2026-10-18T18:30:00Z",
+			to:   "2026-10-19T18:30:00Z",
+			want: []time.Time{utc("2026-10-19T03:30:00Z"), utc("2026-10-19T04:30:00Z"), utc("2026-10-19T05:30:00Z")},
+		},
+		{
+			// from is on Sunday in UTC but Monday in the clinic
+			name: "Wall-clock day",
+			from: "2026-10-18T20:00:00Z",
+			to:   "2026-10-19T05:00:00Z",
+			want: []time.Time{utc("2026-10-19T03:30:00Z"), utc("2026-10-19T04:30:00Z")},
+		},
+		{
+			name: "Slots starting before from",
+			from: "2026-10-19T03:45:00Z",
+			to:   "2026-10-19T18:30:00Z",
+			want: []time.Time{utc("2026-10-19T04:30:00Z"), utc("2026-10-19T05:30:00Z")},
+		},
+		{
+			name:    "Blocked slot",
+			from:    "2026-10-18T18:30:00Z",
+			to:      "2026-10-19T18:30:00Z",
+			blocked: []Interval{{Start: utc("2026-10-19T04:00:00Z"), End: utc("2026-10-19T04:15:00Z")}},
+			want:    []time.Time{utc("2026-10-19T04:30:00Z"), utc("2026-10-19T05:30:00Z")},
+		},
+		{
+			// an interval ending as a slot starts doesn't block it
+			name:    "Adjacent block",
+			from:    "2026-10-18T18:30:00Z",
+			to:      "2026-10-19T18:30:00Z",
+			blocked: []Interval{{Start: utc("2026-10-19T03:00:00Z"), End: utc("2026-10-19T03:30:00Z")}},
+			want:    []time.Time{utc("2026-10-19T03:30:00Z"), utc("2026-10-19T04:30:00Z"), utc("2026-10-19T05:30:00Z")},
+		},
+		{
+			name: "No working hours",
+			from: "2026-10-17T18:30:00Z",
+			to:   "2026-10-18T18:30:00Z",
+			want: []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := Slots(hours, utc(tt.from), utc(tt.to), tt.blocked)
+			require.Equal(t, tt.want, starts(slots))
+		})
+	}
+}
+
+func TestSlotsAcrossDaylightSaving(t *testing.T) {
+	inLocation(t, "Europe/London")
+	hours := []WorkingHours{
+		{Weekday: time.Saturday, Start: 9 * 60, End: 10 * 60, SlotMinutes: 30},
+		{Weekday: time.Monday, Start: 9 * 60, End: 10 * 60, SlotMinutes: 30},
+	}
+
+	// the clocks go forward on Sunday 29 March 2026, so 09:00 is 09:00 UTC
+	// on the Saturday and 08:00 UTC on the Monday
+	slots := Slots(hours, utc("2026-03-28T00:00:00Z"), utc("2026-03-31T00:00:00Z"), nil)
+	require.Equal(t, []time.Time{
+		utc("2026-03-28T09:00:00Z"),
+		utc("2026-03-28T09:30:00Z"),
+		utc("2026-03-30T08:00:00Z"),
+		utc("2026-03-30T08:30:00Z"),
+	}, starts(slots))
+	require.Equal(t, 30*time.Minute, slots[2].End.Sub(slots[2].Start))
+}
+
+func TestSlotAt(t *testing.T) {
+	inLocation(t, "Asia/Kolkata")
+	hours := []WorkingHours{
+		{Weekday: time.Monday, Start: 0, End: 60, SlotMinutes: 30},
+		{Weekday: time.Monday, Start: 9 * 60, End: 12 * 60, SlotMinutes: 60},
+	}
+
+	tests := []struct {
+		name  string
+		start string
+		end   string
+		ok    bool
+	}{
+		{"Slot start", "2026-10-19T04:30:00Z", "2026-10-19T05:30:00Z", true},
+		// Monday 00:00 in the clinic is Sunday in UTC
+		{"Start of the wall-clock day", "2026-10-18T18:30:00Z", "2026-10-18T19:00:00Z", true},
+		{"Within a slot", "2026-10-19T04:45:00Z", "", false},
+		{"Outside working hours", "2026-10-19T08:30:00Z", "", false},
+		{"Another weekday", "2026-10-20T04:30:00Z", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, ok := SlotAt(hours, utc(tt.start))
+			require.Equal(t, tt.ok, ok)
+			if tt.ok {
+				require.True(t, slot.Start.Equal(utc(tt.start)))
+				require.True(t, slot.End.Equal(utc(tt.end)))
+			}
+		})
+	}
+}
+
+func TestValidateHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours []WorkingHours
+		valid bool
+	}{
+		{
+			name: "Morning and afternoon",
+			hours: []WorkingHours{
+				{Weekday: time.Monday, Start: 9 * 60, End: 12 * 60, SlotMinutes: 15},
+				{Weekday: time.Monday, Start: 14 * 60, End: 17 * 60, SlotMinutes: 15},
+			},
+			valid: true,
+		},
+		{
+			name: "Same hours on other days",
+			hours: []WorkingHours{
+				{Weekday: time.Monday, Start: 9 * 60, End: 12 * 60, SlotMinutes: 15},
+				{Weekday: time.Tuesday, Start: 9 * 60, End: 12 * 60, SlotMinutes: 15},
+			},
+			valid: true,
+		},
+		{
+			name: "Adjacent periods",
+			hours: []WorkingHours{
+				{Weekday: time.Monday, Start: 9 * 60, End: 12 * 60, SlotMinutes: 15},
+				{Weekday: time.Monday, Start: 12 * 60, End: 13 * 60, SlotMinutes: 15},
+			},
+			valid: true,
+		},
+		{
+			name:  "Until midnight",
+			hours: []WorkingHours{{Weekday: time.Friday, Start: 20 * 60, End: 24 * 60, SlotMinutes: 30}},
+			valid: true,
+		},
+		{
+			name: "Overlapping periods",
+			hours: []WorkingHours{
+				{Weekday: time.Monday, Start: 14 * 60, End: 17 * 60, SlotMinutes: 15},
+				{Weekday: time.Monday, Start: 9 * 60, End: 14*60 + 30, SlotMinutes: 15},
+			},
+		},
+		{
+			name:  "Shorter than a slot",
+			hours: []WorkingHours{{Weekday: time.Monday, Start: 9 * 60, End: 9*60 + 10, SlotMinutes: 15}},
+		},
+		{
+			name:  "No slot length",
+			hours: []WorkingHours{{Weekday: time.Monday, Start: 9 * 60, End: 12 * 60}},
+		},
+		{
+			name:  "Past midnight",
+			hours: []WorkingHours{{Weekday: time.Monday, Start: 23 * 60, End: 25 * 60, SlotMinutes: 30}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHours(tt.hours)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	for s, want := range map[string]int{"00:00": 0, "09:30": 570, "24:00": 1440} {
+		got, err := ParseClock(s)
+		require.NoError(t, err, s)
+		require.Equal(t, want, got, s)
+	}
+	for _, s := range []string{"9:30", "24:30", "12:60", "ab:cd", ""} {
+		_, err := ParseClock(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,269 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/alerts"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrAlertNotFound = errors.New("alert not found")
+	ErrAlertResolved = errors.New("alert already resolved")
+
+	// ErrNotAlertAssignee is returned when an alert is acknowledged or
+	// resolved by someone who is neither assigned to it nor on the
+	// patient's care team.
+	ErrNotAlertAssignee = errors.New("alert can't be handled by this user")
+)
+
+type Alerts struct {
+	client *db.PrismaClient
+}
+
+// Raise stores the triggered alerts and assigns them to the patient's care
+// team, or to the user who raised them when the patient has no care team.
+// An alert is skipped while another one with the same dedup key is
+// unresolved; the unique active key decides between alerts raised at the
+// same time.
+func (s *Alerts) Raise(ctx context.Context, req *models.RaiseAlertsReq) ([]*models.Alert, error) {
+	members, err := s.client.CareTeamMember.FindMany(
+		db.CareTeamMember.PatientID.Equals(req.PatientID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees := make([]string, 0, len(members))
+	for _, m := range members {
+		assignees = append(assignees, m.UserID)
+	}
+	if len(assignees) == 0 && req.RaisedByID != "" {
+		assignees = append(assignees, req.RaisedByID)
+	}
+
+	raised := make([]*models.Alert, 0, len(req.Alerts))
+	for _, a := range req.Alerts {
+		optional := []db.AlertSetParam{
+			db.Alert.ActiveKey.Set(a.DedupKey),
+			db.Alert.AssignedTo.Set(assignees),
+		}
+		if req.RaisedByID != "" {
+			optional = append(optional, db.Alert.RaisedBy.Link(
+				db.User.ID.Equals(req.RaisedByID),
+			))
+		}
+
+		created, err := s.client.Alert.CreateOne(
+			db.Alert.Patient.Link(
+				db.Patient.ID.Equals(req.PatientID),
+			),
+			db.Alert.RuleID.Set(a.RuleID),
+			db.Alert.Severity.Set(a.Severity),
+			db.Alert.Priority.Set(alerts.Priority(a.Severity)),
+			db.Alert.Source.Set(a.Source),
+			db.Alert.SubjectID.Set(a.SubjectID),
+			db.Alert.Message.Set(a.Message),
+			db.Alert.DedupKey.Set(a.DedupKey),
+			optional...,
+		).Exec(ctx)
+		if err != nil {
+			if _, ok := db.IsErrUniqueConstraint(err); ok {
+				// already raised and not yet resolved
+				continue
+			}
+			return nil, err
+		}
+		raised = append(raised, toAlertModel(created))
+	}
+	return raised, nil
+}
+
+// List returns alerts, most severe and most recent first.
+func (s *Alerts) List(ctx context.Context, req *models.AlertQuery) ([]*models.Alert, error) {
+	var where []db.AlertWhereParam
+	if req.Mine {
+		where = append(where, db.Alert.AssignedTo.Has(req.UserID))
+	}
+	if req.PatientID != "" {
+		where = append(where, db.Alert.PatientID.Equals(req.PatientID))
+	}
+	if req.Status != "" {
+		where = append(where, db.Alert.Status.Equals(req.Status))
+	}
+	if req.Severity != "" {
+		where = append(where, db.Alert.Severity.Equals(req.Severity))
+	}
+
+	as, err := s.client.Alert.FindMany(
+		where...,
+	).OrderBy(
+		db.Alert.Priority.Order(db.SortOrderDesc),
+		db.Alert.RaisedAt.Order(db.SortOrderDesc),
+	).Take(req.Limit).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.Alert, 0, len(as))
+	for i := range as {
+		list = append(list, toAlertModel(&as[i]))
+	}
+	return list, nil
+}
+
+// Acknowledge marks an open alert as seen. Acknowledging an acknowledged
+// alert keeps the first acknowledgement. Only the assignees and the
+// patient's care team can acknowledge an alert.
+func (s *Alerts) Acknowledge(ctx context.Context, req *models.AlertActionReq) (*models.Alert, error) {
+	a, err := s.findHandled(ctx, req.AlertID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch a.Status {
+	case alerts.StatusResolved:
+		return nil, ErrAlertResolved
+	case alerts.StatusAcknowledged:
+		return toAlertModel(a), nil
+	}
+
+	updated, err := s.client.Alert.FindUnique(
+		db.Alert.ID.Equals(req.AlertID),
+	).Update(
+		db.Alert.Status.Set(alerts.StatusAcknowledged),
+		db.Alert.AcknowledgedBy.Link(
+			db.User.ID.Equals(req.UserID),
+		),
+		db.Alert.AcknowledgedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toAlertModel(updated), nil
+}
+
+// Resolve closes an alert, so that the same alert can be raised again.
+// Resolving an open alert acknowledges it too. Only the assignees and the
+// patient's care team can resolve an alert.
+func (s *Alerts) Resolve(ctx context.Context, req *models.AlertActionReq) (*models.Alert, error) {
+	a, err := s.findHandled(ctx, req.AlertID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == alerts.StatusResolved {
+		return nil, ErrAlertResolved
+	}
+
+	now := time.Now()
+	update := []db.AlertSetParam{
+		db.Alert.Status.Set(alerts.StatusResolved),
+		db.Alert.ActiveKey.SetOptional(nil),
+		db.Alert.ResolvedBy.Link(
+			db.User.ID.Equals(req.UserID),
+		),
+		db.Alert.ResolvedAt.Set(now),
+	}
+	if req.Note != "" {
+		update = append(update, db.Alert.ResolutionNote.Set(req.Note))
+	}
+	if a.Status == alerts.StatusOpen {
+		update = append(update,
+			db.Alert.AcknowledgedBy.Link(
+				db.User.ID.Equals(req.UserID),
+			),
+			db.Alert.AcknowledgedAt.Set(now),
+		)
+	}
+
+	updated, err := s.client.Alert.FindUnique(
+		db.Alert.ID.Equals(req.AlertID),
+	).Update(
+		update...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toAlertModel(updated), nil
+}
+
+func (s *Alerts) find(ctx context.Context, aID string) (*db.AlertModel, error) {
+	a, err := s.client.Alert.FindUnique(
+		db.Alert.ID.Equals(aID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrAlertNotFound
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// findHandled returns the alert when userID may handle it: when they are
+// assigned to it or on the patient's care team.
+func (s *Alerts) findHandled(ctx context.Context, aID, userID string) (*db.AlertModel, error) {
+	a, err := s.find(ctx, aID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(a.AssignedTo, userID) {
+		return a, nil
+	}
+
+	_, err = s.client.CareTeamMember.FindUnique(
+		db.CareTeamMember.PatientIDUserID(
+			db.CareTeamMember.PatientID.Equals(a.PatientID),
+			db.CareTeamMember.UserID.Equals(userID),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrNotAlertAssignee
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+func toAlertModel(a *db.AlertModel) *models.Alert {
+	alert := &models.Alert{
+		ID:         a.ID,
+		PatientID:  a.PatientID,
+		RuleID:     a.RuleID,
+		Severity:   a.Severity,
+		Source:     a.Source,
+		SubjectID:  a.SubjectID,
+		Message:    a.Message,
+		Status:     a.Status,
+		AssignedTo: a.AssignedTo,
+		RaisedAt:   a.RaisedAt,
+	}
+	if alert.AssignedTo == nil {
+		alert.AssignedTo = []string{}
+	}
+
+	if raisedBy, ok := a.RaisedByID(); ok {
+		alert.RaisedByID = raisedBy
+	}
+	if acknowledgedBy, ok := a.AcknowledgedByID(); ok {
+		alert.AcknowledgedByID = acknowledgedBy
+	}
+	if acknowledgedAt, ok := a.AcknowledgedAt(); ok {
+		alert.AcknowledgedAt = &acknowledgedAt
+	}
+	if resolvedBy, ok := a.ResolvedByID(); ok {
+		alert.ResolvedByID = resolvedBy
+	}
+	if resolvedAt, ok := a.ResolvedAt(); ok {
+		alert.ResolvedAt = &resolvedAt
+	}
+	if note, ok := a.ResolutionNote(); ok {
+		alert.ResolutionNote = note
+	}
+	return alert
+}
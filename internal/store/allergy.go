@@ -2,57 +2,162 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
 
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
 )
 
+var (
+	ErrAllergyNotFound = errors.New("allergy not found")
+
+	// ErrPatientHasAllergies is returned when asserting "no known allergies"
+	// for a patient with recorded allergies that aren't ruled out.
+	ErrPatientHasAllergies = errors.New("patient has recorded allergies")
+)
+
 type Allergy struct {
 	client *db.PrismaClient
 }
 
+// Record records an allergy. Unless the allergy is ruled out, a "no known
+// allergies" assertion on the patient is cleared with it.
 func (s *Allergy) Record(ctx context.Context, req *models.RegAllergyReq) (*models.Allergy, error) {
-	allergy, err := s.client.Allergy.CreateOne(
+	reactions, err := json.Marshal(req.Reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	optional := []db.AllergySetParam{
+		db.Allergy.Reactions.Set(reactions),
+	}
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PatientID, req.EncounterID); err != nil {
+			return nil, err
+		}
+		optional = append(optional, db.Allergy.Encounter.Link(
+			db.Encounter.ID.Equals(req.EncounterID),
+		))
+	}
+	if req.Category != "" {
+		optional = append(optional, db.Allergy.Category.Set(req.Category))
+	}
+	if req.Substance != nil {
+		optional = append(optional,
+			db.Allergy.SubstanceSystem.Set(req.Substance.System),
+			db.Allergy.SubstanceCode.Set(req.Substance.Code),
+			db.Allergy.SubstanceDisplay.Set(req.Substance.Display),
+		)
+	}
+	if req.Criticality != "" {
+		optional = append(optional, db.Allergy.Criticality.Set(req.Criticality))
+	}
+	if req.VerificationStatus != "" {
+		optional = append(optional, db.Allergy.VerificationStatus.Set(req.VerificationStatus))
+	}
+	if req.Reaction != "" {
+		optional = append(optional, db.Allergy.Reaction.Set(req.Reaction))
+	}
+	if req.Severity != "" {
+		optional = append(optional, db.Allergy.Severity.Set(req.Severity))
+	}
+
+	created := s.client.Allergy.CreateOne(
 		db.Allergy.Name.Set(req.Name),
-		db.Allergy.Reaction.Set(req.Reaction),
-		db.Allergy.Severity.Set(req.Severity),
 		db.Allergy.Patient.Link(
 			db.Patient.ID.Equals(req.PatientID),
 		),
-	).Exec(ctx)
-	if err != nil {
+		optional...,
+	).Tx()
+
+	txs := []db.PrismaTransaction{created}
+	if !clinical.IsAllergyRuledOut(req.VerificationStatus) {
+		txs = append(txs, s.client.Patient.FindUnique(
+			db.Patient.ID.Equals(req.PatientID),
+		).Update(
+			db.Patient.NoKnownAllergiesBy.Unlink(),
+			db.Patient.NoKnownAllergiesAt.SetOptional(nil),
+		).Tx())
+	}
+
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
 		return nil, err
 	}
 
-	return &models.Allergy{
-		ID:        allergy.ID,
-		PatientID: allergy.PatientID,
-		Name:      allergy.Name,
-		Severity:  allergy.Severity,
-		Reaction:  allergy.Reaction,
-		CreatedAt: allergy.RecordedAt,
-	}, nil
+	return toAllergyModel(created.Result())
 }
 
+// Update changes an allergy. Verifying an allergy that isn't ruled out,
+// e.g. confirming a refuted one, clears a "no known allergies" assertion on
+// the patient with it.
 func (s *Allergy) Update(ctx context.Context, req *models.UpdateAllergyReq) (*models.Allergy, error) {
-	update := prepareAllergyUpdateParams(req)
+	update, err := prepareAllergyUpdateParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.client.Allergy.FindUnique(
+		db.Allergy.ID.Equals(req.AllergyID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrAllergyNotFound
+		}
+		return nil, err
+	}
 
-	allergy, err := s.client.Allergy.FindUnique(
+	updated := s.client.Allergy.FindUnique(
 		db.Allergy.ID.Equals(req.AllergyID),
 	).Update(
 		update...,
+	).Tx()
+
+	edit := recordEdit(s.client, current.PatientID, editAllergy, req.AllergyID, req.EditedByID, editedFields(req))
+	txs := []db.PrismaTransaction{updated, edit}
+	if req.VerificationStatus != nil && !clinical.IsAllergyRuledOut(*req.VerificationStatus) {
+		txs = append(txs, s.client.Patient.FindUnique(
+			db.Patient.ID.Equals(current.PatientID),
+		).Update(
+			db.Patient.NoKnownAllergiesBy.Unlink(),
+			db.Patient.NoKnownAllergiesAt.SetOptional(nil),
+		).Tx())
+	}
+
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrAllergyNotFound
+		}
+		return nil, err
+	}
+	return toAllergyModel(updated.Result())
+}
+
+// List returns the patient's allergies, oldest first.
+func (s *Allergy) List(ctx context.Context, pID string) ([]*models.Allergy, error) {
+	records, err := s.client.Allergy.FindMany(
+		db.Allergy.PatientID.Equals(pID),
+	).OrderBy(
+		db.Allergy.RecordedAt.Order(db.SortOrderAsc),
 	).Exec(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &models.Allergy{
-		ID:        allergy.ID,
-		PatientID: allergy.PatientID,
-		Name:      allergy.Name,
-		Severity:  allergy.Severity,
-		Reaction:  allergy.Reaction,
-		CreatedAt: allergy.RecordedAt,
-	}, err
+
+	allergies := make([]*models.Allergy, 0, len(records))
+	for i := range records {
+		allergy, err := toAllergyModel(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		allergies = append(allergies, allergy)
+	}
+	return allergies, nil
 }
 
 func (s *Allergy) Delete(ctx context.Context, aID string) error {
@@ -61,3 +166,125 @@ func (s *Allergy) Delete(ctx context.Context, aID string) error {
 	).Delete().Exec(ctx)
 	return err
 }
+
+// lockPatientQuery locks the patient $1 until the end of the transaction it
+// runs in. Recording or verifying an allergy clears the patient's "no known
+// allergies" assertion and so waits for the lock too.
+const lockPatientQuery = `SELECT id FROM "Patient" WHERE id = $1 FOR UPDATE;`
+
+// noActiveAllergiesQuery fails the transaction it runs in when the patient
+// $1 has allergies that aren't ruled out. It runs as a statement of its own
+// after lockPatientQuery, so that it sees the allergies committed while the
+// lock was awaited.
+const noActiveAllergiesQuery = `
+	SELECT 1 / (1 - COUNT(*)) AS allergy_free
+	FROM (
+		SELECT 1
+		FROM "Allergy"
+		WHERE "patientId" = $1 AND "verificationStatus" NOT IN ($2, $3)
+		LIMIT 1
+	) active;
+`
+
+// AssertNoKnownAllergies records that the patient has no known allergies.
+// It fails with ErrPatientHasAllergies while the patient has allergies that
+// aren't ruled out, checked under a lock on the patient so that an allergy
+// recorded at the same time either comes first and fails the assertion or
+// comes after and clears it.
+func (s *Allergy) AssertNoKnownAllergies(ctx context.Context, req *models.AssertNKAReq) (*models.NoKnownAllergies, error) {
+	hasAllergies, err := s.hasActiveAllergies(ctx, req.PatientID)
+	if err != nil {
+		return nil, err
+	}
+	if hasAllergies {
+		return nil, ErrPatientHasAllergies
+	}
+
+	now := time.Now()
+	update := []db.PatientSetParam{
+		db.Patient.NoKnownAllergiesAt.Set(now),
+	}
+	if req.UserID != "" {
+		update = append(update, db.Patient.NoKnownAllergiesBy.Link(
+			db.User.ID.Equals(req.UserID),
+		))
+	}
+
+	err = s.client.Prisma.Transaction(
+		s.client.Prisma.QueryRaw(lockPatientQuery, req.PatientID).Tx(),
+		s.client.Prisma.QueryRaw(noActiveAllergiesQuery, req.PatientID,
+			clinical.AllergyRefuted, clinical.AllergyEnteredInError).Tx(),
+		s.client.Patient.FindUnique(
+			db.Patient.ID.Equals(req.PatientID),
+		).Update(
+			update...,
+		).Tx(),
+	).Exec(ctx)
+	if err != nil {
+		if hasAllergies, rerr := s.hasActiveAllergies(ctx, req.PatientID); rerr == nil && hasAllergies {
+			return nil, ErrPatientHasAllergies
+		}
+		return nil, err
+	}
+
+	return &models.NoKnownAllergies{
+		AssertedByID: req.UserID,
+		AssertedAt:   now,
+	}, nil
+}
+
+// hasActiveAllergies reports whether the patient has allergies that aren't
+// ruled out.
+func (s *Allergy) hasActiveAllergies(ctx context.Context, pID string) (bool, error) {
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(pID),
+	).With(
+		db.Patient.Allergies.Fetch(
+			db.Allergy.VerificationStatus.NotIn([]string{
+				clinical.AllergyRefuted,
+				clinical.AllergyEnteredInError,
+			}),
+		).Take(1),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return false, ErrPatientNotFound
+		}
+		return false, err
+	}
+	return len(patient.Allergies()) > 0, nil
+}
+
+func toAllergyModel(a *db.AllergyModel) (*models.Allergy, error) {
+	allergy := &models.Allergy{
+		ID:                 a.ID,
+		PatientID:          a.PatientID,
+		Name:               a.Name,
+		Severity:           a.Severity,
+		Reaction:           a.Reaction,
+		Criticality:        a.Criticality,
+		VerificationStatus: a.VerificationStatus,
+		Reactions:          []models.AllergyReaction{},
+		CreatedAt:          a.RecordedAt,
+	}
+	if len(a.Reactions) > 0 {
+		if err := json.Unmarshal(a.Reactions, &allergy.Reactions); err != nil {
+			return nil, err
+		}
+	}
+	if category, ok := a.Category(); ok {
+		allergy.Category = category
+	}
+	if encounter, ok := a.EncounterID(); ok {
+		allergy.EncounterID = encounter
+	}
+	if code, ok := a.SubstanceCode(); ok {
+		allergy.Substance = &models.CodedSubstance{Code: code}
+		allergy.Substance.System, _ = a.SubstanceSystem()
+		allergy.Substance.Display, _ = a.SubstanceDisplay()
+	}
+	if updatedAt, ok := a.UpdatedAt(); ok {
+		allergy.UpdatedAt = &updatedAt
+	}
+	return allergy, nil
+}
@@ -0,0 +1,377 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+	"github.com/vaidik-bajpai/medibridge/internal/scheduling"
+)
+
+var (
+	ErrAppointmentNotFound = errors.New("appointment not found")
+
+	// ErrAppointmentTransition is returned when an appointment that was
+	// already cancelled or rescheduled is changed again.
+	ErrAppointmentTransition = errors.New("appointment is no longer booked")
+
+	// ErrSlotUnavailable is returned when the doctor doesn't see patients at
+	// the requested time: it is in the past, outside the working hours or
+	// during an exception.
+	ErrSlotUnavailable = errors.New("doctor isn't available at the requested time")
+
+	// ErrSlotTaken is returned when another appointment holds the slot.
+	ErrSlotTaken = errors.New("slot is already booked")
+)
+
+type Appointments struct {
+	client *db.PrismaClient
+}
+
+// activeSlot is the key a booked appointment holds its slot with. The
+// unique index on it keeps two concurrent bookings out of the same slot.
+func activeSlot(doctorID string, start time.Time) string {
+	return doctorID + "/" + start.UTC().Format(time.RFC3339)
+}
+
+// lockDoctorQuery locks the doctor $1 until the end of the transaction it
+// runs in, so that bookings of the doctor are made one at a time.
+const lockDoctorQuery = `SELECT id FROM "User" WHERE id = $1 FOR UPDATE;`
+
+// slotFreeQuery fails the transaction it runs in when another appointment
+// than $5 with status $2 of the doctor $1 overlaps the period from $3 to
+// $4. It runs as a statement of its own after lockDoctorQuery, so that it
+// sees the appointments booked while the lock was awaited; the activeSlot
+// index alone only keeps out appointments starting at the same time.
+const slotFreeQuery = `
+	SELECT 1 / (1 - COUNT(*)) AS slot_free
+	FROM (
+		SELECT 1
+		FROM "Appointment"
+		WHERE "doctorId" = $1 AND status = $2 AND id <> $5
+			AND "startsAt" < $4::timestamp AND "endsAt" > $3::timestamp
+		LIMIT 1
+	) taken;
+`
+
+// bookedAppointmentQuery locks the appointment $1 and fails the transaction
+// it runs in unless it still has status $2.
+const bookedAppointmentQuery = `
+	WITH locked AS (
+		SELECT id
+		FROM "Appointment"
+		WHERE id = $1 AND status = $2
+		FOR UPDATE
+	)
+	SELECT 1 / COUNT(*) AS booked FROM locked;
+`
+
+// holdSlot returns the statements that, run first in a transaction, lock
+// the doctor and fail it unless the slot is still free of appointments
+// other than the one with the ID ignored.
+func holdSlot(client *db.PrismaClient, doctorID string, slot scheduling.Slot, ignored string) []db.PrismaTransaction {
+	return []db.PrismaTransaction{
+		client.Prisma.QueryRaw(lockDoctorQuery, doctorID).Tx(),
+		client.Prisma.QueryRaw(slotFreeQuery, doctorID, scheduling.AppointmentBooked,
+			slot.Start.UTC(), slot.End.UTC(), ignored).Tx(),
+	}
+}
+
+// slotTaken reports whether an appointment other than the one with the ID
+// ignored holds part of the doctor's slot.
+func slotTaken(ctx context.Context, client *db.PrismaClient, doctorID string, slot scheduling.Slot, ignored string) (bool, error) {
+	taken, err := client.Appointment.FindMany(
+		db.Appointment.DoctorID.Equals(doctorID),
+		db.Appointment.Status.Equals(scheduling.AppointmentBooked),
+		db.Appointment.ID.Not(ignored),
+		db.Appointment.StartsAt.Lt(slot.End),
+		db.Appointment.EndsAt.Gt(slot.Start),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return len(taken) > 0, nil
+}
+
+// freeSlot returns the doctor's slot starting at start if it is free. The
+// appointment with the ID ignored doesn't take the slot, so an appointment
+// can be moved within its own time.
+func freeSlot(ctx context.Context, client *db.PrismaClient, doctorID string, start time.Time, ignored string) (scheduling.Slot, error) {
+	if !start.After(time.Now()) {
+		return scheduling.Slot{}, ErrSlotUnavailable
+	}
+
+	schedule, err := loadSchedule(ctx, client, doctorID, scheduling.Interval{
+		Start: start,
+		End:   start.Add(24 * time.Hour),
+	})
+	if err != nil {
+		return scheduling.Slot{}, err
+	}
+
+	slot, ok := scheduling.SlotAt(schedule.hours, start)
+	if !ok {
+		return scheduling.Slot{}, ErrSlotUnavailable
+	}
+	period := scheduling.Interval{Start: slot.Start, End: slot.End}
+	for _, e := range schedule.exceptions {
+		if period.Overlaps(scheduling.Interval{Start: e.StartsAt, End: e.EndsAt}) {
+			return scheduling.Slot{}, ErrSlotUnavailable
+		}
+	}
+	for _, a := range schedule.appointments {
+		if a.ID != ignored && period.Overlaps(scheduling.Interval{Start: a.StartsAt, End: a.EndsAt}) {
+			return scheduling.Slot{}, ErrSlotTaken
+		}
+	}
+	return slot, nil
+}
+
+// Book books the patient into a free slot of the doctor. The slot is checked
+// again under a lock on the doctor when the appointment is created, so that
+// overlapping bookings made at the same time don't both succeed.
+func (s *Appointments) Book(ctx context.Context, req *models.BookAppointmentReq) (*models.Appointment, error) {
+	slot, err := freeSlot(ctx, s.client, req.DoctorID, req.StartsAt, "")
+	if err != nil {
+		return nil, err
+	}
+
+	optional := []db.AppointmentSetParam{
+		db.Appointment.ActiveSlot.Set(activeSlot(req.DoctorID, slot.Start)),
+	}
+	if req.Reason != "" {
+		optional = append(optional, db.Appointment.Reason.Set(req.Reason))
+	}
+	if req.BookedByID != "" {
+		optional = append(optional, db.Appointment.BookedBy.Link(
+			db.User.ID.Equals(req.BookedByID),
+		))
+	}
+
+	created := s.client.Appointment.CreateOne(
+		db.Appointment.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.Appointment.Doctor.Link(
+			db.User.ID.Equals(req.DoctorID),
+		),
+		db.Appointment.StartsAt.Set(slot.Start),
+		db.Appointment.EndsAt.Set(slot.End),
+		optional...,
+	).Tx()
+
+	txs := append(holdSlot(s.client, req.DoctorID, slot, ""), created)
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if _, ok := db.IsErrUniqueConstraint(err); ok {
+			return nil, ErrSlotTaken
+		}
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		if taken, rerr := slotTaken(ctx, s.client, req.DoctorID, slot, ""); rerr == nil && taken {
+			return nil, ErrSlotTaken
+		}
+		return nil, err
+	}
+
+	return toAppointmentModel(created.Result()), nil
+}
+
+// Reschedule moves a booked appointment to another free slot of the same
+// doctor. The appointment is closed as rescheduled and a new one, linked to
+// it, is booked in the same transaction, which fails with
+// ErrAppointmentTransition when the appointment was cancelled or
+// rescheduled in the meantime.
+func (s *Appointments) Reschedule(ctx context.Context, req *models.RescheduleAppointmentReq) (*models.Appointment, error) {
+	old, err := s.bookedAppointment(ctx, req.AppointmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := freeSlot(ctx, s.client, old.DoctorID, req.StartsAt, old.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	closed := []db.AppointmentSetParam{
+		db.Appointment.Status.Set(scheduling.AppointmentRescheduled),
+		db.Appointment.ActiveSlot.SetOptional(nil),
+		db.Appointment.CancelledAt.Set(now),
+		db.Appointment.UpdatedAt.Set(now),
+	}
+	optional := []db.AppointmentSetParam{
+		db.Appointment.ActiveSlot.Set(activeSlot(old.DoctorID, slot.Start)),
+		db.Appointment.RescheduledFrom.Link(
+			db.Appointment.ID.Equals(old.ID),
+		),
+	}
+	if req.Reason != "" {
+		closed = append(closed, db.Appointment.CancellationReason.Set(req.Reason))
+	}
+	if reason, ok := old.Reason(); ok {
+		optional = append(optional, db.Appointment.Reason.Set(reason))
+	}
+	if req.RescheduledByID != "" {
+		closed = append(closed, db.Appointment.CancelledBy.Link(
+			db.User.ID.Equals(req.RescheduledByID),
+		))
+		optional = append(optional, db.Appointment.BookedBy.Link(
+			db.User.ID.Equals(req.RescheduledByID),
+		))
+	}
+
+	// only close the appointment while it is still booked; the claim fails
+	// the transaction before the new appointment is created otherwise
+	claimTx := s.client.Prisma.QueryRaw(bookedAppointmentQuery, old.ID, scheduling.AppointmentBooked).Tx()
+	closeTx := s.client.Appointment.FindMany(
+		db.Appointment.ID.Equals(old.ID),
+		db.Appointment.Status.Equals(scheduling.AppointmentBooked),
+	).Update(closed...).Tx()
+
+	createTx := s.client.Appointment.CreateOne(
+		db.Appointment.Patient.Link(
+			db.Patient.ID.Equals(old.PatientID),
+		),
+		db.Appointment.Doctor.Link(
+			db.User.ID.Equals(old.DoctorID),
+		),
+		db.Appointment.StartsAt.Set(slot.Start),
+		db.Appointment.EndsAt.Set(slot.End),
+		optional...,
+	).Tx()
+
+	txs := append(holdSlot(s.client, old.DoctorID, slot, old.ID), claimTx, closeTx, createTx)
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if _, ok := db.IsErrUniqueConstraint(err); ok {
+			return nil, ErrSlotTaken
+		}
+		if _, rerr := s.bookedAppointment(ctx, old.ID); rerr != nil {
+			return nil, rerr
+		}
+		if taken, rerr := slotTaken(ctx, s.client, old.DoctorID, slot, old.ID); rerr == nil && taken {
+			return nil, ErrSlotTaken
+		}
+		return nil, err
+	}
+	if closeTx.Result().Count == 0 {
+		return nil, ErrAppointmentTransition
+	}
+
+	return toAppointmentModel(createTx.Result()), nil
+}
+
+// Cancel cancels a booked appointment and frees its slot.
+func (s *Appointments) Cancel(ctx context.Context, req *models.CancelAppointmentReq) (*models.Appointment, error) {
+	if _, err := s.bookedAppointment(ctx, req.AppointmentID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	params := []db.AppointmentSetParam{
+		db.Appointment.Status.Set(scheduling.AppointmentCancelled),
+		db.Appointment.ActiveSlot.SetOptional(nil),
+		db.Appointment.CancelledAt.Set(now),
+		db.Appointment.CancellationReason.Set(req.Reason),
+		db.Appointment.UpdatedAt.Set(now),
+	}
+	if req.CancelledByID != "" {
+		params = append(params, db.Appointment.CancelledBy.Link(
+			db.User.ID.Equals(req.CancelledByID),
+		))
+	}
+
+	// only cancel the appointment while it is still booked, so that it
+	// isn't cancelled after it was rescheduled at the same time
+	res, err := s.client.Appointment.FindMany(
+		db.Appointment.ID.Equals(req.AppointmentID),
+		db.Appointment.Status.Equals(scheduling.AppointmentBooked),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		return nil, ErrAppointmentTransition
+	}
+
+	appointment, err := s.client.Appointment.FindUnique(
+		db.Appointment.ID.Equals(req.AppointmentID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrAppointmentNotFound
+		}
+		return nil, err
+	}
+	return toAppointmentModel(appointment), nil
+}
+
+// List returns the patient's appointments, the earliest first.
+func (s *Appointments) List(ctx context.Context, pID string) ([]*models.Appointment, error) {
+	records, err := s.client.Appointment.FindMany(
+		db.Appointment.PatientID.Equals(pID),
+	).OrderBy(
+		db.Appointment.StartsAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments := make([]*models.Appointment, 0, len(records))
+	for i := range records {
+		appointments = append(appointments, toAppointmentModel(&records[i]))
+	}
+	return appointments, nil
+}
+
+func (s *Appointments) bookedAppointment(ctx context.Context, id string) (*db.AppointmentModel, error) {
+	appointment, err := s.client.Appointment.FindUnique(
+		db.Appointment.ID.Equals(id),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrAppointmentNotFound
+		}
+		return nil, err
+	}
+	if appointment.Status != scheduling.AppointmentBooked {
+		return nil, ErrAppointmentTransition
+	}
+	return appointment, nil
+}
+
+func toAppointmentModel(a *db.AppointmentModel) *models.Appointment {
+	appointment := &models.Appointment{
+		ID:        a.ID,
+		PatientID: a.PatientID,
+		DoctorID:  a.DoctorID,
+		StartsAt:  a.StartsAt,
+		EndsAt:    a.EndsAt,
+		Status:    a.Status,
+		CreatedAt: a.CreatedAt,
+	}
+	if reason, ok := a.Reason(); ok {
+		appointment.Reason = reason
+	}
+	if bookedBy, ok := a.BookedByID(); ok {
+		appointment.BookedByID = bookedBy
+	}
+	if cancelledBy, ok := a.CancelledByID(); ok {
+		appointment.CancelledByID = cancelledBy
+	}
+	if cancelledAt, ok := a.CancelledAt(); ok {
+		appointment.CancelledAt = &cancelledAt
+	}
+	if reason, ok := a.CancellationReason(); ok {
+		appointment.CancellationReason = reason
+	}
+	if from, ok := a.RescheduledFromID(); ok {
+		appointment.RescheduledFromID = from
+	}
+	if updatedAt, ok := a.UpdatedAt(); ok {
+		appointment.UpdatedAt = &updatedAt
+	}
+	return appointment
+}
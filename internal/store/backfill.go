@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vaidik-bajpai/medibridge/internal/alerts"
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+// backfillBatch is the number of rows a backfill reads at a time.
+const backfillBatch = 500
+
+// Backfill fills in columns added to existing tables for the rows written
+// before they existed. Every task only touches rows still missing the value,
+// so it can be run again after a failure.
+type Backfill struct {
+	client *db.PrismaClient
+}
+
+func NewBackfill(client *db.PrismaClient) *Backfill {
+	return &Backfill{client: client}
+}
+
+// MRNs numbers the patients registered before medical record numbers were
+// assigned, dated by their registration. It returns the number of patients
+// numbered.
+func (b *Backfill) MRNs(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		patients, err := b.client.Patient.FindMany(
+			db.Patient.Mrn.IsNull(),
+		).OrderBy(
+			db.Patient.CreatedAt.Order(db.SortOrderAsc),
+		).Take(backfillBatch).Exec(ctx)
+		if err != nil {
+			return total, err
+		}
+		if len(patients) == 0 {
+			return total, nil
+		}
+
+		for _, p := range patients {
+			if err := b.numberPatient(ctx, &p); err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+// PhoneticKeys computes the phonetic keys of the patients registered before
+// phonetic search, so that it finds them. It returns the number of patients
+// updated.
+func (b *Backfill) PhoneticKeys(ctx context.Context) (int, error) {
+	total := 0
+	after := ""
+	for {
+		// names without letters get no keys, so the batches move on by ID
+		// rather than waiting for the rows to drop out of the filter
+		patients, err := b.client.Patient.FindMany(
+			db.Patient.PhoneticKeys.IsEmpty(true),
+			db.Patient.ID.Gt(after),
+		).OrderBy(
+			db.Patient.ID.Order(db.SortOrderAsc),
+		).Take(backfillBatch).Exec(ctx)
+		if err != nil {
+			return total, err
+		}
+		if len(patients) == 0 {
+			return total, nil
+		}
+
+		for _, p := range patients {
+			after = p.ID
+			keys := phoneticKeys(p.FullName)
+			if len(keys) == 0 {
+				continue
+			}
+			_, err := b.client.Patient.FindUnique(
+				db.Patient.ID.Equals(p.ID),
+			).Update(
+				db.Patient.PhoneticKeys.Set(keys),
+			).Exec(ctx)
+			if err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+// AlertKeys sets the active key of the alerts left unresolved before it
+// existed, so that they keep deduplicating new alerts. When several
+// unresolved alerts share a dedup key only the oldest gets it. It returns
+// the number of alerts updated.
+func (b *Backfill) AlertKeys(ctx context.Context) (int, error) {
+	total := 0
+	after := ""
+	for {
+		as, err := b.client.Alert.FindMany(
+			db.Alert.Status.Not(alerts.StatusResolved),
+			db.Alert.ActiveKey.IsNull(),
+			db.Alert.ID.Gt(after),
+		).OrderBy(
+			db.Alert.ID.Order(db.SortOrderAsc),
+		).Take(backfillBatch).Exec(ctx)
+		if err != nil {
+			return total, err
+		}
+		if len(as) == 0 {
+			return total, nil
+		}
+
+		for _, a := range as {
+			after = a.ID
+			// the older alert of a pair keeps the key, whichever batch
+			// it's in
+			_, err := b.client.Alert.FindFirst(
+				db.Alert.DedupKey.Equals(a.DedupKey),
+				db.Alert.Status.Not(alerts.StatusResolved),
+				db.Alert.RaisedAt.Lt(a.RaisedAt),
+			).Exec(ctx)
+			if err == nil {
+				continue
+			}
+			if err != nil && !db.IsErrNotFound(err) {
+				return total, err
+			}
+
+			_, err = b.client.Alert.FindUnique(
+				db.Alert.ID.Equals(a.ID),
+			).Update(
+				db.Alert.ActiveKey.Set(a.DedupKey),
+			).Exec(ctx)
+			if _, ok := db.IsErrUniqueConstraint(err); ok {
+				continue
+			}
+			if err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+// AllergyReactions records the free-text reaction of the allergies recorded
+// before structured reactions as their single reaction, with the allergy's
+// severity, the way a new allergy recorded with only a reaction is. It
+// returns the number of allergies updated.
+func (b *Backfill) AllergyReactions(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		allergies, err := b.client.Allergy.FindMany(
+			db.Allergy.Reactions.Equals(db.JSON("[]")),
+			db.Allergy.Reaction.Not(""),
+		).Take(backfillBatch).Exec(ctx)
+		if err != nil {
+			return total, err
+		}
+		if len(allergies) == 0 {
+			return total, nil
+		}
+
+		for _, a := range allergies {
+			reactions, err := json.Marshal([]models.AllergyReaction{{
+				Manifestation: a.Reaction,
+				Severity:      a.Severity,
+			}})
+			if err != nil {
+				return total, err
+			}
+			_, err = b.client.Allergy.FindUnique(
+				db.Allergy.ID.Equals(a.ID),
+			).Update(
+				db.Allergy.Reactions.Set(reactions),
+			).Exec(ctx)
+			if err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+// numberPatient gives the patient a new MRN, drawing again when the random
+// part collides with an existing one.
+func (b *Backfill) numberPatient(ctx context.Context, p *db.PatientModel) error {
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		var mrn string
+		mrn, err = helpers.GenerateMRNAt(p.CreatedAt)
+		if err != nil {
+			return err
+		}
+		_, err = b.client.Patient.FindUnique(
+			db.Patient.ID.Equals(p.ID),
+		).Update(
+			db.Patient.Mrn.Set(mrn),
+		).Exec(ctx)
+		if _, ok := db.IsErrUniqueConstraint(err); !ok {
+			return err
+		}
+	}
+	return err
+}
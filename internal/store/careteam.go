@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrCareTeamMemberExists   = errors.New("user already in the care team")
+	ErrCareTeamMemberNotFound = errors.New("care team member not found")
+)
+
+type CareTeam struct {
+	client *db.PrismaClient
+}
+
+func (s *CareTeam) Add(ctx context.Context, req *models.AddCareTeamMemberReq) (*models.CareTeamMember, error) {
+	m, err := s.client.CareTeamMember.CreateOne(
+		db.CareTeamMember.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.CareTeamMember.User.Link(
+			db.User.ID.Equals(req.UserID),
+		),
+		db.CareTeamMember.Role.Set(req.Role),
+	).With(
+		db.CareTeamMember.User.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if _, ok := db.IsErrUniqueConstraint(err); ok {
+			return nil, ErrCareTeamMemberExists
+		}
+		return nil, err
+	}
+	return toCareTeamMember(m), nil
+}
+
+func (s *CareTeam) Remove(ctx context.Context, pID, userID string) error {
+	_, err := s.client.CareTeamMember.FindUnique(
+		db.CareTeamMember.PatientIDUserID(
+			db.CareTeamMember.PatientID.Equals(pID),
+			db.CareTeamMember.UserID.Equals(userID),
+		),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrCareTeamMemberNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *CareTeam) List(ctx context.Context, pID string) ([]*models.CareTeamMember, error) {
+	ms, err := s.client.CareTeamMember.FindMany(
+		db.CareTeamMember.PatientID.Equals(pID),
+	).With(
+		db.CareTeamMember.User.Fetch(),
+	).OrderBy(
+		db.CareTeamMember.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*models.CareTeamMember, 0, len(ms))
+	for i := range ms {
+		members = append(members, toCareTeamMember(&ms[i]))
+	}
+	return members, nil
+}
+
+func toCareTeamMember(m *db.CareTeamMemberModel) *models.CareTeamMember {
+	return &models.CareTeamMember{
+		ID:        m.ID,
+		PatientID: m.PatientID,
+		UserID:    m.UserID,
+		FullName:  m.User().Fullname,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -2,41 +2,236 @@ package store
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
 )
 
+var (
+	ErrConditionNotFound = errors.New("condition not found")
+
+	// ErrConditionTransition is returned when a condition can't move from
+	// its clinical status to the requested one.
+	ErrConditionTransition = errors.New("condition can't move to the requested status")
+
+	// ErrAbatementWhileActive is returned when an abatement date is given
+	// for a condition that is, or stays, active.
+	ErrAbatementWhileActive = errors.New("abatement date given for an active condition")
+)
+
 type Conditions struct {
 	client *db.PrismaClient
 }
 
 func (s *Conditions) Add(ctx context.Context, req *models.AddConditionReq) (*models.Condition, error) {
+	var optional []db.ConditionSetParam
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PatientID, req.EncounterID); err != nil {
+			return nil, err
+		}
+		optional = append(optional, db.Condition.Encounter.Link(
+			db.Encounter.ID.Equals(req.EncounterID),
+		))
+	}
+	if req.ClinicalStatus != "" {
+		optional = append(optional, db.Condition.ClinicalStatus.Set(req.ClinicalStatus))
+	}
+	if req.VerificationStatus != "" {
+		optional = append(optional, db.Condition.VerificationStatus.Set(req.VerificationStatus))
+	}
+	if req.OnsetDate != nil {
+		optional = append(optional, db.Condition.OnsetDate.Set(time.Time(*req.OnsetDate)))
+	}
+	if req.AbatementDate != nil {
+		optional = append(optional, db.Condition.AbatementDate.Set(time.Time(*req.AbatementDate)))
+	}
+	if req.Note != "" {
+		optional = append(optional, db.Condition.Note.Set(req.Note))
+	}
+
 	condition, err := s.client.Condition.CreateOne(
 		db.Condition.Patient.Link(
 			db.Patient.ID.Equals(req.PatientID),
 		),
 		db.Condition.Name.Set(req.Condition),
+		optional...,
 	).Exec(ctx)
 	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
 		return nil, err
 	}
 
-	return &models.Condition{
-		ID:        condition.ID,
-		PatientID: condition.PatientID,
-		Name:      condition.Name,
-		CreatedAt: condition.CreatedAt,
-	}, nil
+	return toConditionModel(condition), nil
 }
 
-func (s *Conditions) Delete(ctx context.Context, pID string) error {
-	_, err := s.client.Condition.FindUnique(
-		db.Condition.ID.Equals(pID),
-	).Delete().Exec(ctx)
+// Update changes a condition. A change of clinical or verification status
+// is recorded as a transition together with the update. The abatement date
+// defaults to today when the condition stops being active and is cleared
+// when it becomes active again.
+func (s *Conditions) Update(ctx context.Context, req *models.UpdateConditionReq) (*models.Condition, error) {
+	current, err := s.client.Condition.FindUnique(
+		db.Condition.ID.Equals(req.CID),
+	).Exec(ctx)
 	if err != nil {
-		return err
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrConditionNotFound
+		}
+		return nil, err
+	}
+
+	toClinical, toVerification := current.ClinicalStatus, current.VerificationStatus
+	if req.ClinicalStatus != "" && req.ClinicalStatus != current.ClinicalStatus {
+		if !clinical.CanTransitionCondition(current.ClinicalStatus, req.ClinicalStatus) {
+			return nil, ErrConditionTransition
+		}
+		toClinical = req.ClinicalStatus
+	}
+	if req.VerificationStatus != "" {
+		toVerification = req.VerificationStatus
+	}
+
+	wasActive := clinical.IsConditionActive(current.ClinicalStatus)
+	isActive := clinical.IsConditionActive(toClinical)
+	if isActive && req.AbatementDate != nil {
+		return nil, ErrAbatementWhileActive
+	}
+
+	update := []db.ConditionSetParam{
+		db.Condition.ClinicalStatus.Set(toClinical),
+		db.Condition.VerificationStatus.Set(toVerification),
+		db.Condition.UpdatedAt.Set(time.Now()),
+	}
+	switch {
+	case req.AbatementDate != nil:
+		update = append(update, db.Condition.AbatementDate.Set(time.Time(*req.AbatementDate)))
+	case wasActive && !isActive:
+		update = append(update, db.Condition.AbatementDate.Set(startOfToday()))
+	case !wasActive && isActive:
+		update = append(update, db.Condition.AbatementDate.SetOptional(nil))
+	}
+	if req.OnsetDate != nil {
+		update = append(update, db.Condition.OnsetDate.Set(time.Time(*req.OnsetDate)))
+	}
+	if req.Note != "" {
+		update = append(update, db.Condition.Note.Set(req.Note))
 	}
 
-	return nil
+	updated := s.client.Condition.FindUnique(
+		db.Condition.ID.Equals(req.CID),
+	).Update(
+		update...,
+	).Tx()
+
+	// the condition is only updated while it still has the statuses read,
+	// so that changes at the same time neither skip the transition check
+	// nor record a transition from a status the condition no longer has
+	txs := []db.PrismaTransaction{
+		s.client.Prisma.QueryRaw(conditionStatusQuery, req.CID,
+			current.ClinicalStatus, current.VerificationStatus).Tx(),
+		updated,
+	}
+	if toClinical != current.ClinicalStatus || toVerification != current.VerificationStatus {
+		var optional []db.ConditionTransitionSetParam
+		if req.Note != "" {
+			optional = append(optional, db.ConditionTransition.Note.Set(req.Note))
+		}
+		if req.ChangedByID != "" {
+			optional = append(optional, db.ConditionTransition.ChangedBy.Link(
+				db.User.ID.Equals(req.ChangedByID),
+			))
+		}
+
+		txs = append(txs, s.client.ConditionTransition.CreateOne(
+			db.ConditionTransition.Condition.Link(
+				db.Condition.ID.Equals(req.CID),
+			),
+			db.ConditionTransition.FromClinicalStatus.Set(current.ClinicalStatus),
+			db.ConditionTransition.ToClinicalStatus.Set(toClinical),
+			db.ConditionTransition.FromVerificationStatus.Set(current.VerificationStatus),
+			db.ConditionTransition.ToVerificationStatus.Set(toVerification),
+			optional...,
+		).Tx())
+	}
+
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		latest, rerr := s.client.Condition.FindUnique(
+			db.Condition.ID.Equals(req.CID),
+		).Exec(ctx)
+		switch {
+		case db.IsErrNotFound(rerr):
+			return nil, ErrConditionNotFound
+		case rerr == nil && (latest.ClinicalStatus != current.ClinicalStatus ||
+			latest.VerificationStatus != current.VerificationStatus):
+			return nil, ErrConditionTransition
+		}
+		return nil, err
+	}
+
+	return toConditionModel(updated.Result()), nil
+}
+
+// conditionStatusQuery locks the condition $1 and fails the transaction it
+// runs in unless the condition still has the clinical status $2 and the
+// verification status $3.
+const conditionStatusQuery = `
+	WITH locked AS (
+		SELECT id
+		FROM "Condition"
+		WHERE id = $1 AND "clinicalStatus" = $2 AND "verificationStatus" = $3
+		FOR UPDATE
+	)
+	SELECT 1 / COUNT(*) AS unchanged FROM locked;
+`
+
+func toConditionModel(c *db.ConditionModel) *models.Condition {
+	condition := &models.Condition{
+		ID:                 c.ID,
+		PatientID:          c.PatientID,
+		Name:               c.Name,
+		ClinicalStatus:     c.ClinicalStatus,
+		VerificationStatus: c.VerificationStatus,
+		CreatedAt:          c.CreatedAt,
+	}
+	if onset, ok := c.OnsetDate(); ok {
+		date := models.DateOnly(onset)
+		condition.OnsetDate = &date
+	}
+	if abatement, ok := c.AbatementDate(); ok {
+		date := models.DateOnly(abatement)
+		condition.AbatementDate = &date
+	}
+	if note, ok := c.Note(); ok {
+		condition.Note = note
+	}
+	if encounter, ok := c.EncounterID(); ok {
+		condition.EncounterID = encounter
+	}
+	if updatedAt, ok := c.UpdatedAt(); ok {
+		condition.UpdatedAt = &updatedAt
+	}
+	return condition
+}
+
+func toConditionTransitionModel(t *db.ConditionTransitionModel) models.ConditionTransition {
+	transition := models.ConditionTransition{
+		ID:                     t.ID,
+		ConditionID:            t.ConditionID,
+		FromClinicalStatus:     t.FromClinicalStatus,
+		ToClinicalStatus:       t.ToClinicalStatus,
+		FromVerificationStatus: t.FromVerificationStatus,
+		ToVerificationStatus:   t.ToVerificationStatus,
+		ChangedAt:              t.ChangedAt,
+	}
+	if note, ok := t.Note(); ok {
+		transition.Note = note
+	}
+	if changedBy, ok := t.ChangedByID(); ok {
+		transition.ChangedByID = changedBy
+	}
+	return transition
 }
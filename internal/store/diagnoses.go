@@ -2,21 +2,56 @@ package store
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
 )
 
+var ErrDiagnosisNotFound = errors.New("diagnosis not found")
+
 type Diagnoses struct {
 	client *db.PrismaClient
 }
 
 func (s *Diagnoses) Add(ctx context.Context, req *models.DiagnosesReq) (*models.Diagnoses, error) {
+	var optional []db.DiagnosisSetParam
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PID, req.EncounterID); err != nil {
+			return nil, err
+		}
+		optional = append(optional, db.Diagnosis.Encounter.Link(
+			db.Encounter.ID.Equals(req.EncounterID),
+		))
+	}
+	if req.Code != "" {
+		optional = append(optional,
+			db.Diagnosis.Code.Set(req.Code),
+			db.Diagnosis.CodeUnverified.Set(req.CodeUnverified),
+		)
+		if req.Display != "" {
+			optional = append(optional, db.Diagnosis.Display.Set(req.Display))
+		}
+	}
+	if req.Type != "" {
+		optional = append(optional, db.Diagnosis.Type.Set(req.Type))
+	}
+	if req.OnsetDate != nil {
+		optional = append(optional, db.Diagnosis.OnsetDate.Set(time.Time(*req.OnsetDate)))
+	}
+	if req.ClinicianID != "" {
+		optional = append(optional, db.Diagnosis.Clinician.Link(
+			db.User.ID.Equals(req.ClinicianID),
+		))
+	}
+
 	diag, err := s.client.Diagnosis.CreateOne(
 		db.Diagnosis.Patient.Link(
 			db.Patient.ID.Equals(req.PID),
 		),
 		db.Diagnosis.Name.Set(req.Name),
+		optional...,
 	).Exec(ctx)
 	if err != nil {
 		if ok := db.IsErrNotFound(err); ok {
@@ -25,29 +60,60 @@ func (s *Diagnoses) Add(ctx context.Context, req *models.DiagnosesReq) (*models.
 		return nil, err
 	}
 
-	return &models.Diagnoses{
-		ID:        diag.ID,
-		PatientID: diag.PatientID,
-		Name:      diag.Name,
-		CreatedAt: diag.CreatedAt,
-	}, nil
+	return toDiagnosisModel(diag), nil
 }
 
 func (s *Diagnoses) Update(ctx context.Context, req *models.UpdateDiagnosesReq) (*models.Diagnoses, error) {
-	diag, err := s.client.Diagnosis.FindUnique(
+	current, err := s.client.Diagnosis.FindUnique(
 		db.Diagnosis.ID.Equals(req.DID),
-	).Update(
-		db.Diagnosis.Name.Set(req.Name),
 	).Exec(ctx)
 	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrDiagnosisNotFound
+		}
 		return nil, err
 	}
-	return &models.Diagnoses{
-		ID:        diag.ID,
-		PatientID: diag.PatientID,
-		Name:      diag.Name,
-		CreatedAt: diag.CreatedAt,
-	}, nil
+
+	update := []db.DiagnosisSetParam{
+		db.Diagnosis.UpdatedAt.Set(time.Now()),
+	}
+	if req.Name != "" {
+		update = append(update, db.Diagnosis.Name.Set(req.Name))
+	}
+	if req.Code != "" {
+		// an unverified code has no display text, so the old code's is
+		// cleared
+		var display *string
+		if req.Display != "" {
+			display = &req.Display
+		}
+		update = append(update,
+			db.Diagnosis.Code.Set(req.Code),
+			db.Diagnosis.Display.SetOptional(display),
+			db.Diagnosis.CodeUnverified.Set(req.CodeUnverified),
+		)
+	}
+	if req.Type != "" {
+		update = append(update, db.Diagnosis.Type.Set(req.Type))
+	}
+	if req.OnsetDate != nil {
+		update = append(update, db.Diagnosis.OnsetDate.Set(time.Time(*req.OnsetDate)))
+	}
+
+	updated := s.client.Diagnosis.FindUnique(
+		db.Diagnosis.ID.Equals(req.DID),
+	).Update(
+		update...,
+	).Tx()
+
+	edit := recordEdit(s.client, current.PatientID, editDiagnosis, req.DID, req.EditedByID, editedFields(req))
+	if err := s.client.Prisma.Transaction(updated, edit).Exec(ctx); err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrDiagnosisNotFound
+		}
+		return nil, err
+	}
+	return toDiagnosisModel(updated.Result()), nil
 }
 
 func (s *Diagnoses) Delete(ctx context.Context, pID string) error {
@@ -59,3 +125,34 @@ func (s *Diagnoses) Delete(ctx context.Context, pID string) error {
 	}
 	return nil
 }
+
+func toDiagnosisModel(d *db.DiagnosisModel) *models.Diagnoses {
+	diag := &models.Diagnoses{
+		ID:             d.ID,
+		PatientID:      d.PatientID,
+		Name:           d.Name,
+		CodeUnverified: d.CodeUnverified,
+		Type:           d.Type,
+		CreatedAt:      d.CreatedAt,
+	}
+	if code, ok := d.Code(); ok {
+		diag.Code = code
+	}
+	if display, ok := d.Display(); ok {
+		diag.Display = display
+	}
+	if onset, ok := d.OnsetDate(); ok {
+		date := models.DateOnly(onset)
+		diag.OnsetDate = &date
+	}
+	if clinician, ok := d.ClinicianID(); ok {
+		diag.ClinicianID = clinician
+	}
+	if encounter, ok := d.EncounterID(); ok {
+		diag.EncounterID = encounter
+	}
+	if updatedAt, ok := d.UpdatedAt(); ok {
+		diag.UpdatedAt = &updatedAt
+	}
+	return diag
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+// Kinds of entries whose edits are recorded.
+const (
+	editPatient   = "patient"
+	editDiagnosis = "diagnosis"
+	editAllergy   = "allergy"
+	editVital     = "vital"
+)
+
+// editedFields returns the JSON names of the fields a correction sets: the
+// non-nil pointers and slices and the non-empty strings. Fields tagged "-"
+// and the names in skip are left out.
+func editedFields(req interface{}, skip ...string) []string {
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	t := v.Type()
+
+	fields := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || slices.Contains(skip, name) {
+			continue
+		}
+		if !v.Field(i).IsZero() {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// recordEdit returns the query recording an edit of an entry of the
+// patient's record, to run in the transaction of the edit itself.
+func recordEdit(client *db.PrismaClient, patientID, entity, entityID, editedByID string, fields []string) db.PrismaTransaction {
+	optional := []db.RecordEditSetParam{
+		db.RecordEdit.Fields.Set(fields),
+	}
+	if editedByID != "" {
+		optional = append(optional, db.RecordEdit.EditedBy.Link(
+			db.User.ID.Equals(editedByID),
+		))
+	}
+
+	return client.RecordEdit.CreateOne(
+		db.RecordEdit.Patient.Link(
+			db.Patient.ID.Equals(patientID),
+		),
+		db.RecordEdit.Entity.Set(entity),
+		db.RecordEdit.EntityID.Set(entityID),
+		optional...,
+	).Tx()
+}
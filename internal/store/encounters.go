@@ -0,0 +1,414 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/notes"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrEncounterNotFound = errors.New("encounter not found")
+
+	// ErrEncounterTransition is returned when an encounter can't move from
+	// its status to the requested one, e.g. once it was finished.
+	ErrEncounterTransition = errors.New("encounter can't move to the requested status")
+
+	// ErrEncounterCancelled is returned when clinical data is recorded in,
+	// or changes are made to, a cancelled encounter.
+	ErrEncounterCancelled = errors.New("encounter was cancelled")
+
+	// ErrEncounterInUse is returned when deleting an encounter that clinical
+	// data was recorded in; it should be cancelled instead.
+	ErrEncounterInUse = errors.New("encounter has clinical data")
+
+	// ErrEndBeforeStart is returned when an encounter would end before it
+	// started.
+	ErrEndBeforeStart = errors.New("encounter ends before it started")
+)
+
+type Encounters struct {
+	client *db.PrismaClient
+}
+
+// checkEncounter makes sure clinical data of the patient can be recorded in
+// the encounter: it must be one of the patient's and not be cancelled.
+func checkEncounter(ctx context.Context, client *db.PrismaClient, pID, eID string) error {
+	encounter, err := client.Encounter.FindUnique(
+		db.Encounter.ID.Equals(eID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrEncounterNotFound
+		}
+		return err
+	}
+	if encounter.PatientID != pID {
+		return ErrEncounterNotFound
+	}
+	if encounter.Status == clinical.EncounterCancelled {
+		return ErrEncounterCancelled
+	}
+	return nil
+}
+
+// Create opens an encounter for the patient. It fails with
+// ErrDoctorNotFound unless the attending user is a doctor.
+func (s *Encounters) Create(ctx context.Context, req *models.CreateEncounterReq) (*models.Encounter, error) {
+	if req.AttendingID != "" {
+		attending, err := s.client.User.FindUnique(
+			db.User.ID.Equals(req.AttendingID),
+		).Exec(ctx)
+		if err != nil {
+			if ok := db.IsErrNotFound(err); ok {
+				return nil, ErrDoctorNotFound
+			}
+			return nil, err
+		}
+		if attending.Role != db.RoleDoctor {
+			return nil, ErrDoctorNotFound
+		}
+	}
+
+	var optional []db.EncounterSetParam
+	if req.Status != "" {
+		optional = append(optional, db.Encounter.Status.Set(req.Status))
+	}
+	if req.Reason != "" {
+		optional = append(optional, db.Encounter.Reason.Set(req.Reason))
+	}
+	if req.AttendingID != "" {
+		optional = append(optional, db.Encounter.Attending.Link(
+			db.User.ID.Equals(req.AttendingID),
+		))
+	}
+	if req.StartedAt != nil {
+		optional = append(optional, db.Encounter.StartedAt.Set(*req.StartedAt))
+	}
+
+	encounter, err := s.client.Encounter.CreateOne(
+		db.Encounter.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.Encounter.Type.Set(req.Type),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			// either the patient or the doctor, deleted since it was checked
+			if _, perr := s.client.Patient.FindUnique(
+				db.Patient.ID.Equals(req.PatientID),
+			).Exec(ctx); perr == nil {
+				return nil, ErrDoctorNotFound
+			}
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	return toEncounterModel(encounter), nil
+}
+
+// Get returns an encounter with the clinical data recorded in it.
+func (s *Encounters) Get(ctx context.Context, eID string) (*models.EncounterRecord, error) {
+	encounter, err := s.client.Encounter.FindUnique(
+		db.Encounter.ID.Equals(eID),
+	).With(
+		encounterData()...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrEncounterNotFound
+		}
+		return nil, err
+	}
+
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(encounter.PatientID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEncounterRecord(encounter, patient)
+}
+
+// List returns a patient's encounters with their clinical data, the most
+// recently started first.
+func (s *Encounters) List(ctx context.Context, req *models.EncounterQuery) ([]*models.EncounterRecord, error) {
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.PatientID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	where := []db.EncounterWhereParam{
+		db.Encounter.PatientID.Equals(req.PatientID),
+	}
+	if req.Status != "" {
+		where = append(where, db.Encounter.Status.Equals(req.Status))
+	}
+	if req.From != nil {
+		where = append(where, db.Encounter.StartedAt.Gte(*req.From))
+	}
+	if req.To != nil {
+		where = append(where, db.Encounter.StartedAt.Lte(*req.To))
+	}
+
+	encounters, err := s.client.Encounter.FindMany(
+		where...,
+	).With(
+		encounterData()...,
+	).OrderBy(
+		db.Encounter.StartedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*models.EncounterRecord, 0, len(encounters))
+	for i := range encounters {
+		record, err := toEncounterRecord(&encounters[i], patient)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Update changes an encounter or moves it to another status. Finishing an
+// encounter sets its end to now unless one is given. A cancelled encounter
+// can't be changed.
+func (s *Encounters) Update(ctx context.Context, req *models.UpdateEncounterReq) (*models.Encounter, error) {
+	current, err := s.client.Encounter.FindUnique(
+		db.Encounter.ID.Equals(req.EncounterID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrEncounterNotFound
+		}
+		return nil, err
+	}
+	if current.Status == clinical.EncounterCancelled {
+		return nil, ErrEncounterCancelled
+	}
+
+	status := current.Status
+	if req.Status != nil && *req.Status != current.Status {
+		if !clinical.CanTransitionEncounter(current.Status, *req.Status) {
+			return nil, ErrEncounterTransition
+		}
+		status = *req.Status
+	}
+
+	update := []db.EncounterSetParam{
+		db.Encounter.Status.Set(status),
+		db.Encounter.UpdatedAt.Set(time.Now()),
+	}
+	if req.Type != nil {
+		update = append(update, db.Encounter.Type.Set(*req.Type))
+	}
+	if req.Reason != nil {
+		update = append(update, db.Encounter.Reason.Set(*req.Reason))
+	}
+	if req.AttendingID != nil {
+		update = append(update, db.Encounter.Attending.Link(
+			db.User.ID.Equals(*req.AttendingID),
+		))
+	}
+
+	started := current.StartedAt
+	if req.StartedAt != nil {
+		started = *req.StartedAt
+		update = append(update, db.Encounter.StartedAt.Set(started))
+	}
+
+	ended, hasEnd := current.EndedAt()
+	switch {
+	case req.EndedAt != nil:
+		ended, hasEnd = *req.EndedAt, true
+	case status == clinical.EncounterFinished && !hasEnd:
+		ended, hasEnd = time.Now(), true
+	}
+	if hasEnd {
+		if ended.Before(started) {
+			return nil, ErrEndBeforeStart
+		}
+		update = append(update, db.Encounter.EndedAt.Set(ended))
+	}
+
+	encounter, err := s.client.Encounter.FindUnique(
+		db.Encounter.ID.Equals(req.EncounterID),
+	).Update(
+		update...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrEncounterNotFound
+		}
+		return nil, err
+	}
+	return toEncounterModel(encounter), nil
+}
+
+// deleteUnusedEncounterQuery deletes the encounter $1 unless clinical data
+// was recorded in it. The check and the delete are a single statement, so
+// that data recorded at the same time either comes first and keeps the
+// encounter or fails to link to it.
+const deleteUnusedEncounterQuery = `
+	DELETE FROM "Encounter" e
+	WHERE e.id = $1
+		AND NOT EXISTS (SELECT 1 FROM "Diagnosis" WHERE "encounterId" = e.id)
+		AND NOT EXISTS (SELECT 1 FROM "Condition" WHERE "encounterId" = e.id)
+		AND NOT EXISTS (SELECT 1 FROM "Allergy" WHERE "encounterId" = e.id)
+		AND NOT EXISTS (SELECT 1 FROM "Medication" WHERE "encounterId" = e.id)
+		AND NOT EXISTS (SELECT 1 FROM "Vital" WHERE "encounterId" = e.id)
+		AND NOT EXISTS (SELECT 1 FROM "ClinicalNote" WHERE "encounterId" = e.id)
+		AND NOT EXISTS (SELECT 1 FROM "LabOrder" WHERE "encounterId" = e.id)
+		AND NOT EXISTS (SELECT 1 FROM "Immunization" WHERE "encounterId" = e.id);
+`
+
+// Delete removes an encounter opened by mistake. Once clinical data was
+// recorded in it, it fails with ErrEncounterInUse.
+func (s *Encounters) Delete(ctx context.Context, eID string) error {
+	res, err := s.client.Prisma.ExecuteRaw(deleteUnusedEncounterQuery, eID).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Count > 0 {
+		return nil
+	}
+
+	_, err = s.client.Encounter.FindUnique(
+		db.Encounter.ID.Equals(eID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrEncounterNotFound
+		}
+		return err
+	}
+	return ErrEncounterInUse
+}
+
+// encounterData fetches the clinical data recorded in an encounter, oldest
+// first.
+func encounterData() []db.EncounterRelationWith {
+	return []db.EncounterRelationWith{
+		db.Encounter.Diagnoses.Fetch().OrderBy(
+			db.Diagnosis.CreatedAt.Order(db.SortOrderAsc),
+		),
+		db.Encounter.Conditions.Fetch().OrderBy(
+			db.Condition.CreatedAt.Order(db.SortOrderAsc),
+		),
+		db.Encounter.Allergies.Fetch().OrderBy(
+			db.Allergy.RecordedAt.Order(db.SortOrderAsc),
+		),
+		db.Encounter.Medications.Fetch().OrderBy(
+			db.Medication.CreatedAt.Order(db.SortOrderAsc),
+		),
+		db.Encounter.Vitals.Fetch().OrderBy(
+			db.Vital.MeasuredAt.Order(db.SortOrderAsc),
+		),
+		db.Encounter.Notes.Fetch(
+			db.ClinicalNote.Status.Not(notes.StatusDraft),
+		).OrderBy(
+			db.ClinicalNote.CreatedAt.Order(db.SortOrderAsc),
+		).With(
+			noteAmendments(),
+		),
+		db.Encounter.LabOrders.Fetch().OrderBy(
+			db.LabOrder.CreatedAt.Order(db.SortOrderAsc),
+		).With(
+			labOrderResults(),
+		),
+		db.Encounter.Immunizations.Fetch().OrderBy(
+			db.Immunization.AdministeredAt.Order(db.SortOrderAsc),
+		),
+	}
+}
+
+func toEncounterModel(e *db.EncounterModel) *models.Encounter {
+	encounter := &models.Encounter{
+		ID:        e.ID,
+		PatientID: e.PatientID,
+		Type:      e.Type,
+		Status:    e.Status,
+		StartedAt: e.StartedAt,
+		CreatedAt: e.CreatedAt,
+	}
+	if reason, ok := e.Reason(); ok {
+		encounter.Reason = reason
+	}
+	if attending, ok := e.AttendingID(); ok {
+		encounter.AttendingID = attending
+	}
+	if ended, ok := e.EndedAt(); ok {
+		encounter.EndedAt = &ended
+	}
+	if updatedAt, ok := e.UpdatedAt(); ok {
+		encounter.UpdatedAt = &updatedAt
+	}
+	return encounter
+}
+
+// toEncounterRecord converts an encounter fetched with encounterData. The
+// patient's sex and date of birth flag the vitals.
+func toEncounterRecord(e *db.EncounterModel, patient *db.PatientModel) (*models.EncounterRecord, error) {
+	record := &models.EncounterRecord{
+		Encounter:   *toEncounterModel(e),
+		Diagnoses:   []models.Diagnoses{},
+		Conditions:  []models.Condition{},
+		Allergies:   []models.Allergy{},
+		Medications: []models.Medication{},
+		Vitals:      []models.VitalModel{},
+		Notes:       []models.ClinicalNote{},
+		LabOrders:   []models.LabOrder{},
+
+		Immunizations: []models.Immunization{},
+	}
+	for i := range e.Diagnoses() {
+		record.Diagnoses = append(record.Diagnoses, *toDiagnosisModel(&e.Diagnoses()[i]))
+	}
+	for i := range e.Conditions() {
+		record.Conditions = append(record.Conditions, *toConditionModel(&e.Conditions()[i]))
+	}
+	for i := range e.Allergies() {
+		allergy, err := toAllergyModel(&e.Allergies()[i])
+		if err != nil {
+			return nil, err
+		}
+		record.Allergies = append(record.Allergies, *allergy)
+	}
+	for i := range e.Medications() {
+		med, err := toMedicationModel(&e.Medications()[i])
+		if err != nil {
+			return nil, err
+		}
+		record.Medications = append(record.Medications, *med)
+	}
+	for i := range e.Vitals() {
+		vital := toVitalModel(&e.Vitals()[i])
+		vital.ApplyFlags(patient.Gender, patient.DateOfBirth)
+		record.Vitals = append(record.Vitals, vital)
+	}
+	for i := range e.Notes() {
+		record.Notes = append(record.Notes, *toNoteModel(&e.Notes()[i]))
+	}
+	for i := range e.LabOrders() {
+		record.LabOrders = append(record.LabOrders, *toLabOrderModel(&e.LabOrders()[i]))
+	}
+	for i := range e.Immunizations() {
+		record.Immunizations = append(record.Immunizations, *toImmunizationModel(&e.Immunizations()[i]))
+	}
+	return record, nil
+}
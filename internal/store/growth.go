@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/growth"
+	dto "github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+// ErrGrowthSexRequired is returned when the patient's gender doesn't pick a
+// growth chart and no sex was given.
+var ErrGrowthSexRequired = errors.New("growth charts need the patient's sex")
+
+// Growth scores the patient's weights, heights and BMIs against the growth
+// standards. Measurements taken at ages the standards don't cover are left
+// out. A BMI missing from a reading is worked out from its height and
+// weight.
+func (s *Vitals) Growth(ctx context.Context, req *dto.GrowthQuery) (*dto.GrowthCharts, error) {
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.PatientID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	sex := req.Sex
+	if sex == "" {
+		sex = patient.Gender
+	}
+	if sex != "MALE" && sex != "FEMALE" {
+		return nil, ErrGrowthSexRequired
+	}
+
+	vitals, err := s.client.Vital.FindMany(
+		db.Vital.PatientID.Equals(req.PatientID),
+	).OrderBy(
+		db.Vital.MeasuredAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	standards := growth.Current()
+	charts := &dto.GrowthCharts{
+		PatientID:   patient.ID,
+		Sex:         sex,
+		DateOfBirth: patient.DateOfBirth,
+		Standards:   standards.Name,
+		Charts:      []dto.GrowthChart{},
+	}
+	ageNow := growth.AgeMonths(patient.DateOfBirth, time.Now())
+
+	for _, ind := range standards.Indicators {
+		if req.Indicator != "" && ind.Code != req.Indicator {
+			continue
+		}
+
+		chart := dto.GrowthChart{
+			Indicator: ind.Code,
+			Name:      ind.Name,
+			Unit:      ind.Unit,
+			Points:    []dto.GrowthPoint{},
+		}
+		for i := range vitals {
+			value, ok := growthValue(&vitals[i], ind.Metric)
+			if !ok {
+				continue
+			}
+			age := growth.AgeMonths(patient.DateOfBirth, vitals[i].MeasuredAt)
+			z, ok := ind.ZScore(sex, age, value)
+			if !ok {
+				continue
+			}
+			chart.Points = append(chart.Points, dto.GrowthPoint{
+				VitalID:    vitals[i].ID,
+				MeasuredAt: vitals[i].MeasuredAt,
+				AgeMonths:  clinical.Round(age, 1),
+				Value:      value,
+				ZScore:     z,
+				Percentile: growth.Percentile(z),
+			})
+		}
+
+		to := min(max(ageNow, 0), ind.MaxAge(sex))
+		chart.Curves = ind.Curves(sex, 0, to)
+		charts.Charts = append(charts.Charts, chart)
+	}
+	return charts, nil
+}
+
+// growthValue returns the value of a growth metric in a reading.
+func growthValue(v *db.VitalModel, metric string) (float64, bool) {
+	switch metric {
+	case "heightCm":
+		return v.HeightCm()
+	case "weightKg":
+		return v.WeightKg()
+	case "bmi":
+		if bmi, ok := v.Bmi(); ok {
+			return bmi, true
+		}
+		height, okHeight := v.HeightCm()
+		weight, okWeight := v.WeightKg()
+		if okHeight && okWeight {
+			return clinical.BMI(height, weight), true
+		}
+	}
+	return 0, false
+}
@@ -1,8 +1,14 @@
 package store
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
 	"strings"
+	"time"
 
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/matching"
 	dto "github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
 )
@@ -16,6 +22,7 @@ func preparePatientUpdateParams(input *dto.UpdatePatientReq) []db.PatientSetPara
 
 	if input.FullName != nil && *input.FullName != "" {
 		addParam(db.Patient.FullName.Set(*input.FullName))
+		addParam(db.Patient.PhoneticKeys.Set(phoneticKeys(*input.FullName)))
 	}
 
 	if input.Gender != nil && *input.Gender != "" {
@@ -30,6 +37,14 @@ func preparePatientUpdateParams(input *dto.UpdatePatientReq) []db.PatientSetPara
 		addParam(db.Patient.Address.Set(*input.Address))
 	}
 
+	if input.Ward != nil {
+		if *input.Ward == "" {
+			addParam(db.Patient.Ward.SetOptional(nil))
+		} else {
+			addParam(db.Patient.Ward.Set(*input.Ward))
+		}
+	}
+
 	if input.EmergencyName != nil && *input.EmergencyName != "" {
 		addParam(db.Patient.EmergencyName.Set(*input.EmergencyName))
 	}
@@ -58,6 +73,22 @@ func preparePatientUpdateParams(input *dto.UpdatePatientReq) []db.PatientSetPara
 func prepareVitalCreateParams(input *dto.CreateVitalReq) []db.VitalSetParam {
 	var params []db.VitalSetParam
 
+	if input.RecordedByID != "" {
+		params = append(params, db.Vital.RecordedBy.Link(db.User.ID.Equals(input.RecordedByID)))
+	}
+	if input.MeasuredAt != nil {
+		params = append(params, db.Vital.MeasuredAt.Set(*input.MeasuredAt))
+	}
+	if input.Source != "" {
+		params = append(params, db.Vital.Source.Set(input.Source))
+	}
+	if input.DeviceID != "" {
+		params = append(params, db.Vital.DeviceID.Set(input.DeviceID))
+	}
+	if input.EncounterID != "" {
+		params = append(params, db.Vital.Encounter.Link(db.Encounter.ID.Equals(input.EncounterID)))
+	}
+
 	if input.HeightCm != nil {
 		params = append(params, db.Vital.HeightCm.Set(*input.HeightCm))
 	}
@@ -77,14 +108,30 @@ func prepareVitalCreateParams(input *dto.CreateVitalReq) []db.VitalSetParam {
 		params = append(params, db.Vital.RespiratoryRate.Set(*input.RespiratoryRate))
 	}
 	if input.BloodPressureSystolic != nil {
-		params = append(params, db.Vital.BloodPressureSystolic.Set(*input.BloodPressureSystolic))
+		params = append(params, db.Vital.BloodPressureSystolic.Set(int(math.Round(*input.BloodPressureSystolic))))
 	}
 	if input.BloodPressureDiastolic != nil {
-		params = append(params, db.Vital.BloodPressureDiastolic.Set(*input.BloodPressureDiastolic))
+		params = append(params, db.Vital.BloodPressureDiastolic.Set(int(math.Round(*input.BloodPressureDiastolic))))
 	}
 	if input.OxygenSaturation != nil {
 		params = append(params, db.Vital.OxygenSaturation.Set(*input.OxygenSaturation))
 	}
+	if input.Consciousness != "" {
+		params = append(params, db.Vital.Consciousness.Set(input.Consciousness))
+	}
+	if input.SupplementalOxygen != nil {
+		params = append(params, db.Vital.SupplementalOxygen.Set(*input.SupplementalOxygen))
+	}
+
+	params = append(params, prepareNEWS2Params(clinical.NEWS2Input{
+		RespiratoryRate:    floatOf(input.RespiratoryRate),
+		OxygenSaturation:   input.OxygenSaturation,
+		SupplementalOxygen: input.SupplementalOxygen,
+		SystolicBP:         roundedOf(input.BloodPressureSystolic),
+		Pulse:              floatOf(input.Pulse),
+		Consciousness:      input.Consciousness,
+		TemperatureC:       input.TemperatureC,
+	})...)
 
 	return params
 }
@@ -117,20 +164,115 @@ func prepareVitalsUpdateParams(input *dto.UpdateVitalReq) []db.VitalSetParam {
 		with(*input.RespiratoryRate >= 0, db.Vital.RespiratoryRate.Set(*input.RespiratoryRate))
 	}
 	if input.BloodPressureSystolic != nil {
-		with(*input.BloodPressureSystolic >= 0, db.Vital.BloodPressureSystolic.Set(*input.BloodPressureSystolic))
+		with(*input.BloodPressureSystolic >= 0, db.Vital.BloodPressureSystolic.Set(int(math.Round(*input.BloodPressureSystolic))))
 	}
 	if input.BloodPressureDiastolic != nil {
-		with(*input.BloodPressureDiastolic >= 0, db.Vital.BloodPressureDiastolic.Set(*input.BloodPressureDiastolic))
+		with(*input.BloodPressureDiastolic >= 0, db.Vital.BloodPressureDiastolic.Set(int(math.Round(*input.BloodPressureDiastolic))))
 	}
 	if input.OxygenSaturation != nil {
 		with(*input.OxygenSaturation >= 0 && *input.OxygenSaturation <= 100, db.Vital.OxygenSaturation.Set(*input.OxygenSaturation))
 	}
+	if input.MeasuredAt != nil {
+		// the handler rejects times too far in the future
+		with(true, db.Vital.MeasuredAt.Set(*input.MeasuredAt))
+	}
+	if input.Consciousness != nil {
+		with(*input.Consciousness != "", db.Vital.Consciousness.Set(*input.Consciousness))
+	}
+	if input.SupplementalOxygen != nil {
+		with(true, db.Vital.SupplementalOxygen.Set(*input.SupplementalOxygen))
+	}
 
 	return params
 }
 
-func prepareAllergyUpdateParams(input *dto.UpdateAllergyReq) []db.AllergySetParam {
-	var params []db.AllergySetParam
+// prepareNEWS2Params stores the NEWS2 score of an observation, or clears it
+// when a parameter is missing.
+func prepareNEWS2Params(in clinical.NEWS2Input) []db.VitalSetParam {
+	score, ok := clinical.NEWS2(in)
+	if !ok {
+		return []db.VitalSetParam{
+			db.Vital.News2Score.SetOptional(nil),
+			db.Vital.News2Risk.SetOptional(nil),
+		}
+	}
+	return []db.VitalSetParam{
+		db.Vital.News2Score.Set(score.Total),
+		db.Vital.News2Risk.Set(string(score.Risk)),
+	}
+}
+
+// mergeNEWS2Input returns the NEWS2 parameters of a stored observation
+// after the corrections in req are applied.
+func mergeNEWS2Input(v *db.VitalModel, req *dto.UpdateVitalReq) clinical.NEWS2Input {
+	var in clinical.NEWS2Input
+	if rr, ok := v.RespiratoryRate(); ok {
+		in.RespiratoryRate = floatOf(&rr)
+	}
+	if spo2, ok := v.OxygenSaturation(); ok {
+		in.OxygenSaturation = &spo2
+	}
+	if o2, ok := v.SupplementalOxygen(); ok {
+		in.SupplementalOxygen = &o2
+	}
+	if sbp, ok := v.BloodPressureSystolic(); ok {
+		in.SystolicBP = floatOf(&sbp)
+	}
+	if pulse, ok := v.Pulse(); ok {
+		in.Pulse = floatOf(&pulse)
+	}
+	if avpu, ok := v.Consciousness(); ok {
+		in.Consciousness = avpu
+	}
+	if temperature, ok := v.TemperatureC(); ok {
+		in.TemperatureC = &temperature
+	}
+
+	if req.RespiratoryRate != nil {
+		in.RespiratoryRate = floatOf(req.RespiratoryRate)
+	}
+	if req.OxygenSaturation != nil {
+		in.OxygenSaturation = req.OxygenSaturation
+	}
+	if req.SupplementalOxygen != nil {
+		in.SupplementalOxygen = req.SupplementalOxygen
+	}
+	if req.BloodPressureSystolic != nil {
+		in.SystolicBP = roundedOf(req.BloodPressureSystolic)
+	}
+	if req.Pulse != nil {
+		in.Pulse = floatOf(req.Pulse)
+	}
+	if req.Consciousness != nil && *req.Consciousness != "" {
+		in.Consciousness = *req.Consciousness
+	}
+	if req.TemperatureC != nil {
+		in.TemperatureC = req.TemperatureC
+	}
+	return in
+}
+
+func floatOf(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+// roundedOf rounds a blood pressure the way it is stored.
+func roundedOf(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	r := math.Round(*v)
+	return &r
+}
+
+func prepareAllergyUpdateParams(input *dto.UpdateAllergyReq) ([]db.AllergySetParam, error) {
+	params := []db.AllergySetParam{
+		db.Allergy.UpdatedAt.Set(time.Now()),
+	}
 
 	with := func(ok bool, p db.AllergySetParam) {
 		if ok {
@@ -151,10 +293,215 @@ func prepareAllergyUpdateParams(input *dto.UpdateAllergyReq) []db.AllergySetPara
 		}
 	}
 
-	if input.Reaction != nil {
+	// The reaction summary is set as given when the reactions are replaced,
+	// even when it's empty because they were cleared.
+	if input.Reactions != nil {
+		reactions, err := json.Marshal(input.Reactions)
+		if err != nil {
+			return nil, err
+		}
+		with(true, db.Allergy.Reactions.Set(reactions))
+		if input.Reaction != nil {
+			with(true, db.Allergy.Reaction.Set(*input.Reaction))
+		}
+	} else if input.Reaction != nil {
 		trimmed := strings.TrimSpace(*input.Reaction)
 		with(len(trimmed) >= 2 && len(trimmed) <= 255, db.Allergy.Reaction.Set(trimmed))
 	}
 
-	return params
+	if input.Category != nil {
+		with(true, db.Allergy.Category.Set(*input.Category))
+	}
+
+	if input.Substance != nil {
+		with(true, db.Allergy.SubstanceSystem.Set(input.Substance.System))
+		with(true, db.Allergy.SubstanceCode.Set(input.Substance.Code))
+		with(true, db.Allergy.SubstanceDisplay.Set(input.Substance.Display))
+	}
+
+	if input.Criticality != nil {
+		with(true, db.Allergy.Criticality.Set(*input.Criticality))
+	}
+
+	if input.VerificationStatus != nil {
+		with(true, db.Allergy.VerificationStatus.Set(*input.VerificationStatus))
+	}
+
+	return params, nil
+}
+
+// startOfToday returns midnight UTC today, the date stored for "today".
+func startOfToday() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// queryArgs collects positional arguments for a raw SQL query.
+type queryArgs struct {
+	values []interface{}
+}
+
+// add appends v and returns its placeholder, e.g. "$3".
+func (q *queryArgs) add(v interface{}) string {
+	q.values = append(q.values, v)
+	return fmt.Sprintf("$%d", len(q.values))
+}
+
+var patientSortColumns = map[string]string{
+	"name":      `p."fullName"`,
+	"dob":       `p."dateOfBirth"`,
+	"createdAt": `p."createdAt"`,
+	"updatedAt": `p."updatedAt"`,
+}
+
+// phoneticKeys never returns nil so that the column is always an array.
+func phoneticKeys(name string) []string {
+	keys := matching.PhoneticKeys(name)
+	if keys == nil {
+		return []string{}
+	}
+	return keys
+}
+
+// preparePatientSearch returns the WHERE condition for a search term and the
+// SQL expression of its relevance score, which is NULL in contains mode.
+func preparePatientSearch(args *queryArgs, term, mode string) (string, string) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return "", "NULL::float8"
+	}
+
+	switch mode {
+	case "fuzzy":
+		ph := args.add(term)
+		cond := fmt.Sprintf(`(p."fullName" %% %[1]s OR %[1]s <%% p."fullName")`, ph)
+		score := fmt.Sprintf(`GREATEST(similarity(p."fullName", %[1]s), word_similarity(%[1]s, p."fullName"))::float8`, ph)
+		return cond, score
+
+	case "phonetic":
+		keys := matching.PhoneticKeys(term)
+		if len(keys) == 0 {
+			return "FALSE", "NULL::float8"
+		}
+		ph := args.add(strings.Join(keys, " "))
+		cond := fmt.Sprintf(`p."phoneticKeys" && string_to_array(%s, ' ')`, ph)
+		score := fmt.Sprintf(
+			`(cardinality(ARRAY(SELECT unnest(p."phoneticKeys") INTERSECT SELECT unnest(string_to_array(%s, ' '))))::float8 / %s)`,
+			ph, args.add(len(keys)),
+		)
+		return cond, score
+	}
+
+	ph := args.add("%" + term + "%")
+	cond := fmt.Sprintf(`(p."fullName" ILIKE %[1]s OR p.mrn ILIKE %[1]s OR p."contactNumber" LIKE %[1]s)`, ph)
+	return cond, "NULL::float8"
+}
+
+// preparePatientListFilters returns the WHERE clause of a patient list and
+// the relevance score expression of its search term.
+func preparePatientListFilters(args *queryArgs, req *dto.Paginate, f *dto.PatientFilter) (string, string) {
+	conds := []string{`p."mergedIntoId" IS NULL`}
+
+	search, score := preparePatientSearch(args, req.SearchTerm, req.SearchMode)
+	if search != "" {
+		conds = append(conds, search)
+	}
+
+	if f == nil {
+		return strings.Join(conds, " AND "), score
+	}
+
+	if f.Gender != "" {
+		conds = append(conds, `p.gender = `+args.add(f.Gender))
+	}
+
+	// Age is derived from the date of birth rather than the stored age, which
+	// is only correct on the day it was entered.
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if f.MinAge != nil {
+		conds = append(conds, `p."dateOfBirth" <= `+args.add(today.AddDate(-*f.MinAge, 0, 0)))
+	}
+	if f.MaxAge != nil {
+		conds = append(conds, `p."dateOfBirth" > `+args.add(today.AddDate(-*f.MaxAge-1, 0, 0)))
+	}
+
+	if f.DOBFrom != nil {
+		conds = append(conds, `p."dateOfBirth" >= `+args.add(*f.DOBFrom))
+	}
+	if f.DOBTo != nil {
+		conds = append(conds, `p."dateOfBirth" < `+args.add(f.DOBTo.AddDate(0, 0, 1)))
+	}
+
+	if f.RegisteredBy != "" {
+		conds = append(conds, `p."registeredById" = `+args.add(f.RegisteredBy))
+	}
+
+	if f.CreatedFrom != nil {
+		conds = append(conds, `p."createdAt" >= `+args.add(*f.CreatedFrom))
+	}
+	if f.CreatedTo != nil {
+		conds = append(conds, `p."createdAt" < `+args.add(f.CreatedTo.AddDate(0, 0, 1)))
+	}
+
+	if f.HasAllergy != nil {
+		conds = append(conds, existsClause(*f.HasAllergy, `SELECT 1 FROM "Allergy" a WHERE a."patientId" = p.id`))
+	}
+	if f.HasDiagnosis != nil {
+		conds = append(conds, existsClause(*f.HasDiagnosis, `SELECT 1 FROM "Diagnosis" d WHERE d."patientId" = p.id`))
+	}
+
+	return strings.Join(conds, " AND "), score
+}
+
+func existsClause(exists bool, subquery string) string {
+	if exists {
+		return "EXISTS (" + subquery + ")"
+	}
+	return "NOT EXISTS (" + subquery + ")"
+}
+
+// preparePatientListOrder builds the ORDER BY clause from whitelisted
+// columns only. The id tiebreaker keeps paging stable. Ranked searches are
+// ordered by relevance unless a sort field is given.
+func preparePatientListOrder(req *dto.Paginate, f *dto.PatientFilter) string {
+	ranked := req.SearchTerm != "" && (req.SearchMode == "fuzzy" || req.SearchMode == "phonetic")
+	if ranked && (f == nil || f.SortBy == "") {
+		return "score DESC, p.id ASC"
+	}
+
+	column, dir := patientSortColumns["createdAt"], "DESC"
+	if f != nil {
+		if c, ok := patientSortColumns[f.SortBy]; ok {
+			column = c
+		}
+		if f.SortDir == "asc" {
+			dir = "ASC"
+		}
+		// the oldest patient has the earliest date of birth
+		if f.SortBy == "age" {
+			column = patientSortColumns["dob"]
+			if dir == "ASC" {
+				dir = "DESC"
+			} else {
+				dir = "ASC"
+			}
+		}
+	}
+	return fmt.Sprintf("%s %s, p.id %s", column, dir, dir)
+}
+
+// reconcileBMI returns the BMI computed from height and weight. A supplied
+// BMI is only accepted when it agrees with the computed one.
+func reconcileBMI(heightCm, weightKg, supplied *float64) (*float64, error) {
+	if heightCm == nil || weightKg == nil {
+		if supplied != nil {
+			return nil, ErrBMIUnverifiable
+		}
+		return nil, nil
+	}
+
+	bmi := clinical.BMI(*heightCm, *weightKg)
+	if supplied != nil && !clinical.BMIConsistent(*supplied, bmi) {
+		return nil, ErrInconsistentBMI
+	}
+	return &bmi, nil
 }
@@ -0,0 +1,378 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrFamilyHistoryNotFound = errors.New("family history not found")
+	ErrSocialHistoryNotFound = errors.New("social history not found")
+
+	// ErrAgeAtDeathNotDeceased is returned when an age at death is given for
+	// a relative who isn't deceased.
+	ErrAgeAtDeathNotDeceased = errors.New("age at death of a relative who isn't deceased")
+
+	// ErrOnsetAfterDeath is returned when a relative's condition would have
+	// begun after they died.
+	ErrOnsetAfterDeath = errors.New("age at onset after the age at death")
+
+	// ErrSmokingAgeTooHigh is returned when a smoking age is above the
+	// patient's age.
+	ErrSmokingAgeTooHigh = errors.New("smoking age above the patient's age")
+)
+
+type FamilyHistory struct {
+	client *db.PrismaClient
+}
+
+// Add records a condition of a relative of the patient.
+func (s *FamilyHistory) Add(ctx context.Context, req *models.AddFamilyHistoryReq) (*models.FamilyHistory, error) {
+	if err := checkFamilyHistory(req.Deceased, req.AgeAtOnset, req.AgeAtDeath); err != nil {
+		return nil, err
+	}
+
+	optional := []db.FamilyHistorySetParam{
+		db.FamilyHistory.Deceased.Set(req.Deceased),
+	}
+	if req.Code != "" {
+		optional = append(optional,
+			db.FamilyHistory.Code.Set(req.Code),
+			db.FamilyHistory.Display.Set(req.Display),
+		)
+	}
+	if req.AgeAtOnset != nil {
+		optional = append(optional, db.FamilyHistory.AgeAtOnset.Set(*req.AgeAtOnset))
+	}
+	if req.AgeAtDeath != nil {
+		optional = append(optional, db.FamilyHistory.AgeAtDeath.Set(*req.AgeAtDeath))
+	}
+	if req.Notes != "" {
+		optional = append(optional, db.FamilyHistory.Notes.Set(req.Notes))
+	}
+	if req.RecordedByID != "" {
+		optional = append(optional, db.FamilyHistory.RecordedBy.Link(
+			db.User.ID.Equals(req.RecordedByID),
+		))
+	}
+
+	entry, err := s.client.FamilyHistory.CreateOne(
+		db.FamilyHistory.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.FamilyHistory.Relationship.Set(req.Relationship),
+		db.FamilyHistory.Condition.Set(req.Condition),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	return toFamilyHistoryModel(entry), nil
+}
+
+// Update corrects a relative's condition. Marking the relative as not
+// deceased clears their age at death.
+func (s *FamilyHistory) Update(ctx context.Context, req *models.UpdateFamilyHistoryReq) (*models.FamilyHistory, error) {
+	entry, err := s.client.FamilyHistory.FindUnique(
+		db.FamilyHistory.ID.Equals(req.ID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrFamilyHistoryNotFound
+		}
+		return nil, err
+	}
+
+	// the entry as it will be, to check the ages together
+	deceased := entry.Deceased
+	if req.Deceased != nil {
+		deceased = *req.Deceased
+	}
+	ageAtOnset, ageAtDeath := req.AgeAtOnset, req.AgeAtDeath
+	if v, ok := entry.AgeAtOnset(); ok && ageAtOnset == nil {
+		ageAtOnset = &v
+	}
+	if v, ok := entry.AgeAtDeath(); ok && ageAtDeath == nil && deceased {
+		ageAtDeath = &v
+	}
+	if err := checkFamilyHistory(deceased, ageAtOnset, ageAtDeath); err != nil {
+		return nil, err
+	}
+
+	update := []db.FamilyHistorySetParam{
+		db.FamilyHistory.UpdatedAt.Set(time.Now()),
+	}
+	if req.Relationship != nil {
+		update = append(update, db.FamilyHistory.Relationship.Set(*req.Relationship))
+	}
+	if req.Code != "" {
+		update = append(update,
+			db.FamilyHistory.Code.Set(req.Code),
+			db.FamilyHistory.Display.Set(req.Display),
+		)
+	}
+	if req.Condition != nil {
+		update = append(update, db.FamilyHistory.Condition.Set(*req.Condition))
+	}
+	if req.AgeAtOnset != nil {
+		update = append(update, db.FamilyHistory.AgeAtOnset.Set(*req.AgeAtOnset))
+	}
+	if req.Deceased != nil {
+		update = append(update, db.FamilyHistory.Deceased.Set(*req.Deceased))
+	}
+	if req.AgeAtDeath != nil || !deceased {
+		update = append(update, db.FamilyHistory.AgeAtDeath.SetOptional(ageAtDeath))
+	}
+	if req.Notes != nil {
+		update = append(update, db.FamilyHistory.Notes.Set(*req.Notes))
+	}
+
+	entry, err = s.client.FamilyHistory.FindUnique(
+		db.FamilyHistory.ID.Equals(req.ID),
+	).Update(
+		update...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrFamilyHistoryNotFound
+		}
+		return nil, err
+	}
+	return toFamilyHistoryModel(entry), nil
+}
+
+func (s *FamilyHistory) Delete(ctx context.Context, id string) error {
+	_, err := s.client.FamilyHistory.FindUnique(
+		db.FamilyHistory.ID.Equals(id),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrFamilyHistoryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// List returns the patient's family history, oldest first.
+func (s *FamilyHistory) List(ctx context.Context, pID string) ([]*models.FamilyHistory, error) {
+	entries, err := s.client.FamilyHistory.FindMany(
+		db.FamilyHistory.PatientID.Equals(pID),
+	).OrderBy(
+		db.FamilyHistory.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.FamilyHistory, 0, len(entries))
+	for i := range entries {
+		list = append(list, toFamilyHistoryModel(&entries[i]))
+	}
+	return list, nil
+}
+
+func checkFamilyHistory(deceased bool, ageAtOnset, ageAtDeath *int) error {
+	if ageAtDeath == nil {
+		return nil
+	}
+	if !deceased {
+		return ErrAgeAtDeathNotDeceased
+	}
+	if ageAtOnset != nil && *ageAtOnset > *ageAtDeath {
+		return ErrOnsetAfterDeath
+	}
+	return nil
+}
+
+func toFamilyHistoryModel(f *db.FamilyHistoryModel) *models.FamilyHistory {
+	entry := &models.FamilyHistory{
+		ID:           f.ID,
+		PatientID:    f.PatientID,
+		Relationship: f.Relationship,
+		Condition:    f.Condition,
+		Deceased:     f.Deceased,
+		CreatedAt:    f.CreatedAt,
+	}
+	if code, ok := f.Code(); ok {
+		entry.Code = code
+	}
+	if display, ok := f.Display(); ok {
+		entry.Display = display
+	}
+	if onset, ok := f.AgeAtOnset(); ok {
+		entry.AgeAtOnset = &onset
+	}
+	if death, ok := f.AgeAtDeath(); ok {
+		entry.AgeAtDeath = &death
+	}
+	if notes, ok := f.Notes(); ok {
+		entry.Notes = notes
+	}
+	if recordedBy, ok := f.RecordedByID(); ok {
+		entry.RecordedByID = recordedBy
+	}
+	if updatedAt, ok := f.UpdatedAt(); ok {
+		entry.UpdatedAt = &updatedAt
+	}
+	return entry
+}
+
+type SocialHistory struct {
+	client *db.PrismaClient
+}
+
+// Set records the patient's social history, replacing the one recorded
+// before.
+func (s *SocialHistory) Set(ctx context.Context, req *models.SetSocialHistoryReq) (*models.SocialHistory, error) {
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.PatientID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	age := clinical.AgeAt(patient.DateOfBirth, time.Now())
+	for _, a := range []*int{req.SmokingStartAge, req.SmokingQuitAge} {
+		if a != nil && float64(*a) > age {
+			return nil, ErrSmokingAgeTooHigh
+		}
+	}
+
+	var alcoholUse, occupation, notes *string
+	if req.AlcoholUse != "" {
+		alcoholUse = &req.AlcoholUse
+	}
+	if req.Occupation != "" {
+		occupation = &req.Occupation
+	}
+	if req.Notes != "" {
+		notes = &req.Notes
+	}
+
+	// every field is set so that a field left out clears the old value
+	fields := []db.SocialHistorySetParam{
+		db.SocialHistory.CigarettesPerDay.SetOptional(req.CigarettesPerDay),
+		db.SocialHistory.SmokingStartAge.SetOptional(req.SmokingStartAge),
+		db.SocialHistory.SmokingQuitAge.SetOptional(req.SmokingQuitAge),
+		db.SocialHistory.AlcoholUse.SetOptional(alcoholUse),
+		db.SocialHistory.AlcoholUnitsPerWeek.SetOptional(req.AlcoholUnitsPerWeek),
+		db.SocialHistory.Occupation.SetOptional(occupation),
+		db.SocialHistory.Notes.SetOptional(notes),
+	}
+	if req.RecordedByID != "" {
+		fields = append(fields, db.SocialHistory.RecordedBy.Link(
+			db.User.ID.Equals(req.RecordedByID),
+		))
+	}
+
+	history, err := s.client.SocialHistory.UpsertOne(
+		db.SocialHistory.PatientID.Equals(req.PatientID),
+	).Create(
+		db.SocialHistory.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.SocialHistory.SmokingStatus.Set(req.SmokingStatus),
+		fields...,
+	).Update(
+		append(fields, db.SocialHistory.SmokingStatus.Set(req.SmokingStatus))...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSocialHistoryModel(history, patient.DateOfBirth), nil
+}
+
+// Get returns the patient's social history.
+func (s *SocialHistory) Get(ctx context.Context, pID string) (*models.SocialHistory, error) {
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(pID),
+	).With(
+		db.Patient.SocialHistory.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	history, ok := patient.SocialHistory()
+	if !ok {
+		return nil, ErrSocialHistoryNotFound
+	}
+	return toSocialHistoryModel(history, patient.DateOfBirth), nil
+}
+
+// Delete removes the patient's social history.
+func (s *SocialHistory) Delete(ctx context.Context, pID string) error {
+	_, err := s.client.SocialHistory.FindUnique(
+		db.SocialHistory.PatientID.Equals(pID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrSocialHistoryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// toSocialHistoryModel converts a social history of a patient born on dob,
+// whose age counts the years a current smoker has smoked.
+func toSocialHistoryModel(h *db.SocialHistoryModel, dob time.Time) *models.SocialHistory {
+	history := &models.SocialHistory{
+		ID:            h.ID,
+		PatientID:     h.PatientID,
+		SmokingStatus: h.SmokingStatus,
+		CreatedAt:     h.CreatedAt,
+		UpdatedAt:     h.UpdatedAt,
+	}
+	if cigarettes, ok := h.CigarettesPerDay(); ok {
+		history.CigarettesPerDay = &cigarettes
+	}
+	if start, ok := h.SmokingStartAge(); ok {
+		history.SmokingStartAge = &start
+	}
+	if quit, ok := h.SmokingQuitAge(); ok {
+		history.SmokingQuitAge = &quit
+	}
+	if alcohol, ok := h.AlcoholUse(); ok {
+		history.AlcoholUse = alcohol
+	}
+	if units, ok := h.AlcoholUnitsPerWeek(); ok {
+		history.AlcoholUnitsPerWeek = &units
+	}
+	if occupation, ok := h.Occupation(); ok {
+		history.Occupation = occupation
+	}
+	if notes, ok := h.Notes(); ok {
+		history.Notes = notes
+	}
+	if recordedBy, ok := h.RecordedByID(); ok {
+		history.RecordedByID = recordedBy
+	}
+
+	if history.CigarettesPerDay != nil && history.SmokingStartAge != nil {
+		years, ok := clinical.SmokingYears(h.SmokingStatus, *history.SmokingStartAge,
+			history.SmokingQuitAge, clinical.AgeAt(dob, time.Now()))
+		if ok {
+			packYears := clinical.PackYears(*history.CigarettesPerDay, years)
+			history.PackYears = &packYears
+		}
+	}
+	return history
+}
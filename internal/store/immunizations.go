@@ -0,0 +1,273 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/immunization"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrImmunizationNotFound = errors.New("immunization not found")
+
+	// ErrDoseRecorded is returned when the dose of the vaccine was already
+	// recorded for the patient.
+	ErrDoseRecorded = errors.New("dose already recorded")
+
+	// ErrGivenBeforeBirth is returned when a dose would have been given
+	// before the patient was born.
+	ErrGivenBeforeBirth = errors.New("dose given before the patient's date of birth")
+)
+
+type Immunizations struct {
+	client *db.PrismaClient
+}
+
+// Record records a dose given to the patient. A dose of a vaccine can only
+// be recorded once.
+func (s *Immunizations) Record(ctx context.Context, req *models.RecordImmunizationReq) (*models.Immunization, error) {
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.PatientID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	administeredAt := time.Now()
+	if req.AdministeredAt != nil {
+		administeredAt = *req.AdministeredAt
+	}
+	if immunization.Date(administeredAt).Before(immunization.Date(patient.DateOfBirth)) {
+		return nil, ErrGivenBeforeBirth
+	}
+
+	var optional []db.ImmunizationSetParam
+	if req.Lot != "" {
+		optional = append(optional, db.Immunization.Lot.Set(req.Lot))
+	}
+	if req.Site != "" {
+		optional = append(optional, db.Immunization.Site.Set(req.Site))
+	}
+	if req.AdministeredByID != "" {
+		optional = append(optional, db.Immunization.AdministeredBy.Link(
+			db.User.ID.Equals(req.AdministeredByID),
+		))
+	}
+	if req.AdministeredByName != "" {
+		optional = append(optional, db.Immunization.AdministeredByName.Set(req.AdministeredByName))
+	}
+	if req.Notes != "" {
+		optional = append(optional, db.Immunization.Notes.Set(req.Notes))
+	}
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PatientID, req.EncounterID); err != nil {
+			return nil, err
+		}
+		optional = append(optional, db.Immunization.Encounter.Link(
+			db.Encounter.ID.Equals(req.EncounterID),
+		))
+	}
+
+	dose, err := s.client.Immunization.CreateOne(
+		db.Immunization.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.Immunization.Vaccine.Set(req.Vaccine),
+		db.Immunization.VaccineName.Set(req.VaccineName),
+		db.Immunization.DoseNumber.Set(req.DoseNumber),
+		db.Immunization.AdministeredAt.Set(administeredAt),
+		db.Immunization.RecordedBy.Link(
+			db.User.ID.Equals(req.RecordedByID),
+		),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		// the unique index keeps a dose recorded at the same time out
+		if _, ok := db.IsErrUniqueConstraint(err); ok {
+			return nil, ErrDoseRecorded
+		}
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toImmunizationModel(dose), nil
+}
+
+// List returns the doses given to the patient, the most recent first.
+func (s *Immunizations) List(ctx context.Context, pID string) ([]*models.Immunization, error) {
+	doses, err := s.client.Immunization.FindMany(
+		db.Immunization.PatientID.Equals(pID),
+	).OrderBy(
+		db.Immunization.AdministeredAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.Immunization, 0, len(doses))
+	for i := range doses {
+		list = append(list, toImmunizationModel(&doses[i]))
+	}
+	return list, nil
+}
+
+// Delete removes a dose recorded by mistake.
+func (s *Immunizations) Delete(ctx context.Context, id string) error {
+	_, err := s.client.Immunization.FindUnique(
+		db.Immunization.ID.Equals(id),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrImmunizationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Status works out the schedule for the patient as of day.
+func (s *Immunizations) Status(ctx context.Context, pID string, day time.Time) (*models.ImmunizationStatus, error) {
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(pID),
+	).With(
+		db.Patient.Immunizations.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	status := &models.ImmunizationStatus{
+		PatientID: pID,
+		AsOf:      immunization.Date(day),
+		Schedule:  immunization.Current().Name,
+		Doses:     immunization.Evaluate(patient.DateOfBirth, givenDoses(patient.Immunizations()), day),
+	}
+	for _, d := range status.Doses {
+		switch d.Status {
+		case immunization.StatusDue:
+			status.Due++
+		case immunization.StatusOverdue:
+			status.Overdue++
+		}
+	}
+	return status, nil
+}
+
+// Overdue lists the patients who are overdue for a dose as of req.AsOf,
+// those overdue longest first. Only patients young enough for a dose of the
+// schedule are looked at.
+func (s *Immunizations) Overdue(ctx context.Context, req *models.OverdueQuery) (*models.OverdueReport, error) {
+	patients, err := s.client.Patient.FindMany(
+		db.Patient.DateOfBirth.Gte(immunization.Eligible(req.AsOf)),
+	).With(
+		db.Patient.Immunizations.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.OverdueReport{
+		AsOf:     immunization.Date(req.AsOf),
+		Patients: []models.OverduePatient{},
+	}
+	for i := range patients {
+		p := &patients[i]
+		if _, merged := p.MergedIntoID(); merged {
+			continue
+		}
+
+		var overdue []immunization.DoseStatus
+		for _, d := range immunization.Evaluate(p.DateOfBirth, givenDoses(p.Immunizations()), req.AsOf) {
+			if d.Status == immunization.StatusOverdue && (req.Vaccine == "" || d.Vaccine == req.Vaccine) {
+				overdue = append(overdue, d)
+			}
+		}
+		if len(overdue) == 0 {
+			continue
+		}
+
+		item := models.OverduePatient{
+			PatientID:     p.ID,
+			FullName:      p.FullName,
+			DateOfBirth:   p.DateOfBirth,
+			ContactNumber: p.ContactNumber,
+			Doses:         overdue,
+		}
+		if mrn, ok := p.Mrn(); ok {
+			item.MRN = mrn
+		}
+		report.Patients = append(report.Patients, item)
+	}
+
+	// the first dose of a patient is the one overdue longest
+	slices.SortStableFunc(report.Patients, func(a, b models.OverduePatient) int {
+		return earliestOverdue(a.Doses).Compare(earliestOverdue(b.Doses))
+	})
+	return report, nil
+}
+
+func earliestOverdue(doses []immunization.DoseStatus) time.Time {
+	earliest := doses[0].OverdueDate
+	for _, d := range doses[1:] {
+		if d.OverdueDate.Before(earliest) {
+			earliest = d.OverdueDate
+		}
+	}
+	return earliest
+}
+
+func givenDoses(doses []db.ImmunizationModel) []immunization.Given {
+	given := make([]immunization.Given, 0, len(doses))
+	for _, d := range doses {
+		given = append(given, immunization.Given{
+			Vaccine: d.Vaccine,
+			Dose:    d.DoseNumber,
+			On:      d.AdministeredAt,
+		})
+	}
+	return given
+}
+
+func toImmunizationModel(i *db.ImmunizationModel) *models.Immunization {
+	dose := &models.Immunization{
+		ID:             i.ID,
+		PatientID:      i.PatientID,
+		Vaccine:        i.Vaccine,
+		VaccineName:    i.VaccineName,
+		DoseNumber:     i.DoseNumber,
+		AdministeredAt: i.AdministeredAt,
+		RecordedByID:   i.RecordedByID,
+		CreatedAt:      i.CreatedAt,
+	}
+	if encounterID, ok := i.EncounterID(); ok {
+		dose.EncounterID = encounterID
+	}
+	if lot, ok := i.Lot(); ok {
+		dose.Lot = lot
+	}
+	if site, ok := i.Site(); ok {
+		dose.Site = site
+	}
+	if administeredBy, ok := i.AdministeredByID(); ok {
+		dose.AdministeredByID = administeredBy
+	}
+	if name, ok := i.AdministeredByName(); ok {
+		dose.AdministeredByName = name
+	}
+	if notes, ok := i.Notes(); ok {
+		dose.Notes = notes
+	}
+	return dose
+}
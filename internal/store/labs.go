@@ -0,0 +1,629 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/labs"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrLabOrderNotFound = errors.New("lab order not found")
+
+	// ErrLabOrderCancelled is returned when results are added to a cancelled
+	// order, or when it is cancelled again.
+	ErrLabOrderCancelled = errors.New("lab order was cancelled")
+
+	// ErrLabOrderHasResults is returned when an order with results would be
+	// cancelled.
+	ErrLabOrderHasResults = errors.New("lab order has results and can't be cancelled")
+
+	// ErrTestNotOrdered is returned when a result is added to an order that
+	// doesn't include its test.
+	ErrTestNotOrdered = errors.New("test is not part of the lab order")
+
+	// ErrLabResultExists is returned when the test of an order already has a
+	// result.
+	ErrLabResultExists = errors.New("test of the lab order already has a result")
+)
+
+// LabResultError is returned when one of a batch of results can't be
+// stored. Index is its position in the batch.
+type LabResultError struct {
+	Index int
+	Err   error
+}
+
+func (e *LabResultError) Error() string {
+	return fmt.Sprintf("result %d: %v", e.Index, e.Err)
+}
+
+func (e *LabResultError) Unwrap() error {
+	return e.Err
+}
+
+type Labs struct {
+	client *db.PrismaClient
+}
+
+// Order orders tests for the patient. The tests are catalogue codes with
+// panels already expanded.
+func (s *Labs) Order(ctx context.Context, req *models.CreateLabOrderReq) (*models.LabOrder, error) {
+	optional := []db.LabOrderSetParam{
+		db.LabOrder.Tests.Set(req.Tests),
+	}
+	if req.Priority != "" {
+		optional = append(optional, db.LabOrder.Priority.Set(req.Priority))
+	}
+	if req.Notes != "" {
+		optional = append(optional, db.LabOrder.Notes.Set(req.Notes))
+	}
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PatientID, req.EncounterID); err != nil {
+			return nil, err
+		}
+		optional = append(optional, db.LabOrder.Encounter.Link(
+			db.Encounter.ID.Equals(req.EncounterID),
+		))
+	}
+
+	order, err := s.client.LabOrder.CreateOne(
+		db.LabOrder.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.LabOrder.OrderedBy.Link(
+			db.User.ID.Equals(req.OrderedByID),
+		),
+		optional...,
+	).With(
+		labOrderResults(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	return toLabOrderModel(order), nil
+}
+
+// GetOrder returns a lab order with its results.
+func (s *Labs) GetOrder(ctx context.Context, orderID string) (*models.LabOrder, error) {
+	order, err := s.client.LabOrder.FindUnique(
+		db.LabOrder.ID.Equals(orderID),
+	).With(
+		labOrderResults(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrLabOrderNotFound
+		}
+		return nil, err
+	}
+
+	return toLabOrderModel(order), nil
+}
+
+// ListOrders returns the patient's lab orders with their results, the most
+// recent first.
+func (s *Labs) ListOrders(ctx context.Context, req *models.LabOrderQuery) ([]*models.LabOrder, error) {
+	params := []db.LabOrderWhereParam{
+		db.LabOrder.PatientID.Equals(req.PatientID),
+	}
+	if req.Status != "" {
+		params = append(params, db.LabOrder.Status.Equals(req.Status))
+	}
+
+	records, err := s.client.LabOrder.FindMany(
+		params...,
+	).With(
+		labOrderResults(),
+	).OrderBy(
+		db.LabOrder.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*models.LabOrder, 0, len(records))
+	for i := range records {
+		orders = append(orders, toLabOrderModel(&records[i]))
+	}
+	return orders, nil
+}
+
+// CancelOrder cancels an order none of whose tests have a result yet.
+func (s *Labs) CancelOrder(ctx context.Context, orderID string) (*models.LabOrder, error) {
+	// only an order still waiting for all of its results is cancelled
+	res, err := s.client.LabOrder.FindMany(
+		db.LabOrder.ID.Equals(orderID),
+		db.LabOrder.Status.Equals(labs.StatusOrdered),
+	).Update(
+		db.LabOrder.Status.Set(labs.StatusCancelled),
+		db.LabOrder.UpdatedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		if order.Status == labs.StatusCancelled {
+			return nil, ErrLabOrderCancelled
+		}
+		return nil, ErrLabOrderHasResults
+	}
+	return order, nil
+}
+
+// labOrderOpenQuery locks the lab order $1 and fails the transaction it
+// runs in when the order has status $2, i.e. was cancelled since it was
+// read.
+const labOrderOpenQuery = `
+	WITH locked AS (
+		SELECT id
+		FROM "LabOrder"
+		WHERE id = $1 AND status <> $2
+		FOR UPDATE
+	)
+	SELECT 1 / COUNT(*) AS open_order FROM locked;
+`
+
+// labOrderStatusQuery moves the lab order $1 on from the results stored for
+// its tests, including those committed by other batches: to $3 without any,
+// $4 with some and $5 with all of them. An order with status $2, i.e.
+// cancelled, is left alone.
+const labOrderStatusQuery = `
+	UPDATE "LabOrder" o
+	SET status = CASE
+			WHEN done.tests = 0 THEN $3
+			WHEN done.tests < cardinality(o.tests) THEN $4
+			ELSE $5
+		END,
+		"updatedAt" = $6
+	FROM (
+		SELECT COUNT(DISTINCT r."testCode") AS tests
+		FROM "LabResult" r
+		JOIN "LabOrder" t ON t.id = r."orderId"
+		WHERE r."orderId" = $1 AND r."testCode" = ANY(t.tests)
+	) done
+	WHERE o.id = $1 AND o.status <> $2;
+`
+
+// AddResults stores a batch of results in one transaction and moves the
+// orders they belong to on. The values have been converted to the units of
+// their tests. Results whose external ID was already received are skipped,
+// and the batch fails with ErrLabOrderCancelled when one of its orders is
+// cancelled before it is stored.
+func (s *Labs) AddResults(ctx context.Context, req *models.AddLabResultsReq) (*models.AddLabResultsRes, error) {
+	res := &models.AddLabResultsRes{
+		Results: []models.LabResult{},
+		Skipped: []string{},
+	}
+
+	received, err := s.receivedResults(ctx, req.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make(map[string]*db.LabOrderModel)
+	resulted := make(map[string][]string)
+	patients := make(map[string]*db.PatientModel)
+
+	var creates []db.LabResultUniqueTxResult
+	for i := range req.Results {
+		in := &req.Results[i]
+		if in.ExternalID != "" {
+			if received[in.ExternalID] {
+				res.Skipped = append(res.Skipped, in.ExternalID)
+				continue
+			}
+			received[in.ExternalID] = true
+		}
+
+		if in.OrderID != "" {
+			order, ok := orders[in.OrderID]
+			if !ok {
+				order, err = s.client.LabOrder.FindUnique(
+					db.LabOrder.ID.Equals(in.OrderID),
+				).With(
+					db.LabOrder.Results.Fetch(),
+				).Exec(ctx)
+				if err != nil {
+					if ok := db.IsErrNotFound(err); ok {
+						return nil, &LabResultError{Index: i, Err: ErrLabOrderNotFound}
+					}
+					return nil, err
+				}
+				orders[in.OrderID] = order
+				for _, r := range order.Results() {
+					resulted[order.ID] = append(resulted[order.ID], r.TestCode)
+				}
+			}
+
+			switch {
+			case in.PatientID != "" && in.PatientID != order.PatientID:
+				return nil, &LabResultError{Index: i, Err: ErrLabOrderNotFound}
+			case order.Status == labs.StatusCancelled:
+				return nil, &LabResultError{Index: i, Err: ErrLabOrderCancelled}
+			case !slices.Contains(order.Tests, in.TestCode):
+				return nil, &LabResultError{Index: i, Err: ErrTestNotOrdered}
+			case slices.Contains(resulted[order.ID], in.TestCode):
+				return nil, &LabResultError{Index: i, Err: ErrLabResultExists}
+			}
+			in.PatientID = order.PatientID
+			resulted[order.ID] = append(resulted[order.ID], in.TestCode)
+		}
+
+		patient, ok := patients[in.PatientID]
+		if !ok {
+			patient, err = s.client.Patient.FindUnique(
+				db.Patient.ID.Equals(in.PatientID),
+			).Exec(ctx)
+			if err != nil {
+				if ok := db.IsErrNotFound(err); ok {
+					return nil, &LabResultError{Index: i, Err: ErrPatientNotFound}
+				}
+				return nil, err
+			}
+			patients[in.PatientID] = patient
+		}
+
+		test, ok := labs.Lookup(in.TestCode)
+		if !ok {
+			return nil, &LabResultError{Index: i, Err: fmt.Errorf("unknown lab test %s", in.TestCode)}
+		}
+
+		collectedAt := time.Now()
+		if in.CollectedAt != nil {
+			collectedAt = *in.CollectedAt
+		}
+
+		optional := prepareLabResultParams(test, in, patient.Gender, clinical.AgeAt(patient.DateOfBirth, collectedAt))
+		optional = append(optional, db.LabResult.Source.Set(req.Source))
+		if in.OrderID != "" {
+			optional = append(optional, db.LabResult.Order.Link(
+				db.LabOrder.ID.Equals(in.OrderID),
+			))
+		}
+		if in.ExternalID != "" {
+			optional = append(optional, db.LabResult.ExternalID.Set(in.ExternalID))
+		}
+		if req.EnteredByID != "" {
+			optional = append(optional, db.LabResult.EnteredBy.Link(
+				db.User.ID.Equals(req.EnteredByID),
+			))
+		}
+
+		creates = append(creates, s.client.LabResult.CreateOne(
+			db.LabResult.Patient.Link(
+				db.Patient.ID.Equals(in.PatientID),
+			),
+			db.LabResult.TestCode.Set(test.Code),
+			db.LabResult.TestName.Set(test.Name),
+			db.LabResult.CollectedAt.Set(collectedAt),
+			optional...,
+		).Tx())
+	}
+	if len(creates) == 0 {
+		return res, nil
+	}
+
+	// the orders are locked in the same order by every batch, so that
+	// batches for the same orders wait for each other instead of deadlocking
+	orderIDs := slices.Sorted(maps.Keys(orders))
+
+	txs := make([]db.PrismaTransaction, 0, len(creates)+2*len(orderIDs))
+	for _, id := range orderIDs {
+		txs = append(txs, s.client.Prisma.QueryRaw(labOrderOpenQuery, id, labs.StatusCancelled).Tx())
+	}
+	for _, create := range creates {
+		txs = append(txs, create)
+	}
+	now := time.Now().UTC()
+	for _, id := range orderIDs {
+		txs = append(txs, s.client.Prisma.ExecuteRaw(labOrderStatusQuery, id, labs.StatusCancelled,
+			labs.StatusOrdered, labs.StatusPartial, labs.StatusCompleted, now).Tx())
+	}
+
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if _, ok := db.IsErrUniqueConstraint(err); ok {
+			return nil, ErrLabResultExists
+		}
+		if cancelled, rerr := s.anyCancelled(ctx, orderIDs); rerr == nil && cancelled {
+			return nil, ErrLabOrderCancelled
+		}
+		return nil, err
+	}
+
+	for _, create := range creates {
+		res.Results = append(res.Results, *toLabResultModel(create.Result()))
+	}
+	return res, nil
+}
+
+// anyCancelled reports whether any of the lab orders is cancelled.
+func (s *Labs) anyCancelled(ctx context.Context, orderIDs []string) (bool, error) {
+	if len(orderIDs) == 0 {
+		return false, nil
+	}
+	cancelled, err := s.client.LabOrder.FindMany(
+		db.LabOrder.ID.In(orderIDs),
+		db.LabOrder.Status.Equals(labs.StatusCancelled),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return len(cancelled) > 0, nil
+}
+
+// receivedResults returns the external IDs of the results that were already
+// stored.
+func (s *Labs) receivedResults(ctx context.Context, results []models.LabResultInput) (map[string]bool, error) {
+	received := make(map[string]bool)
+
+	var external []string
+	for _, in := range results {
+		if in.ExternalID != "" {
+			external = append(external, in.ExternalID)
+		}
+	}
+	if len(external) == 0 {
+		return received, nil
+	}
+
+	existing, err := s.client.LabResult.FindMany(
+		db.LabResult.ExternalID.In(external),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range existing {
+		if id, ok := r.ExternalID(); ok {
+			received[id] = true
+		}
+	}
+	return received, nil
+}
+
+// Cumulative returns the patient's results as a table with a row per test,
+// in catalogue order, and a column per collection time, oldest first.
+func (s *Labs) Cumulative(ctx context.Context, req *models.LabQuery) (*models.LabTable, error) {
+	_, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.PatientID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	params := []db.LabResultWhereParam{
+		db.LabResult.PatientID.Equals(req.PatientID),
+	}
+	if len(req.Tests) > 0 {
+		params = append(params, db.LabResult.TestCode.In(req.Tests))
+	}
+	if req.From != nil {
+		params = append(params, db.LabResult.CollectedAt.Gte(*req.From))
+	}
+	if req.To != nil {
+		params = append(params, db.LabResult.CollectedAt.Lte(*req.To))
+	}
+
+	results, err := s.client.LabResult.FindMany(
+		params...,
+	).OrderBy(
+		db.LabResult.CollectedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toLabTable(req.PatientID, results), nil
+}
+
+// prepareLabResultParams sets the value of a result and flags it. A
+// quantitative result is flagged against the range the lab reported it
+// with, keeping the critical limits of the catalogue, or else against the
+// catalogue's range for the patient.
+func prepareLabResultParams(test labs.Test, in *models.LabResultInput, sex string, age float64) []db.LabResultSetParam {
+	var params []db.LabResultSetParam
+
+	if test.Qualitative() {
+		params = append(params, db.LabResult.ValueText.Set(strings.TrimSpace(in.ValueText)))
+		if len(test.NormalValues) > 0 {
+			params = append(params, db.LabResult.ReferenceText.Set(strings.Join(test.NormalValues, ", ")))
+		}
+		if flag := test.Interpret(in.ValueText); flag != "" {
+			params = append(params, db.LabResult.Flag.Set(string(flag)))
+		}
+		return params
+	}
+
+	params = append(params,
+		db.LabResult.Value.Set(*in.Value),
+		db.LabResult.Unit.Set(test.Unit),
+	)
+
+	rr := test.Range(sex, age)
+	if in.ReferenceLow != nil || in.ReferenceHigh != nil {
+		reported := clinical.ReferenceRange{Low: in.ReferenceLow, High: in.ReferenceHigh}
+		if rr != nil {
+			reported.CriticalLow, reported.CriticalHigh = rr.CriticalLow, rr.CriticalHigh
+		}
+		rr = &reported
+	}
+	if rr == nil {
+		return params
+	}
+
+	if rr.Low != nil {
+		params = append(params, db.LabResult.ReferenceLow.Set(*rr.Low))
+	}
+	if rr.High != nil {
+		params = append(params, db.LabResult.ReferenceHigh.Set(*rr.High))
+	}
+	return append(params, db.LabResult.Flag.Set(string(rr.Flag(*in.Value))))
+}
+
+// labOrderResults fetches the results of an order, oldest first.
+func labOrderResults() db.LabOrderRelationWith {
+	return db.LabOrder.Results.Fetch().OrderBy(
+		db.LabResult.CollectedAt.Order(db.SortOrderAsc),
+	)
+}
+
+// toLabTable lays out results, ordered by collection time, in a cumulative
+// table. Of two results of a test collected at the same time the later
+// stored one is kept.
+func toLabTable(pID string, results []db.LabResultModel) *models.LabTable {
+	table := &models.LabTable{
+		PatientID: pID,
+		Columns:   []time.Time{},
+		Rows:      []models.LabTableRow{},
+	}
+
+	column := make(map[time.Time]int)
+	for _, r := range results {
+		at := r.CollectedAt.UTC()
+		if _, ok := column[at]; !ok {
+			column[at] = len(table.Columns)
+			table.Columns = append(table.Columns, at)
+		}
+	}
+
+	rows := make(map[string]*models.LabTableRow)
+	var codes []string
+	for i := range results {
+		r := &results[i]
+		row, ok := rows[r.TestCode]
+		if !ok {
+			row = &models.LabTableRow{
+				TestCode: r.TestCode,
+				TestName: r.TestName,
+				Cells:    make([]*models.LabTableCell, len(table.Columns)),
+			}
+			rows[r.TestCode] = row
+			codes = append(codes, r.TestCode)
+		}
+
+		cell := &models.LabTableCell{ResultID: r.ID}
+		if value, ok := r.Value(); ok {
+			cell.Value = &value
+		}
+		if text, ok := r.ValueText(); ok {
+			cell.ValueText = text
+		}
+		if flag, ok := r.Flag(); ok {
+			cell.Flag = flag
+		}
+		if unit, ok := r.Unit(); ok {
+			row.Unit = unit
+		}
+		row.Cells[column[r.CollectedAt.UTC()]] = cell
+	}
+
+	// tests no longer in the catalogue come last
+	slices.SortFunc(codes, func(a, b string) int {
+		pa, pb := labs.Position(a), labs.Position(b)
+		switch {
+		case pa == pb:
+			return strings.Compare(a, b)
+		case pa < 0:
+			return 1
+		case pb < 0:
+			return -1
+		}
+		return pa - pb
+	})
+	for _, code := range codes {
+		table.Rows = append(table.Rows, *rows[code])
+	}
+	return table
+}
+
+func toLabOrderModel(o *db.LabOrderModel) *models.LabOrder {
+	order := &models.LabOrder{
+		ID:          o.ID,
+		PatientID:   o.PatientID,
+		OrderedByID: o.OrderedByID,
+		Tests:       o.Tests,
+		Priority:    o.Priority,
+		Status:      o.Status,
+		Results:     []models.LabResult{},
+		CreatedAt:   o.CreatedAt,
+	}
+	if order.Tests == nil {
+		order.Tests = []string{}
+	}
+	if encounterID, ok := o.EncounterID(); ok {
+		order.EncounterID = encounterID
+	}
+	if notes, ok := o.Notes(); ok {
+		order.Notes = notes
+	}
+	if updatedAt, ok := o.UpdatedAt(); ok {
+		order.UpdatedAt = &updatedAt
+	}
+	for i := range o.Results() {
+		order.Results = append(order.Results, *toLabResultModel(&o.Results()[i]))
+	}
+	return order
+}
+
+func toLabResultModel(r *db.LabResultModel) *models.LabResult {
+	result := &models.LabResult{
+		ID:          r.ID,
+		PatientID:   r.PatientID,
+		TestCode:    r.TestCode,
+		TestName:    r.TestName,
+		Source:      r.Source,
+		CollectedAt: r.CollectedAt,
+		CreatedAt:   r.CreatedAt,
+	}
+	if orderID, ok := r.OrderID(); ok {
+		result.OrderID = orderID
+	}
+	if value, ok := r.Value(); ok {
+		result.Value = &value
+	}
+	if text, ok := r.ValueText(); ok {
+		result.ValueText = text
+	}
+	if unit, ok := r.Unit(); ok {
+		result.Unit = unit
+	}
+	if low, ok := r.ReferenceLow(); ok {
+		result.ReferenceLow = &low
+	}
+	if high, ok := r.ReferenceHigh(); ok {
+		result.ReferenceHigh = &high
+	}
+	if text, ok := r.ReferenceText(); ok {
+		result.ReferenceText = text
+	}
+	if flag, ok := r.Flag(); ok {
+		result.Flag = flag
+	}
+	if externalID, ok := r.ExternalID(); ok {
+		result.ExternalID = externalID
+	}
+	if enteredBy, ok := r.EnteredByID(); ok {
+		result.EnteredByID = enteredBy
+	}
+	return result
+}
@@ -0,0 +1,371 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrMedicationNotFound = errors.New("medication not found")
+
+	// ErrMedicationTransition is returned when a medication can't move from
+	// its status to the requested one, e.g. once it was stopped.
+	ErrMedicationTransition = errors.New("medication can't move to the requested status")
+
+	// ErrStopBeforeStart is returned when a medication would stop before it
+	// started.
+	ErrStopBeforeStart = errors.New("medication stop date is before its start date")
+)
+
+type Medications struct {
+	client *db.PrismaClient
+}
+
+// currentMedications filters the medications on the patient's current
+// list: active or on hold, and not past their stop date.
+func currentMedications() []db.MedicationWhereParam {
+	return []db.MedicationWhereParam{
+		db.Medication.Status.In([]string{
+			clinical.MedicationActive,
+			clinical.MedicationOnHold,
+		}),
+		notPastStopDate(),
+	}
+}
+
+// notPastStopDate filters out the medications whose stop date has passed.
+func notPastStopDate() db.MedicationWhereParam {
+	return db.Medication.Or(
+		db.Medication.StopDate.IsNull(),
+		db.Medication.StopDate.Gte(startOfToday()),
+	)
+}
+
+// medicationStatus returns the medication's status today. See
+// clinical.MedicationStatusOn.
+func medicationStatus(m *db.MedicationModel) string {
+	var stop *time.Time
+	if s, ok := m.StopDate(); ok {
+		stop = &s
+	}
+	return clinical.MedicationStatusOn(m.Status, stop, startOfToday())
+}
+
+// courseEnd returns the stop date of a course of days starting on start.
+func courseEnd(start time.Time, days int) time.Time {
+	return start.AddDate(0, 0, days)
+}
+
+// Prescribe adds a medication to the patient's list. Without a stop date a
+// course with a duration stops after it.
+func (s *Medications) Prescribe(ctx context.Context, req *models.PrescribeMedicationReq) (*models.Medication, error) {
+	start := startOfToday()
+	if req.StartDate != nil {
+		start = time.Time(*req.StartDate)
+	}
+
+	var stop *time.Time
+	switch {
+	case req.StopDate != nil:
+		t := time.Time(*req.StopDate)
+		stop = &t
+	case req.DurationDays != nil:
+		t := courseEnd(start, *req.DurationDays)
+		stop = &t
+	}
+	if stop != nil && stop.Before(start) {
+		return nil, ErrStopBeforeStart
+	}
+
+	warnings, err := json.Marshal(req.InteractionWarnings)
+	if err != nil {
+		return nil, err
+	}
+
+	optional := []db.MedicationSetParam{
+		db.Medication.StartDate.Set(start),
+		db.Medication.InteractionWarnings.Set(warnings),
+	}
+	if req.OverrideReason != "" {
+		optional = append(optional, db.Medication.OverrideReason.Set(req.OverrideReason))
+	}
+	if stop != nil {
+		optional = append(optional, db.Medication.StopDate.Set(*stop))
+	}
+	if req.Drug != nil {
+		optional = append(optional,
+			db.Medication.DrugSystem.Set(req.Drug.System),
+			db.Medication.DrugCode.Set(req.Drug.Code),
+			db.Medication.DrugDisplay.Set(req.Drug.Display),
+		)
+	}
+	if req.DurationDays != nil {
+		optional = append(optional, db.Medication.DurationDays.Set(*req.DurationDays))
+	}
+	if req.Instructions != "" {
+		optional = append(optional, db.Medication.Instructions.Set(req.Instructions))
+	}
+	if req.PrescriberID != "" {
+		optional = append(optional, db.Medication.Prescriber.Link(
+			db.User.ID.Equals(req.PrescriberID),
+		))
+	}
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PatientID, req.EncounterID); err != nil {
+			return nil, err
+		}
+		optional = append(optional, db.Medication.Encounter.Link(
+			db.Encounter.ID.Equals(req.EncounterID),
+		))
+	}
+
+	med, err := s.client.Medication.CreateOne(
+		db.Medication.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.Medication.DrugName.Set(req.DrugName),
+		db.Medication.DoseValue.Set(req.DoseValue),
+		db.Medication.DoseUnit.Set(req.DoseUnit),
+		db.Medication.Route.Set(req.Route),
+		db.Medication.Frequency.Set(req.Frequency),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	return toMedicationModel(med)
+}
+
+// Get returns a medication.
+func (s *Medications) Get(ctx context.Context, mID string) (*models.Medication, error) {
+	med, err := s.client.Medication.FindUnique(
+		db.Medication.ID.Equals(mID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrMedicationNotFound
+		}
+		return nil, err
+	}
+	return toMedicationModel(med)
+}
+
+// Update changes a medication's dose, schedule or status. Stopping or
+// completing a medication sets its stop date to today unless it already
+// stopped earlier. Warnings checked on restarting it replace the ones
+// raised before.
+func (s *Medications) Update(ctx context.Context, req *models.UpdateMedicationReq) (*models.Medication, error) {
+	current, err := s.client.Medication.FindUnique(
+		db.Medication.ID.Equals(req.MedicationID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrMedicationNotFound
+		}
+		return nil, err
+	}
+
+	// a course past its stop date is completed, and kept as it was
+	status := medicationStatus(current)
+	if req.Status != nil && *req.Status != status {
+		if !clinical.CanTransitionMedication(status, *req.Status) {
+			return nil, ErrMedicationTransition
+		}
+		status = *req.Status
+	} else if !clinical.IsMedicationCurrent(status) {
+		// A finished prescription is kept as it was; a new one is prescribed
+		// instead.
+		return nil, ErrMedicationTransition
+	}
+
+	update := []db.MedicationSetParam{
+		db.Medication.Status.Set(status),
+		db.Medication.UpdatedAt.Set(time.Now()),
+	}
+	if req.DoseValue != nil {
+		update = append(update, db.Medication.DoseValue.Set(*req.DoseValue))
+	}
+	if req.DoseUnit != nil {
+		update = append(update, db.Medication.DoseUnit.Set(*req.DoseUnit))
+	}
+	if req.Route != nil {
+		update = append(update, db.Medication.Route.Set(*req.Route))
+	}
+	if req.Frequency != nil {
+		update = append(update, db.Medication.Frequency.Set(*req.Frequency))
+	}
+	if req.DurationDays != nil {
+		update = append(update, db.Medication.DurationDays.Set(*req.DurationDays))
+	}
+	if req.Instructions != nil {
+		update = append(update, db.Medication.Instructions.Set(*req.Instructions))
+	}
+	if req.StopReason != nil {
+		update = append(update, db.Medication.StopReason.Set(*req.StopReason))
+	}
+	if req.InteractionWarnings != nil {
+		warnings, err := json.Marshal(req.InteractionWarnings)
+		if err != nil {
+			return nil, err
+		}
+		update = append(update,
+			db.Medication.InteractionWarnings.Set(warnings),
+			db.Medication.OverrideReason.SetOptional(req.OverrideReason),
+		)
+	}
+
+	stop, hasStop := current.StopDate()
+	switch {
+	case req.StopDate != nil:
+		stop, hasStop = time.Time(*req.StopDate), true
+	case !clinical.IsMedicationCurrent(status) && (!hasStop || stop.After(startOfToday())):
+		stop, hasStop = startOfToday(), true
+	case req.DurationDays != nil:
+		stop, hasStop = courseEnd(current.StartDate, *req.DurationDays), true
+	}
+	if hasStop {
+		if stop.Before(current.StartDate) {
+			return nil, ErrStopBeforeStart
+		}
+		update = append(update, db.Medication.StopDate.Set(stop))
+	}
+
+	// only update the medication while it still has the status checked, so
+	// that a change at the same time, e.g. stopping it, isn't overwritten
+	res, err := s.client.Medication.FindMany(
+		db.Medication.ID.Equals(req.MedicationID),
+		db.Medication.Status.Equals(current.Status),
+	).Update(
+		update...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		return nil, ErrMedicationTransition
+	}
+
+	med, err := s.client.Medication.FindUnique(
+		db.Medication.ID.Equals(req.MedicationID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrMedicationNotFound
+		}
+		return nil, err
+	}
+	return toMedicationModel(med)
+}
+
+// List returns a patient's medications, most recently started first. A
+// current medication past its stop date is listed as completed.
+func (s *Medications) List(ctx context.Context, req *models.MedicationQuery) ([]*models.Medication, error) {
+	where := []db.MedicationWhereParam{
+		db.Medication.PatientID.Equals(req.PatientID),
+	}
+	switch req.Status {
+	case "":
+	case "current":
+		where = append(where, currentMedications()...)
+	case clinical.MedicationActive, clinical.MedicationOnHold:
+		where = append(where,
+			db.Medication.Status.Equals(req.Status),
+			notPastStopDate(),
+		)
+	case clinical.MedicationCompleted:
+		where = append(where, db.Medication.Or(
+			db.Medication.Status.Equals(clinical.MedicationCompleted),
+			db.Medication.And(
+				db.Medication.Status.In([]string{
+					clinical.MedicationActive,
+					clinical.MedicationOnHold,
+				}),
+				db.Medication.StopDate.Lt(startOfToday()),
+			),
+		))
+	default:
+		where = append(where, db.Medication.Status.Equals(req.Status))
+	}
+
+	meds, err := s.client.Medication.FindMany(
+		where...,
+	).OrderBy(
+		db.Medication.StartDate.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.Medication, 0, len(meds))
+	for i := range meds {
+		med, err := toMedicationModel(&meds[i])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, med)
+	}
+	return list, nil
+}
+
+func toMedicationModel(m *db.MedicationModel) (*models.Medication, error) {
+	med := &models.Medication{
+		ID:        m.ID,
+		PatientID: m.PatientID,
+		DrugName:  m.DrugName,
+		DoseValue: m.DoseValue,
+		DoseUnit:  m.DoseUnit,
+		Route:     m.Route,
+		Frequency: m.Frequency,
+		StartDate: models.DateOnly(m.StartDate),
+		Status:    medicationStatus(m),
+		CreatedAt: m.CreatedAt,
+
+		InteractionWarnings: []models.InteractionWarning{},
+	}
+	if len(m.InteractionWarnings) > 0 {
+		if err := json.Unmarshal(m.InteractionWarnings, &med.InteractionWarnings); err != nil {
+			return nil, err
+		}
+	}
+	if reason, ok := m.OverrideReason(); ok {
+		med.OverrideReason = reason
+	}
+	if code, ok := m.DrugCode(); ok {
+		med.Drug = &models.CodedSubstance{Code: code}
+		med.Drug.System, _ = m.DrugSystem()
+		med.Drug.Display, _ = m.DrugDisplay()
+	}
+	if days, ok := m.DurationDays(); ok {
+		med.DurationDays = &days
+	}
+	if instructions, ok := m.Instructions(); ok {
+		med.Instructions = instructions
+	}
+	if prescriber, ok := m.PrescriberID(); ok {
+		med.PrescriberID = prescriber
+	}
+	if encounter, ok := m.EncounterID(); ok {
+		med.EncounterID = encounter
+	}
+	if stop, ok := m.StopDate(); ok {
+		date := models.DateOnly(stop)
+		med.StopDate = &date
+	}
+	if reason, ok := m.StopReason(); ok {
+		med.StopReason = reason
+	}
+	if updatedAt, ok := m.UpdatedAt(); ok {
+		med.UpdatedAt = &updatedAt
+	}
+	return med, nil
+}
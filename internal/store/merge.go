@@ -0,0 +1,669 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/alerts"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+	"github.com/vaidik-bajpai/medibridge/internal/queue"
+)
+
+var (
+	ErrPatientMerged   = errors.New("patient merged into another record")
+	ErrMergeNotFound   = errors.New("merge not found")
+	ErrAlreadyMerged   = errors.New("patient already merged")
+	ErrAlreadyUnmerged = errors.New("merge already reversed")
+	ErrMergeOutOfSync  = errors.New("merged records changed since the merge")
+)
+
+// PatientMergedError is returned when a merged (tombstoned) patient is read.
+// It carries the ID of the record the patient was merged into.
+type PatientMergedError struct {
+	TargetID string
+}
+
+func (e *PatientMergedError) Error() string {
+	return fmt.Sprintf("patient merged into %s", e.TargetID)
+}
+
+func (e *PatientMergedError) Unwrap() error {
+	return ErrPatientMerged
+}
+
+// Merge moves the clinical records of req.SourceID to req.TargetID, copies
+// the requested demographics from the source and leaves the source as a
+// tombstone pointing at the target. Everything happens in one transaction and
+// a manifest is stored so that the merge can be reversed with Unmerge. Rows
+// added to the source while the merge runs move with the rest but aren't in
+// the manifest, so they stay with the target when it's reversed.
+func (s *Patient) Merge(ctx context.Context, req *models.MergePatientReq) (*models.PatientMerge, error) {
+	source, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.SourceID),
+	).With(
+		db.Patient.Diagnoses.Fetch(),
+		db.Patient.Conditions.Fetch(),
+		db.Patient.Allergies.Fetch(),
+		db.Patient.Medications.Fetch(),
+		db.Patient.Vitals.Fetch(),
+		db.Patient.Encounters.Fetch(),
+		db.Patient.Appointments.Fetch(),
+		db.Patient.Notes.Fetch(),
+		db.Patient.LabOrders.Fetch(),
+		db.Patient.LabResults.Fetch(),
+		db.Patient.Immunizations.Fetch(),
+		db.Patient.FamilyHistory.Fetch(),
+		db.Patient.SocialHistory.Fetch(),
+		db.Patient.Referrals.Fetch(),
+		db.Patient.Alerts.Fetch(),
+		db.Patient.CareTeam.Fetch(),
+		db.Patient.QueueEntries.Fetch(),
+		db.Patient.Edits.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	target, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.TargetID),
+	).With(
+		db.Patient.SocialHistory.Fetch(),
+		db.Patient.CareTeam.Fetch(),
+		db.Patient.Immunizations.Fetch(),
+		db.Patient.QueueEntries.Fetch(
+			db.QueueEntry.Status.Not(queue.StatusDone),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	if _, ok := source.MergedIntoID(); ok {
+		return nil, ErrAlreadyMerged
+	}
+	if _, ok := target.MergedIntoID(); ok {
+		return nil, ErrAlreadyMerged
+	}
+
+	manifest := models.MergeManifest{
+		Diagnoses:      []string{},
+		Conditions:     []string{},
+		Allergies:      []string{},
+		Medications:    []string{},
+		Vitals:         []string{},
+		Encounters:     []string{},
+		Appointments:   []string{},
+		Notes:          []string{},
+		LabOrders:      []string{},
+		LabResults:     []string{},
+		Immunizations:  []string{},
+		FamilyHistory:  []string{},
+		Referrals:      []string{},
+		Alerts:         []string{},
+		CareTeam:       []string{},
+		QueueEntries:   []string{},
+		Edits:          []string{},
+		KeptFromSource: req.KeepFromSource,
+	}
+	for _, d := range source.Diagnoses() {
+		manifest.Diagnoses = append(manifest.Diagnoses, d.ID)
+	}
+	for _, c := range source.Conditions() {
+		manifest.Conditions = append(manifest.Conditions, c.ID)
+	}
+	for _, a := range source.Allergies() {
+		manifest.Allergies = append(manifest.Allergies, a.ID)
+	}
+	for _, m := range source.Medications() {
+		manifest.Medications = append(manifest.Medications, m.ID)
+	}
+	for _, v := range source.Vitals() {
+		manifest.Vitals = append(manifest.Vitals, v.ID)
+	}
+	for _, e := range source.Encounters() {
+		manifest.Encounters = append(manifest.Encounters, e.ID)
+	}
+	for _, a := range source.Appointments() {
+		manifest.Appointments = append(manifest.Appointments, a.ID)
+	}
+	for _, n := range source.Notes() {
+		manifest.Notes = append(manifest.Notes, n.ID)
+	}
+	for _, o := range source.LabOrders() {
+		manifest.LabOrders = append(manifest.LabOrders, o.ID)
+	}
+	for _, r := range source.LabResults() {
+		manifest.LabResults = append(manifest.LabResults, r.ID)
+	}
+	// a dose of a vaccine is recorded once, so the doses the target already
+	// has stay with the source
+	targetDoses := make(map[string]bool)
+	for _, i := range target.Immunizations() {
+		targetDoses[fmt.Sprintf("%s/%d", i.Vaccine, i.DoseNumber)] = true
+	}
+	for _, i := range source.Immunizations() {
+		if !targetDoses[fmt.Sprintf("%s/%d", i.Vaccine, i.DoseNumber)] {
+			manifest.Immunizations = append(manifest.Immunizations, i.ID)
+		}
+	}
+	for _, f := range source.FamilyHistory() {
+		manifest.FamilyHistory = append(manifest.FamilyHistory, f.ID)
+	}
+	for _, r := range source.Referrals() {
+		manifest.Referrals = append(manifest.Referrals, r.ID)
+	}
+	for _, a := range source.Alerts() {
+		manifest.Alerts = append(manifest.Alerts, a.ID)
+	}
+	// a user is on a patient's care team once, so the members already on
+	// the target's team stay with the source
+	targetTeam := []string{}
+	for _, m := range target.CareTeam() {
+		targetTeam = append(targetTeam, m.UserID)
+	}
+	for _, m := range source.CareTeam() {
+		if !slices.Contains(targetTeam, m.UserID) {
+			manifest.CareTeam = append(manifest.CareTeam, m.ID)
+		}
+	}
+	// a patient is in the queue once, so the source's entry still in the
+	// queue stays with the source when the target is in the queue too
+	targetQueued := len(target.QueueEntries()) > 0
+	for _, e := range source.QueueEntries() {
+		if _, active := e.ActivePatient(); !active || !targetQueued {
+			manifest.QueueEntries = append(manifest.QueueEntries, e.ID)
+		}
+	}
+	for _, e := range source.Edits() {
+		if e.Entity != editPatient {
+			manifest.Edits = append(manifest.Edits, e.ID)
+		}
+	}
+	// a patient has one social history, so the target's own is kept
+	if h, ok := source.SocialHistory(); ok {
+		if _, ok := target.SocialHistory(); !ok {
+			manifest.SocialHistory = h.ID
+		}
+	}
+
+	fromSource, targetBefore := reconcileDemographics(source, target, req.KeepFromSource)
+	manifest.TargetBefore = *targetBefore
+
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tombstone is claimed first, so that the source's rows are moved
+	// while both patients are locked; rows are moved by patient rather than
+	// by the manifest so that ones added since it was read aren't left on
+	// the tombstone
+	txs := []db.PrismaTransaction{
+		s.client.Prisma.QueryRaw(claimMergeQuery, source.ID, target.ID).Tx(),
+		s.client.Diagnosis.FindMany(
+			db.Diagnosis.PatientID.Equals(source.ID),
+		).Update(
+			db.Diagnosis.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Condition.FindMany(
+			db.Condition.PatientID.Equals(source.ID),
+		).Update(
+			db.Condition.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Allergy.FindMany(
+			db.Allergy.PatientID.Equals(source.ID),
+		).Update(
+			db.Allergy.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Medication.FindMany(
+			db.Medication.PatientID.Equals(source.ID),
+		).Update(
+			db.Medication.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Vital.FindMany(
+			db.Vital.PatientID.Equals(source.ID),
+		).Update(
+			db.Vital.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Encounter.FindMany(
+			db.Encounter.PatientID.Equals(source.ID),
+		).Update(
+			db.Encounter.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Appointment.FindMany(
+			db.Appointment.PatientID.Equals(source.ID),
+		).Update(
+			db.Appointment.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.ClinicalNote.FindMany(
+			db.ClinicalNote.PatientID.Equals(source.ID),
+		).Update(
+			db.ClinicalNote.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.LabOrder.FindMany(
+			db.LabOrder.PatientID.Equals(source.ID),
+		).Update(
+			db.LabOrder.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.LabResult.FindMany(
+			db.LabResult.PatientID.Equals(source.ID),
+		).Update(
+			db.LabResult.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Prisma.ExecuteRaw(moveImmunizationsQuery, source.ID, target.ID).Tx(),
+		s.client.FamilyHistory.FindMany(
+			db.FamilyHistory.PatientID.Equals(source.ID),
+		).Update(
+			db.FamilyHistory.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.Referral.FindMany(
+			db.Referral.PatientID.Equals(source.ID),
+		).Update(
+			db.Referral.PatientID.Set(target.ID),
+		).Tx(),
+		// alerts are keyed by their patient's ID; the ones duplicating an
+		// alert open on the target are resolved before they are re-keyed
+		s.client.Prisma.ExecuteRaw(resolveDuplicateAlertsQuery, source.ID, target.ID,
+			alerts.StatusResolved, time.Now().UTC(), duplicateAlertNote).Tx(),
+		s.client.Prisma.ExecuteRaw(rekeyAlertsQuery, source.ID, source.ID, target.ID).Tx(),
+		s.client.Alert.FindMany(
+			db.Alert.PatientID.Equals(source.ID),
+		).Update(
+			db.Alert.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.CareTeamMember.FindMany(
+			db.CareTeamMember.PatientID.Equals(source.ID),
+			db.CareTeamMember.UserID.NotIn(targetTeam),
+		).Update(
+			db.CareTeamMember.PatientID.Set(target.ID),
+		).Tx(),
+		s.client.RecordEdit.FindMany(
+			db.RecordEdit.PatientID.Equals(source.ID),
+			db.RecordEdit.Entity.Not(editPatient),
+		).Update(
+			db.RecordEdit.PatientID.Set(target.ID),
+		).Tx(),
+	}
+	if manifest.SocialHistory != "" {
+		txs = append(txs, s.client.SocialHistory.FindMany(
+			db.SocialHistory.PatientID.Equals(source.ID),
+		).Update(
+			db.SocialHistory.PatientID.Set(target.ID),
+		).Tx())
+	}
+	// an entry still in the queue is keyed by its patient's ID too
+	if !targetQueued {
+		txs = append(txs, s.client.QueueEntry.FindMany(
+			db.QueueEntry.ActivePatient.Equals(source.ID),
+		).Update(
+			db.QueueEntry.PatientID.Set(target.ID),
+			db.QueueEntry.ActivePatient.Set(target.ID),
+		).Tx())
+	}
+	txs = append(txs, s.client.QueueEntry.FindMany(
+		db.QueueEntry.PatientID.Equals(source.ID),
+		db.QueueEntry.ActivePatient.IsNull(),
+	).Update(
+		db.QueueEntry.PatientID.Set(target.ID),
+	).Tx())
+
+	txs = append(txs,
+		s.client.Patient.FindUnique(
+			db.Patient.ID.Equals(target.ID),
+		).Update(
+			preparePatientUpdateParams(fromSource)...,
+		).Tx(),
+	)
+
+	merge := s.client.PatientMerge.CreateOne(
+		db.PatientMerge.Source.Link(
+			db.Patient.ID.Equals(source.ID),
+		),
+		db.PatientMerge.Target.Link(
+			db.Patient.ID.Equals(target.ID),
+		),
+		db.PatientMerge.Manifest.Set(raw),
+		db.PatientMerge.MergedBy.Link(
+			db.User.ID.Equals(req.MergedByID),
+		),
+	).Tx()
+	txs = append(txs, merge)
+
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if s.mergedSince(ctx, source.ID, target.ID) {
+			return nil, ErrAlreadyMerged
+		}
+		return nil, err
+	}
+
+	return toPatientMerge(merge.Result())
+}
+
+// Unmerge reverses a merge using its stored manifest. Records added to the
+// target after the merge stay with the target.
+func (s *Patient) Unmerge(ctx context.Context, req *models.UnmergePatientReq) (*models.PatientMerge, error) {
+	m, err := s.client.PatientMerge.FindUnique(
+		db.PatientMerge.ID.Equals(req.MergeID),
+	).With(
+		db.PatientMerge.Source.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrMergeNotFound
+		}
+		return nil, err
+	}
+
+	if _, ok := m.UnmergedAt(); ok {
+		return nil, ErrAlreadyUnmerged
+	}
+
+	if into, ok := m.Source().MergedIntoID(); !ok || into != m.TargetID {
+		return nil, ErrMergeOutOfSync
+	}
+
+	var manifest models.MergeManifest
+	if err := json.Unmarshal(m.Manifest, &manifest); err != nil {
+		return nil, err
+	}
+
+	txs := []db.PrismaTransaction{
+		s.client.Prisma.QueryRaw(claimUnmergeQuery, m.ID).Tx(),
+		s.client.Diagnosis.FindMany(
+			db.Diagnosis.ID.In(manifest.Diagnoses),
+		).Update(
+			db.Diagnosis.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Condition.FindMany(
+			db.Condition.ID.In(manifest.Conditions),
+		).Update(
+			db.Condition.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Allergy.FindMany(
+			db.Allergy.ID.In(manifest.Allergies),
+		).Update(
+			db.Allergy.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Medication.FindMany(
+			db.Medication.ID.In(manifest.Medications),
+		).Update(
+			db.Medication.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Vital.FindMany(
+			db.Vital.ID.In(manifest.Vitals),
+		).Update(
+			db.Vital.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Encounter.FindMany(
+			db.Encounter.ID.In(manifest.Encounters),
+		).Update(
+			db.Encounter.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Appointment.FindMany(
+			db.Appointment.ID.In(manifest.Appointments),
+		).Update(
+			db.Appointment.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.ClinicalNote.FindMany(
+			db.ClinicalNote.ID.In(manifest.Notes),
+		).Update(
+			db.ClinicalNote.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.LabOrder.FindMany(
+			db.LabOrder.ID.In(manifest.LabOrders),
+		).Update(
+			db.LabOrder.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.LabResult.FindMany(
+			db.LabResult.ID.In(manifest.LabResults),
+		).Update(
+			db.LabResult.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Immunization.FindMany(
+			db.Immunization.ID.In(manifest.Immunizations),
+		).Update(
+			db.Immunization.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.FamilyHistory.FindMany(
+			db.FamilyHistory.ID.In(manifest.FamilyHistory),
+		).Update(
+			db.FamilyHistory.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Referral.FindMany(
+			db.Referral.ID.In(manifest.Referrals),
+		).Update(
+			db.Referral.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.Alert.FindMany(
+			db.Alert.ID.In(manifest.Alerts),
+		).Update(
+			db.Alert.PatientID.Set(m.SourceID),
+		).Tx(),
+		// the alerts moved back are the source's ones keyed by the target
+		s.client.Prisma.ExecuteRaw(rekeyAlertsQuery, m.SourceID, m.TargetID, m.SourceID).Tx(),
+		s.client.CareTeamMember.FindMany(
+			db.CareTeamMember.ID.In(manifest.CareTeam),
+		).Update(
+			db.CareTeamMember.PatientID.Set(m.SourceID),
+		).Tx(),
+		s.client.RecordEdit.FindMany(
+			db.RecordEdit.ID.In(manifest.Edits),
+		).Update(
+			db.RecordEdit.PatientID.Set(m.SourceID),
+		).Tx(),
+	}
+	if manifest.SocialHistory != "" {
+		txs = append(txs, s.client.SocialHistory.FindMany(
+			db.SocialHistory.ID.Equals(manifest.SocialHistory),
+		).Update(
+			db.SocialHistory.PatientID.Set(m.SourceID),
+		).Tx())
+	}
+	txs = append(txs,
+		s.client.QueueEntry.FindMany(
+			db.QueueEntry.ID.In(manifest.QueueEntries),
+			db.QueueEntry.ActivePatient.Equals(m.TargetID),
+		).Update(
+			db.QueueEntry.PatientID.Set(m.SourceID),
+			db.QueueEntry.ActivePatient.Set(m.SourceID),
+		).Tx(),
+		s.client.QueueEntry.FindMany(
+			db.QueueEntry.ID.In(manifest.QueueEntries),
+		).Update(
+			db.QueueEntry.PatientID.Set(m.SourceID),
+		).Tx(),
+	)
+
+	txs = append(txs,
+		s.client.Patient.FindUnique(
+			db.Patient.ID.Equals(m.TargetID),
+		).Update(
+			preparePatientUpdateParams(&manifest.TargetBefore)...,
+		).Tx(),
+		s.client.Patient.FindUnique(
+			db.Patient.ID.Equals(m.SourceID),
+		).Update(
+			db.Patient.MergedInto.Unlink(),
+			db.Patient.MergedAt.SetOptional(nil),
+		).Tx(),
+	)
+
+	unmerge := s.client.PatientMerge.FindUnique(
+		db.PatientMerge.ID.Equals(m.ID),
+	).Update(
+		db.PatientMerge.UnmergedBy.Link(
+			db.User.ID.Equals(req.UnmergedByID),
+		),
+		db.PatientMerge.UnmergedAt.Set(time.Now()),
+	).Tx()
+	txs = append(txs, unmerge)
+
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if current, rerr := s.client.PatientMerge.FindUnique(
+			db.PatientMerge.ID.Equals(m.ID),
+		).Exec(ctx); rerr == nil {
+			if _, ok := current.UnmergedAt(); ok {
+				return nil, ErrAlreadyUnmerged
+			}
+		}
+		return nil, err
+	}
+
+	return toPatientMerge(unmerge.Result())
+}
+
+// claimMergeQuery locks the source ($1) and the target ($2) and tombstones
+// the source, only while neither is merged. Concurrent merges of either
+// patient wait for the lock and then see it merged. When the claim doesn't
+// match, the division by zero fails the statement and rolls back the
+// transaction it runs in.
+const claimMergeQuery = `
+	WITH locked AS (
+		SELECT id
+		FROM "Patient"
+		WHERE id IN ($1, $2) AND "mergedIntoId" IS NULL
+		ORDER BY id
+		FOR UPDATE
+	), tombstone AS (
+		UPDATE "Patient"
+		SET "mergedIntoId" = $2, "mergedAt" = now() AT TIME ZONE 'UTC'
+		WHERE id = $1 AND (SELECT COUNT(*) FROM locked) = 2
+		RETURNING id
+	)
+	SELECT 1 / COUNT(*) AS claimed FROM tombstone;
+`
+
+// moveImmunizationsQuery moves the doses of the patient $1 to the patient
+// $2, except the ones $2 already has a record of.
+const moveImmunizationsQuery = `
+	UPDATE "Immunization" s
+	SET "patientId" = $2
+	WHERE s."patientId" = $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM "Immunization" t
+			WHERE t."patientId" = $2 AND t.vaccine = s.vaccine AND t."doseNumber" = s."doseNumber"
+		);
+`
+
+// duplicateAlertNote is the resolution note of an alert resolved by a merge.
+const duplicateAlertNote = "Resolved on merge: the patient merged into has the same alert open"
+
+// resolveDuplicateAlertsQuery resolves, with status $3 at $4 and note $5,
+// the unresolved alerts of the patient $1 that the patient $2 has an
+// unresolved alert with the same key for, so that re-keying them for $2
+// leaves one unresolved alert per key.
+const resolveDuplicateAlertsQuery = `
+	UPDATE "Alert" a
+	SET status = $3, "activeKey" = NULL, "resolvedAt" = $4::timestamp, "resolutionNote" = $5
+	WHERE a."patientId" = $1 AND a."activeKey" IS NOT NULL
+		AND EXISTS (
+			SELECT 1
+			FROM "Alert" t
+			WHERE t."activeKey" = $2 || substr(a."activeKey", length($1) + 1)
+		);
+`
+
+// rekeyAlertsQuery rewrites the keys of the alerts of the patient $1 that
+// start with the patient ID $2 to start with $3 instead. A resolved alert
+// has no activeKey, and the concatenation keeps it NULL.
+const rekeyAlertsQuery = `
+	UPDATE "Alert"
+	SET "dedupKey" = $3 || substr("dedupKey", length($2) + 1),
+		"activeKey" = $3 || substr("activeKey", length($2) + 1)
+	WHERE "patientId" = $1 AND left("dedupKey", length($2) + 1) = $2 || ':';
+`
+
+// claimUnmergeQuery locks the merge $1 and its source, and fails the
+// transaction it runs in unless the merge is still in effect.
+const claimUnmergeQuery = `
+	WITH locked AS (
+		SELECT m.id
+		FROM "PatientMerge" m
+		JOIN "Patient" p ON p.id = m."sourceId"
+		WHERE m.id = $1 AND m."unmergedAt" IS NULL AND p."mergedIntoId" = m."targetId"
+		FOR UPDATE OF m, p
+	)
+	SELECT 1 / COUNT(*) AS claimed FROM locked;
+`
+
+// mergedSince reports whether any of the patients has been merged, to tell a
+// failed merge claim from other errors.
+func (s *Patient) mergedSince(ctx context.Context, ids ...string) bool {
+	patients, err := s.client.Patient.FindMany(
+		db.Patient.ID.In(ids),
+	).Exec(ctx)
+	if err != nil {
+		return false
+	}
+	for _, p := range patients {
+		if _, ok := p.MergedIntoID(); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileDemographics returns the update that copies the kept fields from
+// source onto target, and the update that restores target's previous values.
+func reconcileDemographics(source, target *db.PatientModel, keep []string) (*models.UpdatePatientReq, *models.UpdatePatientReq) {
+	from := &models.UpdatePatientReq{ID: target.ID}
+	before := &models.UpdatePatientReq{ID: target.ID}
+
+	for _, field := range keep {
+		switch field {
+		case "fullname":
+			from.FullName, before.FullName = &source.FullName, &target.FullName
+		case "gender":
+			from.Gender, before.Gender = &source.Gender, &target.Gender
+		case "dob":
+			from.DOB, before.DOB = &source.DateOfBirth, &target.DateOfBirth
+			from.Age, before.Age = &source.Age, &target.Age
+		case "contactNo":
+			from.ContactNumber, before.ContactNumber = &source.ContactNumber, &target.ContactNumber
+		case "address":
+			from.Address, before.Address = &source.Address, &target.Address
+		case "emergencyName":
+			from.EmergencyName, before.EmergencyName = &source.EmergencyName, &target.EmergencyName
+		case "emergencyRelation":
+			from.EmergencyRelation, before.EmergencyRelation = &source.EmergencyRelation, &target.EmergencyRelation
+		case "emergencyPhone":
+			from.EmergencyPhone, before.EmergencyPhone = &source.EmergencyPhone, &target.EmergencyPhone
+		}
+	}
+
+	return from, before
+}
+
+func toPatientMerge(m *db.PatientMergeModel) (*models.PatientMerge, error) {
+	res := &models.PatientMerge{
+		ID:         m.ID,
+		SourceID:   m.SourceID,
+		TargetID:   m.TargetID,
+		MergedByID: m.MergedByID,
+		MergedAt:   m.MergedAt,
+	}
+
+	if err := json.Unmarshal(m.Manifest, &res.Manifest); err != nil {
+		return nil, err
+	}
+	if unmergedBy, ok := m.UnmergedByID(); ok {
+		res.UnmergedByID = unmergedBy
+	}
+	if unmergedAt, ok := m.UnmergedAt(); ok {
+		res.UnmergedAt = &unmergedAt
+	}
+
+	return res, nil
+}
@@ -0,0 +1,366 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/notes"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrNoteNotFound = errors.New("note not found")
+
+	// ErrNoteSigned is returned when a signed note would be edited, signed
+	// again or deleted.
+	ErrNoteSigned = errors.New("note is signed and can't be changed")
+
+	// ErrNoteNotSigned is returned when a draft would be amended; it is
+	// edited instead.
+	ErrNoteNotSigned = errors.New("note is a draft and can't be amended")
+
+	// ErrNoteNotAuthor is returned when someone other than its author edits,
+	// signs or deletes a draft.
+	ErrNoteNotAuthor = errors.New("draft notes can only be changed by their author")
+
+	// ErrNoteEmpty is returned when a note without any text would be signed.
+	ErrNoteEmpty = errors.New("note is empty")
+)
+
+type Notes struct {
+	client *db.PrismaClient
+}
+
+// Create starts a draft note.
+func (s *Notes) Create(ctx context.Context, req *models.CreateNoteReq) (*models.ClinicalNote, error) {
+	optional := []db.ClinicalNoteSetParam{
+		db.ClinicalNote.Subjective.Set(req.Subjective),
+		db.ClinicalNote.Objective.Set(req.Objective),
+		db.ClinicalNote.Assessment.Set(req.Assessment),
+		db.ClinicalNote.Plan.Set(req.Plan),
+	}
+	if req.TemplateID != "" {
+		optional = append(optional, db.ClinicalNote.TemplateID.Set(req.TemplateID))
+	}
+	if req.Title != "" {
+		optional = append(optional, db.ClinicalNote.Title.Set(req.Title))
+	}
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PatientID, req.EncounterID); err != nil {
+			return nil, err
+		}
+		optional = append(optional, db.ClinicalNote.Encounter.Link(
+			db.Encounter.ID.Equals(req.EncounterID),
+		))
+	}
+
+	note, err := s.client.ClinicalNote.CreateOne(
+		db.ClinicalNote.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.ClinicalNote.Author.Link(
+			db.User.ID.Equals(req.AuthorID),
+		),
+		optional...,
+	).With(
+		noteAmendments(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	return toNoteModel(note), nil
+}
+
+// Get returns a note with its amendments. Drafts are only returned to their
+// author.
+func (s *Notes) Get(ctx context.Context, noteID, viewerID string) (*models.ClinicalNote, error) {
+	note, err := s.client.ClinicalNote.FindUnique(
+		db.ClinicalNote.ID.Equals(noteID),
+	).With(
+		noteAmendments(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrNoteNotFound
+		}
+		return nil, err
+	}
+	if !notes.Visible(note.Status, note.AuthorID, viewerID) {
+		return nil, ErrNoteNotFound
+	}
+
+	return toNoteModel(note), nil
+}
+
+// List returns the patient's notes, the most recent first. Drafts are only
+// listed for their author.
+func (s *Notes) List(ctx context.Context, req *models.NoteQuery) ([]*models.ClinicalNote, error) {
+	params := []db.ClinicalNoteWhereParam{
+		db.ClinicalNote.PatientID.Equals(req.PatientID),
+	}
+	if req.Status != "" {
+		params = append(params, db.ClinicalNote.Status.Equals(req.Status))
+	}
+
+	records, err := s.client.ClinicalNote.FindMany(
+		params...,
+	).With(
+		noteAmendments(),
+	).OrderBy(
+		db.ClinicalNote.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.ClinicalNote, 0, len(records))
+	for i := range records {
+		if !notes.Visible(records[i].Status, records[i].AuthorID, req.ViewerID) {
+			continue
+		}
+		list = append(list, toNoteModel(&records[i]))
+	}
+	return list, nil
+}
+
+// Update edits a draft note of the author.
+func (s *Notes) Update(ctx context.Context, req *models.UpdateNoteReq) (*models.ClinicalNote, error) {
+	if _, err := s.draft(ctx, req.NoteID, req.AuthorID); err != nil {
+		return nil, err
+	}
+
+	params := []db.ClinicalNoteSetParam{
+		db.ClinicalNote.UpdatedAt.Set(time.Now()),
+	}
+	if req.Title != nil {
+		params = append(params, db.ClinicalNote.Title.Set(*req.Title))
+	}
+	if req.Subjective != nil {
+		params = append(params, db.ClinicalNote.Subjective.Set(*req.Subjective))
+	}
+	if req.Objective != nil {
+		params = append(params, db.ClinicalNote.Objective.Set(*req.Objective))
+	}
+	if req.Assessment != nil {
+		params = append(params, db.ClinicalNote.Assessment.Set(*req.Assessment))
+	}
+	if req.Plan != nil {
+		params = append(params, db.ClinicalNote.Plan.Set(*req.Plan))
+	}
+
+	if err := s.updateDraft(ctx, req.NoteID, params...); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, req.NoteID, req.AuthorID)
+}
+
+// Sign signs a draft note of the author, after which it is immutable.
+func (s *Notes) Sign(ctx context.Context, noteID, authorID string) (*models.ClinicalNote, error) {
+	note, err := s.draft(ctx, noteID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if (notes.Sections{
+		Subjective: note.Subjective,
+		Objective:  note.Objective,
+		Assessment: note.Assessment,
+		Plan:       note.Plan,
+	}).Empty() {
+		return nil, ErrNoteEmpty
+	}
+
+	now := time.Now()
+	err = s.updateDraft(ctx, noteID,
+		db.ClinicalNote.Status.Set(notes.StatusSigned),
+		db.ClinicalNote.SignedAt.Set(now),
+		db.ClinicalNote.UpdatedAt.Set(now),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, noteID, authorID)
+}
+
+// Amend appends an amendment to a signed note. The note itself is left as
+// it was signed.
+func (s *Notes) Amend(ctx context.Context, req *models.AmendNoteReq) (*models.ClinicalNote, error) {
+	note, err := s.client.ClinicalNote.FindUnique(
+		db.ClinicalNote.ID.Equals(req.NoteID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrNoteNotFound
+		}
+		return nil, err
+	}
+	if !notes.CanAmend(note.Status) {
+		if note.AuthorID != req.AuthorID {
+			return nil, ErrNoteNotFound
+		}
+		return nil, ErrNoteNotSigned
+	}
+
+	var optional []db.NoteAmendmentSetParam
+	if req.Subjective != "" {
+		optional = append(optional, db.NoteAmendment.Subjective.Set(req.Subjective))
+	}
+	if req.Objective != "" {
+		optional = append(optional, db.NoteAmendment.Objective.Set(req.Objective))
+	}
+	if req.Assessment != "" {
+		optional = append(optional, db.NoteAmendment.Assessment.Set(req.Assessment))
+	}
+	if req.Plan != "" {
+		optional = append(optional, db.NoteAmendment.Plan.Set(req.Plan))
+	}
+
+	amend := s.client.NoteAmendment.CreateOne(
+		db.NoteAmendment.Note.Link(
+			db.ClinicalNote.ID.Equals(req.NoteID),
+		),
+		db.NoteAmendment.Author.Link(
+			db.User.ID.Equals(req.AuthorID),
+		),
+		db.NoteAmendment.Reason.Set(req.Reason),
+		optional...,
+	).Tx()
+
+	mark := s.client.ClinicalNote.FindUnique(
+		db.ClinicalNote.ID.Equals(req.NoteID),
+	).Update(
+		db.ClinicalNote.Status.Set(notes.StatusAmended),
+		db.ClinicalNote.UpdatedAt.Set(time.Now()),
+	).Tx()
+
+	if err := s.client.Prisma.Transaction(amend, mark).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, req.NoteID, req.AuthorID)
+}
+
+// Delete discards a draft note of the author. Signed notes are kept.
+func (s *Notes) Delete(ctx context.Context, noteID, authorID string) error {
+	if _, err := s.draft(ctx, noteID, authorID); err != nil {
+		return err
+	}
+
+	res, err := s.client.ClinicalNote.FindMany(
+		db.ClinicalNote.ID.Equals(noteID),
+		db.ClinicalNote.Status.Equals(notes.StatusDraft),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Count == 0 {
+		return ErrNoteSigned
+	}
+	return nil
+}
+
+// draft returns the note if it is a draft of the author.
+func (s *Notes) draft(ctx context.Context, noteID, authorID string) (*db.ClinicalNoteModel, error) {
+	note, err := s.client.ClinicalNote.FindUnique(
+		db.ClinicalNote.ID.Equals(noteID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrNoteNotFound
+		}
+		return nil, err
+	}
+	if note.Status != notes.StatusDraft {
+		return nil, ErrNoteSigned
+	}
+	if note.AuthorID != authorID {
+		return nil, ErrNoteNotAuthor
+	}
+	return note, nil
+}
+
+// updateDraft updates the note only while it is still a draft, so that a
+// note signed in the meantime isn't changed.
+func (s *Notes) updateDraft(ctx context.Context, noteID string, params ...db.ClinicalNoteSetParam) error {
+	res, err := s.client.ClinicalNote.FindMany(
+		db.ClinicalNote.ID.Equals(noteID),
+		db.ClinicalNote.Status.Equals(notes.StatusDraft),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Count == 0 {
+		return ErrNoteSigned
+	}
+	return nil
+}
+
+// noteAmendments fetches the amendments of a note, oldest first.
+func noteAmendments() db.ClinicalNoteRelationWith {
+	return db.ClinicalNote.Amendments.Fetch().OrderBy(
+		db.NoteAmendment.CreatedAt.Order(db.SortOrderAsc),
+	)
+}
+
+func toNoteModel(n *db.ClinicalNoteModel) *models.ClinicalNote {
+	note := &models.ClinicalNote{
+		ID:        n.ID,
+		PatientID: n.PatientID,
+		AuthorID:  n.AuthorID,
+		Sections: notes.Sections{
+			Subjective: n.Subjective,
+			Objective:  n.Objective,
+			Assessment: n.Assessment,
+			Plan:       n.Plan,
+		},
+		Status:     n.Status,
+		Amendments: []models.NoteAmendment{},
+		CreatedAt:  n.CreatedAt,
+	}
+	if encounterID, ok := n.EncounterID(); ok {
+		note.EncounterID = encounterID
+	}
+	if templateID, ok := n.TemplateID(); ok {
+		note.TemplateID = templateID
+	}
+	if title, ok := n.Title(); ok {
+		note.Title = title
+	}
+	if signedAt, ok := n.SignedAt(); ok {
+		note.SignedAt = &signedAt
+	}
+	if updatedAt, ok := n.UpdatedAt(); ok {
+		note.UpdatedAt = &updatedAt
+	}
+	for i := range n.Amendments() {
+		note.Amendments = append(note.Amendments, toNoteAmendmentModel(&n.Amendments()[i]))
+	}
+	return note
+}
+
+func toNoteAmendmentModel(a *db.NoteAmendmentModel) models.NoteAmendment {
+	amendment := models.NoteAmendment{
+		ID:        a.ID,
+		NoteID:    a.NoteID,
+		AuthorID:  a.AuthorID,
+		Reason:    a.Reason,
+		CreatedAt: a.CreatedAt,
+	}
+	if subjective, ok := a.Subjective(); ok {
+		amendment.Subjective = subjective
+	}
+	if objective, ok := a.Objective(); ok {
+		amendment.Objective = objective
+	}
+	if assessment, ok := a.Assessment(); ok {
+		amendment.Assessment = assessment
+	}
+	if plan, ok := a.Plan(); ok {
+		amendment.Plan = plan
+	}
+	return amendment
+}
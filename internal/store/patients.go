@@ -5,9 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"slices"
+	"sort"
 	"strconv"
 	"time"
 
+	"github.com/vaidik-bajpai/medibridge/internal/helpers"
+	"github.com/vaidik-bajpai/medibridge/internal/matching"
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
 )
@@ -21,6 +26,30 @@ type Patient struct {
 }
 
 func (s *Patient) Create(ctx context.Context, req *models.RegPatientReq) (*models.Patient, error) {
+	mrn, err := helpers.GenerateMRN()
+	if err != nil {
+		return nil, err
+	}
+
+	optional := []db.PatientSetParam{
+		db.Patient.Mrn.Set(mrn),
+		db.Patient.PhoneticKeys.Set(phoneticKeys(req.FullName)),
+	}
+	if req.Ward != "" {
+		optional = append(optional, db.Patient.Ward.Set(req.Ward))
+	}
+	if req.Force {
+		optional = append(optional,
+			db.Patient.DuplicateOverrideBy.Link(
+				db.User.ID.Equals(req.RegByID),
+			),
+			db.Patient.DuplicateOverrideAt.Set(time.Now()),
+		)
+		if req.ForceReason != "" {
+			optional = append(optional, db.Patient.DuplicateOverrideReason.Set(req.ForceReason))
+		}
+	}
+
 	p, err := s.client.Patient.CreateOne(
 		db.Patient.FullName.Set(req.FullName),
 		db.Patient.Age.Set(req.Age),
@@ -34,6 +63,7 @@ func (s *Patient) Create(ctx context.Context, req *models.RegPatientReq) (*model
 		db.Patient.RegisteredBy.Link(
 			db.User.ID.Equals(req.RegByID),
 		),
+		optional...,
 	).With(
 		db.Patient.RegisteredBy.Fetch(),
 	).Exec(ctx)
@@ -43,12 +73,14 @@ func (s *Patient) Create(ctx context.Context, req *models.RegPatientReq) (*model
 
 	patient := models.Patient{
 		ID:                p.ID,
+		MRN:               mrn,
 		FullName:          p.FullName,
 		Gender:            p.Gender,
 		DOB:               models.DateOnly(p.DateOfBirth),
 		Age:               p.Age,
 		ContactNumber:     p.ContactNumber,
 		Address:           p.Address,
+		Ward:              req.Ward,
 		EmergencyName:     p.EmergencyName,
 		EmergencyPhone:    p.EmergencyPhone,
 		EmergencyRelation: p.EmergencyRelation,
@@ -56,12 +88,31 @@ func (s *Patient) Create(ctx context.Context, req *models.RegPatientReq) (*model
 		Registrar:         p.RegisteredBy().Fullname,
 	}
 
+	setDuplicateOverride(&patient, p)
+
 	return &patient, err
 }
 
-func (s *Patient) List(ctx context.Context, req *models.Paginate) (*models.ListPatientRes, error) {
-	offset := (req.Page - 1) * req.PageSize
+// setDuplicateOverride copies who registered a probable duplicate anyway,
+// when and why onto the patient.
+func setDuplicateOverride(patient *models.Patient, p *db.PatientModel) {
+	if overrideBy, ok := p.DuplicateOverrideByID(); ok {
+		patient.DuplicateOverrideByID = overrideBy
+	}
+	if overrideAt, ok := p.DuplicateOverrideAt(); ok {
+		patient.DuplicateOverrideAt = &overrideAt
+	}
+	if reason, ok := p.DuplicateOverrideReason(); ok {
+		patient.DuplicateOverrideReason = reason
+	}
+}
 
+// FindDuplicates returns existing patients that probably describe the same
+// person as req, best match first. Candidates are narrowed down in SQL by
+// date of birth, birth year and contact number, and ranked there by a rough
+// score of the same fields and name similarity so that the best ones are
+// kept when there are more than the limit, before being scored.
+func (s *Patient) FindDuplicates(ctx context.Context, req *models.RegPatientReq) ([]*models.DuplicateCandidate, error) {
 	query := `
 		SELECT
 			id,
@@ -69,35 +120,112 @@ func (s *Patient) List(ctx context.Context, req *models.Paginate) (*models.ListP
 			gender,
 			age,
 			"dateOfBirth" AS dob,
-			COUNT(*) OVER() AS "totalCount"
+			"contactNumber" AS "contactNo",
+			"createdAt"
 		FROM
 			"Patient"
 		WHERE
-			"fullName" ILIKE $1
+			"mergedIntoId" IS NULL
+			AND (
+				"dateOfBirth"::date = $1::date
+				OR "contactNumber" = $2
+				OR (gender = $3 AND EXTRACT(YEAR FROM "dateOfBirth") = $4)
+			)
 		ORDER BY
-			"createdAt" DESC
-		LIMIT $2 OFFSET $3;
+			0.4 * similarity(lower("fullName"), lower($5))
+			+ 0.3 * ("dateOfBirth"::date = $1::date)::int
+			+ 0.2 * ("contactNumber" = $2)::int
+			+ 0.1 * (gender = $3)::int DESC,
+			"createdAt" ASC,
+			id ASC
+		LIMIT 200;
 	`
 
-	var args []interface{}
-	args = append(args, "%"+req.SearchTerm+"%")
-	args = append(args, req.PageSize)
-	args = append(args, offset)
+	var rows []struct {
+		models.ListPatientItem
+		ContactNumber string `json:"contactNo"`
+	}
+
+	err := s.client.Prisma.QueryRaw(
+		query, req.DOB, req.ContactNumber, req.Gender, req.DOB.Year(), req.FullName,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	incoming := matching.Demographics{
+		FullName:      req.FullName,
+		DOB:           req.DOB,
+		Gender:        req.Gender,
+		ContactNumber: req.ContactNumber,
+	}
+
+	var candidates []*models.DuplicateCandidate
+	for _, row := range rows {
+		res := matching.Compare(incoming, matching.Demographics{
+			FullName:      row.FullName,
+			DOB:           row.DOB,
+			Gender:        row.Gender,
+			ContactNumber: row.ContactNumber,
+		})
+		if res.Score < matching.DuplicateThreshold {
+			continue
+		}
+
+		candidates = append(candidates, &models.DuplicateCandidate{
+			Patient:       row.ListPatientItem,
+			ContactNumber: row.ContactNumber,
+			Score:         math.Round(res.Score*100) / 100,
+			MatchedOn:     res.Reasons,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	return candidates, nil
+}
+
+func (s *Patient) List(ctx context.Context, req *models.Paginate, filter *models.PatientFilter) (*models.ListPatientRes, error) {
+	offset := (req.Page - 1) * req.PageSize
+
+	args := &queryArgs{}
+	where, score := preparePatientListFilters(args, req, filter)
+	orderBy := preparePatientListOrder(req, filter)
+
+	query := fmt.Sprintf(`
+		SELECT
+			p.id,
+			p.mrn,
+			p."fullName",
+			p.gender,
+			p.age,
+			p."dateOfBirth" AS dob,
+			p."createdAt",
+			%s AS score,
+			COUNT(*) OVER() AS "totalCount"
+		FROM
+			"Patient" p
+		WHERE
+			%s
+		ORDER BY
+			%s
+		LIMIT %s OFFSET %s;
+	`, score, where, orderBy, args.add(req.PageSize), args.add(offset))
 
 	var queryRes []struct {
 		models.ListPatientItem
-		TotalCount string `json:"totalCount"`
+		MRN        *string `json:"mrn"`
+		TotalCount string  `json:"totalCount"`
 	}
 
-	err := s.client.Prisma.QueryRaw(query, args...).Exec(ctx, &queryRes)
+	err := s.client.Prisma.QueryRaw(query, args.values...).Exec(ctx, &queryRes)
 	if err != nil {
 		log.Println("=====ERROR=====")
 		return nil, err
 	}
 
-	log.Println("=====QUERYRES=====")
-	log.Println(queryRes)
-
 	if len(queryRes) == 0 {
 		return nil, nil
 	}
@@ -109,13 +237,15 @@ func (s *Patient) List(ctx context.Context, req *models.Paginate) (*models.ListP
 	}
 
 	for _, p := range queryRes {
-		res.Patients = append(res.Patients, &models.ListPatientItem{
-			ID:       p.ID,
-			FullName: p.FullName,
-			Gender:   p.Gender,
-			Age:      p.Age,
-			DOB:      p.DOB,
-		})
+		item := p.ListPatientItem
+		if p.MRN != nil {
+			item.MRN = *p.MRN
+		}
+		if item.Score != nil {
+			rounded := math.Round(*item.Score*100) / 100
+			item.Score = &rounded
+		}
+		res.Patients = append(res.Patients, &item)
 	}
 
 	totalPages := (totalItems + req.PageSize - 1) / req.PageSize
@@ -140,24 +270,160 @@ func (s *Patient) List(ctx context.Context, req *models.Paginate) (*models.ListP
 	return res, nil
 }
 
+// estimatedCountCap bounds the count run for filtered cursor-mode lists.
+const estimatedCountCap = 10000
+
+// ListByCursor lists patients using keyset pagination on (createdAt, id),
+// which stays fast on large tables where OFFSET does not. The structured
+// filters apply as in List; the ordering is always by registration time.
+func (s *Patient) ListByCursor(ctx context.Context, req *models.Paginate, filter *models.PatientFilter) (*models.ListPatientRes, error) {
+	args := &queryArgs{}
+	where, _ := preparePatientListFilters(args, req, filter)
+	filterArgs := append([]interface{}{}, args.values...)
+
+	desc := filter == nil || filter.SortDir != "asc"
+	backward := req.After != nil && req.After.Backward
+
+	// Paging backwards scans in the opposite order from the cursor and the
+	// rows are reversed afterwards.
+	cmp, dir := ">", "ASC"
+	if desc != backward {
+		cmp, dir = "<", "DESC"
+	}
+
+	keyset := where
+	if req.After != nil {
+		keyset += fmt.Sprintf(` AND (p."createdAt", p.id) %s (%s::timestamp, %s)`,
+			cmp, args.add(req.After.CreatedAt), args.add(req.After.ID))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			p.id,
+			p.mrn,
+			p."fullName",
+			p.gender,
+			p.age,
+			p."dateOfBirth" AS dob,
+			p."createdAt"
+		FROM
+			"Patient" p
+		WHERE
+			%s
+		ORDER BY
+			p."createdAt" %s, p.id %s
+		LIMIT %s;
+	`, keyset, dir, dir, args.add(req.PageSize+1))
+
+	var rows []struct {
+		models.ListPatientItem
+		MRN *string `json:"mrn"`
+	}
+
+	if err := s.client.Prisma.QueryRaw(query, args.values...).Exec(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	hasMore := int64(len(rows)) > req.PageSize
+	if hasMore {
+		rows = rows[:req.PageSize]
+	}
+
+	res := &models.ListPatientRes{
+		Patients: make([]*models.ListPatientItem, 0, len(rows)),
+	}
+	for _, p := range rows {
+		item := p.ListPatientItem
+		if p.MRN != nil {
+			item.MRN = *p.MRN
+		}
+		res.Patients = append(res.Patients, &item)
+	}
+	if backward {
+		slices.Reverse(res.Patients)
+	}
+
+	meta := &models.CursorMetadata{PageSize: req.PageSize}
+	if backward {
+		meta.HasNext, meta.HasPrevious = true, hasMore
+	} else {
+		meta.HasNext, meta.HasPrevious = hasMore, req.After != nil
+	}
+
+	if n := len(res.Patients); n > 0 {
+		var err error
+		if meta.HasNext {
+			last := res.Patients[n-1]
+			meta.Next, err = helpers.EncodeCursor(models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if meta.HasPrevious {
+			first := res.Patients[0]
+			meta.Previous, err = helpers.EncodeCursor(models.Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if req.WithCount {
+		estimate, err := s.estimateCount(ctx, where, filterArgs)
+		if err != nil {
+			return nil, err
+		}
+		meta.Estimated = &estimate
+	}
+
+	res.Cursor = meta
+	return res, nil
+}
+
+// estimateCount returns the planner's row estimate for the whole table when
+// the list is unfiltered, and a count capped at estimatedCountCap otherwise.
+func (s *Patient) estimateCount(ctx context.Context, where string, args []interface{}) (int64, error) {
+	var query string
+	if len(args) == 0 {
+		query = `SELECT GREATEST(reltuples, 0)::bigint AS "count" FROM pg_class WHERE relname = 'Patient';`
+	} else {
+		query = fmt.Sprintf(`
+			SELECT COUNT(*) AS "count"
+			FROM (SELECT 1 FROM "Patient" p WHERE %s LIMIT %d) t;
+		`, where, estimatedCountCap)
+	}
+
+	var rows []struct {
+		Count string `json:"count"`
+	}
+	if err := s.client.Prisma.QueryRaw(query, args...).Exec(ctx, &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseInt(rows[0].Count, 10, 64)
+}
+
 func (s *Patient) Update(ctx context.Context, req *models.UpdatePatientReq) (*models.Patient, error) {
 	update := preparePatientUpdateParams(req)
 
-	fmt.Println("ID", req.ID)
-
-	p, err := s.client.Patient.FindUnique(
+	updated := s.client.Patient.FindUnique(
 		db.Patient.ID.Equals(req.ID),
 	).With(
 		db.Patient.RegisteredBy.Fetch(),
 	).Update(
 		update...,
-	).Exec(ctx)
-	if err != nil {
+	).Tx()
+
+	edit := recordEdit(s.client, req.ID, editPatient, req.ID, req.EditedByID, editedFields(req))
+	if err := s.client.Prisma.Transaction(updated, edit).Exec(ctx); err != nil {
 		if ok := db.IsErrNotFound(err); ok {
 			return nil, ErrPatientNotFound
 		}
 		return nil, err
 	}
+	p := updated.Result()
 
 	patient := models.Patient{
 		ID:                p.ID,
@@ -176,6 +442,12 @@ func (s *Patient) Update(ctx context.Context, req *models.UpdatePatientReq) (*mo
 		UpdatedAt:         &p.UpdatedAt,
 		Version:           p.Version,
 	}
+	if mrn, ok := p.Mrn(); ok {
+		patient.MRN = mrn
+	}
+	if ward, ok := p.Ward(); ok {
+		patient.Ward = ward
+	}
 	return &patient, nil
 }
 
@@ -196,10 +468,25 @@ func (s *Patient) Get(ctx context.Context, pID string) (*models.Record, error) {
 	patient, err := s.client.Patient.FindUnique(
 		db.Patient.ID.Equals(pID),
 	).With(
-		db.Patient.Conditions.Fetch(),
+		db.Patient.Conditions.Fetch().With(
+			db.Condition.Transitions.Fetch().OrderBy(
+				db.ConditionTransition.ChangedAt.Order(db.SortOrderAsc),
+			),
+		),
 		db.Patient.Diagnoses.Fetch(),
 		db.Patient.Allergies.Fetch(),
-		db.Patient.Diagnoses.Fetch(),
+		db.Patient.Medications.Fetch(
+			currentMedications()...,
+		).OrderBy(
+			db.Medication.StartDate.Order(db.SortOrderDesc),
+		),
+		db.Patient.Vitals.Fetch().OrderBy(
+			db.Vital.MeasuredAt.Order(db.SortOrderDesc),
+		).Take(1),
+		db.Patient.FamilyHistory.Fetch().OrderBy(
+			db.FamilyHistory.CreatedAt.Order(db.SortOrderAsc),
+		),
+		db.Patient.SocialHistory.Fetch(),
 	).Exec(ctx)
 	if err != nil {
 		if ok := db.IsErrNotFound(err); ok {
@@ -208,6 +495,10 @@ func (s *Patient) Get(ctx context.Context, pID string) (*models.Record, error) {
 		return nil, err
 	}
 
+	if into, ok := patient.MergedIntoID(); ok {
+		return nil, &PatientMergedError{TargetID: into}
+	}
+
 	var updatedAt *time.Time
 	if patient.Version == 0 {
 		updatedAt = &patient.UpdatedAt
@@ -231,44 +522,88 @@ func (s *Patient) Get(ctx context.Context, pID string) (*models.Record, error) {
 			Version:           patient.Version,
 		},
 	}
+	if mrn, ok := patient.Mrn(); ok {
+		record.Patient.MRN = mrn
+	}
+	if ward, ok := patient.Ward(); ok {
+		record.Patient.Ward = ward
+	}
+	setDuplicateOverride(&record.Patient, patient)
 
 	for _, a := range patient.Allergies() {
-		allergy := models.AllergyModel{
-			ID:         a.ID,
-			PatientID:  a.PatientID,
-			Name:       a.Name,
-			Reaction:   a.Reaction,
-			Severity:   a.Severity,
-			RecordedAt: a.RecordedAt,
-		}
-		updatedAt, ok := a.UpdatedAt()
-		if ok {
-			allergy.UpdatedAt = &updatedAt
+		am, err := toAllergyModel(&a)
+		if err != nil {
+			return nil, err
 		}
-		record.Allergies = append(record.Allergies, allergy)
+		record.Allergies = append(record.Allergies, models.AllergyModel{
+			ID:                 am.ID,
+			PatientID:          am.PatientID,
+			Name:               am.Name,
+			Reaction:           am.Reaction,
+			Severity:           am.Severity,
+			Category:           am.Category,
+			Substance:          am.Substance,
+			Criticality:        am.Criticality,
+			VerificationStatus: am.VerificationStatus,
+			Reactions:          am.Reactions,
+			EncounterID:        am.EncounterID,
+			RecordedAt:         am.CreatedAt,
+			UpdatedAt:          am.UpdatedAt,
+		})
+	}
+	if assertedAt, ok := patient.NoKnownAllergiesAt(); ok {
+		record.NoKnownAllergies = &models.NoKnownAllergies{AssertedAt: assertedAt}
+		record.NoKnownAllergies.AssertedByID, _ = patient.NoKnownAllergiesByID()
 	}
 
 	for _, c := range patient.Conditions() {
+		cm := toConditionModel(&c)
 		condition := models.ConditionModel{
-			ID:        c.ID,
-			PatientID: c.PatientID,
-			Name:      c.Name,
-			CreatedAt: c.CreatedAt,
+			ID:                 cm.ID,
+			PatientID:          cm.PatientID,
+			Name:               cm.Name,
+			ClinicalStatus:     cm.ClinicalStatus,
+			VerificationStatus: cm.VerificationStatus,
+			OnsetDate:          cm.OnsetDate,
+			AbatementDate:      cm.AbatementDate,
+			Note:               cm.Note,
+			EncounterID:        cm.EncounterID,
+			CreatedAt:          cm.CreatedAt,
+			UpdatedAt:          cm.UpdatedAt,
+			History:            []models.ConditionTransition{},
 		}
-		updatedAt, ok := c.UpdatedAt()
-		if ok {
-			condition.UpdatedAt = &updatedAt
+		for _, t := range c.Transitions() {
+			condition.History = append(condition.History, toConditionTransitionModel(&t))
 		}
 		record.Conditions = append(record.Conditions, condition)
 	}
 
+	record.Medications = []models.Medication{}
+	for _, m := range patient.Medications() {
+		med, err := toMedicationModel(&m)
+		if err != nil {
+			return nil, err
+		}
+		record.Medications = append(record.Medications, *med)
+	}
+
 	for _, d := range patient.Diagnoses() {
 		diagnosis := models.DiagnosesModel{
 			ID:        d.ID,
 			PatientID: d.PatientID,
 			Name:      d.Name,
+			Type:      d.Type,
 			CreatedAt: d.CreatedAt,
 		}
+		if code, ok := d.Code(); ok {
+			diagnosis.Code = code
+		}
+		if display, ok := d.Display(); ok {
+			diagnosis.Display = display
+		}
+		if encounter, ok := d.EncounterID(); ok {
+			diagnosis.EncounterID = encounter
+		}
 		updatedAt, ok := d.UpdatedAt()
 		if ok {
 			diagnosis.UpdatedAt = &updatedAt
@@ -276,46 +611,19 @@ func (s *Patient) Get(ctx context.Context, pID string) (*models.Record, error) {
 		record.Diagnoses = append(record.Diagnoses, diagnosis)
 	}
 
-	var vitals models.VitalModel
-
-	v, ok := patient.Vitals()
-	if ok {
-		vitals.ID = v.ID
-		vitals.PatientID = v.PatientID
-		vitals.CreatedAt = &v.CreatedAt
-		vitals.UpdatedAt = &v.UpdatedAt
-
-		if height, ok := v.HeightCm(); ok {
-			vitals.HeightCm = &height
-		}
-
-		if weight, ok := v.WeightKg(); ok {
-			vitals.WeightKg = &weight
-		}
-		if bmi, ok := v.Bmi(); ok {
-			vitals.BMI = &bmi
-		}
-		if temperature, ok := v.TemperatureC(); ok {
-			vitals.TemperatureC = &temperature
-		}
-		if pulse, ok := v.Pulse(); ok {
-			vitals.Pulse = &pulse
-		}
-		if respiratoryRate, ok := v.RespiratoryRate(); ok {
-			vitals.RespiratoryRate = &respiratoryRate
-		}
-		if systolic, ok := v.BloodPressureSystolic(); ok {
-			vitals.BloodPressureSystolic = &systolic
-		}
-		if diastolic, ok := v.BloodPressureDiastolic(); ok {
-			vitals.BloodPressureDiastolic = &diastolic
-		}
-		if oxygenSaturation, ok := v.OxygenSaturation(); ok {
-			vitals.OxygenSaturation = &oxygenSaturation
-		}
+	// the record carries the most recent observation only
+	if vitals := patient.Vitals(); len(vitals) > 0 {
+		record.Vitals = toVitalModel(&vitals[0])
+		record.Vitals.ApplyFlags(patient.Gender, patient.DateOfBirth)
 	}
 
-	record.Vitals = vitals
+	record.FamilyHistory = []models.FamilyHistory{}
+	for i := range patient.FamilyHistory() {
+		record.FamilyHistory = append(record.FamilyHistory, *toFamilyHistoryModel(&patient.FamilyHistory()[i]))
+	}
+	if history, ok := patient.SocialHistory(); ok {
+		record.SocialHistory = toSocialHistoryModel(history, patient.DateOfBirth)
+	}
 
 	return record, nil
 }
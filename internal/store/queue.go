@@ -0,0 +1,292 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+	"github.com/vaidik-bajpai/medibridge/internal/queue"
+)
+
+var (
+	ErrQueueEntryNotFound = errors.New("queue entry not found")
+
+	// ErrAlreadyQueued is returned when a patient checks in while still
+	// waiting or in consultation.
+	ErrAlreadyQueued = errors.New("patient is already in the queue")
+
+	// ErrQueueTransition is returned when an entry can't move from its
+	// status to the requested one, e.g. once it is done.
+	ErrQueueTransition = errors.New("queue entry can't move to the requested status")
+)
+
+type Queue struct {
+	client *db.PrismaClient
+}
+
+// CheckIn adds a registered patient to the walk-in queue.
+func (s *Queue) CheckIn(ctx context.Context, req *models.CheckInReq) (*models.QueueEntry, error) {
+	optional := []db.QueueEntrySetParam{
+		db.QueueEntry.ActivePatient.Set(req.PatientID),
+	}
+	if req.Priority != "" {
+		optional = append(optional, db.QueueEntry.Priority.Set(req.Priority))
+	}
+	if req.Complaint != "" {
+		optional = append(optional, db.QueueEntry.Complaint.Set(req.Complaint))
+	}
+	if req.CheckedInByID != "" {
+		optional = append(optional, db.QueueEntry.CheckedInBy.Link(
+			db.User.ID.Equals(req.CheckedInByID),
+		))
+	}
+
+	entry, err := s.client.QueueEntry.CreateOne(
+		db.QueueEntry.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		optional...,
+	).With(
+		db.QueueEntry.Patient.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if _, ok := db.IsErrUniqueConstraint(err); ok {
+			return nil, ErrAlreadyQueued
+		}
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	return toQueueEntryModel(entry), nil
+}
+
+// Triage sets the priority of a patient still in the queue.
+func (s *Queue) Triage(ctx context.Context, req *models.TriageReq) (*models.QueueEntry, error) {
+	entry, err := s.findEntry(ctx, req.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status == queue.StatusDone {
+		return nil, ErrQueueTransition
+	}
+
+	// only triage the entry while it still has the status checked, so that
+	// a patient done in the meantime keeps their priority
+	res, err := s.client.QueueEntry.FindMany(
+		db.QueueEntry.ID.Equals(req.EntryID),
+		db.QueueEntry.Status.Equals(entry.Status),
+	).Update(
+		db.QueueEntry.Priority.Set(req.Priority),
+		db.QueueEntry.UpdatedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		return nil, ErrQueueTransition
+	}
+
+	return s.entry(ctx, req.EntryID)
+}
+
+// UpdateStatus moves a patient along the queue. Calling a patient in
+// records the doctor and the time; a patient sent back to wait keeps their
+// place by check-in time. Once done the patient may check in again.
+func (s *Queue) UpdateStatus(ctx context.Context, req *models.QueueStatusReq) (*models.QueueEntry, error) {
+	entry, err := s.findEntry(ctx, req.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	if !queue.CanTransition(entry.Status, req.Status) {
+		return nil, ErrQueueTransition
+	}
+
+	now := time.Now()
+	params := []db.QueueEntrySetParam{
+		db.QueueEntry.Status.Set(req.Status),
+		db.QueueEntry.UpdatedAt.Set(now),
+	}
+	switch req.Status {
+	case queue.StatusInConsultation:
+		params = append(params, db.QueueEntry.CalledAt.Set(now))
+		if req.DoctorID != "" {
+			params = append(params, db.QueueEntry.Doctor.Link(
+				db.User.ID.Equals(req.DoctorID),
+			))
+		}
+	case queue.StatusWaiting:
+		params = append(params,
+			db.QueueEntry.CalledAt.SetOptional(nil),
+			db.QueueEntry.Doctor.Unlink(),
+		)
+	case queue.StatusDone:
+		params = append(params,
+			db.QueueEntry.CompletedAt.Set(now),
+			db.QueueEntry.ActivePatient.SetOptional(nil),
+		)
+	}
+
+	// only move the entry while it still has the status checked, so that
+	// two doctors calling in the same patient don't both succeed
+	res, err := s.client.QueueEntry.FindMany(
+		db.QueueEntry.ID.Equals(req.EntryID),
+		db.QueueEntry.Status.Equals(entry.Status),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		return nil, ErrQueueTransition
+	}
+
+	return s.entry(ctx, req.EntryID)
+}
+
+// Board returns the walk-in queue of the day, which is midnight in the
+// clinic's time zone. Patients still waiting or in consultation from an
+// earlier day stay on the board until they are done.
+func (s *Queue) Board(ctx context.Context, day time.Time) (*models.QueueBoard, error) {
+	earlier, err := s.client.QueueEntry.FindMany(
+		db.QueueEntry.CheckedInAt.Lt(day),
+		db.QueueEntry.Status.In([]string{queue.StatusWaiting, queue.StatusInConsultation}),
+	).With(
+		db.QueueEntry.Patient.Fetch(),
+	).OrderBy(
+		db.QueueEntry.CheckedInAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	today, err := s.client.QueueEntry.FindMany(
+		db.QueueEntry.CheckedInAt.Gte(day),
+		db.QueueEntry.CheckedInAt.Lt(day.AddDate(0, 0, 1)),
+	).With(
+		db.QueueEntry.Patient.Fetch(),
+	).OrderBy(
+		db.QueueEntry.CheckedInAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &models.QueueBoard{
+		Date:           models.DateOnly(time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)),
+		Waiting:        []models.QueueEntry{},
+		InConsultation: []models.QueueEntry{},
+		Done:           []models.QueueEntry{},
+	}
+
+	var (
+		waiting  []queue.Waiting
+		entries  []*models.QueueEntry
+		lengths  []time.Duration
+		doctors  = make(map[string]bool)
+		consults int
+	)
+	for _, e := range append(earlier, today...) {
+		entry := toQueueEntryModel(&e)
+		switch e.Status {
+		case queue.StatusWaiting:
+			waiting = append(waiting, queue.Waiting{Priority: e.Priority, CheckedInAt: e.CheckedInAt})
+			entries = append(entries, entry)
+		case queue.StatusInConsultation:
+			consults++
+			if entry.DoctorID != "" {
+				doctors[entry.DoctorID] = true
+			}
+			board.InConsultation = append(board.InConsultation, *entry)
+		case queue.StatusDone:
+			if entry.CalledAt != nil && entry.CompletedAt != nil {
+				lengths = append(lengths, entry.CompletedAt.Sub(*entry.CalledAt))
+			}
+			if entry.DoctorID != "" {
+				doctors[entry.DoctorID] = true
+			}
+			board.Done = append(board.Done, *entry)
+		}
+	}
+
+	average := queue.AverageConsultation(lengths)
+	board.AverageConsultationMinutes = int(average.Round(time.Minute) / time.Minute)
+
+	order := queue.Order(waiting)
+	waits := queue.EstimateWaits(len(order), average, len(doctors), consults)
+	for i, idx := range order {
+		entry := entries[idx]
+		minutes := int(waits[i].Round(time.Minute) / time.Minute)
+		entry.Position = i + 1
+		entry.EstimatedWaitMinutes = &minutes
+		board.Waiting = append(board.Waiting, *entry)
+	}
+
+	return board, nil
+}
+
+func (s *Queue) findEntry(ctx context.Context, id string) (*db.QueueEntryModel, error) {
+	entry, err := s.client.QueueEntry.FindUnique(
+		db.QueueEntry.ID.Equals(id),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrQueueEntryNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// entry returns the entry with the patient it is for.
+func (s *Queue) entry(ctx context.Context, id string) (*models.QueueEntry, error) {
+	entry, err := s.client.QueueEntry.FindUnique(
+		db.QueueEntry.ID.Equals(id),
+	).With(
+		db.QueueEntry.Patient.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrQueueEntryNotFound
+		}
+		return nil, err
+	}
+	return toQueueEntryModel(entry), nil
+}
+
+func toQueueEntryModel(e *db.QueueEntryModel) *models.QueueEntry {
+	entry := &models.QueueEntry{
+		ID:          e.ID,
+		PatientID:   e.PatientID,
+		Priority:    e.Priority,
+		Status:      e.Status,
+		CheckedInAt: e.CheckedInAt,
+	}
+	if p := e.Patient(); p != nil {
+		entry.PatientName = p.FullName
+		if mrn, ok := p.Mrn(); ok {
+			entry.MRN = mrn
+		}
+	}
+	if complaint, ok := e.Complaint(); ok {
+		entry.Complaint = complaint
+	}
+	if doctor, ok := e.DoctorID(); ok {
+		entry.DoctorID = doctor
+	}
+	if checkedInBy, ok := e.CheckedInByID(); ok {
+		entry.CheckedInByID = checkedInBy
+	}
+	if calledAt, ok := e.CalledAt(); ok {
+		entry.CalledAt = &calledAt
+	}
+	if completedAt, ok := e.CompletedAt(); ok {
+		entry.CompletedAt = &completedAt
+	}
+	if updatedAt, ok := e.UpdatedAt(); ok {
+		entry.UpdatedAt = &updatedAt
+	}
+	return entry
+}
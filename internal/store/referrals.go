@@ -0,0 +1,316 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+var (
+	ErrReferralNotFound = errors.New("referral not found")
+
+	// ErrRecipientNotDoctor is returned when a patient would be referred to
+	// a user who isn't a doctor.
+	ErrRecipientNotDoctor = errors.New("referrals can only be made to doctors")
+
+	// ErrSelfReferral is returned when a doctor would refer a patient to
+	// themselves.
+	ErrSelfReferral = errors.New("a doctor can't refer a patient to themselves")
+
+	// ErrNotReferralParty is returned when someone answers a referral who
+	// may not: the doctor referred to answers it, or the referring doctor
+	// for an outside facility, and only the referring doctor cancels it.
+	ErrNotReferralParty = errors.New("referral can't be changed by this user")
+
+	// ErrReferralTransition is returned when a referral can't move from
+	// its status to the one asked for.
+	ErrReferralTransition = errors.New("referral can't change to this status")
+)
+
+type Referrals struct {
+	client *db.PrismaClient
+}
+
+// Create refers the patient to a doctor of the clinic or to an outside
+// facility, with a snapshot of the patient's record.
+func (s *Referrals) Create(ctx context.Context, req *models.CreateReferralReq) (*models.Referral, error) {
+	if req.ToDoctorID != "" {
+		if req.ToDoctorID == req.ReferredByID {
+			return nil, ErrSelfReferral
+		}
+		doctor, err := s.client.User.FindUnique(
+			db.User.ID.Equals(req.ToDoctorID),
+		).Exec(ctx)
+		if err != nil {
+			if ok := db.IsErrNotFound(err); ok {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+		if doctor.Role != db.RoleDoctor {
+			return nil, ErrRecipientNotDoctor
+		}
+	}
+
+	record, err := (&Patient{client: s.client}).Get(ctx, req.PatientID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	urgency := req.Urgency
+	if urgency == "" {
+		urgency = clinical.UrgencyRoutine
+	}
+	optional := []db.ReferralSetParam{
+		db.Referral.Urgency.Set(urgency),
+	}
+	if req.ToDoctorID != "" {
+		optional = append(optional, db.Referral.ToDoctor.Link(
+			db.User.ID.Equals(req.ToDoctorID),
+		))
+	}
+	if req.Facility != nil {
+		optional = append(optional, db.Referral.FacilityName.Set(req.Facility.Name))
+		if req.Facility.Contact != "" {
+			optional = append(optional, db.Referral.FacilityContact.Set(req.Facility.Contact))
+		}
+	}
+	if req.Specialty != "" {
+		optional = append(optional, db.Referral.Specialty.Set(req.Specialty))
+	}
+
+	referral, err := s.client.Referral.CreateOne(
+		db.Referral.Patient.Link(
+			db.Patient.ID.Equals(req.PatientID),
+		),
+		db.Referral.ReferredBy.Link(
+			db.User.ID.Equals(req.ReferredByID),
+		),
+		db.Referral.Reason.Set(req.Reason),
+		db.Referral.Summary.Set(summary),
+		optional...,
+	).With(
+		referralParties()...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReferralModel(referral, false)
+}
+
+// Get returns a referral with the snapshot of the patient's record.
+func (s *Referrals) Get(ctx context.Context, id string) (*models.Referral, error) {
+	referral, err := s.client.Referral.FindUnique(
+		db.Referral.ID.Equals(id),
+	).With(
+		referralParties()...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrReferralNotFound
+		}
+		return nil, err
+	}
+	return toReferralModel(referral, true)
+}
+
+// List returns the patient's referrals, newest first.
+func (s *Referrals) List(ctx context.Context, pID string) ([]*models.Referral, error) {
+	referrals, err := s.client.Referral.FindMany(
+		db.Referral.PatientID.Equals(pID),
+	).With(
+		referralParties()...,
+	).OrderBy(
+		db.Referral.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toReferralModels(referrals)
+}
+
+// Inbox returns the referrals made to the user, or the ones they made,
+// newest first.
+func (s *Referrals) Inbox(ctx context.Context, req *models.ReferralQuery) ([]*models.Referral, error) {
+	where := []db.ReferralWhereParam{
+		db.Referral.ToDoctorID.Equals(req.UserID),
+	}
+	if req.Box == "sent" {
+		where = []db.ReferralWhereParam{
+			db.Referral.ReferredByID.Equals(req.UserID),
+		}
+	}
+	if req.Status != "" {
+		where = append(where, db.Referral.Status.Equals(req.Status))
+	}
+	if req.Urgency != "" {
+		where = append(where, db.Referral.Urgency.Equals(req.Urgency))
+	}
+
+	referrals, err := s.client.Referral.FindMany(
+		where...,
+	).With(
+		referralParties()...,
+	).OrderBy(
+		db.Referral.CreatedAt.Order(db.SortOrderDesc),
+	).Take(req.Limit).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toReferralModels(referrals)
+}
+
+// Transition accepts, declines, completes or cancels a referral.
+func (s *Referrals) Transition(ctx context.Context, req *models.ReferralActionReq) (*models.Referral, error) {
+	current, err := s.client.Referral.FindUnique(
+		db.Referral.ID.Equals(req.ReferralID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrReferralNotFound
+		}
+		return nil, err
+	}
+
+	// the doctor referred to answers the referral; an outside facility
+	// can't answer in the app, so the referring doctor records its answers
+	party := current.ReferredByID
+	if _, external := current.FacilityName(); !external {
+		// no one can answer once the doctor's account is deleted
+		party, _ = current.ToDoctorID()
+	}
+	if req.Status == clinical.ReferralCancelled {
+		party = current.ReferredByID
+	}
+	if req.UserID != party {
+		return nil, ErrNotReferralParty
+	}
+
+	if !clinical.CanTransitionReferral(current.Status, req.Status) {
+		return nil, ErrReferralTransition
+	}
+
+	now := time.Now()
+	update := []db.ReferralSetParam{
+		db.Referral.Status.Set(req.Status),
+		db.Referral.UpdatedAt.Set(now),
+	}
+	switch req.Status {
+	case clinical.ReferralAccepted, clinical.ReferralDeclined:
+		update = append(update, db.Referral.RespondedAt.Set(now))
+	case clinical.ReferralCompleted:
+		update = append(update, db.Referral.CompletedAt.Set(now))
+	}
+	if req.Note != "" {
+		update = append(update, db.Referral.ResponseNote.Set(req.Note))
+	}
+
+	// only update the referral while it still has the status checked, so
+	// that answers at the same time don't overwrite each other
+	res, err := s.client.Referral.FindMany(
+		db.Referral.ID.Equals(req.ReferralID),
+		db.Referral.Status.Equals(current.Status),
+	).Update(update...).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		return nil, ErrReferralTransition
+	}
+
+	referral, err := s.client.Referral.FindUnique(
+		db.Referral.ID.Equals(req.ReferralID),
+	).With(
+		referralParties()...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toReferralModel(referral, false)
+}
+
+// referralParties fetches the patient and the doctors of a referral, for
+// their names.
+func referralParties() []db.ReferralRelationWith {
+	return []db.ReferralRelationWith{
+		db.Referral.Patient.Fetch(),
+		db.Referral.ReferredBy.Fetch(),
+		db.Referral.ToDoctor.Fetch(),
+	}
+}
+
+func toReferralModels(referrals []db.ReferralModel) ([]*models.Referral, error) {
+	list := make([]*models.Referral, 0, len(referrals))
+	for i := range referrals {
+		referral, err := toReferralModel(&referrals[i], false)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, referral)
+	}
+	return list, nil
+}
+
+// toReferralModel converts a referral fetched with referralParties. The
+// snapshot of the record is only decoded when withSummary is set.
+func toReferralModel(r *db.ReferralModel, withSummary bool) (*models.Referral, error) {
+	referral := &models.Referral{
+		ID:           r.ID,
+		PatientID:    r.PatientID,
+		PatientName:  r.Patient().FullName,
+		ReferredByID: r.ReferredByID,
+		Reason:       r.Reason,
+		Urgency:      r.Urgency,
+		Status:       r.Status,
+		CreatedAt:    r.CreatedAt,
+	}
+	if by := r.ReferredBy(); by != nil {
+		referral.ReferredByName = by.Fullname
+	}
+	if toDoctor, ok := r.ToDoctorID(); ok {
+		referral.ToDoctorID = toDoctor
+	}
+	if doctor, ok := r.ToDoctor(); ok {
+		referral.ToDoctorName = doctor.Fullname
+	}
+	if name, ok := r.FacilityName(); ok {
+		referral.Facility = &models.ReferralFacility{Name: name}
+		if contact, ok := r.FacilityContact(); ok {
+			referral.Facility.Contact = contact
+		}
+	}
+	if specialty, ok := r.Specialty(); ok {
+		referral.Specialty = specialty
+	}
+	if note, ok := r.ResponseNote(); ok {
+		referral.ResponseNote = note
+	}
+	if respondedAt, ok := r.RespondedAt(); ok {
+		referral.RespondedAt = &respondedAt
+	}
+	if completedAt, ok := r.CompletedAt(); ok {
+		referral.CompletedAt = &completedAt
+	}
+	if updatedAt, ok := r.UpdatedAt(); ok {
+		referral.UpdatedAt = &updatedAt
+	}
+
+	if withSummary {
+		var record models.Record
+		if err := json.Unmarshal(r.Summary, &record); err != nil {
+			return nil, err
+		}
+		referral.Summary = &record
+	}
+	return referral, nil
+}
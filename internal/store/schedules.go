@@ -0,0 +1,343 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+	"github.com/vaidik-bajpai/medibridge/internal/scheduling"
+)
+
+var (
+	ErrDoctorNotFound    = errors.New("doctor not found")
+	ErrExceptionNotFound = errors.New("schedule exception not found")
+)
+
+type Schedules struct {
+	client *db.PrismaClient
+}
+
+// doctorSchedule is what decides a doctor's free slots in a period.
+type doctorSchedule struct {
+	hours        []scheduling.WorkingHours
+	exceptions   []db.ScheduleExceptionModel
+	appointments []db.AppointmentModel
+}
+
+// loadSchedule loads the doctor's weekly template with the exceptions and
+// booked appointments that overlap the period. It fails with
+// ErrDoctorNotFound unless the user is a doctor.
+func loadSchedule(ctx context.Context, client *db.PrismaClient, doctorID string, period scheduling.Interval) (*doctorSchedule, error) {
+	doctor, err := client.User.FindUnique(
+		db.User.ID.Equals(doctorID),
+	).With(
+		db.User.WorkingHours.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrDoctorNotFound
+		}
+		return nil, err
+	}
+	if doctor.Role != db.RoleDoctor {
+		return nil, ErrDoctorNotFound
+	}
+
+	exceptions, err := client.ScheduleException.FindMany(
+		db.ScheduleException.DoctorID.Equals(doctorID),
+		db.ScheduleException.StartsAt.Lt(period.End),
+		db.ScheduleException.EndsAt.Gt(period.Start),
+	).OrderBy(
+		db.ScheduleException.StartsAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, err := client.Appointment.FindMany(
+		db.Appointment.DoctorID.Equals(doctorID),
+		db.Appointment.Status.Equals(scheduling.AppointmentBooked),
+		db.Appointment.StartsAt.Lt(period.End),
+		db.Appointment.EndsAt.Gt(period.Start),
+	).OrderBy(
+		db.Appointment.StartsAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &doctorSchedule{
+		exceptions:   exceptions,
+		appointments: appointments,
+	}
+	for _, h := range doctor.WorkingHours() {
+		schedule.hours = append(schedule.hours, toSchedulingHours(&h))
+	}
+	return schedule, nil
+}
+
+// blocked returns the exceptions and the booked appointments, except the
+// one with the ID ignored.
+func (d *doctorSchedule) blocked(ignored string) []scheduling.Interval {
+	var intervals []scheduling.Interval
+	for _, e := range d.exceptions {
+		intervals = append(intervals, scheduling.Interval{Start: e.StartsAt, End: e.EndsAt})
+	}
+	for _, a := range d.appointments {
+		if a.ID != ignored {
+			intervals = append(intervals, scheduling.Interval{Start: a.StartsAt, End: a.EndsAt})
+		}
+	}
+	return intervals
+}
+
+// SetWorkingHours replaces the doctor's weekly template.
+func (s *Schedules) SetWorkingHours(ctx context.Context, req *models.SetWorkingHoursReq) ([]*models.WorkingHours, error) {
+	doctor, err := s.client.User.FindUnique(
+		db.User.ID.Equals(req.DoctorID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrDoctorNotFound
+		}
+		return nil, err
+	}
+	if doctor.Role != db.RoleDoctor {
+		return nil, ErrDoctorNotFound
+	}
+
+	txs := []db.PrismaTransaction{
+		s.client.WorkingHours.FindMany(
+			db.WorkingHours.DoctorID.Equals(req.DoctorID),
+		).Delete().Tx(),
+	}
+	created := make([]db.WorkingHoursUniqueTxResult, 0, len(req.Template))
+	for _, h := range req.Template {
+		tx := s.client.WorkingHours.CreateOne(
+			db.WorkingHours.Doctor.Link(
+				db.User.ID.Equals(req.DoctorID),
+			),
+			db.WorkingHours.Weekday.Set(int(h.Weekday)),
+			db.WorkingHours.StartMinute.Set(h.Start),
+			db.WorkingHours.EndMinute.Set(h.End),
+			db.WorkingHours.SlotMinutes.Set(h.SlotMinutes),
+		).Tx()
+		created = append(created, tx)
+		txs = append(txs, tx)
+	}
+
+	if err := s.client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	hours := make([]*models.WorkingHours, 0, len(created))
+	for _, tx := range created {
+		hours = append(hours, toWorkingHoursModel(tx.Result()))
+	}
+	return hours, nil
+}
+
+// WorkingHours returns the doctor's weekly template, from Sunday.
+func (s *Schedules) WorkingHours(ctx context.Context, doctorID string) ([]*models.WorkingHours, error) {
+	records, err := s.client.WorkingHours.FindMany(
+		db.WorkingHours.DoctorID.Equals(doctorID),
+	).OrderBy(
+		db.WorkingHours.Weekday.Order(db.SortOrderAsc),
+	).OrderBy(
+		db.WorkingHours.StartMinute.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := make([]*models.WorkingHours, 0, len(records))
+	for i := range records {
+		hours = append(hours, toWorkingHoursModel(&records[i]))
+	}
+	return hours, nil
+}
+
+// AddException blocks out the doctor's time. Appointments already booked
+// in it are kept and returned as conflicts to be rescheduled.
+func (s *Schedules) AddException(ctx context.Context, req *models.AddScheduleExceptionReq) (*models.ScheduleException, error) {
+	schedule, err := loadSchedule(ctx, s.client, req.DoctorID, scheduling.Interval{
+		Start: req.StartsAt,
+		End:   req.EndsAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var optional []db.ScheduleExceptionSetParam
+	if req.Reason != "" {
+		optional = append(optional, db.ScheduleException.Reason.Set(req.Reason))
+	}
+	if req.CreatedByID != "" {
+		optional = append(optional, db.ScheduleException.CreatedBy.Link(
+			db.User.ID.Equals(req.CreatedByID),
+		))
+	}
+
+	e, err := s.client.ScheduleException.CreateOne(
+		db.ScheduleException.Doctor.Link(
+			db.User.ID.Equals(req.DoctorID),
+		),
+		db.ScheduleException.StartsAt.Set(req.StartsAt),
+		db.ScheduleException.EndsAt.Set(req.EndsAt),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exception := toScheduleExceptionModel(e)
+	for i := range schedule.appointments {
+		exception.ConflictingAppointments = append(exception.ConflictingAppointments,
+			*toAppointmentModel(&schedule.appointments[i]))
+	}
+	return exception, nil
+}
+
+// DeleteException removes one of the doctor's exceptions.
+func (s *Schedules) DeleteException(ctx context.Context, doctorID, exceptionID string) error {
+	e, err := s.client.ScheduleException.FindUnique(
+		db.ScheduleException.ID.Equals(exceptionID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrExceptionNotFound
+		}
+		return err
+	}
+	if e.DoctorID != doctorID {
+		return ErrExceptionNotFound
+	}
+
+	_, err = s.client.ScheduleException.FindUnique(
+		db.ScheduleException.ID.Equals(exceptionID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrExceptionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Slots returns the doctor's free slots in the period that haven't started
+// yet.
+func (s *Schedules) Slots(ctx context.Context, req *models.SlotQuery) ([]scheduling.Slot, error) {
+	from := req.From
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+	if !from.Before(req.To) {
+		return []scheduling.Slot{}, nil
+	}
+
+	schedule, err := loadSchedule(ctx, s.client, req.DoctorID, scheduling.Interval{Start: from, End: req.To})
+	if err != nil {
+		return nil, err
+	}
+	return scheduling.Slots(schedule.hours, from, req.To, schedule.blocked("")), nil
+}
+
+// Schedule returns the doctor's day or week: the working hours, the
+// exceptions, the booked appointments and the free slots of every day.
+func (s *Schedules) Schedule(ctx context.Context, req *models.ScheduleQuery) (*models.DoctorSchedule, error) {
+	start, days := req.Date, 1
+	if req.View == "week" {
+		// weeks start on Monday
+		offset := (int(start.Weekday()) + 6) % 7
+		start, days = start.AddDate(0, 0, -offset), 7
+	}
+	end := start.AddDate(0, 0, days)
+
+	schedule, err := loadSchedule(ctx, s.client, req.DoctorID, scheduling.Interval{Start: start, End: end})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	blocked := schedule.blocked("")
+	view := &models.DoctorSchedule{
+		DoctorID: req.DoctorID,
+		View:     req.View,
+		Days:     make([]models.ScheduleDay, 0, days),
+	}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		period := scheduling.Interval{Start: day, End: next}
+
+		d := models.ScheduleDay{
+			Date:         models.DateOnly(time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)),
+			Hours:        []models.WorkingPeriod{},
+			Exceptions:   []models.ScheduleException{},
+			Appointments: []models.Appointment{},
+		}
+		for _, h := range scheduling.DayHours(schedule.hours, day) {
+			d.Hours = append(d.Hours, models.WorkingPeriod{Start: h.Start, End: h.End})
+		}
+		for i, e := range schedule.exceptions {
+			if period.Overlaps(scheduling.Interval{Start: e.StartsAt, End: e.EndsAt}) {
+				d.Exceptions = append(d.Exceptions, *toScheduleExceptionModel(&schedule.exceptions[i]))
+			}
+		}
+		for i, a := range schedule.appointments {
+			if period.Overlaps(scheduling.Interval{Start: a.StartsAt, End: a.EndsAt}) {
+				d.Appointments = append(d.Appointments, *toAppointmentModel(&schedule.appointments[i]))
+			}
+		}
+
+		from := day
+		if from.Before(now) {
+			from = now
+		}
+		d.FreeSlots = []scheduling.Slot{}
+		if from.Before(next) {
+			d.FreeSlots = scheduling.Slots(schedule.hours, from, next, blocked)
+		}
+		view.Days = append(view.Days, d)
+	}
+	return view, nil
+}
+
+func toSchedulingHours(h *db.WorkingHoursModel) scheduling.WorkingHours {
+	return scheduling.WorkingHours{
+		Weekday:     time.Weekday(h.Weekday),
+		Start:       h.StartMinute,
+		End:         h.EndMinute,
+		SlotMinutes: h.SlotMinutes,
+	}
+}
+
+func toWorkingHoursModel(h *db.WorkingHoursModel) *models.WorkingHours {
+	return &models.WorkingHours{
+		ID:          h.ID,
+		DoctorID:    h.DoctorID,
+		Weekday:     h.Weekday,
+		Start:       scheduling.FormatClock(h.StartMinute),
+		End:         scheduling.FormatClock(h.EndMinute),
+		SlotMinutes: h.SlotMinutes,
+	}
+}
+
+func toScheduleExceptionModel(e *db.ScheduleExceptionModel) *models.ScheduleException {
+	exception := &models.ScheduleException{
+		ID:        e.ID,
+		DoctorID:  e.DoctorID,
+		StartsAt:  e.StartsAt,
+		EndsAt:    e.EndsAt,
+		CreatedAt: e.CreatedAt,
+	}
+	if reason, ok := e.Reason(); ok {
+		exception.Reason = reason
+	}
+	if createdBy, ok := e.CreatedByID(); ok {
+		exception.CreatedByID = createdBy
+	}
+	return exception
+}
@@ -2,9 +2,11 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+	"github.com/vaidik-bajpai/medibridge/internal/scheduling"
 )
 
 type UserStorer interface {
@@ -14,10 +16,15 @@ type UserStorer interface {
 
 type PatientStorer interface {
 	Create(context.Context, *models.RegPatientReq) (*models.Patient, error)
+	FindDuplicates(ctx context.Context, req *models.RegPatientReq) ([]*models.DuplicateCandidate, error)
 	Update(context.Context, *models.UpdatePatientReq) (*models.Patient, error)
 	Delete(ctx context.Context, pID string) error
-	List(ctx context.Context, req *models.Paginate) (*models.ListPatientRes, error)
+	List(ctx context.Context, req *models.Paginate, filter *models.PatientFilter) (*models.ListPatientRes, error)
+	ListByCursor(ctx context.Context, req *models.Paginate, filter *models.PatientFilter) (*models.ListPatientRes, error)
 	Get(ctx context.Context, pID string) (*models.Record, error)
+	Merge(ctx context.Context, req *models.MergePatientReq) (*models.PatientMerge, error)
+	Unmerge(ctx context.Context, req *models.UnmergePatientReq) (*models.PatientMerge, error)
+	Timeline(ctx context.Context, req *models.TimelineQuery) (*models.Timeline, error)
 }
 
 type SessionStorer interface {
@@ -32,40 +39,172 @@ type DiagnosesStorer interface {
 }
 
 type VitalsStorer interface {
-	Create(ctx context.Context, req *models.CreateVitalReq) error
-	Update(ctx context.Context, req *models.UpdateVitalReq) error
-	Delete(ctx context.Context, pID string) error
+	Create(ctx context.Context, req *models.CreateVitalReq) (*models.VitalModel, error)
+	Update(ctx context.Context, req *models.UpdateVitalReq) (*models.VitalModel, error)
+	Delete(ctx context.Context, vID string) error
+	List(ctx context.Context, req *models.VitalsQuery) ([]*models.VitalModel, error)
+	Latest(ctx context.Context, pID string) (models.LatestVitals, error)
+	Series(ctx context.Context, req *models.VitalsQuery) (*models.VitalSeries, error)
+	WardNEWS2(ctx context.Context, ward string) ([]*models.WardNEWS2Item, error)
+	Growth(ctx context.Context, req *models.GrowthQuery) (*models.GrowthCharts, error)
 }
 
 type ConditionStorer interface {
 	Add(ctx context.Context, req *models.AddConditionReq) (*models.Condition, error)
-	Delete(ctx context.Context, pID string) error
+	Update(ctx context.Context, req *models.UpdateConditionReq) (*models.Condition, error)
 }
 
 type AllergyStorer interface {
 	Record(ctx context.Context, req *models.RegAllergyReq) (*models.Allergy, error)
 	Update(ctx context.Context, req *models.UpdateAllergyReq) (*models.Allergy, error)
+	List(ctx context.Context, pID string) ([]*models.Allergy, error)
 	Delete(ctx context.Context, aID string) error
+	AssertNoKnownAllergies(ctx context.Context, req *models.AssertNKAReq) (*models.NoKnownAllergies, error)
+}
+
+type MedicationStorer interface {
+	Prescribe(ctx context.Context, req *models.PrescribeMedicationReq) (*models.Medication, error)
+	Get(ctx context.Context, mID string) (*models.Medication, error)
+	Update(ctx context.Context, req *models.UpdateMedicationReq) (*models.Medication, error)
+	List(ctx context.Context, req *models.MedicationQuery) ([]*models.Medication, error)
+}
+
+type EncounterStorer interface {
+	Create(ctx context.Context, req *models.CreateEncounterReq) (*models.Encounter, error)
+	Get(ctx context.Context, eID string) (*models.EncounterRecord, error)
+	List(ctx context.Context, req *models.EncounterQuery) ([]*models.EncounterRecord, error)
+	Update(ctx context.Context, req *models.UpdateEncounterReq) (*models.Encounter, error)
+	Delete(ctx context.Context, eID string) error
+}
+
+type AlertStorer interface {
+	Raise(ctx context.Context, req *models.RaiseAlertsReq) ([]*models.Alert, error)
+	List(ctx context.Context, req *models.AlertQuery) ([]*models.Alert, error)
+	Acknowledge(ctx context.Context, req *models.AlertActionReq) (*models.Alert, error)
+	Resolve(ctx context.Context, req *models.AlertActionReq) (*models.Alert, error)
+}
+
+type CareTeamStorer interface {
+	Add(ctx context.Context, req *models.AddCareTeamMemberReq) (*models.CareTeamMember, error)
+	Remove(ctx context.Context, pID, userID string) error
+	List(ctx context.Context, pID string) ([]*models.CareTeamMember, error)
+}
+
+type ScheduleStorer interface {
+	SetWorkingHours(ctx context.Context, req *models.SetWorkingHoursReq) ([]*models.WorkingHours, error)
+	WorkingHours(ctx context.Context, doctorID string) ([]*models.WorkingHours, error)
+	AddException(ctx context.Context, req *models.AddScheduleExceptionReq) (*models.ScheduleException, error)
+	DeleteException(ctx context.Context, doctorID, exceptionID string) error
+	Slots(ctx context.Context, req *models.SlotQuery) ([]scheduling.Slot, error)
+	Schedule(ctx context.Context, req *models.ScheduleQuery) (*models.DoctorSchedule, error)
+}
+
+type AppointmentStorer interface {
+	Book(ctx context.Context, req *models.BookAppointmentReq) (*models.Appointment, error)
+	Reschedule(ctx context.Context, req *models.RescheduleAppointmentReq) (*models.Appointment, error)
+	Cancel(ctx context.Context, req *models.CancelAppointmentReq) (*models.Appointment, error)
+	List(ctx context.Context, pID string) ([]*models.Appointment, error)
+}
+
+type QueueStorer interface {
+	CheckIn(ctx context.Context, req *models.CheckInReq) (*models.QueueEntry, error)
+	Triage(ctx context.Context, req *models.TriageReq) (*models.QueueEntry, error)
+	UpdateStatus(ctx context.Context, req *models.QueueStatusReq) (*models.QueueEntry, error)
+	Board(ctx context.Context, day time.Time) (*models.QueueBoard, error)
+}
+
+type NoteStorer interface {
+	Create(ctx context.Context, req *models.CreateNoteReq) (*models.ClinicalNote, error)
+	Get(ctx context.Context, noteID, viewerID string) (*models.ClinicalNote, error)
+	List(ctx context.Context, req *models.NoteQuery) ([]*models.ClinicalNote, error)
+	Update(ctx context.Context, req *models.UpdateNoteReq) (*models.ClinicalNote, error)
+	Sign(ctx context.Context, noteID, authorID string) (*models.ClinicalNote, error)
+	Amend(ctx context.Context, req *models.AmendNoteReq) (*models.ClinicalNote, error)
+	Delete(ctx context.Context, noteID, authorID string) error
+}
+
+type LabStorer interface {
+	Order(ctx context.Context, req *models.CreateLabOrderReq) (*models.LabOrder, error)
+	GetOrder(ctx context.Context, orderID string) (*models.LabOrder, error)
+	ListOrders(ctx context.Context, req *models.LabOrderQuery) ([]*models.LabOrder, error)
+	CancelOrder(ctx context.Context, orderID string) (*models.LabOrder, error)
+	AddResults(ctx context.Context, req *models.AddLabResultsReq) (*models.AddLabResultsRes, error)
+	Cumulative(ctx context.Context, req *models.LabQuery) (*models.LabTable, error)
+}
+
+type ImmunizationStorer interface {
+	Record(ctx context.Context, req *models.RecordImmunizationReq) (*models.Immunization, error)
+	List(ctx context.Context, pID string) ([]*models.Immunization, error)
+	Delete(ctx context.Context, id string) error
+	Status(ctx context.Context, pID string, day time.Time) (*models.ImmunizationStatus, error)
+	Overdue(ctx context.Context, req *models.OverdueQuery) (*models.OverdueReport, error)
+}
+
+type FamilyHistoryStorer interface {
+	Add(ctx context.Context, req *models.AddFamilyHistoryReq) (*models.FamilyHistory, error)
+	Update(ctx context.Context, req *models.UpdateFamilyHistoryReq) (*models.FamilyHistory, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, pID string) ([]*models.FamilyHistory, error)
+}
+
+type SocialHistoryStorer interface {
+	Set(ctx context.Context, req *models.SetSocialHistoryReq) (*models.SocialHistory, error)
+	Get(ctx context.Context, pID string) (*models.SocialHistory, error)
+	Delete(ctx context.Context, pID string) error
+}
+
+type ReferralStorer interface {
+	Create(ctx context.Context, req *models.CreateReferralReq) (*models.Referral, error)
+	Get(ctx context.Context, id string) (*models.Referral, error)
+	List(ctx context.Context, pID string) ([]*models.Referral, error)
+	Inbox(ctx context.Context, req *models.ReferralQuery) ([]*models.Referral, error)
+	Transition(ctx context.Context, req *models.ReferralActionReq) (*models.Referral, error)
 }
 
 type Store struct {
-	User       UserStorer
-	Patient    PatientStorer
-	Session    SessionStorer
-	Diagnoses  DiagnosesStorer
-	Vitals     VitalsStorer
-	Conditions ConditionStorer
-	Allergy    AllergyStorer
+	User          UserStorer
+	Patient       PatientStorer
+	Session       SessionStorer
+	Diagnoses     DiagnosesStorer
+	Vitals        VitalsStorer
+	Conditions    ConditionStorer
+	Allergy       AllergyStorer
+	Medications   MedicationStorer
+	Encounters    EncounterStorer
+	Alerts        AlertStorer
+	CareTeam      CareTeamStorer
+	Schedules     ScheduleStorer
+	Appointments  AppointmentStorer
+	Queue         QueueStorer
+	Notes         NoteStorer
+	Labs          LabStorer
+	Immunizations ImmunizationStorer
+	FamilyHistory FamilyHistoryStorer
+	SocialHistory SocialHistoryStorer
+	Referrals     ReferralStorer
 }
 
 func NewStore(client *db.PrismaClient) *Store {
 	return &Store{
-		User:       &User{client: client},
-		Patient:    &Patient{client: client},
-		Session:    &Session{client: client},
-		Diagnoses:  &Diagnoses{client: client},
-		Vitals:     &Vitals{client: client},
-		Conditions: &Conditions{client: client},
-		Allergy:    &Allergy{client: client},
+		User:          &User{client: client},
+		Patient:       &Patient{client: client},
+		Session:       &Session{client: client},
+		Diagnoses:     &Diagnoses{client: client},
+		Vitals:        &Vitals{client: client},
+		Conditions:    &Conditions{client: client},
+		Allergy:       &Allergy{client: client},
+		Medications:   &Medications{client: client},
+		Encounters:    &Encounters{client: client},
+		Alerts:        &Alerts{client: client},
+		CareTeam:      &CareTeam{client: client},
+		Schedules:     &Schedules{client: client},
+		Appointments:  &Appointments{client: client},
+		Queue:         &Queue{client: client},
+		Notes:         &Notes{client: client},
+		Labs:          &Labs{client: client},
+		Immunizations: &Immunizations{client: client},
+		FamilyHistory: &FamilyHistory{client: client},
+		SocialHistory: &SocialHistory{client: client},
+		Referrals:     &Referrals{client: client},
 	}
 }
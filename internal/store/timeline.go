@@ -0,0 +1,575 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
+	"github.com/vaidik-bajpai/medibridge/internal/models"
+	"github.com/vaidik-bajpai/medibridge/internal/notes"
+	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
+)
+
+// Types of the events of a patient's timeline.
+const (
+	eventRegistration    = "registration"
+	eventVitals          = "vitals"
+	eventDiagnosis       = "diagnosis"
+	eventCondition       = "condition"
+	eventConditionStatus = "condition-status"
+	eventAllergy         = "allergy"
+	eventNote            = "note"
+	eventNoteAmendment   = "note-amendment"
+	eventReferral        = "referral"
+	eventEdit            = "edit"
+	eventEncounter       = "encounter"
+	eventMedication      = "medication"
+	eventLabResult       = "lab-result"
+	eventImmunization    = "immunization"
+)
+
+// Timeline returns a page of the events of the patient's record, newest
+// first, with the names of the users who caused them. Draft notes are only
+// shown to their author.
+func (s *Patient) Timeline(ctx context.Context, req *models.TimelineQuery) (*models.Timeline, error) {
+	// only the entries of the types asked for are fetched, within the
+	// period asked for
+	wants := func(types ...string) bool {
+		if len(req.Types) == 0 {
+			return true
+		}
+		return slices.ContainsFunc(types, func(t string) bool {
+			return slices.Contains(req.Types, t)
+		})
+	}
+
+	var with []db.PatientRelationWith
+	if wants(eventVitals) {
+		var where []db.VitalWhereParam
+		if req.From != nil {
+			where = append(where, db.Vital.MeasuredAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Vital.MeasuredAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Vitals.Fetch(where...))
+	}
+	if wants(eventDiagnosis) {
+		var where []db.DiagnosisWhereParam
+		if req.From != nil {
+			where = append(where, db.Diagnosis.CreatedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Diagnosis.CreatedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Diagnoses.Fetch(where...))
+	}
+	if wants(eventCondition, eventConditionStatus) {
+		// a condition recorded before the period may change status within it
+		var where []db.ConditionWhereParam
+		if req.From != nil && !wants(eventConditionStatus) {
+			where = append(where, db.Condition.CreatedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Condition.CreatedAt.Lte(*req.To))
+		}
+		var transitions []db.ConditionTransitionWhereParam
+		if req.From != nil {
+			transitions = append(transitions, db.ConditionTransition.ChangedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			transitions = append(transitions, db.ConditionTransition.ChangedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Conditions.Fetch(where...).With(
+			db.Condition.Transitions.Fetch(transitions...),
+		))
+	}
+	if wants(eventAllergy) {
+		var where []db.AllergyWhereParam
+		if req.From != nil {
+			where = append(where, db.Allergy.RecordedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Allergy.RecordedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Allergies.Fetch(where...))
+	}
+	if wants(eventNote, eventNoteAmendment) {
+		// a note written before the period may be amended within it
+		var where []db.ClinicalNoteWhereParam
+		if req.From != nil && !wants(eventNoteAmendment) {
+			where = append(where, db.ClinicalNote.CreatedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.ClinicalNote.CreatedAt.Lte(*req.To))
+		}
+		var amendments []db.NoteAmendmentWhereParam
+		if req.From != nil {
+			amendments = append(amendments, db.NoteAmendment.CreatedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			amendments = append(amendments, db.NoteAmendment.CreatedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Notes.Fetch(where...).With(
+			db.ClinicalNote.Amendments.Fetch(amendments...),
+		))
+	}
+	if wants(eventReferral) {
+		var where []db.ReferralWhereParam
+		if req.From != nil {
+			where = append(where, db.Referral.CreatedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Referral.CreatedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Referrals.Fetch(where...).With(
+			db.Referral.ToDoctor.Fetch(),
+		))
+	}
+	if wants(eventEdit) {
+		var where []db.RecordEditWhereParam
+		if req.From != nil {
+			where = append(where, db.RecordEdit.EditedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.RecordEdit.EditedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Edits.Fetch(where...))
+	}
+	if wants(eventEncounter) {
+		var where []db.EncounterWhereParam
+		if req.From != nil {
+			where = append(where, db.Encounter.StartedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Encounter.StartedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Encounters.Fetch(where...))
+	}
+	if wants(eventMedication) {
+		var where []db.MedicationWhereParam
+		if req.From != nil {
+			where = append(where, db.Medication.CreatedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Medication.CreatedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Medications.Fetch(where...))
+	}
+	if wants(eventLabResult) {
+		var where []db.LabResultWhereParam
+		if req.From != nil {
+			where = append(where, db.LabResult.CollectedAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.LabResult.CollectedAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.LabResults.Fetch(where...))
+	}
+	if wants(eventImmunization) {
+		var where []db.ImmunizationWhereParam
+		if req.From != nil {
+			where = append(where, db.Immunization.AdministeredAt.Gte(*req.From))
+		}
+		if req.To != nil {
+			where = append(where, db.Immunization.AdministeredAt.Lte(*req.To))
+		}
+		with = append(with, db.Patient.Immunizations.Fetch(where...))
+	}
+
+	patient, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(req.PatientID),
+	).With(
+		with...,
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	var events []*models.TimelineEvent
+	add := func(e *models.TimelineEvent) {
+		if !wants(e.Type) {
+			return
+		}
+		if req.From != nil && e.OccurredAt.Before(*req.From) {
+			return
+		}
+		if req.To != nil && e.OccurredAt.After(*req.To) {
+			return
+		}
+		events = append(events, e)
+	}
+
+	registration := &models.TimelineEvent{
+		Type:       eventRegistration,
+		OccurredAt: patient.CreatedAt,
+		Entity:     editPatient,
+		EntityID:   patient.ID,
+		Summary:    "Registered",
+		ActorID:    patient.RegisteredByID,
+	}
+	if mrn, ok := patient.Mrn(); ok {
+		registration.Summary = "Registered as " + mrn
+	}
+	add(registration)
+
+	if wants(eventVitals) {
+		for _, v := range patient.Vitals() {
+			e := &models.TimelineEvent{
+				Type:       eventVitals,
+				OccurredAt: v.MeasuredAt,
+				Entity:     editVital,
+				EntityID:   v.ID,
+				Summary:    vitalsSummary(&v),
+			}
+			if by, ok := v.RecordedByID(); ok {
+				e.ActorID = by
+			}
+			add(e)
+		}
+	}
+
+	if wants(eventDiagnosis) {
+		for _, d := range patient.Diagnoses() {
+			e := &models.TimelineEvent{
+				Type:       eventDiagnosis,
+				OccurredAt: d.CreatedAt,
+				Entity:     editDiagnosis,
+				EntityID:   d.ID,
+				Summary:    "Diagnosed with " + d.Name,
+			}
+			if code, ok := d.Code(); ok {
+				e.Summary += " (" + code + ")"
+			}
+			if d.Type != "primary" {
+				e.Summary += ", " + d.Type
+			}
+			if by, ok := d.ClinicianID(); ok {
+				e.ActorID = by
+			}
+			add(e)
+		}
+	}
+
+	if wants(eventCondition, eventConditionStatus) {
+		for _, c := range patient.Conditions() {
+			add(&models.TimelineEvent{
+				Type:       eventCondition,
+				OccurredAt: c.CreatedAt,
+				Entity:     eventCondition,
+				EntityID:   c.ID,
+				Summary:    fmt.Sprintf("Condition %s recorded, %s", c.Name, c.ClinicalStatus),
+			})
+
+			for _, t := range c.Transitions() {
+				e := &models.TimelineEvent{
+					Type:       eventConditionStatus,
+					OccurredAt: t.ChangedAt,
+					Entity:     eventCondition,
+					EntityID:   c.ID,
+					Summary:    conditionTransitionSummary(c.Name, &t),
+				}
+				if by, ok := t.ChangedByID(); ok {
+					e.ActorID = by
+				}
+				add(e)
+			}
+		}
+	}
+
+	if wants(eventAllergy) {
+		for _, a := range patient.Allergies() {
+			summary := fmt.Sprintf("Allergy to %s recorded", a.Name)
+			if a.Criticality != clinical.CriticalityUnableToAssess {
+				summary += ", " + a.Criticality + " criticality"
+			}
+			add(&models.TimelineEvent{
+				Type:       eventAllergy,
+				OccurredAt: a.RecordedAt,
+				Entity:     editAllergy,
+				EntityID:   a.ID,
+				Summary:    summary,
+			})
+		}
+	}
+
+	if wants(eventNote, eventNoteAmendment) {
+		for _, n := range patient.Notes() {
+			if !notes.Visible(n.Status, n.AuthorID, req.ViewerID) {
+				continue
+			}
+			title, ok := n.Title()
+			if !ok {
+				title = "Clinical note"
+			}
+			add(&models.TimelineEvent{
+				Type:       eventNote,
+				OccurredAt: n.CreatedAt,
+				Entity:     eventNote,
+				EntityID:   n.ID,
+				Summary:    fmt.Sprintf("%s written, %s", title, n.Status),
+				ActorID:    n.AuthorID,
+			})
+
+			for _, a := range n.Amendments() {
+				add(&models.TimelineEvent{
+					Type:       eventNoteAmendment,
+					OccurredAt: a.CreatedAt,
+					Entity:     eventNote,
+					EntityID:   n.ID,
+					Summary:    fmt.Sprintf("%s amended: %s", title, a.Reason),
+					ActorID:    a.AuthorID,
+				})
+			}
+		}
+	}
+
+	if wants(eventReferral) {
+		for _, r := range patient.Referrals() {
+			to, ok := r.FacilityName()
+			if doctor, found := r.ToDoctor(); found {
+				to = doctor.Fullname
+			} else if !ok {
+				to = "a doctor"
+			}
+			add(&models.TimelineEvent{
+				Type:       eventReferral,
+				OccurredAt: r.CreatedAt,
+				Entity:     eventReferral,
+				EntityID:   r.ID,
+				Summary:    fmt.Sprintf("Referred to %s, %s: %s", to, r.Urgency, r.Reason),
+				ActorID:    r.ReferredByID,
+			})
+		}
+	}
+
+	if wants(eventEdit) {
+		for _, edit := range patient.Edits() {
+			e := &models.TimelineEvent{
+				Type:       eventEdit,
+				OccurredAt: edit.EditedAt,
+				Entity:     edit.Entity,
+				EntityID:   edit.EntityID,
+				Summary:    fmt.Sprintf("%s edited", strings.ToUpper(edit.Entity[:1])+edit.Entity[1:]),
+				Fields:     edit.Fields,
+			}
+			if len(edit.Fields) > 0 {
+				e.Summary += ": " + strings.Join(edit.Fields, ", ")
+			}
+			if by, ok := edit.EditedByID(); ok {
+				e.ActorID = by
+			}
+			add(e)
+		}
+	}
+
+	if wants(eventEncounter) {
+		for _, enc := range patient.Encounters() {
+			e := &models.TimelineEvent{
+				Type:       eventEncounter,
+				OccurredAt: enc.StartedAt,
+				Entity:     eventEncounter,
+				EntityID:   enc.ID,
+				Summary:    fmt.Sprintf("%s encounter, %s", strings.ToUpper(enc.Type[:1])+enc.Type[1:], enc.Status),
+			}
+			if reason, ok := enc.Reason(); ok {
+				e.Summary += ": " + reason
+			}
+			if by, ok := enc.AttendingID(); ok {
+				e.ActorID = by
+			}
+			add(e)
+		}
+	}
+
+	if wants(eventMedication) {
+		for _, m := range patient.Medications() {
+			e := &models.TimelineEvent{
+				Type:       eventMedication,
+				OccurredAt: m.CreatedAt,
+				Entity:     eventMedication,
+				EntityID:   m.ID,
+				Summary: fmt.Sprintf("Prescribed %s %g %s %s %s, %s",
+					m.DrugName, m.DoseValue, m.DoseUnit, m.Route, m.Frequency, m.Status),
+			}
+			if by, ok := m.PrescriberID(); ok {
+				e.ActorID = by
+			}
+			add(e)
+		}
+	}
+
+	if wants(eventLabResult) {
+		for _, r := range patient.LabResults() {
+			e := &models.TimelineEvent{
+				Type:       eventLabResult,
+				OccurredAt: r.CollectedAt,
+				Entity:     eventLabResult,
+				EntityID:   r.ID,
+				Summary:    labResultSummary(&r),
+			}
+			if by, ok := r.EnteredByID(); ok {
+				e.ActorID = by
+			}
+			add(e)
+		}
+	}
+
+	if wants(eventImmunization) {
+		for _, im := range patient.Immunizations() {
+			e := &models.TimelineEvent{
+				Type:       eventImmunization,
+				OccurredAt: im.AdministeredAt,
+				Entity:     eventImmunization,
+				EntityID:   im.ID,
+				Summary:    fmt.Sprintf("%s dose %d given", im.VaccineName, im.DoseNumber),
+				ActorID:    im.RecordedByID,
+			}
+			if by, ok := im.AdministeredByID(); ok {
+				e.ActorID = by
+			} else if name, ok := im.AdministeredByName(); ok {
+				e.Summary += " by " + name
+			}
+			add(e)
+		}
+	}
+
+	// newest first; events at the same time keep a stable order
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].OccurredAt.Equal(events[j].OccurredAt) {
+			return events[i].OccurredAt.After(events[j].OccurredAt)
+		}
+		return events[i].EntityID < events[j].EntityID
+	})
+
+	totalItems := int64(len(events))
+	offset := (req.Page - 1) * req.PageSize
+	page := []*models.TimelineEvent{}
+	if offset < totalItems {
+		page = events[offset:min(offset+req.PageSize, totalItems)]
+	}
+
+	if err := s.nameActors(ctx, page); err != nil {
+		return nil, err
+	}
+
+	totalPages := (totalItems + req.PageSize - 1) / req.PageSize
+	from := offset + 1
+	to := offset + int64(len(page))
+	if len(page) == 0 {
+		from = 0
+		to = 0
+	}
+
+	return &models.Timeline{
+		PatientID: patient.ID,
+		Events:    page,
+		Meta: &models.ListPatientMetadata{
+			CurrentPage: req.Page,
+			PageSize:    req.PageSize,
+			TotalItems:  totalItems,
+			TotalPages:  totalPages,
+			From:        from,
+			To:          to,
+			HasNext:     req.Page < totalPages,
+			HasPrevious: req.Page != 1,
+		},
+	}, nil
+}
+
+// nameActors fills in the names of the users who caused the events.
+func (s *Patient) nameActors(ctx context.Context, events []*models.TimelineEvent) error {
+	var ids []string
+	for _, e := range events {
+		if e.ActorID != "" && !slices.Contains(ids, e.ActorID) {
+			ids = append(ids, e.ActorID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	users, err := s.client.User.FindMany(
+		db.User.ID.In(ids),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Fullname
+	}
+	for _, e := range events {
+		e.ActorName = names[e.ActorID]
+	}
+	return nil
+}
+
+func vitalsSummary(v *db.VitalModel) string {
+	var parts []string
+	if sys, ok := v.BloodPressureSystolic(); ok {
+		if dia, ok := v.BloodPressureDiastolic(); ok {
+			parts = append(parts, fmt.Sprintf("BP %d/%d mmHg", sys, dia))
+		}
+	}
+	if pulse, ok := v.Pulse(); ok {
+		parts = append(parts, fmt.Sprintf("pulse %d/min", pulse))
+	}
+	if rr, ok := v.RespiratoryRate(); ok {
+		parts = append(parts, fmt.Sprintf("RR %d/min", rr))
+	}
+	if spo2, ok := v.OxygenSaturation(); ok {
+		parts = append(parts, fmt.Sprintf("SpO2 %g%%", spo2))
+	}
+	if temp, ok := v.TemperatureC(); ok {
+		parts = append(parts, fmt.Sprintf("temp %g °C", temp))
+	}
+	if weight, ok := v.WeightKg(); ok {
+		parts = append(parts, fmt.Sprintf("weight %g kg", weight))
+	}
+	if height, ok := v.HeightCm(); ok {
+		parts = append(parts, fmt.Sprintf("height %g cm", height))
+	}
+	if score, ok := v.News2Score(); ok {
+		parts = append(parts, fmt.Sprintf("NEWS2 %d", score))
+	}
+
+	if len(parts) == 0 {
+		return "Vitals recorded"
+	}
+	return "Vitals recorded: " + strings.Join(parts, ", ")
+}
+
+func labResultSummary(r *db.LabResultModel) string {
+	summary := r.TestName
+	if value, ok := r.Value(); ok {
+		summary += fmt.Sprintf(" %g", value)
+		if unit, ok := r.Unit(); ok {
+			summary += " " + unit
+		}
+	} else if text, ok := r.ValueText(); ok {
+		summary += " " + text
+	}
+	if flag, ok := r.Flag(); ok {
+		summary += ", " + flag
+	}
+	return summary
+}
+
+func conditionTransitionSummary(name string, t *db.ConditionTransitionModel) string {
+	var changes []string
+	if t.FromClinicalStatus != t.ToClinicalStatus {
+		changes = append(changes, t.FromClinicalStatus+" to "+t.ToClinicalStatus)
+	}
+	if t.FromVerificationStatus != t.ToVerificationStatus {
+		changes = append(changes, t.FromVerificationStatus+" to "+t.ToVerificationStatus)
+	}
+	return fmt.Sprintf("Condition %s changed from %s", name, strings.Join(changes, ", from "))
+}
@@ -3,22 +3,72 @@ package store
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/vaidik-bajpai/medibridge/internal/clinical"
 	dto "github.com/vaidik-bajpai/medibridge/internal/models"
 	"github.com/vaidik-bajpai/medibridge/internal/prisma/db"
 )
 
 var (
 	ErrUniqueConstraintViolated = errors.New("unique constraint violated")
+	ErrVitalNotFound            = errors.New("vitals not found")
+	ErrInconsistentBMI          = errors.New("bmi does not match height and weight")
+	ErrBMIUnverifiable          = errors.New("bmi requires height and weight")
+	ErrBloodPressureInverted    = errors.New("diastolic pressure must be below systolic pressure")
+
+	// ErrVitalChanged is returned when an observation is changed by someone
+	// else while a correction is checked against it.
+	ErrVitalChanged = errors.New("vitals changed since they were read")
 )
 
+// vitalUnchangedQuery locks the observation $1 and fails the transaction it
+// runs in unless it was last updated at $2, so that a correction checked
+// against the stored values isn't applied on top of a concurrent one.
+const vitalUnchangedQuery = `
+	WITH locked AS (
+		SELECT id
+		FROM "Vital"
+		WHERE id = $1 AND "updatedAt" = $2::timestamp
+		FOR UPDATE
+	)
+	SELECT 1 / COUNT(*) AS unchanged FROM locked;
+`
+
+// vitalColumns maps a metric name to its column. It is also the whitelist
+// for metric names interpolated into raw queries.
+var vitalColumns = map[string]string{
+	"heightCm":               `"heightCm"`,
+	"weightKg":               `"weightKg"`,
+	"bmi":                    `bmi`,
+	"temperatureC":           `"temperatureC"`,
+	"pulse":                  `pulse`,
+	"respiratoryRate":        `"respiratoryRate"`,
+	"bloodPressureSystolic":  `"bloodPressureSystolic"`,
+	"bloodPressureDiastolic": `"bloodPressureDiastolic"`,
+	"oxygenSaturation":       `"oxygenSaturation"`,
+}
+
 type Vitals struct {
 	client *db.PrismaClient
 }
 
-func (s *Vitals) Create(ctx context.Context, req *dto.CreateVitalReq) error {
+func (s *Vitals) Create(ctx context.Context, req *dto.CreateVitalReq) (*dto.VitalModel, error) {
+	bmi, err := reconcileBMI(req.HeightCm, req.WeightKg, req.BMI)
+	if err != nil {
+		return nil, err
+	}
+	req.BMI = bmi
+
+	if req.EncounterID != "" {
+		if err := checkEncounter(ctx, s.client, req.PatientID, req.EncounterID); err != nil {
+			return nil, err
+		}
+	}
+
 	create := prepareVitalCreateParams(req)
-	_, err := s.client.Vital.CreateOne(
+	v, err := s.client.Vital.CreateOne(
 		db.Vital.Patient.Link(
 			db.Patient.ID.Equals(req.PatientID),
 		),
@@ -26,32 +76,419 @@ func (s *Vitals) Create(ctx context.Context, req *dto.CreateVitalReq) error {
 	).Exec(ctx)
 	if err != nil {
 		if _, ok := db.IsErrUniqueConstraint(err); ok {
-			return ErrUniqueConstraintViolated
+			return nil, ErrUniqueConstraintViolated
 		}
-		return err
+		return nil, err
 	}
-	return nil
+
+	sex, dob, err := s.demographics(ctx, v.PatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	vital := toVitalModel(v)
+	vital.ApplyFlags(sex, dob)
+	return &vital, nil
 }
 
-func (s *Vitals) Update(ctx context.Context, req *dto.UpdateVitalReq) error {
-	update := prepareVitalsUpdateParams(req)
-	_, err := s.client.Vital.FindUnique(
-		db.Vital.PatientID.Equals(req.PatientID),
+func (s *Vitals) Update(ctx context.Context, req *dto.UpdateVitalReq) (*dto.VitalModel, error) {
+	bodySize := req.HeightCm != nil || req.WeightKg != nil || req.BMI != nil
+	pressure := req.BloodPressureSystolic != nil || req.BloodPressureDiastolic != nil
+	scored := req.RespiratoryRate != nil || req.OxygenSaturation != nil || req.SupplementalOxygen != nil ||
+		req.BloodPressureSystolic != nil || req.Pulse != nil || req.Consciousness != nil || req.TemperatureC != nil
+
+	existing, err := s.client.Vital.FindUnique(
+		db.Vital.ID.Equals(req.ID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return nil, ErrVitalNotFound
+		}
+		return nil, err
+	}
+
+	// the BMI is recomputed from the corrected and the stored values
+	if bodySize {
+		height, weight := req.HeightCm, req.WeightKg
+		if h, ok := existing.HeightCm(); ok && height == nil {
+			height = &h
+		}
+		if w, ok := existing.WeightKg(); ok && weight == nil {
+			weight = &w
+		}
+
+		bmi, err := reconcileBMI(height, weight, req.BMI)
+		if err != nil {
+			return nil, err
+		}
+		req.BMI = bmi
+	}
+
+	// a corrected pressure must still make sense with the stored one
+	if pressure {
+		systolic, diastolic := req.BloodPressureSystolic, req.BloodPressureDiastolic
+		if bp, ok := existing.BloodPressureSystolic(); ok && systolic == nil {
+			v := float64(bp)
+			systolic = &v
+		}
+		if bp, ok := existing.BloodPressureDiastolic(); ok && diastolic == nil {
+			v := float64(bp)
+			diastolic = &v
+		}
+		if systolic != nil && diastolic != nil && *diastolic >= *systolic {
+			return nil, ErrBloodPressureInverted
+		}
+	}
+
+	// the NEWS2 score is recomputed from the corrected and the stored values
+	var news2 []db.VitalSetParam
+	if scored {
+		news2 = prepareNEWS2Params(mergeNEWS2Input(existing, req))
+	}
+
+	update := append(prepareVitalsUpdateParams(req), news2...)
+	updated := s.client.Vital.FindUnique(
+		db.Vital.ID.Equals(req.ID),
 	).Update(
 		update...,
-	).Exec(ctx)
+	).Tx()
+
+	// the BMI, pressure and NEWS2 checks above used the stored values, so
+	// the correction only applies while they are unchanged
+	unchanged := s.client.Prisma.QueryRaw(vitalUnchangedQuery, req.ID, existing.UpdatedAt.UTC()).Tx()
+
+	edit := recordEdit(s.client, existing.PatientID, editVital, req.ID, req.EditedByID, editedFields(req, "units"))
+	if err := s.client.Prisma.Transaction(unchanged, updated, edit).Exec(ctx); err != nil {
+		current, rerr := s.client.Vital.FindUnique(
+			db.Vital.ID.Equals(req.ID),
+		).Exec(ctx)
+		switch {
+		case db.IsErrNotFound(err), db.IsErrNotFound(rerr):
+			return nil, ErrVitalNotFound
+		case rerr == nil && !current.UpdatedAt.Equal(existing.UpdatedAt):
+			return nil, ErrVitalChanged
+		}
+		return nil, err
+	}
+	v := updated.Result()
+
+	sex, dob, err := s.demographics(ctx, v.PatientID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+
+	vital := toVitalModel(v)
+	vital.ApplyFlags(sex, dob)
+	return &vital, nil
 }
 
-func (s *Vitals) Delete(ctx context.Context, pID string) error {
+func (s *Vitals) Delete(ctx context.Context, vID string) error {
 	_, err := s.client.Vital.FindUnique(
-		db.Vital.PatientID.Equals(pID),
+		db.Vital.ID.Equals(vID),
 	).Delete().Exec(ctx)
 	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return ErrVitalNotFound
+		}
 		return err
 	}
 	return nil
 }
+
+// List returns the observations of a patient, newest first.
+func (s *Vitals) List(ctx context.Context, req *dto.VitalsQuery) ([]*dto.VitalModel, error) {
+	where := []db.VitalWhereParam{
+		db.Vital.PatientID.Equals(req.PatientID),
+	}
+	if req.From != nil {
+		where = append(where, db.Vital.MeasuredAt.Gte(*req.From))
+	}
+	if req.To != nil {
+		where = append(where, db.Vital.MeasuredAt.Lte(*req.To))
+	}
+
+	query := s.client.Vital.FindMany(
+		where...,
+	).OrderBy(
+		db.Vital.MeasuredAt.Order(db.SortOrderDesc),
+	)
+	if req.Limit > 0 {
+		query = query.Take(req.Limit)
+	}
+
+	vs, err := query.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vitals := make([]*dto.VitalModel, 0, len(vs))
+	if len(vs) == 0 {
+		return vitals, nil
+	}
+
+	sex, dob, err := s.demographics(ctx, req.PatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range vs {
+		vital := toVitalModel(&vs[i])
+		vital.ApplyFlags(sex, dob)
+		vitals = append(vitals, &vital)
+	}
+	return vitals, nil
+}
+
+// Latest returns the most recent reading of every metric. Observations are
+// often partial, so each metric may come from a different observation.
+func (s *Vitals) Latest(ctx context.Context, pID string) (dto.LatestVitals, error) {
+	query := `
+		SELECT DISTINCT ON (m.metric)
+			m.metric,
+			m.value,
+			v.id AS "vitalId",
+			v."measuredAt"
+		FROM
+			"Vital" v
+		CROSS JOIN LATERAL (
+			VALUES
+				('heightCm', v."heightCm"),
+				('weightKg', v."weightKg"),
+				('bmi', v.bmi),
+				('temperatureC', v."temperatureC"),
+				('pulse', v.pulse::float8),
+				('respiratoryRate', v."respiratoryRate"::float8),
+				('bloodPressureSystolic', v."bloodPressureSystolic"::float8),
+				('bloodPressureDiastolic', v."bloodPressureDiastolic"::float8),
+				('oxygenSaturation', v."oxygenSaturation")
+		) AS m(metric, value)
+		WHERE
+			v."patientId" = $1
+			AND m.value IS NOT NULL
+		ORDER BY
+			m.metric, v."measuredAt" DESC;
+	`
+
+	var rows []struct {
+		dto.VitalReading
+		Metric string `json:"metric"`
+	}
+	if err := s.client.Prisma.QueryRaw(query, pID).Exec(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	latest := make(dto.LatestVitals, len(rows))
+	if len(rows) == 0 {
+		return latest, nil
+	}
+
+	sex, dob, err := s.demographics(ctx, pID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].ApplyFlag(rows[i].Metric, sex, dob)
+		latest[rows[i].Metric] = &rows[i].VitalReading
+	}
+	return latest, nil
+}
+
+// Series returns the readings of a single metric, oldest first.
+func (s *Vitals) Series(ctx context.Context, req *dto.VitalsQuery) (*dto.VitalSeries, error) {
+	column, ok := vitalColumns[req.Metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown vitals metric %q", req.Metric)
+	}
+
+	args := &queryArgs{}
+	conds := fmt.Sprintf(`"patientId" = %s AND %s IS NOT NULL`, args.add(req.PatientID), column)
+	if req.From != nil {
+		// the column holds UTC without an offset, which the cast would drop
+		conds += fmt.Sprintf(` AND "measuredAt" >= %s::timestamp`, args.add(req.From.UTC()))
+	}
+	if req.To != nil {
+		conds += fmt.Sprintf(` AND "measuredAt" <= %s::timestamp`, args.add(req.To.UTC()))
+	}
+	limit := ""
+	if req.Limit > 0 {
+		limit = "LIMIT " + args.add(req.Limit)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id AS "vitalId",
+			%s::float8 AS value,
+			"measuredAt"
+		FROM
+			"Vital"
+		WHERE
+			%s
+		ORDER BY
+			"measuredAt" ASC
+		%s;
+	`, column, conds, limit)
+
+	series := &dto.VitalSeries{
+		Metric:   req.Metric,
+		Readings: []*dto.VitalReading{},
+	}
+	if err := s.client.Prisma.QueryRaw(query, args.values...).Exec(ctx, &series.Readings); err != nil {
+		return nil, err
+	}
+	if len(series.Readings) == 0 {
+		return series, nil
+	}
+
+	sex, dob, err := s.demographics(ctx, req.PatientID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range series.Readings {
+		r.ApplyFlag(req.Metric, sex, dob)
+	}
+	return series, nil
+}
+
+// demographics returns the sex and date of birth of a patient, which select
+// the reference ranges used to flag their vitals.
+func (s *Vitals) demographics(ctx context.Context, pID string) (string, time.Time, error) {
+	p, err := s.client.Patient.FindUnique(
+		db.Patient.ID.Equals(pID),
+	).Exec(ctx)
+	if err != nil {
+		if ok := db.IsErrNotFound(err); ok {
+			return "", time.Time{}, ErrPatientNotFound
+		}
+		return "", time.Time{}, err
+	}
+	return p.Gender, p.DateOfBirth, nil
+}
+
+func toVitalModel(v *db.VitalModel) dto.VitalModel {
+	measuredAt := v.MeasuredAt
+	vital := dto.VitalModel{
+		ID:         v.ID,
+		PatientID:  v.PatientID,
+		MeasuredAt: &measuredAt,
+		Source:     v.Source,
+		CreatedAt:  &v.CreatedAt,
+		UpdatedAt:  &v.UpdatedAt,
+		Units:      dto.CanonicalVitalUnits(),
+	}
+
+	if recordedBy, ok := v.RecordedByID(); ok {
+		vital.RecordedByID = recordedBy
+	}
+	if deviceID, ok := v.DeviceID(); ok {
+		vital.DeviceID = deviceID
+	}
+	if encounter, ok := v.EncounterID(); ok {
+		vital.EncounterID = encounter
+	}
+	if height, ok := v.HeightCm(); ok {
+		vital.HeightCm = &height
+	}
+	if weight, ok := v.WeightKg(); ok {
+		vital.WeightKg = &weight
+	}
+	if bmi, ok := v.Bmi(); ok {
+		vital.BMI = &bmi
+	}
+	if temperature, ok := v.TemperatureC(); ok {
+		vital.TemperatureC = &temperature
+	}
+	if pulse, ok := v.Pulse(); ok {
+		vital.Pulse = &pulse
+	}
+	if respiratoryRate, ok := v.RespiratoryRate(); ok {
+		vital.RespiratoryRate = &respiratoryRate
+	}
+	if systolic, ok := v.BloodPressureSystolic(); ok {
+		bp := float64(systolic)
+		vital.BloodPressureSystolic = &bp
+	}
+	if diastolic, ok := v.BloodPressureDiastolic(); ok {
+		bp := float64(diastolic)
+		vital.BloodPressureDiastolic = &bp
+	}
+	if oxygenSaturation, ok := v.OxygenSaturation(); ok {
+		vital.OxygenSaturation = &oxygenSaturation
+	}
+	if consciousness, ok := v.Consciousness(); ok {
+		vital.Consciousness = consciousness
+	}
+	if supplementalOxygen, ok := v.SupplementalOxygen(); ok {
+		vital.SupplementalOxygen = &supplementalOxygen
+	}
+	if score, ok := v.News2Score(); ok {
+		risk, _ := v.News2Risk()
+		vital.NEWS2 = &clinical.NEWS2Score{Total: score, Risk: clinical.NEWS2Risk(risk)}
+	}
+
+	return vital
+}
+
+// WardNEWS2 lists the patients on a ward by the NEWS2 score of their latest
+// scored observation, highest first. Patients without a score come last.
+func (s *Vitals) WardNEWS2(ctx context.Context, ward string) ([]*dto.WardNEWS2Item, error) {
+	query := `
+		SELECT
+			p.id AS "patientId",
+			p.mrn,
+			p."fullName",
+			p.ward,
+			v.id AS "vitalId",
+			v."measuredAt",
+			v."news2Score",
+			v."news2Risk"
+		FROM
+			"Patient" p
+		LEFT JOIN LATERAL (
+			SELECT id, "measuredAt", "news2Score", "news2Risk"
+			FROM "Vital"
+			WHERE "patientId" = p.id AND "news2Score" IS NOT NULL
+			ORDER BY "measuredAt" DESC
+			LIMIT 1
+		) v ON true
+		WHERE
+			p.ward = $1
+			AND p."mergedIntoId" IS NULL
+		ORDER BY
+			v."news2Score" DESC NULLS LAST, v."measuredAt" DESC, p."fullName";
+	`
+
+	var rows []struct {
+		PatientID  string     `json:"patientId"`
+		MRN        *string    `json:"mrn"`
+		FullName   string     `json:"fullName"`
+		Ward       string     `json:"ward"`
+		VitalID    *string    `json:"vitalId"`
+		MeasuredAt *time.Time `json:"measuredAt"`
+		Score      *int       `json:"news2Score"`
+		Risk       *string    `json:"news2Risk"`
+	}
+	if err := s.client.Prisma.QueryRaw(query, ward).Exec(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	items := make([]*dto.WardNEWS2Item, 0, len(rows))
+	for _, row := range rows {
+		item := &dto.WardNEWS2Item{
+			PatientID:  row.PatientID,
+			FullName:   row.FullName,
+			Ward:       row.Ward,
+			MeasuredAt: row.MeasuredAt,
+		}
+		if row.MRN != nil {
+			item.MRN = *row.MRN
+		}
+		if row.VitalID != nil {
+			item.VitalID = *row.VitalID
+		}
+		if row.Score != nil && row.Risk != nil {
+			item.NEWS2 = &clinical.NEWS2Score{Total: *row.Score, Risk: clinical.NEWS2Risk(*row.Risk)}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
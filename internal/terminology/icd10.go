@@ -0,0 +1,188 @@
+// Package terminology holds the clinical code sets used to code patient
+// records.
+package terminology
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Code is an entry of a code set.
+type Code struct {
+	Code    string `json:"code" example:"J45.9"`
+	Display string `json:"display" example:"Asthma, unspecified"`
+}
+
+// CodeSet is a searchable set of codes.
+type CodeSet struct {
+	codes  []Code
+	byCode map[string]int
+}
+
+//go:embed icd10.tsv
+var bundledICD10 []byte
+
+var (
+	mu    sync.RWMutex
+	icd10 *CodeSet
+)
+
+func init() {
+	cs, err := ParseCodeSet(bytes.NewReader(bundledICD10))
+	if err != nil {
+		panic(fmt.Sprintf("terminology: invalid bundled ICD-10 codes: %v", err))
+	}
+	icd10 = cs
+}
+
+// ParseCodeSet reads a code set with one "code<TAB>display" entry per line.
+// Blank lines and lines starting with # are skipped.
+func ParseCodeSet(r io.Reader) (*CodeSet, error) {
+	cs := &CodeSet{byCode: make(map[string]int)}
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		code, display, ok := strings.Cut(line, "\t")
+		code, display = NormaliseICD10(code), strings.TrimSpace(display)
+		if !ok || code == "" || display == "" {
+			return nil, fmt.Errorf("line %d: want code<TAB>display", n)
+		}
+		if _, dup := cs.byCode[code]; dup {
+			return nil, fmt.Errorf("line %d: duplicate code %s", n, code)
+		}
+
+		cs.byCode[code] = len(cs.codes)
+		cs.codes = append(cs.codes, Code{Code: code, Display: display})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(cs.codes, func(i, j int) bool { return cs.codes[i].Code < cs.codes[j].Code })
+	for i, c := range cs.codes {
+		cs.byCode[c.Code] = i
+	}
+	return cs, nil
+}
+
+// LoadICD10File replaces the bundled ICD-10 codes with the code set in the
+// file at path.
+func LoadICD10File(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cs, err := ParseCodeSet(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	icd10 = cs
+	mu.Unlock()
+	return nil
+}
+
+// NormaliseICD10 upper-cases a code and writes it with a dot after the
+// category, so that "j459" and "J45.9" are the same code.
+func NormaliseICD10(code string) string {
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), ".", ""))
+	if len(code) > 3 {
+		code = code[:3] + "." + code[3:]
+	}
+	return code
+}
+
+// icd10Format matches a normalised ICD-10 code: a letter and two characters
+// for the category, then up to four characters for the subcategory.
+var icd10Format = regexp.MustCompile(`^[A-Z][0-9][0-9A-Z](\.[0-9A-Z]{1,4})?$`)
+
+// WellFormedICD10 reports whether code has the shape of an ICD-10 code,
+// whether or not the bundled code set has it.
+func WellFormedICD10(code string) bool {
+	return icd10Format.MatchString(NormaliseICD10(code))
+}
+
+// LookupICD10 returns the ICD-10 code, in normalised form, and whether it
+// exists.
+func LookupICD10(code string) (Code, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return icd10.Lookup(code)
+}
+
+// SearchICD10 returns up to limit ICD-10 codes matching q. See CodeSet.Search.
+func SearchICD10(q string, limit int) []Code {
+	mu.RLock()
+	defer mu.RUnlock()
+	return icd10.Search(q, limit)
+}
+
+// Lookup returns the code and whether it exists.
+func (cs *CodeSet) Lookup(code string) (Code, bool) {
+	i, ok := cs.byCode[NormaliseICD10(code)]
+	if !ok {
+		return Code{}, false
+	}
+	return cs.codes[i], true
+}
+
+// Search returns up to limit codes matching q for autocompletion. Codes
+// starting with q come first, then codes whose display has a word starting
+// with q, then codes whose display contains q anywhere. Within a group the
+// codes keep their order.
+func (cs *CodeSet) Search(q string, limit int) []Code {
+	q = strings.ToLower(strings.TrimSpace(q))
+	if q == "" || limit <= 0 {
+		return []Code{}
+	}
+	codePrefix := strings.ToLower(NormaliseICD10(q))
+
+	var byCode, byWord, byText []Code
+	for _, c := range cs.codes {
+		display := strings.ToLower(c.Display)
+		switch {
+		case strings.HasPrefix(strings.ToLower(c.Code), codePrefix):
+			byCode = append(byCode, c)
+		case hasWordPrefix(display, q):
+			byWord = append(byWord, c)
+		case strings.Contains(display, q):
+			byText = append(byText, c)
+		}
+	}
+
+	matches := append(append(byCode, byWord...), byText...)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	if matches == nil {
+		matches = []Code{}
+	}
+	return matches
+}
+
+func hasWordPrefix(text, prefix string) bool {
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '(' || r == ')' || r == '[' || r == ']' || r == '-' || r == ':'
+	}) {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package terminology
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Code systems of allergy substances.
+const (
+	SystemRxNorm = "rxnorm"
+	SystemSNOMED = "snomed"
+)
+
+// Categories of allergy substances.
+const (
+	CategoryFood        = "food"
+	CategoryMedication  = "medication"
+	CategoryEnvironment = "environment"
+	CategoryBiologic    = "biologic"
+)
+
+// Substance is a coded substance a patient can be allergic to.
+type Substance struct {
+	System   string `json:"system" example:"rxnorm"`
+	Code     string `json:"code" example:"723"`
+	Display  string `json:"display" example:"Amoxicillin"`
+	Category string `json:"category" example:"medication"`
+}
+
+// SubstanceSet is a searchable set of substances.
+type SubstanceSet struct {
+	substances []Substance
+	byKey      map[string]int
+}
+
+//go:embed substances.tsv
+var bundledSubstances []byte
+
+var substances *SubstanceSet
+
+func init() {
+	ss, err := ParseSubstances(bytes.NewReader(bundledSubstances))
+	if err != nil {
+		panic(fmt.Sprintf("terminology: invalid bundled substances: %v", err))
+	}
+	substances = ss
+}
+
+func substanceKey(system, code string) string {
+	return strings.ToLower(strings.TrimSpace(system)) + "|" + strings.TrimSpace(code)
+}
+
+// ParseSubstances reads a substance list with one
+// "system<TAB>code<TAB>display<TAB>category" entry per line. Blank lines
+// and lines starting with # are skipped.
+func ParseSubstances(r io.Reader) (*SubstanceSet, error) {
+	ss := &SubstanceSet{byKey: make(map[string]int)}
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("line %d: want system<TAB>code<TAB>display<TAB>category", n)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		s := Substance{
+			System:   strings.ToLower(fields[0]),
+			Code:     fields[1],
+			Display:  fields[2],
+			Category: strings.ToLower(fields[3]),
+		}
+		if s.System != SystemRxNorm && s.System != SystemSNOMED {
+			return nil, fmt.Errorf("line %d: unknown system %q", n, s.System)
+		}
+		switch s.Category {
+		case CategoryFood, CategoryMedication, CategoryEnvironment, CategoryBiologic:
+		default:
+			return nil, fmt.Errorf("line %d: unknown category %q", n, s.Category)
+		}
+		if s.Code == "" || s.Display == "" {
+			return nil, fmt.Errorf("line %d: want system<TAB>code<TAB>display<TAB>category", n)
+		}
+
+		key := substanceKey(s.System, s.Code)
+		if _, dup := ss.byKey[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate substance %s %s", n, s.System, s.Code)
+		}
+		ss.byKey[key] = len(ss.substances)
+		ss.substances = append(ss.substances, s)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ss.substances, func(i, j int) bool {
+		return ss.substances[i].Display < ss.substances[j].Display
+	})
+	for i, s := range ss.substances {
+		ss.byKey[substanceKey(s.System, s.Code)] = i
+	}
+	return ss, nil
+}
+
+// LoadSubstancesFile replaces the bundled substances with the list in the
+// file at path.
+func LoadSubstancesFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ss, err := ParseSubstances(f)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	substances = ss
+	mu.Unlock()
+	return nil
+}
+
+// LookupSubstance returns the substance with the code in the system and
+// whether it exists.
+func LookupSubstance(system, code string) (Substance, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return substances.Lookup(system, code)
+}
+
+// SearchSubstances returns up to limit substances matching q, optionally
+// of one category. See SubstanceSet.Search.
+func SearchSubstances(q, category string, limit int) []Substance {
+	mu.RLock()
+	defer mu.RUnlock()
+	return substances.Search(q, category, limit)
+}
+
+// Lookup returns the substance and whether it exists.
+func (ss *SubstanceSet) Lookup(system, code string) (Substance, bool) {
+	i, ok := ss.byKey[substanceKey(system, code)]
+	if !ok {
+		return Substance{}, false
+	}
+	return ss.substances[i], true
+}
+
+// Search returns up to limit substances matching q for autocompletion.
+// Substances with a code equal to q or a display word starting with q come
+// first, then substances whose display contains q anywhere. An empty
+// category matches every category.
+func (ss *SubstanceSet) Search(q, category string, limit int) []Substance {
+	q = strings.ToLower(strings.TrimSpace(q))
+	if q == "" || limit <= 0 {
+		return []Substance{}
+	}
+
+	var byWord, byText []Substance
+	for _, s := range ss.substances {
+		if category != "" && s.Category != category {
+			continue
+		}
+		display := strings.ToLower(s.Display)
+		switch {
+		case s.Code == q, hasWordPrefix(display, q):
+			byWord = append(byWord, s)
+		case strings.Contains(display, q):
+			byText = append(byText, s)
+		}
+	}
+
+	matches := append(byWord, byText...)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	if matches == nil {
+		matches = []Substance{}
+	}
+	return matches
+}